@@ -0,0 +1,10 @@
+// Package benchmarks holds the performance benchmarks of the SDK: query
+// parameter encoding, decoding of large result sets and concurrent use of a
+// client. Requests are answered in memory, so the benchmarks measure the SDK
+// only.
+//
+// Run them with CPU and memory profiles and inspect the hotspots with pprof:
+//
+//	go test ./benchmarks -run '^$' -bench . -benchmem -cpuprofile cpu.out -memprofile mem.out
+//	go tool pprof -top cpu.out
+package benchmarks
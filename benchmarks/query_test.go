@@ -0,0 +1,158 @@
+package benchmarks
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// staticTransport answers every request with the same body.
+type staticTransport struct {
+	body string
+}
+
+func (t staticTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+// newClient returns a client whose requests are answered with body.
+func newClient(body string, useNumber bool) *sdk.Client {
+	return sdk.NewClient(utils.Configuration{
+		BaseURL:    "https://bench.example.com",
+		Token:      "bench-token",
+		DataDockID: "dd",
+		UseNumber:  useNumber,
+		Transport:  staticTransport{body: body},
+	})
+}
+
+// rowsJSON returns a JSON array of n rows of a typical orders table.
+func rowsJSON(n int) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i := range n {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, `{"id":%d,"customer":"customer-%d","status":"shipped","total":%d.%02d,"created_at":"2025-03-01T08:%02d:00Z"}`,
+			i, i%1000, i%5000, i%100, i%60)
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// BenchmarkSimpleGet measures the request path of a Catalog/Schema/Table/Limit/Get
+// query, the most common query of pollers.
+func BenchmarkSimpleGet(b *testing.B) {
+	client := newClient(`[]`, false)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := client.Catalog("sales").Schema("public").Table("orders").Limit(10).Get(ctx); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkFilteredGet measures parameter building with selected columns,
+// filters of several types and a multi-column ordering.
+func BenchmarkFilteredGet(b *testing.B) {
+	client := newClient(`[]`, false)
+	ctx := context.Background()
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	b.ReportAllocs()
+	for b.Loop() {
+		_, err := client.Catalog("sales").Schema("public").Table("orders").
+			Select("id", "customer", "status", "total", "created_at").
+			Where("status", "=", "shipped").
+			Where("total", ">=", 100.5).
+			Where("created_at", ">", since).
+			Where("customer", "LIKE", "acme%").
+			Where("region", "IN", "eu,us").
+			OrderBy("created_at", "DESC").
+			OrderBy("id", "ASC").
+			Limit(100).
+			Offset(200).
+			Get(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDecodeRows measures the decoding of large result sets into rows.
+func BenchmarkDecodeRows(b *testing.B) {
+	for _, n := range []int{100, 10_000} {
+		for _, useNumber := range []bool{false, true} {
+			body := rowsJSON(n)
+			b.Run(fmt.Sprintf("rows=%d/useNumber=%t", n, useNumber), func(b *testing.B) {
+				client := newClient(body, useNumber)
+				ctx := context.Background()
+				b.SetBytes(int64(len(body)))
+				b.ReportAllocs()
+				for b.Loop() {
+					resp, err := client.Catalog("sales").Schema("public").Table("orders").Get(ctx)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if _, err := resp.Rows(); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkDecodeStructs measures the decoding of large result sets into structs.
+func BenchmarkDecodeStructs(b *testing.B) {
+	type order struct {
+		ID        int       `json:"id"`
+		Customer  string    `json:"customer"`
+		Status    string    `json:"status"`
+		Total     float64   `json:"total"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	body := rowsJSON(10_000)
+	client := newClient(body, false)
+	ctx := context.Background()
+	b.SetBytes(int64(len(body)))
+	b.ReportAllocs()
+	for b.Loop() {
+		resp, err := client.Catalog("sales").Schema("public").Table("orders").Get(ctx)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var orders []order
+		if err := utils.UnmarshalData(resp.Data, &orders); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkConcurrentGet measures a client shared by concurrent goroutines,
+// exposing contention on its shared state (token, history, metrics).
+func BenchmarkConcurrentGet(b *testing.B) {
+	client := newClient(rowsJSON(10), false)
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := client.Catalog("sales").Schema("public").Table("orders").Limit(10).Get(ctx); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
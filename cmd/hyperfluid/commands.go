@@ -0,0 +1,294 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/progressive"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string     { return strings.Join(*l, ", ") }
+func (l *stringList) Set(v string) error { *l = append(*l, v); return nil }
+
+// runQuery implements `hyperfluid query [flags] <catalog.schema.table>`.
+func runQuery(ctx context.Context, client *sdk.Client, opts *options, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("query", flag.ContinueOnError)
+	columns := fs.String("select", "", "Comma-separated columns to retrieve")
+	var where, orderBy stringList
+	fs.Var(&where, "where", `Filter "column operator value", e.g. "status = active" (repeatable)`)
+	fs.Var(&orderBy, "order", `Ordering "column" or "column desc" (repeatable)`)
+	limit := fs.Int("limit", 100, "Maximum number of rows")
+	offset := fs.Int("offset", 0, "Number of rows to skip")
+	structName := fs.String("struct", "", "Print a Go struct with this name decoding the result rows, instead of the rows")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("%w: usage: hyperfluid query [flags] <catalog.schema.table>", utils.ErrInvalidRequest)
+	}
+
+	path, err := splitPath(fs.Arg(0), ".", 3)
+	if err != nil {
+		return err
+	}
+	qb := client.Query().DataDock(opts.dataDockID).Catalog(path[0]).Schema(path[1]).Table(path[2])
+	if *columns != "" {
+		qb = qb.Select(strings.Split(*columns, ",")...)
+	}
+	for _, filter := range where {
+		column, operator, value, err := parseWhere(filter)
+		if err != nil {
+			return err
+		}
+		qb = qb.Where(column, strings.ToUpper(operator), value)
+	}
+	for _, order := range orderBy {
+		column, direction, _ := strings.Cut(strings.TrimSpace(order), " ")
+		if direction == "" {
+			direction = "ASC"
+		}
+		qb = qb.OrderBy(column, strings.ToUpper(strings.TrimSpace(direction)))
+	}
+	if *offset > 0 {
+		qb = qb.Offset(*offset)
+	}
+
+	resp, err := qb.Limit(*limit).Get(ctx)
+	if err != nil {
+		return err
+	}
+	if *structName != "" {
+		code, err := sdk.InferStruct(resp.Data, *structName)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(stdout, code)
+		return err
+	}
+	return render(stdout, opts.output, resp.Data)
+}
+
+// runCatalog implements `hyperfluid catalog ls [catalog[.schema]]`.
+func runCatalog(ctx context.Context, client *sdk.Client, opts *options, args []string, stdout io.Writer) error {
+	if len(args) == 0 || args[0] != "ls" || len(args) > 2 {
+		return fmt.Errorf("%w: usage: hyperfluid catalog ls [catalog[.schema]]", utils.ErrInvalidRequest)
+	}
+	dataDock, err := dataDockBuilder(client, opts, "")
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		resp, err := dataDock.GetCatalog(ctx)
+		if err != nil {
+			return err
+		}
+		if opts.output == "json" {
+			return render(stdout, opts.output, resp.Data)
+		}
+		return render(stdout, opts.output, catalogRows(resp.Data))
+	}
+
+	path := strings.SplitN(args[1], ".", 2)
+	var names []string
+	if len(path) == 1 {
+		names, err = dataDock.Catalog(path[0]).ListSchemas(ctx)
+	} else {
+		names, err = dataDock.Catalog(path[0]).Schema(path[1]).ListTables(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	return render(stdout, opts.output, names)
+}
+
+// runDataDock implements `hyperfluid datadock wake|sleep|refresh [id]`.
+func runDataDock(ctx context.Context, client *sdk.Client, opts *options, args []string, stdout io.Writer) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("%w: usage: hyperfluid datadock wake|sleep|refresh [id]", utils.ErrInvalidRequest)
+	}
+	id := ""
+	if len(args) == 2 {
+		id = args[1]
+	}
+	dataDock, err := dataDockBuilder(client, opts, id)
+	if err != nil {
+		return err
+	}
+
+	var resp *utils.Response
+	switch args[0] {
+	case "wake":
+		if err := dataDock.WakeUpAndWait(ctx); err != nil {
+			return err
+		}
+		resp, err = dataDock.Get(ctx)
+	case "sleep":
+		resp, err = dataDock.Sleep(ctx)
+	case "refresh":
+		resp, err = dataDock.RefreshCatalog(ctx)
+	default:
+		return fmt.Errorf("%w: unknown datadock command %q", utils.ErrInvalidRequest, args[0])
+	}
+	if err != nil {
+		return err
+	}
+	return render(stdout, opts.output, resp.Data)
+}
+
+// runS3 implements `hyperfluid s3 ls|get|put`.
+func runS3(ctx context.Context, client *sdk.Client, opts *options, args []string, stdout io.Writer) error {
+	if len(args) < 2 {
+		return fmt.Errorf("%w: usage: hyperfluid s3 ls <bucket> [prefix] | get <bucket/key> [file] | put <file> <bucket/key>", utils.ErrInvalidRequest)
+	}
+	s3, err := client.S3()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "ls":
+		prefix := ""
+		if len(args) > 2 {
+			prefix = args[2]
+		}
+		resp, err := s3.Bucket(args[1]).List(ctx, prefix)
+		if err != nil {
+			return err
+		}
+		if data, ok := resp.GetDataAsMap(); ok && opts.output == "table" {
+			return render(stdout, opts.output, data["objects"])
+		}
+		return render(stdout, opts.output, resp.Data)
+
+	case "get":
+		location, err := splitPath(args[1], "/", 2)
+		if err != nil {
+			return err
+		}
+		obj, err := s3.Bucket(location[0]).Key(location[1]).Get(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = obj.Body.Close() }()
+
+		out := stdout
+		if len(args) > 2 {
+			file, err := os.Create(args[2])
+			if err != nil {
+				return err
+			}
+			defer func() { _ = file.Close() }()
+			out = file
+		}
+		_, err = io.Copy(out, obj.Body)
+		return err
+
+	case "put":
+		if len(args) != 3 {
+			return fmt.Errorf("%w: usage: hyperfluid s3 put <file> <bucket/key>", utils.ErrInvalidRequest)
+		}
+		location, err := splitPath(args[2], "/", 2)
+		if err != nil {
+			return err
+		}
+		file, err := os.Open(args[1])
+		if err != nil {
+			return err
+		}
+		defer func() { _ = file.Close() }()
+
+		resp, err := s3.Bucket(location[0]).Key(location[1]).Put(ctx, file, mime.TypeByExtension(filepath.Ext(args[1])))
+		if err != nil {
+			return err
+		}
+		return render(stdout, opts.output, resp.Data)
+	}
+	return fmt.Errorf("%w: unknown s3 command %q", utils.ErrInvalidRequest, args[0])
+}
+
+// dataDockBuilder returns the builder of the given datadock, or of the configured one.
+// Datadock endpoints do not depend on the harbor, so none is needed.
+func dataDockBuilder(client *sdk.Client, opts *options, id string) (*progressive.DataDockBuilder, error) {
+	if id == "" {
+		id = opts.dataDockID
+	}
+	if id == "" {
+		return nil, fmt.Errorf("%w: a datadock ID is required (-datadock or HYPERFLUID_DATADOCK_ID)", utils.ErrInvalidConfiguration)
+	}
+	return client.Org(opts.orgID).Harbor("").DataDock(id), nil
+}
+
+// splitPath splits "catalog.schema.table" or "bucket/key" into exactly n non-empty
+// parts. The last part keeps any remaining separators.
+func splitPath(path, sep string, n int) ([]string, error) {
+	parts := strings.SplitN(path, sep, n)
+	if len(parts) != n {
+		return nil, fmt.Errorf("%w: invalid path %q", utils.ErrInvalidRequest, path)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("%w: invalid path %q", utils.ErrInvalidRequest, path)
+		}
+	}
+	return parts, nil
+}
+
+// parseWhere parses a "column operator value" filter.
+func parseWhere(filter string) (column, operator, value string, err error) {
+	column, rest, _ := strings.Cut(strings.TrimSpace(filter), " ")
+	operator, value, _ = strings.Cut(strings.TrimSpace(rest), " ")
+	// Keep the value as written, including inner spaces
+	value = strings.TrimSpace(value)
+	if column == "" || operator == "" || value == "" {
+		return "", "", "", fmt.Errorf("%w: invalid filter %q, expected \"column operator value\"", utils.ErrInvalidRequest, filter)
+	}
+	return column, operator, value, nil
+}
+
+// catalogRows flattens catalog metadata into one row per table.
+func catalogRows(data any) []map[string]any {
+	var rows []map[string]any
+	for _, catalog := range items(data, "catalogs") {
+		for _, schema := range items(catalog["schemas"]) {
+			for _, table := range items(schema["tables"]) {
+				rows = append(rows, map[string]any{
+					"catalog": catalog["catalog_name"],
+					"schema":  schema["schema_name"],
+					"table":   table["table_name"],
+				})
+			}
+		}
+	}
+	return rows
+}
+
+// items returns the objects of a list, possibly wrapped in an object under key.
+func items(data any, keys ...string) []map[string]any {
+	list, _ := data.([]any)
+	if obj, ok := data.(map[string]any); ok {
+		for _, key := range keys {
+			if l, ok := obj[key].([]any); ok {
+				list = l
+			}
+		}
+	}
+	var result []map[string]any
+	for _, item := range list {
+		if m, ok := item.(map[string]any); ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
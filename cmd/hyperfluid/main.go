@@ -0,0 +1,191 @@
+// Command hyperfluid is a command-line client for the Hyperfluid platform built on the SDK.
+//
+// Usage:
+//
+//	hyperfluid [global flags] <command> [arguments]
+//
+// Commands:
+//
+//	query <catalog.schema.table>        Query a table (-struct Name prints a Go struct for the rows)
+//	catalog ls [catalog[.schema]]       List catalogs, schemas or tables of the datadock
+//	datadock wake|sleep|refresh [id]    Manage a datadock
+//	s3 ls <bucket> [prefix]             List objects
+//	s3 get <bucket/key> [file]          Download an object (to stdout by default)
+//	s3 put <file> <bucket/key>          Upload a file
+//	logout                              Forget the cached login of the profile
+//
+// Configuration is read from flags, then from the environment:
+// HYPERFLUID_BASE_URL, HYPERFLUID_ORG_ID, HYPERFLUID_DATADOCK_ID, HYPERFLUID_TOKEN,
+// HYPERFLUID_SERVICE_ACCOUNT (JSON) or HYPERFLUID_SERVICE_ACCOUNT_FILE, then from
+// the profile named by -profile or HYPERFLUID_PROFILE (see sdk.LoadProfile).
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// options holds the global flags shared by every command.
+type options struct {
+	baseURL            string
+	orgID              string
+	dataDockID         string
+	token              string
+	serviceAccountFile string
+	profile            string
+	output             string
+	timeout            time.Duration
+	timeoutSet         bool
+	skipTLSVerify      bool
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run parses the global flags and dispatches to the requested command.
+// It returns the process exit code.
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	var opts options
+	fs := flag.NewFlagSet("hyperfluid", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&opts.baseURL, "base-url", os.Getenv("HYPERFLUID_BASE_URL"), "Hyperfluid API base URL")
+	fs.StringVar(&opts.orgID, "org", os.Getenv("HYPERFLUID_ORG_ID"), "Organization ID")
+	fs.StringVar(&opts.dataDockID, "datadock", os.Getenv("HYPERFLUID_DATADOCK_ID"), "Default datadock ID")
+	fs.StringVar(&opts.token, "token", os.Getenv("HYPERFLUID_TOKEN"), "Bearer token")
+	fs.StringVar(&opts.serviceAccountFile, "service-account", os.Getenv("HYPERFLUID_SERVICE_ACCOUNT_FILE"), "Service account JSON file")
+	fs.StringVar(&opts.profile, "profile", os.Getenv("HYPERFLUID_PROFILE"), "Profile of ~/.hyperfluid/config to use")
+	fs.StringVar(&opts.output, "o", "table", "Output format: table or json")
+	fs.DurationVar(&opts.timeout, "timeout", utils.DefaultRequestTimeout, "Request timeout")
+	fs.BoolVar(&opts.skipTLSVerify, "skip-tls-verify", false, "Skip TLS certificate verification (development only)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: hyperfluid [global flags] <command> [arguments]")
+		fmt.Fprintln(stderr, "\nCommands:")
+		fmt.Fprintln(stderr, "  query <catalog.schema.table>       Query a table")
+		fmt.Fprintln(stderr, "  catalog ls [catalog[.schema]]      List catalogs, schemas or tables")
+		fmt.Fprintln(stderr, "  datadock wake|sleep|refresh [id]   Manage a datadock")
+		fmt.Fprintln(stderr, "  s3 ls|get|put ...                  Manage objects")
+		fmt.Fprintln(stderr, "  logout                             Forget the cached login of the profile")
+		fmt.Fprintln(stderr, "\nGlobal flags:")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	fs.Visit(func(f *flag.Flag) { opts.timeoutSet = opts.timeoutSet || f.Name == "timeout" })
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+	if opts.output != "table" && opts.output != "json" {
+		fmt.Fprintf(stderr, "unknown output format %q\n", opts.output)
+		return 2
+	}
+
+	if fs.Arg(0) == "logout" {
+		if err := sdk.Logout(opts.profile); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	commands := map[string]func(context.Context, *sdk.Client, *options, []string, io.Writer) error{
+		"query":    runQuery,
+		"catalog":  runCatalog,
+		"datadock": runDataDock,
+		"s3":       runS3,
+	}
+	command, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		return 2
+	}
+
+	client, err := newClient(&opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if err := command(ctx, client, &opts, fs.Args()[1:], stdout); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// newClient creates a client from a service account when one is configured,
+// and from the token otherwise. With a profile, the flags override its settings.
+func newClient(opts *options) (*sdk.Client, error) {
+	if opts.profile != "" {
+		return newProfileClient(opts)
+	}
+	if opts.baseURL == "" {
+		return nil, fmt.Errorf("%w: -base-url or HYPERFLUID_BASE_URL is required", utils.ErrInvalidConfiguration)
+	}
+
+	saOpts := sdk.ServiceAccountOptions{
+		BaseURL:        opts.baseURL,
+		OrgID:          opts.orgID,
+		DataDockID:     opts.dataDockID,
+		SkipTLSVerify:  opts.skipTLSVerify,
+		RequestTimeout: int(opts.timeout / time.Second),
+	}
+	if opts.serviceAccountFile != "" {
+		return sdk.NewClientFromServiceAccountFile(opts.serviceAccountFile, saOpts)
+	}
+	if saJSON := os.Getenv("HYPERFLUID_SERVICE_ACCOUNT"); saJSON != "" {
+		return sdk.NewClientFromServiceAccountJSON(saJSON, saOpts)
+	}
+	if opts.token == "" {
+		return nil, fmt.Errorf("%w: a token or a service account is required", utils.ErrInvalidConfiguration)
+	}
+
+	return sdk.NewClient(utils.Configuration{
+		BaseURL:        opts.baseURL,
+		OrgID:          opts.orgID,
+		DataDockID:     opts.dataDockID,
+		Token:          opts.token,
+		SkipTLSVerify:  opts.skipTLSVerify,
+		RequestTimeout: opts.timeout,
+		MaxRetries:     utils.DefaultMaxRetries,
+	}), nil
+}
+
+// newProfileClient creates a client from the profile of the options, overridden by the flags.
+func newProfileClient(opts *options) (*sdk.Client, error) {
+	profile, err := sdk.LoadProfile(opts.profile)
+	if err != nil {
+		return nil, err
+	}
+	for _, override := range []struct {
+		field *string
+		value string
+	}{
+		{&profile.BaseURL, opts.baseURL},
+		{&profile.OrgID, opts.orgID},
+		{&profile.DataDockID, opts.dataDockID},
+		{&profile.Token, opts.token},
+		{&profile.ServiceAccountFile, opts.serviceAccountFile},
+	} {
+		if override.value != "" {
+			*override.field = override.value
+		}
+	}
+	if opts.timeoutSet || profile.RequestTimeout == 0 {
+		profile.RequestTimeout = opts.timeout
+	}
+	profile.SkipTLSVerify = profile.SkipTLSVerify || opts.skipTLSVerify
+	return profile.NewClient()
+}
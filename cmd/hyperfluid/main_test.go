@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_QueryTable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dd-1/openapi/sales/public/orders" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("status.eq") != "active" || query.Get("__limit") != "5" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`[{"id": 1, "status": "active"}, {"id": 2, "status": "active", "note": "two words"}]`))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{
+		"-base-url", server.URL, "-token", "t", "-datadock", "dd-1",
+		"query", "-where", "status = active", "-limit", "5", "sales.public.orders",
+	}, &stdout, &stderr)
+
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "ID") || !strings.Contains(lines[2], "two words") {
+		t.Errorf("Unexpected table output:\n%s", stdout.String())
+	}
+}
+
+func TestRun_QueryStruct(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": 1, "note": null}, {"id": 2, "note": "two"}]`))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{
+		"-base-url", server.URL, "-token", "t", "-datadock", "dd-1",
+		"query", "-struct", "Order", "sales.public.orders",
+	}, &stdout, &stderr)
+
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "type Order struct") || !strings.Contains(stdout.String(), "*string") {
+		t.Errorf("Unexpected struct output:\n%s", stdout.String())
+	}
+}
+
+func TestRun_DataDockSleepJSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/data-docks/dd-2/sleep" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"status": "Stopping"}`))
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-base-url", server.URL, "-token", "t", "-o", "json", "datadock", "sleep", "dd-2"}, &stdout, &stderr)
+
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), `"status": "Stopping"`) {
+		t.Errorf("Unexpected JSON output: %s", stdout.String())
+	}
+}
+
+func TestRun_Profile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer staging-token" || r.URL.Path != "/data-docks/dd-flag/sleep" {
+			t.Errorf("Unexpected request %s %s", r.Header.Get("Authorization"), r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"status": "Stopping"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "config")
+	profiles := "staging:\n  base_url: " + server.URL + "\n  token: staging-token\n  datadock_id: dd-staging\n"
+	if err := os.WriteFile(path, []byte(profiles), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HYPERFLUID_CONFIG_FILE", path)
+	t.Setenv("HYPERFLUID_BASE_URL", "")
+	t.Setenv("HYPERFLUID_TOKEN", "")
+	t.Setenv("HYPERFLUID_DATADOCK_ID", "")
+
+	// The flags override the profile
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), []string{"-profile", "staging", "-datadock", "dd-flag", "datadock", "sleep"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr.String())
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"no command", []string{}, 2},
+		{"unknown command", []string{"-base-url", "http://localhost", "-token", "t", "nope"}, 2},
+		{"missing credentials", []string{"-base-url", "http://localhost", "query", "a.b.c"}, 1},
+		{"invalid table path", []string{"-base-url", "http://localhost", "-token", "t", "query", "a.b"}, 1},
+		{"missing datadock", []string{"-base-url", "http://localhost", "-token", "t", "datadock", "wake"}, 1},
+	}
+	t.Setenv("HYPERFLUID_TOKEN", "")
+	t.Setenv("HYPERFLUID_SERVICE_ACCOUNT", "")
+	t.Setenv("HYPERFLUID_SERVICE_ACCOUNT_FILE", "")
+	t.Setenv("HYPERFLUID_DATADOCK_ID", "")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(context.Background(), tt.args, &stdout, &stderr); code != tt.code {
+				t.Errorf("Expected exit code %d, got %d: %s", tt.code, code, stderr.String())
+			}
+		})
+	}
+}
+
+func TestParseWhere(t *testing.T) {
+	column, operator, value, err := parseWhere("  name  like  %john doe% ")
+	if err != nil || column != "name" || operator != "like" || value != "%john doe%" {
+		t.Errorf("parseWhere() = %q %q %q %v", column, operator, value, err)
+	}
+	if _, _, _, err := parseWhere("name ="); err == nil {
+		t.Error("Expected error for a filter without value")
+	}
+}
+
+func TestRenderTable(t *testing.T) {
+	var out bytes.Buffer
+	err := renderTable(&out, []any{
+		map[string]any{"id": float64(1), "tags": []any{"a", "b"}},
+		map[string]any{"id": float64(2), "name": "x"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "ID  TAGS       NAME\n1   [\"a\",\"b\"]  \n2              x\n"
+	if out.String() != expected {
+		t.Errorf("Unexpected output:\n%q\nexpected:\n%q", out.String(), expected)
+	}
+}
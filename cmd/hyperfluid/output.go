@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// render writes data in the requested output format.
+func render(w io.Writer, format string, data any) error {
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(data)
+	}
+	return renderTable(w, data)
+}
+
+// renderTable writes lists of objects as aligned columns, lists of scalars one
+// per line and single objects as key/value pairs.
+func renderTable(w io.Writer, data any) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	switch value := data.(type) {
+	case []map[string]any:
+		writeRows(tw, value)
+	case []any:
+		rows := make([]map[string]any, 0, len(value))
+		for _, item := range value {
+			row, ok := item.(map[string]any)
+			if !ok {
+				rows = nil
+				break
+			}
+			rows = append(rows, row)
+		}
+		if rows != nil || len(value) == 0 {
+			writeRows(tw, rows)
+		} else {
+			for _, item := range value {
+				fmt.Fprintln(tw, formatCell(item))
+			}
+		}
+	case []string:
+		for _, item := range value {
+			fmt.Fprintln(tw, item)
+		}
+	case map[string]any:
+		for _, key := range sortedKeys(value) {
+			fmt.Fprintf(tw, "%s\t%s\n", key, formatCell(value[key]))
+		}
+	default:
+		fmt.Fprintln(tw, formatCell(value))
+	}
+	return tw.Flush()
+}
+
+// writeRows writes a header with the union of the row keys, then one line per row.
+func writeRows(w io.Writer, rows []map[string]any) {
+	if len(rows) == 0 {
+		fmt.Fprintln(w, "(no rows)")
+		return
+	}
+
+	seen := map[string]bool{}
+	var columns []string
+	for _, row := range rows {
+		for _, key := range sortedKeys(row) {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+	}
+
+	fmt.Fprintln(w, strings.ToUpper(strings.Join(columns, "\t")))
+	cells := make([]string, len(columns))
+	for _, row := range rows {
+		for i, column := range columns {
+			cells[i] = formatCell(row[column])
+		}
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+}
+
+// formatCell renders a value on a single line. Nested values are written as JSON.
+func formatCell(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any, []any:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	}
+	return fmt.Sprint(value)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
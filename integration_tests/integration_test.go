@@ -6,11 +6,14 @@ import (
 	"testing"
 
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/sdktest"
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
 )
 
 func TestIntegration_GetData(t *testing.T) {
-	if testing.Short() {
+	// Replays testdata/recordings/get_data.json; HYPERFLUID_RECORD=1 records it against the platform
+	rec := sdktest.Start(t, "get_data")
+	if rec.Mode() == sdktest.ModeRecord && testing.Short() {
 		t.Skip("⏭️  Skipping integration test in short mode")
 	}
 
@@ -19,15 +22,15 @@ func TestIntegration_GetData(t *testing.T) {
 		t.Fatalf("Failed to get test config: %v", err)
 	}
 
-	testCatalog := os.Getenv("BIFROST_TEST_CATALOG")
-	testSchema := os.Getenv("BIFROST_TEST_SCHEMA")
-	testTable := os.Getenv("BIFROST_TEST_TABLE")
+	testCatalog := rec.Value("catalog", os.Getenv("BIFROST_TEST_CATALOG"))
+	testSchema := rec.Value("schema", os.Getenv("BIFROST_TEST_SCHEMA"))
+	testTable := rec.Value("table", os.Getenv("BIFROST_TEST_TABLE"))
 
 	if testCatalog == "" || testSchema == "" || testTable == "" {
 		t.Skip("⏭️  Skipping integration test because BIFROST_TEST_CATALOG, BIFROST_TEST_SCHEMA or BIFROST_TEST_TABLE are not set")
 	}
 
-	client := sdk.NewClient(config)
+	client := sdk.NewClient(rec.Configure(config))
 
 	resp, err := client.
 		Catalog(testCatalog).
@@ -60,6 +60,7 @@ func loadTestConfigFromEnv() utils.Configuration {
 	config := utils.Configuration{
 		BaseURL:        getEnv("HYPERFLUID_BASE_URL", ""),
 		OrgID:          getEnv("HYPERFLUID_ORG_ID", ""),
+		DataDockID:     getEnv("HYPERFLUID_DATADOCK_ID", ""),
 		Token:          getEnv("HYPERFLUID_TOKEN", ""),
 		RequestTimeout: time.Duration(getEnvInt("HYPERFLUID_REQUEST_TIMEOUT", 30)) * time.Second,
 		SkipTLSVerify:  getEnv("HYPERFLUID_SKIP_TLS_VERIFY", "false") == "true",
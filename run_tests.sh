@@ -13,6 +13,10 @@ case "$1" in
     echo "🔗 Running integration tests..."
     go test -v ./integration_tests
     ;;
+  bench)
+    echo "⏱️ Running benchmarks..."
+    go test ./benchmarks ./sdk/builders/fluent -run '^$' -bench . -benchmem
+    ;;
   all)
     echo "🚀 Running all tests..."
     echo ""
@@ -25,10 +29,11 @@ case "$1" in
     echo "✅ All tests completed!"
     ;;
   *)
-    echo "Usage: $0 {unit|integration|all}"
+    echo "Usage: $0 {unit|integration|bench|all}"
     echo ""
     echo "  unit        - Run fast unit tests"
     echo "  integration - Run integration tests (requires external services)"
+    echo "  bench       - Run benchmarks (see benchmarks/doc.go for profiling)"
     echo "  all         - Run all tests"
     exit 1
     ;;
@@ -2,7 +2,6 @@ package sdk
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -27,13 +26,20 @@ func (c *Client) hasKeycloakClientCredentials() bool {
 }
 
 func (c *Client) isKeycloakAuthMethodConfigured() bool {
-	return c.hasKeycloakPasswordGrantCredentials() || c.hasKeycloakClientCredentials()
+	return c.hasKeycloakPasswordGrantCredentials() || c.hasKeycloakClientCredentials() || c.federation != nil
 }
 
 // refreshToken attempts to refresh the access token using available Keycloak credentials.
-func (c *Client) refreshToken(ctx context.Context) (string, error) {
+func (c *Client) refreshToken(ctx context.Context) (token string, err error) {
 	authMutex.Lock()
 	defer authMutex.Unlock()
+	defer func() { c.metrics.recordTokenRefresh(err) }()
+	// Close cancels in-flight refreshes
+	ctx, cancel := c.lifecycle.bind(ctx)
+	defer cancel()
+	if err := c.resolveEndpoints(ctx, false); err != nil {
+		return "", err
+	}
 
 	// Note: This is a simplified implementation.
 	// In production, you should:
@@ -43,10 +49,40 @@ func (c *Client) refreshToken(ctx context.Context) (string, error) {
 	//
 	// For now, we always refresh when this is called (typically on 401 errors)
 
+	if c.subjectToken != "" {
+		newToken, err := c.refreshAccessTokenTokenExchange(ctx)
+		if err != nil {
+			return "", err
+		}
+		c.config.Token = newToken
+		return newToken, nil
+	}
+
+	if c.federation != nil {
+		newToken, err := c.refreshAccessTokenWorkloadIdentity(ctx)
+		if err != nil {
+			return "", err
+		}
+		c.config.Token = newToken
+		c.saveToken(ctx, newToken)
+		return newToken, nil
+	}
+
+	if c.serviceAccounts != nil {
+		newToken, err := c.refreshWithServiceAccounts(ctx)
+		if err != nil {
+			return "", err
+		}
+		c.config.Token = newToken
+		c.saveToken(ctx, newToken)
+		return newToken, nil
+	}
+
 	if c.hasKeycloakClientCredentials() {
 		newToken, err := c.refreshAccessTokenClientCredentials(ctx)
 		if err == nil {
 			c.config.Token = newToken
+			c.saveToken(ctx, newToken)
 			return newToken, nil
 		}
 		// Log error but try password grant as fallback if configured
@@ -57,6 +93,7 @@ func (c *Client) refreshToken(ctx context.Context) (string, error) {
 		newToken, err := c.refreshAccessTokenPasswordGrant(ctx)
 		if err == nil {
 			c.config.Token = newToken
+			c.saveToken(ctx, newToken)
 			return newToken, nil
 		}
 		return "", fmt.Errorf("%w: password grant failed: %w", utils.ErrAuthenticationFailed, err)
@@ -88,16 +125,15 @@ func (c *Client) refreshAccessTokenPasswordGrant(ctx context.Context) (string, e
 
 // exchangeKeycloakToken sends the request to Keycloak's token endpoint.
 func (c *Client) exchangeKeycloakToken(ctx context.Context, form url.Values) (string, error) {
-	if c.config.KeycloakBaseURL == "" || c.config.KeycloakRealm == "" {
+	if c.oidcIssuer() == "" {
 		return "", fmt.Errorf("%w: Keycloak base URL or realm not configured", utils.ErrInvalidConfiguration)
 	}
+	tokenURL, err := c.tokenEndpoint(ctx, form.Get("grant_type"))
+	if err != nil {
+		return "", err
+	}
 
-	req, err := http.NewRequestWithContext(
-		ctx,
-		"POST",
-		fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token", c.config.KeycloakBaseURL, c.config.KeycloakRealm),
-		strings.NewReader(form.Encode()),
-	)
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(form.Encode()))
 	if err != nil {
 		return "", fmt.Errorf("%w: cannot create Keycloak request: %w", utils.ErrInvalidRequest, err)
 	}
@@ -105,11 +141,14 @@ func (c *Client) exchangeKeycloakToken(ctx context.Context, form url.Values) (st
 
 	// Use a dedicated HTTP client for Keycloak to avoid potential deadlocks
 	// if the main client's transport relies on token refresh itself.
-	keycloakClient := &http.Client{
-		Timeout: c.config.RequestTimeout, // Use the same timeout as main requests
+	// The underlying connection pool is shared with the main client.
+	transport, err := utils.ClientTransport(c.config)
+	if err != nil {
+		return "", err
 	}
-	if c.config.SkipTLSVerify {
-		keycloakClient.Transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	keycloakClient := &http.Client{
+		Transport: transport,
+		Timeout:   c.config.RequestTimeout, // Use the same timeout as main requests
 	}
 
 	resp, err := keycloakClient.Do(req)
@@ -122,7 +161,18 @@ func (c *Client) exchangeKeycloakToken(ctx context.Context, form url.Values) (st
 	_ = resp.Body.Close()            // Always close after reading (error ignored - we already have the body)
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("%w: Keycloak token exchange failed (%d): %s", utils.ErrAuthenticationFailed, resp.StatusCode, body)
+		// Never echo the submitted credentials in the error
+		detail := utils.RedactText(string(body), form.Get("client_secret"), form.Get("password"), form.Get("subject_token"))
+		keycloakErr := &utils.KeycloakError{StatusCode: resp.StatusCode, Body: detail}
+		var oauthErr struct {
+			Error            string `json:"error"`
+			ErrorDescription string `json:"error_description"`
+		}
+		if json.Unmarshal(body, &oauthErr) == nil {
+			keycloakErr.Code = oauthErr.Error
+			keycloakErr.Description = utils.RedactText(oauthErr.ErrorDescription, form.Get("client_secret"), form.Get("password"), form.Get("subject_token"))
+		}
+		return "", keycloakErr
 	}
 
 	var parsed map[string]any
@@ -133,6 +183,11 @@ func (c *Client) exchangeKeycloakToken(ctx context.Context, form url.Values) (st
 	if !ok || token == "" {
 		return "", fmt.Errorf("%w: missing access_token in Keycloak response", utils.ErrAuthenticationFailed)
 	}
+	if c.config.VerifyTokens {
+		if _, err := c.VerifyToken(ctx, token, VerifyOptions{}); err != nil {
+			return "", fmt.Errorf("%w: issued token rejected: %w", utils.ErrAuthenticationFailed, err)
+		}
+	}
 
 	return token, nil
 }
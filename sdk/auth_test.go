@@ -0,0 +1,75 @@
+package sdk
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestKeycloakErrorClasses(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"unknown realm", http.StatusNotFound, `{"error":"Realm does not exist"}`, utils.ErrRealmNotFound},
+		{"expired subject token", http.StatusBadRequest, `{"error":"invalid_grant","error_description":"Token is not active"}`, utils.ErrTokenExpired},
+		{"bad credentials", http.StatusUnauthorized, `{"error":"unauthorized_client","error_description":"Invalid client secret"}`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(utils.Configuration{
+				BaseURL:              server.URL,
+				Token:                "sa-token",
+				KeycloakBaseURL:      server.URL,
+				KeycloakRealm:        "test",
+				KeycloakClientID:     "sa",
+				KeycloakClientSecret: "secret",
+			})
+			_, err := client.Impersonate(context.Background(), "user-token")
+
+			var keycloakErr *utils.KeycloakError
+			if !errors.As(err, &keycloakErr) || keycloakErr.StatusCode != tt.status {
+				t.Fatalf("Impersonate() error = %v, want a *KeycloakError with status %d", err, tt.status)
+			}
+			if !errors.Is(err, utils.ErrAuthenticationFailed) {
+				t.Errorf("expected ErrAuthenticationFailed, got %v", err)
+			}
+			for _, class := range []error{utils.ErrRealmNotFound, utils.ErrTokenExpired} {
+				if errors.Is(err, class) != (class == tt.want) {
+					t.Errorf("unexpected errors.Is(err, %v) for %v", class, err)
+				}
+			}
+		})
+	}
+}
+
+func TestExpiredStaticToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	claims := fmt.Sprintf(`{"exp":%d}`, time.Now().Add(-time.Hour).Unix())
+	expired := "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".sig"
+	client := NewClient(utils.Configuration{BaseURL: server.URL, DataDockID: "dd", Token: expired})
+
+	_, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background())
+	if !errors.Is(err, utils.ErrTokenExpired) || !errors.Is(err, utils.ErrAuthenticationFailed) {
+		t.Errorf("expected an expired token error, got %v", err)
+	}
+}
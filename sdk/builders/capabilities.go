@@ -0,0 +1,49 @@
+package builders
+
+import (
+	"context"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// CapabilityProvider is implemented by clients that discover the platform capabilities.
+type CapabilityProvider interface {
+	Capabilities(ctx context.Context) (*utils.Capabilities, error)
+	// CachedCapabilities returns the capabilities discovered so far, or nil.
+	CachedCapabilities() *utils.Capabilities
+}
+
+// CheckCapabilities runs check against the capabilities of the platform. They are
+// discovered on first use when Configuration.DiscoverCapabilities is set; otherwise
+// only capabilities already discovered through the client are checked. Discovery
+// failures do not block the request: it is sent as is and the server decides.
+func CheckCapabilities(ctx context.Context, client any, check func(*utils.Capabilities) error) error {
+	provider, ok := client.(CapabilityProvider)
+	if !ok {
+		return nil
+	}
+	capabilities := provider.CachedCapabilities()
+	if capabilities == nil {
+		configured, ok := client.(interface{ GetConfig() utils.Configuration })
+		if !ok || !configured.GetConfig().DiscoverCapabilities {
+			return nil
+		}
+		var err error
+		if capabilities, err = provider.Capabilities(ctx); err != nil {
+			return nil
+		}
+	}
+	return check(capabilities)
+}
+
+// RequireFeature fails with ErrUnsupportedFeature when the platform reported that an
+// optional feature (e.g. utils.FeatureSearch) is not available. description names
+// the feature in the error.
+func RequireFeature(ctx context.Context, client any, feature, description string) error {
+	return CheckCapabilities(ctx, client, func(caps *utils.Capabilities) error {
+		if !caps.SupportsFeature(feature) {
+			return caps.Unsupported(description)
+		}
+		return nil
+	})
+}
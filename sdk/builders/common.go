@@ -2,6 +2,7 @@ package builders
 
 import (
 	"context"
+	"io"
 
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
 )
@@ -12,3 +13,9 @@ type ClientInterface interface {
 	Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error)
 	GetConfig() utils.Configuration
 }
+
+// Downloader is implemented by clients that stream binary responses, such as
+// server-side exports, without decoding them as JSON.
+type Downloader interface {
+	Download(ctx context.Context, endpoint string) (io.ReadCloser, utils.ContentInfo, error)
+}
@@ -0,0 +1,146 @@
+package fluent
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// CheckpointStore persists the high-water marks of incremental readers.
+// Implementations must be safe for concurrent use by different names.
+type CheckpointStore interface {
+	// Load returns the checkpoint saved under name, or utils.ErrNotFound.
+	Load(ctx context.Context, name string) (string, error)
+	Save(ctx context.Context, name, value string) error
+}
+
+// fileCheckpointStore keeps one file per checkpoint in a directory.
+type fileCheckpointStore struct {
+	dir string
+}
+
+// NewFileCheckpointStore returns a CheckpointStore writing one file per
+// checkpoint in dir. Files are replaced atomically.
+func NewFileCheckpointStore(dir string) CheckpointStore {
+	return &fileCheckpointStore{dir: dir}
+}
+
+// path maps a name to a file name that is safe whatever the name contains.
+func (s *fileCheckpointStore) path(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:16])+".checkpoint")
+}
+
+func (s *fileCheckpointStore) Load(ctx context.Context, name string) (string, error) {
+	value, err := os.ReadFile(s.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", utils.ErrNotFound
+	}
+	return string(value), err
+}
+
+func (s *fileCheckpointStore) Save(ctx context.Context, name, value string) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, ".checkpoint-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.WriteString(value); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(name))
+}
+
+// s3CheckpointStore keeps one object per checkpoint.
+type s3CheckpointStore struct {
+	s3     *S3Builder
+	bucket string
+	prefix string
+}
+
+// NewS3CheckpointStore returns a CheckpointStore writing one object per checkpoint
+// under "<prefix><name>" in the bucket, so that extractions running on different
+// hosts share their progress.
+func NewS3CheckpointStore(s3 *S3Builder, bucket, prefix string) CheckpointStore {
+	return &s3CheckpointStore{s3: s3, bucket: bucket, prefix: prefix}
+}
+
+// object returns a builder of the checkpoint object, leaving the shared builder untouched.
+func (s *s3CheckpointStore) object(name string) *S3Builder {
+	object := *s.s3
+	object.errors = append([]error(nil), s.s3.errors...)
+	return object.Bucket(s.bucket).Key(s.prefix + name)
+}
+
+func (s *s3CheckpointStore) Load(ctx context.Context, name string) (string, error) {
+	obj, err := s.object(name).Get(ctx)
+	if errors.Is(err, utils.ErrObjectNotFound) {
+		return "", utils.ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = obj.Body.Close() }()
+	value, err := io.ReadAll(obj.Body)
+	return string(value), err
+}
+
+func (s *s3CheckpointStore) Save(ctx context.Context, name, value string) error {
+	_, err := s.object(name).Put(ctx, bytes.NewReader([]byte(value)), "text/plain")
+	return err
+}
+
+// tableCheckpointStore keeps one row per checkpoint.
+type tableCheckpointStore struct {
+	table *QueryBuilder
+}
+
+// NewTableCheckpointStore returns a CheckpointStore keeping one row per checkpoint
+// in a table with a "name" and a "value" text column, "name" being unique.
+//
+// Example:
+//
+//	store := fluent.NewTableCheckpointStore(client.Catalog("ops").Schema("public").Table("checkpoints"))
+func NewTableCheckpointStore(table *QueryBuilder) CheckpointStore {
+	return &tableCheckpointStore{table: table}
+}
+
+func (s *tableCheckpointStore) Load(ctx context.Context, name string) (string, error) {
+	var row struct {
+		Value string `json:"value"`
+	}
+	if err := s.table.Select("value").Where("name", "=", name).First(ctx, &row); err != nil {
+		return "", err
+	}
+	return row.Value, nil
+}
+
+func (s *tableCheckpointStore) Save(ctx context.Context, name, value string) error {
+	_, err := s.Load(ctx, name)
+	switch {
+	case errors.Is(err, utils.ErrNotFound):
+		_, err = s.table.Post(ctx, map[string]any{"name": name, "value": value})
+	case err == nil:
+		_, err = s.table.Where("name", "=", name).Put(ctx, map[string]any{"value": value})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save checkpoint %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// AssertionError reports a failed data quality assertion. It wraps utils.ErrCheckFailed.
+type AssertionError struct {
+	Check  string
+	Detail string
+}
+
+func (e *AssertionError) Error() string {
+	return fmt.Sprintf("%v: %s: %s", utils.ErrCheckFailed, e.Check, e.Detail)
+}
+
+func (e *AssertionError) Unwrap() error {
+	return utils.ErrCheckFailed
+}
+
+// AssertRowCount fails with a *AssertionError unless the exact count of matching rows
+// is between min and max, inclusive. A negative max means no upper bound.
+//
+// Example:
+//
+//	err := qb.Where("day", "=", today).AssertRowCount(ctx, 1, -1)
+func (qb *QueryBuilder) AssertRowCount(ctx context.Context, min, max int) error {
+	count, err := qb.Count(ctx)
+	if err != nil {
+		return err
+	}
+	check := fmt.Sprintf("row count of %s", qb.tableName)
+	if count < min {
+		return &AssertionError{Check: check, Detail: fmt.Sprintf("%d rows, expected at least %d", count, min)}
+	}
+	if max >= 0 && count > max {
+		return &AssertionError{Check: check, Detail: fmt.Sprintf("%d rows, expected at most %d", count, max)}
+	}
+	return nil
+}
+
+// AssertNoNulls fails with a *AssertionError when a matching row has a NULL (or missing)
+// value in one of the columns. The filter protocol cannot select NULL values, so
+// the rows are scanned page by page (see Iter) with only these columns selected.
+//
+// Example:
+//
+//	err := qb.AssertNoNulls(ctx, "customer_id", "total")
+func (qb *QueryBuilder) AssertNoNulls(ctx context.Context, columns ...string) error {
+	if len(columns) == 0 {
+		return fmt.Errorf("%w: no columns to check", utils.ErrInvalidRequest)
+	}
+	scan := qb.clone()
+	scan.selectCols = append([]string(nil), columns...)
+
+	nulls := make(map[string]int, len(columns))
+	for row, err := range scan.Iter(ctx) {
+		if err != nil {
+			return err
+		}
+		for _, column := range columns {
+			if row[column] == nil {
+				nulls[column]++
+			}
+		}
+	}
+	if len(nulls) == 0 {
+		return nil
+	}
+
+	details := make([]string, 0, len(nulls))
+	for column, count := range nulls {
+		details = append(details, fmt.Sprintf("%s has %d NULL values", column, count))
+	}
+	sort.Strings(details)
+	return &AssertionError{Check: fmt.Sprintf("no NULLs in %s", qb.tableName), Detail: strings.Join(details, ", ")}
+}
+
+// DataCheck is a named data quality check, failing with a *AssertionError, or any
+// other error when it could not run.
+type DataCheck struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// RowCountCheck returns a DataCheck running AssertRowCount.
+func (qb *QueryBuilder) RowCountCheck(name string, min, max int) DataCheck {
+	return DataCheck{Name: name, Run: func(ctx context.Context) error { return qb.AssertRowCount(ctx, min, max) }}
+}
+
+// NoNullsCheck returns a DataCheck running AssertNoNulls.
+func (qb *QueryBuilder) NoNullsCheck(name string, columns ...string) DataCheck {
+	return DataCheck{Name: name, Run: func(ctx context.Context) error { return qb.AssertNoNulls(ctx, columns...) }}
+}
+
+// CheckStatus is the outcome of a DataCheck.
+type CheckStatus string
+
+const (
+	CheckPassed  CheckStatus = "passed"
+	CheckFailed  CheckStatus = "failed" // The assertion does not hold
+	CheckErrored CheckStatus = "error"  // The check could not run
+)
+
+// CheckResult is the outcome of one DataCheck of a report.
+type CheckResult struct {
+	Name     string        `json:"name"`
+	Status   CheckStatus   `json:"status"`
+	Detail   string        `json:"detail,omitempty"`
+	Duration time.Duration `json:"duration"`
+	Err      error         `json:"-"`
+}
+
+// CheckReport is the outcome of RunDataChecks, with one result per check in order.
+type CheckReport struct {
+	Results  []CheckResult `json:"results"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Passed reports whether every check passed.
+func (r *CheckReport) Passed() bool {
+	for _, result := range r.Results {
+		if result.Status != CheckPassed {
+			return false
+		}
+	}
+	return true
+}
+
+// Err returns the errors of the checks that did not pass, joined, or nil.
+func (r *CheckReport) Err() error {
+	var errs []error
+	for _, result := range r.Results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Name, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RunDataChecks runs the checks in order and reports each outcome. A failing or
+// erroring check does not stop the following ones; a cancelled ctx does.
+//
+// Example:
+//
+//	orders := client.Catalog("sales").Schema("public").Table("orders").Where("day", "=", today)
+//	report := fluent.RunDataChecks(ctx,
+//	    orders.RowCountCheck("orders loaded", 1, -1),
+//	    orders.NoNullsCheck("orders have a customer", "customer_id"),
+//	)
+//	if !report.Passed() {
+//	    log.Fatal(report.Err())
+//	}
+func RunDataChecks(ctx context.Context, checks ...DataCheck) *CheckReport {
+	report := &CheckReport{Results: make([]CheckResult, 0, len(checks))}
+	started := time.Now()
+	for _, check := range checks {
+		if ctx.Err() != nil {
+			report.Results = append(report.Results, CheckResult{Name: check.Name, Status: CheckErrored, Detail: ctx.Err().Error(), Err: ctx.Err()})
+			continue
+		}
+
+		checkStarted := time.Now()
+		err := check.Run(ctx)
+		result := CheckResult{Name: check.Name, Status: CheckPassed, Duration: time.Since(checkStarted), Err: err}
+		var checkErr *AssertionError
+		switch {
+		case errors.As(err, &checkErr):
+			result.Status, result.Detail = CheckFailed, checkErr.Detail
+		case err != nil:
+			result.Status, result.Detail = CheckErrored, err.Error()
+		}
+		report.Results = append(report.Results, result)
+	}
+	report.Duration = time.Since(started)
+	return report
+}
@@ -0,0 +1,116 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// checksTestClient serves a table: HEAD requests report its row count, GET
+// requests a page of the selected columns.
+type checksTestClient struct {
+	rows    []map[string]any
+	selects []string
+}
+
+func (c *checksTestClient) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	if method == "HEAD" {
+		return &utils.Response{Status: utils.StatusOK, TotalCount: int64(len(c.rows))}, nil
+	}
+	parsed, _ := url.Parse(endpoint)
+	query := parsed.Query()
+	c.selects = append(c.selects, query.Get("__select"))
+	limit, _ := strconv.Atoi(query.Get("__limit"))
+	offset, _ := strconv.Atoi(query.Get("__offset"))
+
+	page := []any{}
+	for i := offset; i < len(c.rows) && len(page) < limit; i++ {
+		row := map[string]any{}
+		for _, column := range strings.Split(query.Get("__select"), ",") {
+			if value, ok := c.rows[i][column]; ok {
+				row[column] = value
+			}
+		}
+		page = append(page, row)
+	}
+	return &utils.Response{Status: utils.StatusOK, Data: page}, nil
+}
+
+func (c *checksTestClient) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://test.example.com", DataDockID: "dd"}
+}
+
+func newChecksTestQuery() (*checksTestClient, *QueryBuilder) {
+	client := &checksTestClient{rows: []map[string]any{
+		{"id": 1, "customer_id": 10, "total": 5},
+		{"id": 2, "customer_id": nil, "total": 7},
+		{"id": 3, "total": nil},
+	}}
+	return client, NewQueryBuilder(client).Catalog("sales").Schema("public").Table("orders").Limit(2)
+}
+
+func TestQueryBuilder_AssertRowCount(t *testing.T) {
+	_, qb := newChecksTestQuery()
+	ctx := context.Background()
+
+	if err := qb.AssertRowCount(ctx, 1, -1); err != nil {
+		t.Errorf("AssertRowCount(1, -1) unexpected error = %v", err)
+	}
+	var assertionErr *AssertionError
+	if err := qb.AssertRowCount(ctx, 5, 10); !errors.As(err, &assertionErr) || !errors.Is(err, utils.ErrCheckFailed) {
+		t.Errorf("Expected an AssertionError, got %v", err)
+	} else if assertionErr.Detail != "3 rows, expected at least 5" {
+		t.Errorf("Detail = %q", assertionErr.Detail)
+	}
+	if err := qb.AssertRowCount(ctx, 0, 2); !errors.Is(err, utils.ErrCheckFailed) {
+		t.Errorf("Expected ErrCheckFailed over the maximum, got %v", err)
+	}
+}
+
+func TestQueryBuilder_AssertNoNulls(t *testing.T) {
+	client, qb := newChecksTestQuery()
+	ctx := context.Background()
+
+	if err := qb.AssertNoNulls(ctx, "id"); err != nil {
+		t.Errorf("AssertNoNulls(id) unexpected error = %v", err)
+	}
+	var assertionErr *AssertionError
+	if err := qb.AssertNoNulls(ctx, "customer_id", "total"); !errors.As(err, &assertionErr) {
+		t.Fatalf("Expected an AssertionError, got %v", err)
+	}
+	if assertionErr.Detail != "customer_id has 2 NULL values, total has 1 NULL values" {
+		t.Errorf("Detail = %q", assertionErr.Detail)
+	}
+	if client.selects[len(client.selects)-1] != "customer_id,total" {
+		t.Errorf("Expected only the checked columns to be selected, got %v", client.selects)
+	}
+	if err := qb.AssertNoNulls(ctx); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest without columns, got %v", err)
+	}
+}
+
+func TestRunDataChecks(t *testing.T) {
+	_, qb := newChecksTestQuery()
+	broken := errors.New("connection refused")
+
+	report := RunDataChecks(context.Background(),
+		qb.RowCountCheck("orders loaded", 1, -1),
+		qb.NoNullsCheck("orders have a customer", "customer_id"),
+		DataCheck{Name: "custom", Run: func(ctx context.Context) error { return broken }},
+	)
+	if report.Passed() || len(report.Results) != 3 {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+	statuses := []CheckStatus{report.Results[0].Status, report.Results[1].Status, report.Results[2].Status}
+	if statuses[0] != CheckPassed || statuses[1] != CheckFailed || statuses[2] != CheckErrored {
+		t.Errorf("Unexpected statuses %v", statuses)
+	}
+	if err := report.Err(); !errors.Is(err, utils.ErrCheckFailed) || !errors.Is(err, broken) {
+		t.Errorf("Err() = %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Sources of a CostEstimate.
+const (
+	CostSourceExplain = "explain" // Query plan of the backend (Trino EXPLAIN)
+	CostSourcePlanner = "planner" // Planned row count only
+)
+
+// CostEstimate is the expected cost of running a query. Unknown values are -1.
+type CostEstimate struct {
+	ScannedBytes int64
+	Rows         int64
+	Source       string // CostSourceExplain or CostSourcePlanner
+}
+
+// CostLimitError reports a query refused because its estimated scan exceeds the
+// budget set with MaxScannedBytes. It wraps utils.ErrInvalidRequest.
+type CostLimitError struct {
+	Estimate        CostEstimate
+	MaxScannedBytes int64
+}
+
+func (e *CostLimitError) Error() string {
+	return fmt.Sprintf("%v: query would scan about %d bytes, over the budget of %d bytes",
+		utils.ErrInvalidRequest, e.Estimate.ScannedBytes, e.MaxScannedBytes)
+}
+
+func (e *CostLimitError) Unwrap() error {
+	return utils.ErrInvalidRequest
+}
+
+// MaxScannedBytes sets a budget on the bytes a query may scan. Get and Iter then
+// estimate the cost first and fail with a *CostLimitError instead of running a query
+// over budget. Queries whose scan cannot be estimated run normally. 0 disables the guard.
+func (qb *QueryBuilder) MaxScannedBytes(n int64) *QueryBuilder {
+	qb = qb.clone()
+	if n < 0 {
+		qb.errors = append(qb.errors, fmt.Errorf("max scanned bytes cannot be negative"))
+		return qb
+	}
+	qb.maxScannedBytes = n
+	return qb
+}
+
+// EstimateCost returns the expected scanned bytes and rows of the query, without
+// running it. The backend query plan is used when the platform exposes it; otherwise
+// only the planned row count is returned, with ScannedBytes unknown.
+//
+// Example:
+//
+//	estimate, err := client.Catalog("sales").Schema("public").Table("orders").EstimateCost(ctx)
+//	if estimate.ScannedBytes > 10<<30 {
+//	    // Narrow the query down first
+//	}
+func (qb *QueryBuilder) EstimateCost(ctx context.Context) (*CostEstimate, error) {
+	if err := qb.validate(); err != nil {
+		return nil, err
+	}
+
+	if builders.RequireFeature(ctx, qb.client, utils.FeatureCostEstimate, "cost estimation") == nil {
+		params := qb.buildParams()
+		params.Del("__offset")
+		endpoint := qb.buildEndpoint() + "/estimate?" + params.Encode()
+
+		resp, err := qb.do(utils.ContextWithoutTransformers(ctx), "GET", endpoint, nil)
+		if err == nil {
+			if data, ok := resp.GetDataAsMap(); ok {
+				if estimate, ok := parseCostEstimate(data); ok {
+					return estimate, nil
+				}
+			}
+		} else if !errors.Is(err, utils.ErrNotFound) && !errors.Is(err, utils.ErrInvalidRequest) {
+			return nil, err
+		}
+		// Endpoint not available on this platform: fall back to the planner
+	}
+
+	rows, err := qb.CountWithMode(ctx, CountPlanned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate query cost: %w", err)
+	}
+	if qb.limitVal > 0 && rows > qb.limitVal {
+		rows = qb.limitVal
+	}
+	return &CostEstimate{ScannedBytes: -1, Rows: int64(rows), Source: CostSourcePlanner}, nil
+}
+
+// checkCost enforces MaxScannedBytes.
+func (qb *QueryBuilder) checkCost(ctx context.Context) error {
+	if qb.maxScannedBytes == 0 {
+		return nil
+	}
+	estimate, err := qb.EstimateCost(ctx)
+	if err != nil {
+		return err
+	}
+	if estimate.ScannedBytes > qb.maxScannedBytes {
+		return &CostLimitError{Estimate: *estimate, MaxScannedBytes: qb.maxScannedBytes}
+	}
+	return nil
+}
+
+// parseCostEstimate reads an estimate in the platform format ({"scanned_bytes",
+// "rows"}) or in the Trino EXPLAIN (TYPE IO, FORMAT JSON) format.
+func parseCostEstimate(data map[string]any) (*CostEstimate, bool) {
+	estimate := &CostEstimate{ScannedBytes: -1, Rows: -1, Source: CostSourceExplain}
+
+	if bytes, ok := estimateNumber(data["scanned_bytes"]); ok {
+		estimate.ScannedBytes = bytes
+	}
+	if rows, ok := estimateNumber(data["rows"]); ok {
+		estimate.Rows = rows
+	}
+
+	if inputs, ok := data["inputTableColumnInfos"].([]any); ok {
+		var total int64
+		known := len(inputs) > 0
+		for _, input := range inputs {
+			info, _ := input.(map[string]any)
+			inputEstimate, _ := info["estimate"].(map[string]any)
+			bytes, ok := estimateNumber(inputEstimate["outputSizeInBytes"])
+			if !ok {
+				known = false
+				break
+			}
+			total += bytes
+		}
+		if known {
+			estimate.ScannedBytes = total
+		}
+		if output, ok := data["estimate"].(map[string]any); ok {
+			if rows, ok := estimateNumber(output["outputRowCount"]); ok {
+				estimate.Rows = rows
+			}
+		}
+	}
+
+	return estimate, estimate.ScannedBytes >= 0 || estimate.Rows >= 0
+}
+
+// estimateNumber reads a non-negative JSON number; Trino reports unknown values as "NaN".
+func estimateNumber(value any) (int64, bool) {
+	number, ok := utils.Float64(value)
+	if !ok || number < 0 || number != number {
+		return 0, false
+	}
+	return int64(number), true
+}
@@ -0,0 +1,107 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// costHandler answers /estimate requests with estimate (404 when empty) and records the other requests.
+func costHandler(estimate string, queried *[]string) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/estimate") {
+			if estimate == "" {
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(estimate))}, nil
+		}
+		*queried = append(*queried, req.Method+" "+req.URL.Path)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"id": 1}]`))}, nil
+	}
+}
+
+func TestQueryBuilder_EstimateCostTrinoExplain(t *testing.T) {
+	var queried []string
+	explain := `{"inputTableColumnInfos": [
+		{"table": {"schemaTable": {"table": "orders"}}, "estimate": {"outputRowCount": 10, "outputSizeInBytes": 1000}},
+		{"table": {"schemaTable": {"table": "customers"}}, "estimate": {"outputRowCount": 5, "outputSizeInBytes": 500}}
+	], "estimate": {"outputRowCount": 10, "outputSizeInBytes": "NaN"}}`
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, costHandler(explain, &queried))
+
+	estimate, err := qb.Catalog("c").Schema("s").Table("orders").EstimateCost(context.Background())
+	if err != nil {
+		t.Fatalf("EstimateCost() unexpected error = %v", err)
+	}
+	if estimate.ScannedBytes != 1500 || estimate.Rows != 10 || estimate.Source != CostSourceExplain {
+		t.Errorf("EstimateCost() = %+v", estimate)
+	}
+	if len(queried) != 0 {
+		t.Errorf("estimating must not run the query, got %v", queried)
+	}
+}
+
+func TestQueryBuilder_MaxScannedBytes(t *testing.T) {
+	t.Run("over budget", func(t *testing.T) {
+		var queried []string
+		qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, costHandler(`{"scanned_bytes": 5000, "rows": 1}`, &queried))
+
+		_, err := qb.Catalog("c").Schema("s").Table("t").MaxScannedBytes(1000).Get(context.Background())
+		var costErr *CostLimitError
+		if !errors.As(err, &costErr) || !errors.Is(err, utils.ErrInvalidRequest) {
+			t.Fatalf("Get() error = %v, want a *CostLimitError", err)
+		}
+		if costErr.Estimate.ScannedBytes != 5000 || costErr.MaxScannedBytes != 1000 {
+			t.Errorf("unexpected error details: %+v", costErr)
+		}
+		if len(queried) != 0 {
+			t.Errorf("query over budget was sent: %v", queried)
+		}
+	})
+
+	t.Run("within budget", func(t *testing.T) {
+		var queried []string
+		qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, costHandler(`{"scanned_bytes": 500, "rows": 1}`, &queried))
+
+		rows := 0
+		for _, err := range qb.Catalog("c").Schema("s").Table("t").MaxScannedBytes(1000).Iter(context.Background()) {
+			if err != nil {
+				t.Fatalf("Iter() unexpected error = %v", err)
+			}
+			rows++
+		}
+		if rows != 1 || len(queried) != 1 {
+			t.Errorf("expected the query to run once, got %d rows and requests %v", rows, queried)
+		}
+	})
+
+	t.Run("no estimate available", func(t *testing.T) {
+		var queried []string
+		qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, costHandler("", &queried))
+		table := qb.Catalog("c").Schema("s").Table("t")
+
+		estimate, err := table.EstimateCost(context.Background())
+		if err != nil {
+			t.Fatalf("EstimateCost() unexpected error = %v", err)
+		}
+		if estimate.Source != CostSourcePlanner || estimate.ScannedBytes != -1 {
+			t.Errorf("EstimateCost() = %+v, want a planner estimate", estimate)
+		}
+
+		queried = nil
+		if _, err := table.MaxScannedBytes(1000).Get(context.Background()); err != nil {
+			t.Fatalf("Get() unexpected error = %v", err)
+		}
+		if last := queried[len(queried)-1]; last != "GET /dd/openapi/c/s/t" {
+			t.Errorf("expected the query to run, last request %q", last)
+		}
+	})
+
+	if _, err := NewQueryBuilder(&mockClient{}).MaxScannedBytes(-1).Catalog("c").Schema("s").Table("t").DataDock("dd").Get(context.Background()); err == nil {
+		t.Error("expected an error for a negative budget")
+	}
+}
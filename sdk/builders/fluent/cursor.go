@@ -0,0 +1,196 @@
+package fluent
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// defaultIterPageSize is the page size used by Iter when no Limit is set.
+const defaultIterPageSize = 100
+
+// keysetCursorPrefix marks cursors generated by the SDK from the last row of a page.
+// Any other cursor is an opaque continuation token issued by the server.
+const keysetCursorPrefix = "k1."
+
+// keysetCursor is the decoded form of an SDK-generated cursor.
+type keysetCursor struct {
+	Column    string `json:"c"`
+	Direction string `json:"d"`
+	Value     any    `json:"v"`
+}
+
+func encodeKeysetCursor(cursor keysetCursor) string {
+	return keysetCursorPrefix + base64.RawURLEncoding.EncodeToString(utils.JsonMarshal(cursor))
+}
+
+func decodeKeysetCursor(cursor string) (*keysetCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(cursor, keysetCursorPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var decoded keysetCursor
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return &decoded, nil
+}
+
+// After resumes the query after the given cursor, as returned in Response.NextCursor.
+// Cursors replace Offset: the offset is ignored when a cursor is set.
+func (qb *QueryBuilder) After(cursor string) *QueryBuilder {
+	qb = qb.clone()
+	qb.cursor = cursor
+	if strings.HasPrefix(cursor, keysetCursorPrefix) {
+		if _, err := decodeKeysetCursor(cursor); err != nil {
+			qb.errors = append(qb.errors, err)
+		}
+	}
+	return qb
+}
+
+// keysetOrder returns the ordering used for keyset pagination. Keyset cursors
+// are only generated for queries ordered on exactly one column, which should be unique.
+func (qb *QueryBuilder) keysetOrder() (column, direction string, ok bool) {
+	if len(qb.orderBy) != 1 {
+		return "", "", false
+	}
+	return qb.orderBy[0].Column, qb.orderBy[0].Direction, true
+}
+
+// validateCursor checks that a keyset cursor matches the query ordering.
+func (qb *QueryBuilder) validateCursor() error {
+	if !strings.HasPrefix(qb.cursor, keysetCursorPrefix) {
+		return nil
+	}
+	cursor, err := decodeKeysetCursor(qb.cursor)
+	if err != nil {
+		return fmt.Errorf("%w: %w", utils.ErrInvalidRequest, err)
+	}
+	column, direction, ok := qb.keysetOrder()
+	if !ok || column != cursor.Column || direction != cursor.Direction {
+		return fmt.Errorf("%w: cursor was issued for ORDER BY %s %s", utils.ErrInvalidRequest, cursor.Column, cursor.Direction)
+	}
+	return nil
+}
+
+// applyCursor adds the cursor to the query parameters, replacing the offset.
+func (qb *QueryBuilder) applyCursor(params url.Values) {
+	if qb.cursor == "" {
+		return
+	}
+	params.Del("__offset")
+
+	if !strings.HasPrefix(qb.cursor, keysetCursorPrefix) {
+		params.Set("__cursor", qb.cursor)
+		return
+	}
+	cursor, err := decodeKeysetCursor(qb.cursor)
+	if err != nil {
+		return // reported by validate
+	}
+	op := "gt"
+	if cursor.Direction == "DESC" {
+		op = "lt"
+	}
+	params.Add(cursor.Column+"."+op, formatCursorValue(cursor.Value))
+}
+
+// formatCursorValue renders a JSON value as a filter value, keeping numbers out of exponent notation.
+func formatCursorValue(value any) string {
+	switch number := value.(type) {
+	case float64:
+		return strconv.FormatFloat(number, 'f', -1, 64)
+	case json.Number:
+		return number.String()
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// setNextCursor fills resp.NextCursor from the last row when the server did not
+// issue a continuation token and the page is full.
+func (qb *QueryBuilder) setNextCursor(resp *utils.Response) {
+	if resp == nil || resp.NextCursor != "" || qb.limitVal <= 0 {
+		return
+	}
+	column, direction, ok := qb.keysetOrder()
+	if !ok {
+		return
+	}
+	rows, err := resp.Rows()
+	if err != nil || len(rows) < qb.limitVal {
+		return
+	}
+	if value, ok := rows[len(rows)-1][column]; ok && value != nil {
+		resp.NextCursor = encodeKeysetCursor(keysetCursor{Column: column, Direction: direction, Value: value})
+	}
+}
+
+// Iter returns an iterator over every row matching the query, fetched page by page.
+// The page size is the query Limit (default 100). Pages are chained with the
+// server continuation token or a keyset cursor when available, and with offsets otherwise.
+//
+//	for row, err := range qb.OrderBy("id", "ASC").Iter(ctx) {
+//	    if err != nil { ... }
+//	}
+func (qb *QueryBuilder) Iter(ctx context.Context) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		page, err := qb.stableOrder(ctx)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		page = page.clone()
+		if page.limitVal <= 0 {
+			page.limitVal = defaultIterPageSize
+		}
+
+		// The budget applies to the whole iteration, not to each page
+		if page.maxScannedBytes > 0 {
+			whole := qb.clone()
+			whole.limitVal = 0
+			if err := whole.checkCost(ctx); err != nil {
+				yield(nil, err)
+				return
+			}
+			page.maxScannedBytes = 0
+		}
+
+		for {
+			resp, err := page.Get(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			rows, err := resp.Rows()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, row := range rows {
+				if !yield(row, nil) {
+					return
+				}
+			}
+
+			switch {
+			case len(rows) == 0:
+				return
+			case resp.NextCursor != "":
+				page.cursor = resp.NextCursor
+			case len(rows) < page.limitVal || page.cursor != "":
+				// Last page, or a cursor chain the server stopped continuing
+				return
+			default:
+				page.offsetVal += len(rows)
+			}
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package fluent
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// DiffKind is the kind of a row difference.
+type DiffKind string
+
+const (
+	// DiffMissing is a row of the source that has no match in the target.
+	DiffMissing DiffKind = "missing"
+	// DiffExtra is a row of the target that has no match in the source.
+	DiffExtra DiffKind = "extra"
+	// DiffChanged is a row present on both sides with different column values.
+	DiffChanged DiffKind = "changed"
+)
+
+// DiffOptions configures Diff.
+type DiffOptions struct {
+	// KeyColumns identify a row on both sides (required).
+	KeyColumns []string
+	// Columns are the compared columns. Defaults to every non-key column of either row.
+	Columns []string
+	// FloatTolerance is the largest absolute difference for numbers to be considered equal.
+	FloatTolerance float64
+}
+
+// RowDiff is one difference between the source and the target.
+type RowDiff struct {
+	Kind    DiffKind
+	Key     map[string]any
+	Source  map[string]any // nil for DiffExtra
+	Target  map[string]any // nil for DiffMissing
+	Columns []string       // Columns with different values, for DiffChanged
+}
+
+// Diff compares the rows of two queries (e.g. the source and the target of a
+// migration) and yields their differences. Both queries are ordered by the key
+// columns and merged while they are read page by page, so memory use does not
+// depend on the size of the tables. Any ordering already set on the queries is replaced.
+//
+// Key values must sort the same way on both sides: numbers numerically and
+// strings in byte order.
+//
+// Example:
+//
+//	source := client.Catalog("legacy").Schema("public").Table("orders")
+//	target := client.Catalog("lake").Schema("sales").Table("orders")
+//	for diff, err := range fluent.Diff(ctx, source, target, fluent.DiffOptions{KeyColumns: []string{"id"}, FloatTolerance: 0.01}) {
+//	    if err != nil { ... }
+//	    log.Printf("%s %v %v", diff.Kind, diff.Key, diff.Columns)
+//	}
+func Diff(ctx context.Context, source, target *QueryBuilder, opts DiffOptions) iter.Seq2[RowDiff, error] {
+	return func(yield func(RowDiff, error) bool) {
+		if len(opts.KeyColumns) == 0 {
+			yield(RowDiff{}, fmt.Errorf("%w: diff requires at least one key column", utils.ErrInvalidRequest))
+			return
+		}
+
+		nextSource, stopSource := iter.Pull2(orderedByKeys(source, opts.KeyColumns).Iter(ctx))
+		defer stopSource()
+		nextTarget, stopTarget := iter.Pull2(orderedByKeys(target, opts.KeyColumns).Iter(ctx))
+		defer stopTarget()
+
+		// A query error is yielded once and ends the diff
+		failed := false
+		next := func(pull func() (map[string]any, error, bool)) (map[string]any, bool) {
+			row, err, ok := pull()
+			if err != nil {
+				failed = true
+				yield(RowDiff{}, err)
+				return nil, false
+			}
+			return row, ok
+		}
+
+		sourceRow, sourceOK := next(nextSource)
+		var targetRow map[string]any
+		var targetOK bool
+		if !failed {
+			targetRow, targetOK = next(nextTarget)
+		}
+		for !failed && (sourceOK || targetOK) {
+			cmp := 0
+			switch {
+			case !targetOK:
+				cmp = -1
+			case !sourceOK:
+				cmp = 1
+			default:
+				cmp = compareKeys(sourceRow, targetRow, opts.KeyColumns)
+			}
+
+			var diff *RowDiff
+			switch {
+			case cmp < 0:
+				diff = &RowDiff{Kind: DiffMissing, Key: rowKey(sourceRow, opts.KeyColumns), Source: sourceRow}
+			case cmp > 0:
+				diff = &RowDiff{Kind: DiffExtra, Key: rowKey(targetRow, opts.KeyColumns), Target: targetRow}
+			default:
+				if columns := changedColumns(sourceRow, targetRow, opts); len(columns) > 0 {
+					diff = &RowDiff{Kind: DiffChanged, Key: rowKey(sourceRow, opts.KeyColumns), Source: sourceRow, Target: targetRow, Columns: columns}
+				}
+			}
+			if diff != nil && !yield(*diff, nil) {
+				return
+			}
+
+			if cmp <= 0 {
+				sourceRow, sourceOK = next(nextSource)
+			}
+			if cmp >= 0 && !failed {
+				targetRow, targetOK = next(nextTarget)
+			}
+		}
+	}
+}
+
+// orderedByKeys returns a copy of the query ordered by the key columns.
+func orderedByKeys(qb *QueryBuilder, keyColumns []string) *QueryBuilder {
+	qb = qb.clone()
+	qb.orderBy = make([]builders.OrderClause, 0, len(keyColumns))
+	for _, column := range keyColumns {
+		qb.orderBy = append(qb.orderBy, builders.OrderClause{Column: column, Direction: "ASC"})
+	}
+	return qb
+}
+
+func rowKey(row map[string]any, keyColumns []string) map[string]any {
+	key := make(map[string]any, len(keyColumns))
+	for _, column := range keyColumns {
+		key[column] = row[column]
+	}
+	return key
+}
+
+// compareKeys orders two rows by their key columns.
+func compareKeys(a, b map[string]any, keyColumns []string) int {
+	for _, column := range keyColumns {
+		if cmp := compareValues(a[column], b[column]); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// compareValues orders JSON values: nulls first, then numbers, strings and booleans.
+func compareValues(a, b any) int {
+	if a == nil || b == nil {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		}
+		return 1
+	}
+
+	if x, ok := utils.Float64(a); ok {
+		if y, ok := utils.Float64(b); ok {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	switch x := a.(type) {
+	case string:
+		if y, ok := b.(string); ok {
+			return strings.Compare(x, y)
+		}
+	case bool:
+		if y, ok := b.(bool); ok {
+			switch {
+			case x == y:
+				return 0
+			case !x:
+				return -1
+			}
+			return 1
+		}
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+// changedColumns returns the compared columns whose values differ.
+func changedColumns(source, target map[string]any, opts DiffOptions) []string {
+	columns := opts.Columns
+	if len(columns) == 0 {
+		keys := make(map[string]bool, len(opts.KeyColumns))
+		for _, column := range opts.KeyColumns {
+			keys[column] = true
+		}
+		seen := make(map[string]bool, len(source))
+		for _, row := range []map[string]any{source, target} {
+			for column := range row {
+				if !keys[column] && !seen[column] {
+					seen[column] = true
+					columns = append(columns, column)
+				}
+			}
+		}
+		sort.Strings(columns)
+	}
+
+	var changed []string
+	for _, column := range columns {
+		if !valuesEqual(source[column], target[column], opts.FloatTolerance) {
+			changed = append(changed, column)
+		}
+	}
+	return changed
+}
+
+func valuesEqual(a, b any, tolerance float64) bool {
+	x, xNumber := utils.Float64(a)
+	y, yNumber := utils.Float64(b)
+	if xNumber && yNumber {
+		return math.Abs(x-y) <= tolerance
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,87 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// pagedTables serves the rows of each table page by page, following __limit and
+// either __offset or the keyset cursor on id.
+func pagedTables(t *testing.T, tables map[string][]map[string]any) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		if query.Get("order") != "id.asc" {
+			t.Errorf("Expected ordering by key, got %s", req.URL.RawQuery)
+		}
+		rows := tables[req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]]
+		offset, _ := strconv.Atoi(query.Get("__offset"))
+		if after := query.Get("id.gt"); after != "" {
+			// Keyset cursor issued from the last row of the previous page
+			last, _ := strconv.Atoi(after)
+			for offset < len(rows) && rows[offset]["id"].(int) <= last {
+				offset++
+			}
+		}
+		limit, _ := strconv.Atoi(query.Get("__limit"))
+		page := rows[min(offset, len(rows)):min(offset+limit, len(rows))]
+		body, _ := json.Marshal(page)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(body)))}, nil
+	}
+}
+
+func TestDiff(t *testing.T) {
+	handler := pagedTables(t, map[string][]map[string]any{
+		"source": {{"id": 1, "total": 10.0}, {"id": 2, "total": 20.0}, {"id": 3, "total": 30.0}, {"id": 5, "total": 50.0}},
+		"target": {{"id": 1, "total": 10.001}, {"id": 3, "total": 31.0}, {"id": 4, "total": 40.0}, {"id": 5, "total": 50.0}},
+	})
+	base := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, handler).Catalog("cat").Schema("schema").Limit(2)
+
+	var diffs []RowDiff
+	for diff, err := range Diff(context.Background(), base.Table("source"), base.Table("target").OrderBy("total", "DESC"),
+		DiffOptions{KeyColumns: []string{"id"}, FloatTolerance: 0.01}) {
+		if err != nil {
+			t.Fatalf("Diff() unexpected error = %v", err)
+		}
+		diffs = append(diffs, diff)
+	}
+
+	if len(diffs) != 3 {
+		t.Fatalf("Expected 3 differences, got %+v", diffs)
+	}
+	if diffs[0].Kind != DiffMissing || diffs[0].Key["id"] != float64(2) {
+		t.Errorf("Expected row 2 to be missing, got %+v", diffs[0])
+	}
+	if diffs[1].Kind != DiffChanged || diffs[1].Key["id"] != float64(3) || len(diffs[1].Columns) != 1 || diffs[1].Columns[0] != "total" {
+		t.Errorf("Expected row 3 to be changed, got %+v", diffs[1])
+	}
+	if diffs[2].Kind != DiffExtra || diffs[2].Key["id"] != float64(4) || diffs[2].Source != nil {
+		t.Errorf("Expected row 4 to be extra, got %+v", diffs[2])
+	}
+}
+
+func TestDiff_Errors(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}).Catalog("cat").Schema("schema").Table("t")
+
+	var errs []error
+	for _, err := range Diff(context.Background(), qb, qb, DiffOptions{KeyColumns: []string{"id"}}) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || errs[0] != utils.ErrPermissionDenied {
+		t.Errorf("Expected a single ErrPermissionDenied, got %v", errs)
+	}
+
+	for _, err := range Diff(context.Background(), qb, qb, DiffOptions{}) {
+		if err == nil || !strings.Contains(err.Error(), "key column") {
+			t.Errorf("Expected a key column error, got %v", err)
+		}
+	}
+}
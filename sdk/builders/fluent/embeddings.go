@@ -0,0 +1,160 @@
+package fluent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// defaultEmbeddingBatchSize is the number of texts sent per embedding request.
+const defaultEmbeddingBatchSize = 64
+
+// Embeddings holds the vectors generated for a list of texts.
+type Embeddings struct {
+	Model   string      `json:"model"`
+	Vectors [][]float32 `json:"vectors"` // One vector per input text, in input order
+	Usage   TokenUsage  `json:"usage"`
+}
+
+// TokenUsage reports the tokens consumed by a model request.
+type TokenUsage struct {
+	PromptTokens int `json:"prompt_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
+
+// EmbeddingsBuilder generates embeddings with the models served by the platform,
+// so that documents and queries are embedded with the same model as the vector
+// indexes used by HybridSearch.
+// Chaining methods return a new builder and leave the receiver untouched.
+type EmbeddingsBuilder struct {
+	client interface {
+		Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error)
+		GetConfig() utils.Configuration
+	}
+	errors []error
+
+	dimensions int
+	batchSize  int
+}
+
+// NewEmbeddingsBuilder creates a new EmbeddingsBuilder instance.
+func NewEmbeddingsBuilder(client interface {
+	Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error)
+	GetConfig() utils.Configuration
+}) *EmbeddingsBuilder {
+	return &EmbeddingsBuilder{
+		client:    client,
+		batchSize: defaultEmbeddingBatchSize,
+	}
+}
+
+// Dimensions asks models that support it to shorten the vectors to n dimensions.
+func (b *EmbeddingsBuilder) Dimensions(n int) *EmbeddingsBuilder {
+	b = b.clone()
+	if n <= 0 {
+		b.errors = append(b.errors, fmt.Errorf("dimensions must be greater than 0"))
+	}
+	b.dimensions = n
+	return b
+}
+
+// BatchSize sets the number of texts sent per request (default 64). Larger
+// inputs are split into several requests.
+func (b *EmbeddingsBuilder) BatchSize(n int) *EmbeddingsBuilder {
+	b = b.clone()
+	if n <= 0 {
+		b.errors = append(b.errors, fmt.Errorf("batch size must be greater than 0"))
+	}
+	b.batchSize = n
+	return b
+}
+
+// Create generates one embedding vector per text with the given model (a served
+// model name, see the shared models of the control plane).
+//
+// Example:
+//
+//	embeddings, err := client.Embeddings().Create(ctx, []string{"first chunk", "second chunk"}, "bge-m3")
+//	vector := embeddings.Vectors[0]
+func (b *EmbeddingsBuilder) Create(ctx context.Context, texts []string, model string) (*Embeddings, error) {
+	if len(b.errors) > 0 {
+		return nil, fmt.Errorf("embeddings builder validation failed: %s", b.errors[0].Error())
+	}
+	if model == "" {
+		return nil, fmt.Errorf("%w: model is required", utils.ErrInvalidRequest)
+	}
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("%w: at least one text is required", utils.ErrInvalidRequest)
+	}
+	if err := builders.RequireFeature(ctx, b.client, utils.FeatureEmbeddings, "embedding generation"); err != nil {
+		return nil, err
+	}
+
+	result := &Embeddings{Model: model, Vectors: make([][]float32, 0, len(texts))}
+	for start := 0; start < len(texts); start += b.batchSize {
+		batch := texts[start:min(start+b.batchSize, len(texts))]
+		vectors, usage, err := b.createBatch(ctx, batch, model)
+		if err != nil {
+			return nil, err
+		}
+		result.Vectors = append(result.Vectors, vectors...)
+		result.Usage.PromptTokens += usage.PromptTokens
+		result.Usage.TotalTokens += usage.TotalTokens
+	}
+	return result, nil
+}
+
+// embeddingResponse is the OpenAI-compatible response of the embeddings endpoint.
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Usage TokenUsage `json:"usage"`
+}
+
+// createBatch requests the embeddings of one batch of texts.
+func (b *EmbeddingsBuilder) createBatch(ctx context.Context, texts []string, model string) ([][]float32, TokenUsage, error) {
+	requestBody := map[string]interface{}{
+		"model": model,
+		"input": texts,
+	}
+	if b.dimensions > 0 {
+		requestBody["dimensions"] = b.dimensions
+	}
+	body, err := utils.EncodeBody(b.client.GetConfig(), requestBody)
+	if err != nil {
+		return nil, TokenUsage{}, err
+	}
+
+	endpoint := fmt.Sprintf("%s/api/embeddings", b.client.GetConfig().BaseURL)
+	resp, err := b.client.Do(ctx, "POST", endpoint, body)
+	if err != nil {
+		return nil, TokenUsage{}, err
+	}
+
+	var decoded embeddingResponse
+	if err := utils.UnmarshalData(resp.Data, &decoded); err != nil {
+		return nil, TokenUsage{}, fmt.Errorf("failed to unmarshal embeddings: %w", err)
+	}
+	if len(decoded.Data) != len(texts) {
+		return nil, TokenUsage{}, fmt.Errorf("%w: got %d embeddings for %d texts", utils.ErrAPIError, len(decoded.Data), len(texts))
+	}
+	sort.SliceStable(decoded.Data, func(i, j int) bool { return decoded.Data[i].Index < decoded.Data[j].Index })
+
+	vectors := make([][]float32, len(decoded.Data))
+	for i, item := range decoded.Data {
+		vectors[i] = item.Embedding
+	}
+	return vectors, decoded.Usage, nil
+}
+
+// clone returns a copy of the builder that can be modified independently.
+func (b *EmbeddingsBuilder) clone() *EmbeddingsBuilder {
+	next := *b
+	next.errors = append([]error(nil), b.errors...)
+	return &next
+}
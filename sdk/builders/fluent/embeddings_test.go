@@ -0,0 +1,61 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// fakeEmbedder answers embedding requests with [len(text), 0.5] vectors, out of order.
+type fakeEmbedder struct {
+	requests []map[string]any
+}
+
+func (f *fakeEmbedder) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	if method != "POST" || endpoint != "https://test.example.com/api/embeddings" {
+		return nil, fmt.Errorf("unexpected request %s %s", method, endpoint)
+	}
+	var request map[string]any
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, err
+	}
+	f.requests = append(f.requests, request)
+
+	var items []any
+	for i, text := range request["input"].([]any) {
+		items = append([]any{map[string]any{"index": float64(i), "embedding": []any{float64(len(text.(string))), 0.5}}}, items...)
+	}
+	data := map[string]any{"data": items, "usage": map[string]any{"prompt_tokens": float64(2), "total_tokens": float64(2)}}
+	return &utils.Response{Status: utils.StatusOK, Data: data, HTTPCode: 200}, nil
+}
+
+func (f *fakeEmbedder) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://test.example.com"}
+}
+
+func TestEmbeddingsBuilder_Create(t *testing.T) {
+	client := &fakeEmbedder{}
+	embeddings, err := NewEmbeddingsBuilder(client).BatchSize(2).Dimensions(2).
+		Create(context.Background(), []string{"a", "bb", "ccc"}, "bge-m3")
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	requests := client.requests
+	if len(requests) != 2 || requests[0]["model"] != "bge-m3" || requests[0]["dimensions"] != float64(2) {
+		t.Errorf("Unexpected requests: %v", requests)
+	}
+	if len(embeddings.Vectors) != 3 || embeddings.Vectors[0][0] != 1 || embeddings.Vectors[2][0] != 3 || embeddings.Vectors[1][1] != 0.5 {
+		t.Errorf("Unexpected vectors: %v", embeddings.Vectors)
+	}
+	if embeddings.Usage.TotalTokens != 4 {
+		t.Errorf("Expected the usage of both batches, got %+v", embeddings.Usage)
+	}
+
+	if _, err := NewEmbeddingsBuilder(client).Create(context.Background(), nil, "bge-m3"); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected an empty input to fail, got %v", err)
+	}
+}
@@ -0,0 +1,288 @@
+package fluent
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// encryptedValuePrefix marks the values encrypted by the SDK:
+// "enc:v1:<key ID>:<base64 of nonce and ciphertext>".
+const encryptedValuePrefix = "enc:v1:"
+
+// KeyProvider supplies the AES keys (16, 24 or 32 bytes) of field encryption.
+// Keys are identified so that they can be rotated: values are encrypted with the
+// current key and decrypted with the key they were encrypted with.
+type KeyProvider interface {
+	// CurrentKey returns the key used to encrypt new values.
+	CurrentKey(ctx context.Context) (id string, key []byte, err error)
+	// Key returns the key with the given ID.
+	Key(ctx context.Context, id string) ([]byte, error)
+}
+
+// StaticKeys is a KeyProvider holding its keys in memory, e.g. loaded from a
+// secret manager at startup. Current is the ID of the encryption key.
+type StaticKeys struct {
+	Current string
+	Keys    map[string][]byte
+}
+
+// CurrentKey implements KeyProvider.
+func (s StaticKeys) CurrentKey(ctx context.Context) (string, []byte, error) {
+	key, err := s.Key(ctx, s.Current)
+	return s.Current, key, err
+}
+
+// Key implements KeyProvider.
+func (s StaticKeys) Key(ctx context.Context, id string) ([]byte, error) {
+	key, ok := s.Keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown encryption key %q", utils.ErrInvalidConfiguration, id)
+	}
+	return key, nil
+}
+
+// fieldEncryption is the encryption of some columns of a query.
+type fieldEncryption struct {
+	keys    KeyProvider
+	columns map[string]bool
+}
+
+// EncryptColumns encrypts the given columns client-side with AES-GCM: their values
+// are encrypted before Post and Put, and decrypted in the rows returned by Get
+// (and Iter, First, Scan, ...). Values are JSON-encoded before encryption, so any
+// type round-trips, and stored as strings: the columns must be text columns.
+// Values that are not encrypted, such as data written before the columns were
+// encrypted, are returned as they are.
+//
+// Encryption is randomized: encrypted columns cannot be filtered or sorted on, and
+// such queries fail with utils.ErrInvalidRequest.
+//
+// Example:
+//
+//	keys := fluent.StaticKeys{Current: "2025-03", Keys: map[string][]byte{"2025-03": key}}
+//	patients := client.Catalog("care").Schema("public").Table("patients").EncryptColumns(keys, "ssn", "diagnosis")
+//	_, err := patients.Post(ctx, patient)
+//	err = patients.Where("id", "=", id).First(ctx, &patient) // ssn and diagnosis decrypted
+func (qb *QueryBuilder) EncryptColumns(keys KeyProvider, columns ...string) *QueryBuilder {
+	qb = qb.clone()
+	if keys == nil || len(columns) == 0 {
+		qb.errors = append(qb.errors, fmt.Errorf("column encryption requires a key provider and columns"))
+		return qb
+	}
+	encryption := &fieldEncryption{keys: keys, columns: make(map[string]bool)}
+	if qb.encryption != nil {
+		for column := range qb.encryption.columns {
+			encryption.columns[column] = true
+		}
+	}
+	for _, column := range columns {
+		encryption.columns[column] = true
+	}
+	qb.encryption = encryption
+	return qb
+}
+
+// checkQuery refuses filters and sorts on encrypted columns: they would send the
+// plaintext in the query string, and could not match the randomized ciphertexts.
+func (e *fieldEncryption) checkQuery(filters []builders.Filter, orderBy []builders.OrderClause) error {
+	if e == nil {
+		return nil
+	}
+	for _, filter := range filters {
+		if e.columns[filter.Column] {
+			return fmt.Errorf("%w: cannot filter on encrypted column %s", utils.ErrInvalidRequest, filter.Column)
+		}
+	}
+	for _, clause := range orderBy {
+		if e.columns[clause.Column] {
+			return fmt.Errorf("%w: cannot sort on encrypted column %s", utils.ErrInvalidRequest, clause.Column)
+		}
+	}
+	return nil
+}
+
+// encryptRows returns a copy of the rows of a Post or Put payload with the
+// encrypted columns sealed. Other payload types (e.g. map[string]string or
+// json.RawMessage) are normalized through JSON first; payloads that still are not
+// rows fail, so that no column is ever sent in plaintext.
+func (e *fieldEncryption) encryptRows(ctx context.Context, data any) (any, error) {
+	if e == nil {
+		return data, nil
+	}
+	switch data.(type) {
+	case map[string]any, []map[string]any, []any:
+	default:
+		normalized, err := normalizeRows(data)
+		if err != nil {
+			return nil, err
+		}
+		data = normalized
+	}
+	id, key, err := e.keys.CurrentKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	encryptRow := func(row map[string]any) (map[string]any, error) {
+		sealed := make(map[string]any, len(row))
+		for column, value := range row {
+			if e.columns[column] && value != nil {
+				encrypted, err := sealValue(aead, id, column, value)
+				if err != nil {
+					return nil, err
+				}
+				value = encrypted
+			}
+			sealed[column] = value
+		}
+		return sealed, nil
+	}
+
+	switch rows := data.(type) {
+	case map[string]any:
+		return encryptRow(rows)
+	case []map[string]any:
+		sealed := make([]map[string]any, len(rows))
+		for i, row := range rows {
+			if sealed[i], err = encryptRow(row); err != nil {
+				return nil, err
+			}
+		}
+		return sealed, nil
+	case []any:
+		sealed := make([]any, len(rows))
+		for i, item := range rows {
+			row, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%w: encrypted columns require rows, got a %T item", utils.ErrInvalidRequest, item)
+			}
+			if sealed[i], err = encryptRow(row); err != nil {
+				return nil, err
+			}
+		}
+		return sealed, nil
+	}
+	return nil, fmt.Errorf("%w: encrypted columns require rows, got %T", utils.ErrInvalidRequest, data)
+}
+
+// normalizeRows decodes the JSON encoding of a payload into generic values,
+// keeping numbers as json.Number.
+func normalizeRows(data any) (any, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot encode the payload: %w", utils.ErrInvalidRequest, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var normalized any
+	if err := decoder.Decode(&normalized); err != nil {
+		return nil, fmt.Errorf("%w: cannot decode the payload: %w", utils.ErrInvalidRequest, err)
+	}
+	return normalized, nil
+}
+
+// decryptResponse opens the encrypted columns of the rows of resp in place.
+func (e *fieldEncryption) decryptResponse(ctx context.Context, resp *utils.Response, useNumber bool) error {
+	if e == nil || resp == nil {
+		return nil
+	}
+	var rows []map[string]any
+	switch data := resp.Data.(type) {
+	case map[string]any:
+		rows = []map[string]any{data}
+	case []any:
+		for _, item := range data {
+			if row, ok := item.(map[string]any); ok {
+				rows = append(rows, row)
+			}
+		}
+	}
+
+	ciphers := make(map[string]cipher.AEAD)
+	for _, row := range rows {
+		for column := range e.columns {
+			encrypted, ok := row[column].(string)
+			if !ok || !strings.HasPrefix(encrypted, encryptedValuePrefix) {
+				continue
+			}
+			id, sealed, ok := strings.Cut(strings.TrimPrefix(encrypted, encryptedValuePrefix), ":")
+			if !ok {
+				return fmt.Errorf("%w: malformed encrypted value in column %s", utils.ErrAPIError, column)
+			}
+			aead, ok := ciphers[id]
+			if !ok {
+				key, err := e.keys.Key(ctx, id)
+				if err != nil {
+					return err
+				}
+				if aead, err = newGCM(key); err != nil {
+					return err
+				}
+				ciphers[id] = aead
+			}
+			value, err := openValue(aead, column, sealed, useNumber)
+			if err != nil {
+				return err
+			}
+			row[column] = value
+		}
+	}
+	return nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid encryption key: %w", utils.ErrInvalidConfiguration, err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealValue encrypts the JSON encoding of value. The column name is authenticated,
+// so that an encrypted value cannot be moved to another column.
+func sealValue(aead cipher.AEAD, keyID, column string, value any) (string, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: cannot encrypt column %s: %w", utils.ErrInvalidRequest, column, err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(column))
+	return encryptedValuePrefix + keyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func openValue(aead cipher.AEAD, column, encoded string, useNumber bool) (any, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("%w: malformed encrypted value in column %s", utils.ErrAPIError, column)
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(column))
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot decrypt column %s: %w", utils.ErrInvalidSignature, column, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(plaintext))
+	if useNumber {
+		decoder.UseNumber()
+	}
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("%w: cannot decode column %s: %w", utils.ErrAPIError, column, err)
+	}
+	return value, nil
+}
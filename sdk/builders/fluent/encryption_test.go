@@ -0,0 +1,149 @@
+package fluent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// storeTestClient keeps the rows posted to it and returns them on GET.
+type storeTestClient struct {
+	rows []any
+}
+
+func (c *storeTestClient) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	switch method {
+	case "POST":
+		var row map[string]any
+		if err := json.Unmarshal(body, &row); err != nil {
+			return nil, err
+		}
+		c.rows = append(c.rows, row)
+		return &utils.Response{Status: utils.StatusOK}, nil
+	case "GET":
+		// Rows go through JSON like a real response
+		raw, _ := json.Marshal(c.rows)
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+		var data any
+		_ = decoder.Decode(&data)
+		return &utils.Response{Status: utils.StatusOK, Data: data}, nil
+	}
+	return nil, errors.New("unexpected method " + method)
+}
+
+func (c *storeTestClient) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://test.example.com", DataDockID: "dd", UseNumber: true}
+}
+
+func TestQueryBuilder_EncryptColumns(t *testing.T) {
+	client := &storeTestClient{}
+	keys := StaticKeys{Current: "k1", Keys: map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)}}
+	patients := NewQueryBuilder(client).Catalog("care").Schema("public").Table("patients").EncryptColumns(keys, "ssn", "scores")
+	ctx := context.Background()
+
+	patient := map[string]any{"id": 1, "ssn": "1 85 05 78 006 084 36", "scores": []int{3, 5}, "note": nil}
+	if _, err := patients.Post(ctx, patient); err != nil {
+		t.Fatalf("Post() unexpected error = %v", err)
+	}
+	if patient["ssn"] != "1 85 05 78 006 084 36" {
+		t.Error("Post() must not modify the caller's row")
+	}
+	stored := client.rows[0].(map[string]any)
+	if ssn, _ := stored["ssn"].(string); !strings.HasPrefix(ssn, "enc:v1:k1:") || strings.Contains(ssn, "006 084") {
+		t.Errorf("Expected ssn to be stored encrypted, got %v", stored["ssn"])
+	}
+	if stored["id"] != float64(1) || stored["note"] != nil {
+		t.Errorf("Other columns must be stored as they are, got %v", stored)
+	}
+
+	// Rotating the key keeps older values readable
+	keys.Current, keys.Keys["k2"] = "k2", bytes.Repeat([]byte{2}, 16)
+	client.rows = append(client.rows, map[string]any{"id": 2, "ssn": "plain"})
+	resp, err := patients.EncryptColumns(keys, "ssn").Get(ctx)
+	if err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	rows, _ := resp.Rows()
+	if rows[0]["ssn"] != "1 85 05 78 006 084 36" || rows[1]["ssn"] != "plain" {
+		t.Errorf("Unexpected decrypted rows %v", rows)
+	}
+	if scores, _ := rows[0]["scores"].([]any); len(scores) != 2 || scores[1] != json.Number("5") {
+		t.Errorf("Expected scores to round-trip, got %v", rows[0]["scores"])
+	}
+
+	// A value moved to another column does not decrypt
+	client.rows = []any{map[string]any{"id": 1, "scores": stored["ssn"]}}
+	if _, err := patients.Get(ctx); !errors.Is(err, utils.ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestQueryBuilder_EncryptColumnsInvalid(t *testing.T) {
+	qb := NewQueryBuilder(&storeTestClient{}).Catalog("c").Schema("s").Table("t")
+	if _, err := qb.EncryptColumns(nil, "ssn").Get(context.Background()); err == nil {
+		t.Error("Expected an error without a key provider")
+	}
+
+	keys := StaticKeys{Current: "k1", Keys: map[string][]byte{"k1": []byte("short")}}
+	if _, err := qb.EncryptColumns(keys, "ssn").Post(context.Background(), map[string]any{"ssn": "x"}); !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for an invalid key, got %v", err)
+	}
+}
+
+func TestQueryBuilder_EncryptColumnsNoFilter(t *testing.T) {
+	client := &storeTestClient{rows: []any{map[string]any{"id": 1}}}
+	keys := StaticKeys{Current: "k1", Keys: map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)}}
+	patients := NewQueryBuilder(client).Catalog("care").Schema("public").Table("patients").EncryptColumns(keys, "ssn")
+	ctx := context.Background()
+
+	if _, err := patients.Where("ssn", "=", "1 85 05 78 006 084 36").Get(ctx); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected a filter on an encrypted column to be refused, got %v", err)
+	}
+	if _, err := patients.OrderBy("ssn", "ASC").Get(ctx); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected a sort on an encrypted column to be refused, got %v", err)
+	}
+	if _, err := patients.Where("ssn", "=", "1 85 05 78 006 084 36").Delete(ctx); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected a delete filtered on an encrypted column to be refused, got %v", err)
+	}
+	if _, err := patients.Where("id", "=", 1).OrderBy("id", "DESC").Get(ctx); err != nil {
+		t.Errorf("Expected filters on other columns to pass, got %v", err)
+	}
+}
+
+func TestQueryBuilder_EncryptColumnsFailsClosed(t *testing.T) {
+	client := &storeTestClient{}
+	keys := StaticKeys{Current: "k1", Keys: map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)}}
+	patients := NewQueryBuilder(client).Catalog("care").Schema("public").Table("patients").EncryptColumns(keys, "ssn")
+	ctx := context.Background()
+
+	// Other row types are normalized, then encrypted
+	for _, payload := range []any{
+		map[string]string{"ssn": "1 85 05 78 006 084 36"},
+		json.RawMessage(`{"ssn": "1 85 05 78 006 084 36"}`),
+	} {
+		if _, err := patients.Post(ctx, payload); err != nil {
+			t.Fatalf("Post(%T) unexpected error = %v", payload, err)
+		}
+	}
+	for _, row := range client.rows {
+		if ssn, _ := row.(map[string]any)["ssn"].(string); !strings.HasPrefix(ssn, "enc:v1:k1:") {
+			t.Errorf("Expected ssn to be stored encrypted, got %v", row)
+		}
+	}
+
+	// Payloads that are not rows are rejected instead of being sent as they are
+	for _, payload := range []any{[]byte(`{"ssn": "x"}`), "ssn=x", []any{"ssn"}} {
+		if _, err := patients.Post(ctx, payload); !errors.Is(err, utils.ErrInvalidRequest) {
+			t.Errorf("Post(%T) error = %v, want ErrInvalidRequest", payload, err)
+		}
+	}
+	if len(client.rows) != 2 {
+		t.Errorf("Expected only the rows to be posted, got %v", client.rows)
+	}
+}
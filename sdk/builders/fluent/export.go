@@ -0,0 +1,361 @@
+package fluent
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// ExportFormat is the file format of exported objects.
+type ExportFormat string
+
+const (
+	// ExportJSONLines writes one JSON object per line.
+	ExportJSONLines ExportFormat = "jsonl"
+	// ExportCSV writes a header line followed by one line per row.
+	ExportCSV ExportFormat = "csv"
+)
+
+// Export sizing. These are variables so that tests can lower them.
+var (
+	// exportRowsPerObject is the number of rows after which a new object is started.
+	exportRowsPerObject = 1_000_000
+	// exportPartSize is the size of multipart upload parts (S3 requires at least 5 MiB).
+	exportPartSize = 8 << 20
+)
+
+// ExportedObject describes an object written by ExportToS3.
+type ExportedObject struct {
+	Key   string `json:"key"`
+	Rows  int    `json:"rows"`
+	Bytes int64  `json:"bytes"`
+}
+
+// ExportResult describes a completed export. It is also written as the manifest object.
+type ExportResult struct {
+	Bucket    string           `json:"bucket"`
+	Manifest  string           `json:"manifest"`
+	Format    ExportFormat     `json:"format"`
+	Columns   []string         `json:"columns,omitempty"`
+	TotalRows int              `json:"total_rows"`
+	Objects   []ExportedObject `json:"objects"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// ExportToS3 streams every row matching the query into objects of the configured
+// MinIO bucket, fetching the results page by page (see Iter; Limit sets the page size).
+// Objects are named "<keyPrefix>part-00000.<format>" and hold at most one million rows;
+// large objects are sent with multipart uploads. A "<keyPrefix>manifest.json" object
+// listing the exported objects is written last, so its presence marks a complete export.
+//
+// CSV columns are the selected ones, or the columns of the first row for SELECT *.
+//
+// Example:
+//
+//	result, err := client.Catalog("sales").Schema("public").Table("orders").
+//	    Where("year", "=", 2024).
+//	    ExportToS3(ctx, "exports", "orders/2024/", fluent.ExportCSV)
+func (qb *QueryBuilder) ExportToS3(ctx context.Context, bucket, keyPrefix string, format ExportFormat) (*ExportResult, error) {
+	if format != ExportJSONLines && format != ExportCSV {
+		return nil, fmt.Errorf("%w: unsupported export format %q", utils.ErrInvalidRequest, format)
+	}
+	if err := qb.validate(); err != nil {
+		return nil, err
+	}
+
+	s3Builder, err := NewS3Builder(qb.client)
+	if err != nil {
+		return nil, err
+	}
+	s3Builder = s3Builder.Bucket(bucket)
+	if err := s3Builder.validateList(ctx); err != nil {
+		return nil, err
+	}
+
+	result := &ExportResult{
+		Bucket:    bucket,
+		Manifest:  keyPrefix + "manifest.json",
+		Format:    format,
+		Columns:   qb.selectCols,
+		CreatedAt: time.Now().UTC(),
+	}
+	if format != ExportCSV {
+		result.Columns = nil
+	}
+
+	var object *exportObject
+	for row, err := range qb.Iter(ctx) {
+		if err != nil {
+			if object != nil {
+				object.abort(ctx)
+			}
+			return nil, err
+		}
+
+		if object == nil {
+			if format == ExportCSV && result.Columns == nil {
+				result.Columns = sortedColumns(row)
+			}
+			key := fmt.Sprintf("%spart-%05d.%s", keyPrefix, len(result.Objects), format)
+			object = newExportObject(s3Builder.s3Client, bucket, key, format, result.Columns)
+		}
+		if err := object.writeRow(ctx, row); err != nil {
+			object.abort(ctx)
+			return nil, err
+		}
+		result.TotalRows++
+
+		if object.rows >= exportRowsPerObject {
+			if err := object.close(ctx); err != nil {
+				return nil, err
+			}
+			result.Objects = append(result.Objects, object.info())
+			object = nil
+		}
+	}
+	if object != nil {
+		if err := object.close(ctx); err != nil {
+			return nil, err
+		}
+		result.Objects = append(result.Objects, object.info())
+	}
+
+	manifest, _ := json.MarshalIndent(result, "", "  ")
+	_, err = s3Builder.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String(result.Manifest),
+		Body:        bytes.NewReader(manifest),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return nil, s3Error(err, "failed to write export manifest")
+	}
+	return result, nil
+}
+
+// Export streams the rows matching the query as a file rendered by the server,
+// in any format it supports (e.g. ExportCSV or "parquet").
+// Unlike Get, the rows are not decoded: the body is read by the caller, who must
+// close it. It fails with ErrUnsupportedFeature when the server reported that it
+// cannot export to the format, or when the client cannot stream downloads.
+//
+// Example:
+//
+//	body, info, err := client.Catalog("sales").Schema("public").Table("orders").
+//	    Where("year", "=", 2024).
+//	    Export(ctx, fluent.ExportCSV)
+//	if err != nil {
+//	    return err
+//	}
+//	defer body.Close()
+//	_, err = io.Copy(file, body)
+func (qb *QueryBuilder) Export(ctx context.Context, format ExportFormat) (io.ReadCloser, utils.ContentInfo, error) {
+	if format == "" {
+		return nil, utils.ContentInfo{}, fmt.Errorf("%w: export format is required", utils.ErrInvalidRequest)
+	}
+	if err := qb.validate(); err != nil {
+		return nil, utils.ContentInfo{}, err
+	}
+	if qb.encryption != nil {
+		// The server would export the encrypted values as they are stored
+		return nil, utils.ContentInfo{}, fmt.Errorf("%w: encrypted columns cannot be exported by the server, use Iter", utils.ErrInvalidRequest)
+	}
+	downloader, ok := qb.client.(builders.Downloader)
+	if !ok {
+		return nil, utils.ContentInfo{}, fmt.Errorf("%w: the client cannot stream downloads", utils.ErrUnsupportedFeature)
+	}
+	err := builders.CheckCapabilities(ctx, qb.client, func(caps *utils.Capabilities) error {
+		if !caps.SupportsExportFormat(string(format)) {
+			return caps.Unsupported(fmt.Sprintf("export to %s", format))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, utils.ContentInfo{}, err
+	}
+	if err := qb.checkFilterOperators(ctx); err != nil {
+		return nil, utils.ContentInfo{}, err
+	}
+
+	params := qb.buildParams()
+	params.Set("__format", string(format))
+	ctx = utils.ContextWithHeaders(ctx, qb.headers)
+	return downloader.Download(ctx, qb.buildEndpoint()+"/export?"+params.Encode())
+}
+
+// exportObject buffers the rows of one exported object and uploads them in parts.
+// Objects smaller than one part are sent with a single PutObject.
+type exportObject struct {
+	client  *s3.Client
+	bucket  string
+	key     string
+	format  ExportFormat
+	columns []string
+
+	buf      bytes.Buffer
+	csv      *csv.Writer
+	rows     int
+	bytes    int64
+	uploadID string
+	parts    []types.CompletedPart
+}
+
+func newExportObject(client *s3.Client, bucket, key string, format ExportFormat, columns []string) *exportObject {
+	object := &exportObject{client: client, bucket: bucket, key: key, format: format, columns: columns}
+	if format == ExportCSV {
+		object.csv = csv.NewWriter(&object.buf)
+		_ = object.csv.Write(columns)
+	}
+	return object
+}
+
+func (o *exportObject) contentType() string {
+	if o.format == ExportCSV {
+		return "text/csv"
+	}
+	return "application/x-ndjson"
+}
+
+func (o *exportObject) writeRow(ctx context.Context, row map[string]any) error {
+	if o.format == ExportCSV {
+		record := make([]string, len(o.columns))
+		for i, column := range o.columns {
+			record[i] = csvValue(row[column])
+		}
+		if err := o.csv.Write(record); err != nil {
+			return err
+		}
+		o.csv.Flush()
+	} else {
+		o.buf.Write(utils.JsonMarshal(row))
+		o.buf.WriteByte('\n')
+	}
+	o.rows++
+
+	if o.buf.Len() >= exportPartSize {
+		return o.uploadPart(ctx)
+	}
+	return nil
+}
+
+// uploadPart sends the buffered data as the next part, starting the multipart upload if needed.
+func (o *exportObject) uploadPart(ctx context.Context) error {
+	if o.uploadID == "" {
+		created, err := o.client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+			Bucket:      aws.String(o.bucket),
+			Key:         aws.String(o.key),
+			ContentType: aws.String(o.contentType()),
+		})
+		if err != nil {
+			return s3Error(err, "failed to start upload of %s", o.key)
+		}
+		o.uploadID = aws.ToString(created.UploadId)
+	}
+
+	partNumber := int32(len(o.parts) + 1)
+	size := int64(o.buf.Len())
+	uploaded, err := o.client.UploadPart(ctx, &s3.UploadPartInput{
+		Bucket:     aws.String(o.bucket),
+		Key:        aws.String(o.key),
+		UploadId:   aws.String(o.uploadID),
+		PartNumber: aws.Int32(partNumber),
+		Body:       bytes.NewReader(o.buf.Bytes()),
+	})
+	if err != nil {
+		return s3Error(err, "failed to upload part %d of %s", partNumber, o.key)
+	}
+	o.parts = append(o.parts, types.CompletedPart{ETag: uploaded.ETag, PartNumber: aws.Int32(partNumber)})
+	o.bytes += size
+	o.buf.Reset()
+	return nil
+}
+
+// close uploads the remaining data and completes the object.
+func (o *exportObject) close(ctx context.Context) error {
+	if o.uploadID == "" {
+		o.bytes = int64(o.buf.Len())
+		_, err := o.client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket:      aws.String(o.bucket),
+			Key:         aws.String(o.key),
+			Body:        bytes.NewReader(o.buf.Bytes()),
+			ContentType: aws.String(o.contentType()),
+		})
+		if err != nil {
+			return s3Error(err, "failed to upload %s", o.key)
+		}
+		return nil
+	}
+
+	if o.buf.Len() > 0 {
+		if err := o.uploadPart(ctx); err != nil {
+			o.abort(ctx)
+			return err
+		}
+	}
+	_, err := o.client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+		Bucket:          aws.String(o.bucket),
+		Key:             aws.String(o.key),
+		UploadId:        aws.String(o.uploadID),
+		MultipartUpload: &types.CompletedMultipartUpload{Parts: o.parts},
+	})
+	if err != nil {
+		o.abort(ctx)
+		return s3Error(err, "failed to complete upload of %s", o.key)
+	}
+	return nil
+}
+
+// abort releases the parts of an unfinished multipart upload.
+func (o *exportObject) abort(ctx context.Context) {
+	if o.uploadID == "" {
+		return
+	}
+	_, _ = o.client.AbortMultipartUpload(context.WithoutCancel(ctx), &s3.AbortMultipartUploadInput{
+		Bucket:   aws.String(o.bucket),
+		Key:      aws.String(o.key),
+		UploadId: aws.String(o.uploadID),
+	})
+}
+
+func (o *exportObject) info() ExportedObject {
+	return ExportedObject{Key: o.key, Rows: o.rows, Bytes: o.bytes}
+}
+
+// sortedColumns returns the keys of a row in alphabetical order.
+func sortedColumns(row map[string]any) []string {
+	columns := make([]string, 0, len(row))
+	for column := range row {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// csvValue renders a JSON value as a CSV field. Nested values are written as JSON.
+func csvValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return string(utils.JsonMarshal(value))
+}
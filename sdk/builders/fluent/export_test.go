@@ -0,0 +1,141 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// fakeS3 is a minimal path-style S3 server supporting PutObject and multipart uploads.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]string
+	uploads map[string][]string
+	aborted int
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	query := r.URL.Query()
+
+	switch {
+	case r.Method == "POST" && query.Has("uploads"):
+		f.uploads[key] = nil
+		fmt.Fprintf(w, `<InitiateMultipartUploadResult><Bucket>b</Bucket><Key>%s</Key><UploadId>upload-%s</UploadId></InitiateMultipartUploadResult>`, key, key)
+	case r.Method == "PUT" && query.Has("partNumber"):
+		f.uploads[key] = append(f.uploads[key], string(body))
+		w.Header().Set("ETag", fmt.Sprintf(`"etag-%d"`, len(f.uploads[key])))
+	case r.Method == "POST" && query.Has("uploadId"):
+		f.objects[key] = strings.Join(f.uploads[key], "")
+		fmt.Fprintf(w, `<CompleteMultipartUploadResult><Key>%s</Key><ETag>"done"</ETag></CompleteMultipartUploadResult>`, key)
+	case r.Method == "DELETE" && query.Has("uploadId"):
+		f.aborted++
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == "PUT":
+		f.objects[key] = string(body)
+		w.Header().Set("ETag", `"etag"`)
+	default:
+		http.Error(w, "unexpected request", http.StatusBadRequest)
+	}
+}
+
+// setupFakeS3Env isolates the S3 client from the environment of the machine running the tests.
+func setupFakeS3Env(t *testing.T) {
+	t.Setenv("MINIO_USE_OIDC", "false")
+	t.Setenv("AWS_CA_BUNDLE", "")
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+}
+
+func TestQueryBuilder_ExportToS3(t *testing.T) {
+	defer func(rows, size int) { exportRowsPerObject, exportPartSize = rows, size }(exportRowsPerObject, exportPartSize)
+	exportRowsPerObject, exportPartSize = 3, 16
+	setupFakeS3Env(t)
+
+	s3 := &fakeS3{objects: map[string]string{}, uploads: map[string][]string{}}
+	server := httptest.NewServer(s3)
+	defer server.Close()
+
+	config := utils.Configuration{
+		DataDockID:     "dd",
+		MinIOEndpoint:  server.URL,
+		MinIORegion:    "us-east-1",
+		MinIOAccessKey: "access",
+		MinIOSecretKey: "secret",
+	}
+	qb := newTestQueryBuilder(config, func(req *http.Request) (*http.Response, error) {
+		body := `[{"id": 1, "name": "a"}, {"id": 2, "name": "b,c"}]`
+		switch req.URL.Query().Get("__offset") {
+		case "2":
+			body = `[{"id": 3, "name": null}, {"id": 4, "name": "d"}]`
+		case "4":
+			body = `[{"id": 5, "name": "e", "extra": true}]`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}).Catalog("cat").Schema("schema").Table("users").Limit(2)
+
+	result, err := qb.ExportToS3(context.Background(), "exports", "users/", ExportCSV)
+	if err != nil {
+		t.Fatalf("ExportToS3() unexpected error = %v", err)
+	}
+
+	if result.TotalRows != 5 || len(result.Objects) != 2 {
+		t.Fatalf("Unexpected result %+v", result)
+	}
+	if result.Objects[0].Key != "users/part-00000.csv" || result.Objects[0].Rows != 3 || result.Objects[1].Rows != 2 {
+		t.Errorf("Unexpected objects %+v", result.Objects)
+	}
+
+	// The first object exceeded the part size and went through a multipart upload
+	if len(s3.uploads["exports/users/part-00000.csv"]) < 2 {
+		t.Errorf("Expected a multipart upload, got parts %v", s3.uploads)
+	}
+	if got := s3.objects["exports/users/part-00000.csv"]; got != "id,name\n1,a\n2,\"b,c\"\n3,\n" {
+		t.Errorf("Unexpected first object %q", got)
+	}
+	if got := s3.objects["exports/users/part-00001.csv"]; got != "id,name\n4,d\n5,e\n" {
+		t.Errorf("Unexpected second object %q", got)
+	}
+
+	var manifest ExportResult
+	if err := json.Unmarshal([]byte(s3.objects["exports/users/manifest.json"]), &manifest); err != nil {
+		t.Fatalf("Invalid manifest: %v", err)
+	}
+	if manifest.TotalRows != 5 || len(manifest.Objects) != 2 || manifest.Objects[1].Bytes != int64(len("id,name\n4,d\n5,e\n")) {
+		t.Errorf("Unexpected manifest %+v", manifest)
+	}
+}
+
+func TestQueryBuilder_ExportToS3QueryFailure(t *testing.T) {
+	setupFakeS3Env(t)
+	s3 := &fakeS3{objects: map[string]string{}, uploads: map[string][]string{}}
+	server := httptest.NewServer(s3)
+	defer server.Close()
+
+	config := utils.Configuration{DataDockID: "dd", MinIOEndpoint: server.URL, MinIORegion: "us-east-1", MinIOAccessKey: "a", MinIOSecretKey: "s"}
+	qb := newTestQueryBuilder(config, func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}).Catalog("cat").Schema("schema").Table("users")
+
+	if _, err := qb.ExportToS3(context.Background(), "exports", "users/", ExportJSONLines); err != utils.ErrPermissionDenied {
+		t.Errorf("Expected ErrPermissionDenied, got %v", err)
+	}
+	if _, ok := s3.objects["exports/users/manifest.json"]; ok {
+		t.Error("Manifest must not be written for a failed export")
+	}
+
+	if _, err := qb.ExportToS3(context.Background(), "exports", "users/", "parquet"); err == nil {
+		t.Error("Expected an error for an unsupported format")
+	}
+}
@@ -0,0 +1,225 @@
+package fluent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// defaultFanOutConcurrency bounds the sources queried at once by FanOut.
+const defaultFanOutConcurrency = 4
+
+// DefaultSourceColumn is the column FanOut adds to each merged row to label its source.
+const DefaultSourceColumn = "_source"
+
+// Source is a datadock queried by FanOut. OrgID is informational: it labels the
+// results of datadocks resolved from several organizations (see Client.OrgSources).
+type Source struct {
+	OrgID      string
+	DataDockID string
+}
+
+// DataDocks returns the sources of the given datadock IDs.
+func DataDocks(dataDockIDs ...string) []Source {
+	sources := make([]Source, len(dataDockIDs))
+	for i, id := range dataDockIDs {
+		sources[i] = Source{DataDockID: id}
+	}
+	return sources
+}
+
+func (s Source) String() string {
+	if s.OrgID == "" {
+		return s.DataDockID
+	}
+	return s.OrgID + "/" + s.DataDockID
+}
+
+// FanOutOptions configures FanOut.
+type FanOutOptions struct {
+	Concurrency  int    // Sources queried at once (default 4)
+	SourceColumn string // Column labeling merged rows with their source (default "_source")
+}
+
+// SourceResult is the outcome of a fanned-out query on one source.
+type SourceResult struct {
+	Source   Source
+	Rows     []map[string]any // Rows of a table query
+	Search   *SearchResults   // Results of a search
+	Response *utils.Response  // Response of a table query
+	Err      error
+}
+
+// SourceDocument is a search hit labeled with the source it was found in.
+type SourceDocument struct {
+	Source Source
+	DocumentResult
+}
+
+// FanOutResult holds the per-source results of FanOut and their merge.
+type FanOutResult struct {
+	Sources   []SourceResult   // In the order of the sources
+	Rows      []map[string]any // Rows of the successful sources, in source order
+	Documents []SourceDocument // Search hits of the successful sources, by descending score
+}
+
+// FanOutError reports the sources a fanned-out query failed on. errors.Is and
+// errors.As see through it to the error of each source.
+type FanOutError struct {
+	Failed []SourceResult
+	Total  int // Sources queried
+}
+
+func (e *FanOutError) Error() string {
+	messages := make([]string, 0, len(e.Failed))
+	for _, result := range e.Failed {
+		messages = append(messages, fmt.Sprintf("%s: %v", result.Source, result.Err))
+	}
+	return fmt.Sprintf("%d of %d sources failed: %s", len(e.Failed), e.Total, strings.Join(messages, "; "))
+}
+
+func (e *FanOutError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, result := range e.Failed {
+		errs = append(errs, result.Err)
+	}
+	return errs
+}
+
+// FanOut runs the query on every source concurrently, at most opts.Concurrency at
+// a time, and merges the rows. Each merged row is a copy labeled with the datadock
+// ID of its source in opts.SourceColumn (prefixed with the organization ID when
+// set, as "org/datadock"). The per-source rows are left unlabeled.
+//
+// A failing source does not stop the others: the results of the successful sources
+// are returned along with a *FanOutError listing the failures.
+//
+// Example:
+//
+//	result, err := client.Query().Catalog("sales").Schema("public").Table("orders").
+//	    Where("status", "=", "open").
+//	    FanOut(ctx, fluent.DataDocks("tenant-a", "tenant-b"), fluent.FanOutOptions{})
+func (qb *QueryBuilder) FanOut(ctx context.Context, sources []Source, opts FanOutOptions) (*FanOutResult, error) {
+	if err := qb.validateFanOut(sources); err != nil {
+		return nil, err
+	}
+
+	column := opts.SourceColumn
+	if column == "" {
+		column = DefaultSourceColumn
+	}
+
+	results := fanOut(ctx, sources, opts.Concurrency, func(ctx context.Context, result *SourceResult) {
+		result.Response, result.Err = qb.DataDock(result.Source.DataDockID).Get(ctx)
+		if result.Err == nil {
+			result.Rows, result.Err = result.Response.Rows()
+		}
+	})
+
+	merged := &FanOutResult{Sources: results}
+	for _, result := range results {
+		for _, row := range result.Rows {
+			labeled := make(map[string]any, len(row)+1)
+			for key, value := range row {
+				labeled[key] = value
+			}
+			labeled[column] = result.Source.String()
+			merged.Rows = append(merged.Rows, labeled)
+		}
+	}
+	return merged, fanOutError(results)
+}
+
+// FanOut runs the search on every source concurrently, at most opts.Concurrency at
+// a time, and merges the hits by descending score. opts.SourceColumn is unused:
+// hits are labeled through SourceDocument.Source.
+//
+// A failing source does not stop the others: the results of the successful sources
+// are returned along with a *FanOutError listing the failures.
+func (sb *SearchBuilder) FanOut(ctx context.Context, sources []Source, opts FanOutOptions) (*FanOutResult, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("%w: fan-out requires at least one source", utils.ErrInvalidRequest)
+	}
+
+	results := fanOut(ctx, sources, opts.Concurrency, func(ctx context.Context, result *SourceResult) {
+		result.Search, result.Err = sb.DataDock(result.Source.DataDockID).Execute(ctx)
+	})
+
+	merged := &FanOutResult{Sources: results}
+	for _, result := range results {
+		if result.Search == nil {
+			continue
+		}
+		for _, document := range result.Search.Results {
+			merged.Documents = append(merged.Documents, SourceDocument{Source: result.Source, DocumentResult: document})
+		}
+	}
+	sort.SliceStable(merged.Documents, func(i, j int) bool {
+		return merged.Documents[i].Score > merged.Documents[j].Score
+	})
+	return merged, fanOutError(results)
+}
+
+// validateFanOut checks the query before it is sent to every source, so that an
+// invalid query fails once instead of once per source.
+func (qb *QueryBuilder) validateFanOut(sources []Source) error {
+	if len(sources) == 0 {
+		return fmt.Errorf("%w: fan-out requires at least one source", utils.ErrInvalidRequest)
+	}
+	for _, source := range sources {
+		if source.DataDockID == "" {
+			return fmt.Errorf("%w: fan-out source has an empty datadock ID", utils.ErrInvalidRequest)
+		}
+	}
+	probe := qb.clone()
+	probe.dataDockID = sources[0].DataDockID
+	return probe.validate()
+}
+
+// fanOut runs query on every source, at most concurrency at a time, and returns
+// the results in source order.
+func fanOut(ctx context.Context, sources []Source, concurrency int, query func(ctx context.Context, result *SourceResult)) []SourceResult {
+	if concurrency <= 0 {
+		concurrency = defaultFanOutConcurrency
+	}
+
+	results := make([]SourceResult, len(sources))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		results[i].Source = source
+		wg.Add(1)
+		go func(result *SourceResult) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				result.Err = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			query(ctx, result)
+		}(&results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+// fanOutError returns a *FanOutError listing the failed results, or nil.
+func fanOutError(results []SourceResult) error {
+	fanOutErr := &FanOutError{Total: len(results)}
+	for _, result := range results {
+		if result.Err != nil {
+			fanOutErr.Failed = append(fanOutErr.Failed, result)
+		}
+	}
+	if len(fanOutErr.Failed) > 0 {
+		return fanOutErr
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestQueryBuilder_FanOut(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{}, func(req *http.Request) (*http.Response, error) {
+		switch {
+		case strings.HasPrefix(req.URL.Path, "/tenant-a/"):
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"id": 1}, {"id": 2}]`))}, nil
+		case strings.HasPrefix(req.URL.Path, "/tenant-b/"):
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"id": 3}]`))}, nil
+		}
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`{"error": "unknown datadock"}`))}, nil
+	}).Catalog("c").Schema("s").Table("orders")
+
+	sources := []Source{{OrgID: "org", DataDockID: "tenant-a"}, {DataDockID: "tenant-b"}, {DataDockID: "gone"}}
+	result, err := qb.FanOut(context.Background(), sources, FanOutOptions{Concurrency: 2, SourceColumn: "tenant"})
+
+	var fanOutErr *FanOutError
+	if !errors.As(err, &fanOutErr) || len(fanOutErr.Failed) != 1 || fanOutErr.Failed[0].Source.DataDockID != "gone" {
+		t.Fatalf("Expected a FanOutError on the missing datadock, got %v", err)
+	}
+	if !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Expected the FanOutError to wrap ErrNotFound, got %v", err)
+	}
+
+	if len(result.Sources) != 3 || len(result.Sources[0].Rows) != 2 || len(result.Sources[1].Rows) != 1 {
+		t.Fatalf("Unexpected per-source results: %+v", result.Sources)
+	}
+	if _, labeled := result.Sources[0].Rows[0]["tenant"]; labeled {
+		t.Error("Expected per-source rows to be left unlabeled")
+	}
+	labels := []string{}
+	for _, row := range result.Rows {
+		labels = append(labels, row["tenant"].(string))
+	}
+	if got := strings.Join(labels, ","); got != "org/tenant-a,org/tenant-a,tenant-b" {
+		t.Errorf("Unexpected merged row labels: %s", got)
+	}
+}
+
+func TestQueryBuilder_FanOutValidation(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{}, nil).Catalog("c").Schema("s")
+
+	if _, err := qb.FanOut(context.Background(), DataDocks("a"), FanOutOptions{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected a missing table to fail once, got %v", err)
+	}
+	if _, err := qb.Table("t").FanOut(context.Background(), nil, FanOutOptions{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected an empty source list to fail, got %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
 )
 
@@ -38,6 +39,7 @@ type HybridSearchResults struct {
 }
 
 // HybridSearchBuilder provides a fluent interface for building and executing hybrid search queries.
+// Chaining methods return a new builder and leave the receiver untouched.
 type HybridSearchBuilder struct {
 	client interface {
 		Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error)
@@ -75,6 +77,7 @@ func NewHybridSearchBuilder(client interface {
 
 // FTSQuery sets the full-text search query string (BM25 keyword matching).
 func (b *HybridSearchBuilder) FTSQuery(query string) *HybridSearchBuilder {
+	b = b.clone()
 	if query == "" {
 		b.errors = append(b.errors, fmt.Errorf("FTS query cannot be empty"))
 	}
@@ -84,6 +87,7 @@ func (b *HybridSearchBuilder) FTSQuery(query string) *HybridSearchBuilder {
 
 // VectorQuery sets the vector search query string (semantic similarity / embedding generation).
 func (b *HybridSearchBuilder) VectorQuery(query string) *HybridSearchBuilder {
+	b = b.clone()
 	if query == "" {
 		b.errors = append(b.errors, fmt.Errorf("vector query cannot be empty"))
 	}
@@ -93,6 +97,7 @@ func (b *HybridSearchBuilder) VectorQuery(query string) *HybridSearchBuilder {
 
 // DataDock sets the data dock ID for the search.
 func (b *HybridSearchBuilder) DataDock(dataDockID string) *HybridSearchBuilder {
+	b = b.clone()
 	if dataDockID == "" {
 		b.errors = append(b.errors, fmt.Errorf("data dock ID cannot be empty"))
 	}
@@ -102,6 +107,7 @@ func (b *HybridSearchBuilder) DataDock(dataDockID string) *HybridSearchBuilder {
 
 // Catalog sets the catalog name for the search.
 func (b *HybridSearchBuilder) Catalog(name string) *HybridSearchBuilder {
+	b = b.clone()
 	if name == "" {
 		b.errors = append(b.errors, fmt.Errorf("catalog name cannot be empty"))
 	}
@@ -111,6 +117,7 @@ func (b *HybridSearchBuilder) Catalog(name string) *HybridSearchBuilder {
 
 // Schema sets the schema name for the search.
 func (b *HybridSearchBuilder) Schema(name string) *HybridSearchBuilder {
+	b = b.clone()
 	if name == "" {
 		b.errors = append(b.errors, fmt.Errorf("schema name cannot be empty"))
 	}
@@ -120,6 +127,7 @@ func (b *HybridSearchBuilder) Schema(name string) *HybridSearchBuilder {
 
 // Table sets the table name for the search.
 func (b *HybridSearchBuilder) Table(name string) *HybridSearchBuilder {
+	b = b.clone()
 	if name == "" {
 		b.errors = append(b.errors, fmt.Errorf("table name cannot be empty"))
 	}
@@ -129,24 +137,28 @@ func (b *HybridSearchBuilder) Table(name string) *HybridSearchBuilder {
 
 // Columns sets the columns to index for the search.
 func (b *HybridSearchBuilder) Columns(columns ...string) *HybridSearchBuilder {
+	b = b.clone()
 	b.columnsToIndex = append(b.columnsToIndex, columns...)
 	return b
 }
 
 // ColumnWeights sets per-column FTS weights for biased keyword matching.
 func (b *HybridSearchBuilder) ColumnWeights(weights map[string]float32) *HybridSearchBuilder {
+	b = b.clone()
 	b.columnWeights = weights
 	return b
 }
 
 // Fusion sets the fusion configuration (strategy, rrf_k, alpha).
 func (b *HybridSearchBuilder) Fusion(config FusionConfig) *HybridSearchBuilder {
+	b = b.clone()
 	b.fusion = &config
 	return b
 }
 
 // FTSLimit sets the number of FTS candidates before fusion (default: 100, max: 1000).
 func (b *HybridSearchBuilder) FTSLimit(n int) *HybridSearchBuilder {
+	b = b.clone()
 	if n <= 0 || n > 1000 {
 		b.errors = append(b.errors, fmt.Errorf("fts_limit must be between 1 and 1000"))
 		return b
@@ -157,6 +169,7 @@ func (b *HybridSearchBuilder) FTSLimit(n int) *HybridSearchBuilder {
 
 // VectorLimit sets the number of vector candidates before fusion (default: 100, max: 1000).
 func (b *HybridSearchBuilder) VectorLimit(n int) *HybridSearchBuilder {
+	b = b.clone()
 	if n <= 0 || n > 1000 {
 		b.errors = append(b.errors, fmt.Errorf("vector_limit must be between 1 and 1000"))
 		return b
@@ -167,6 +180,7 @@ func (b *HybridSearchBuilder) VectorLimit(n int) *HybridSearchBuilder {
 
 // Limit sets the maximum number of final results to return.
 func (b *HybridSearchBuilder) Limit(n int) *HybridSearchBuilder {
+	b = b.clone()
 	if n <= 0 || n > 100 {
 		b.errors = append(b.errors, fmt.Errorf("limit must be between 1 and 100"))
 		return b
@@ -233,8 +247,15 @@ func (b *HybridSearchBuilder) Execute(ctx context.Context) (*HybridSearchResults
 		requestBody["vector_limit"] = b.vectorLimit
 	}
 
+	if err := builders.RequireFeature(ctx, b.client, utils.FeatureHybridSearch, "hybrid search"); err != nil {
+		return nil, err
+	}
+
 	endpoint := fmt.Sprintf("%s/api/hybrid-search", b.client.GetConfig().BaseURL)
-	body := utils.JsonMarshal(requestBody)
+	body, err := utils.EncodeBody(b.client.GetConfig(), requestBody)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := b.client.Do(ctx, "POST", endpoint, body)
 	if err != nil {
@@ -252,3 +273,12 @@ func (b *HybridSearchBuilder) Execute(ctx context.Context) (*HybridSearchResults
 
 	return results, nil
 }
+
+// clone returns a copy of the builder that can be modified independently.
+// Column weights and fusion settings are replaced, never modified, so they are shared.
+func (b *HybridSearchBuilder) clone() *HybridSearchBuilder {
+	next := *b
+	next.errors = append([]error(nil), b.errors...)
+	next.columnsToIndex = append([]string(nil), b.columnsToIndex...)
+	return &next
+}
@@ -0,0 +1,123 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestIncrementalReader_Run(t *testing.T) {
+	table := []int{1, 2, 3, 4, 5}
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		if query.Get("order") != "id.asc" {
+			t.Errorf("order = %q, want id.asc", query.Get("order"))
+		}
+		limit, _ := strconv.Atoi(query.Get("__limit"))
+		after, _ := strconv.Atoi(query.Get("id.gt"))
+		rows := []map[string]any{}
+		for _, id := range table {
+			if id > after && len(rows) < limit {
+				rows = append(rows, map[string]any{"id": id})
+			}
+		}
+		body, _ := json.Marshal(rows)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(body)))}, nil
+	}).Catalog("c").Schema("s").Table("orders").Limit(2)
+
+	store := NewFileCheckpointStore(t.TempDir())
+	reader := NewIncrementalReader(qb, CursorSpec{Column: "id"}, store)
+	ctx := context.Background()
+
+	var read int
+	count := func(ctx context.Context, rows []map[string]any) error {
+		read += len(rows)
+		return nil
+	}
+	result, err := reader.Run(ctx, count)
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+	if read != 5 || result.Rows != 5 || result.Previous != "" || result.Current != "5" {
+		t.Errorf("first run read %d rows, result = %+v", read, result)
+	}
+
+	table = append(table, 6, 7)
+	read = 0
+	result, err = reader.Run(ctx, count)
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+	if read != 2 || result.Previous != "5" || result.Current != "7" {
+		t.Errorf("second run read %d rows, result = %+v", read, result)
+	}
+	if checkpoint, _ := store.Load(ctx, "c.s.orders.id"); checkpoint != "7" {
+		t.Errorf("saved checkpoint = %q, want 7", checkpoint)
+	}
+
+	// A failing page is read again on the next run
+	if err := reader.Reset(ctx); err != nil {
+		t.Fatalf("Reset() unexpected error = %v", err)
+	}
+	errSink := errors.New("sink unavailable")
+	pages := 0
+	result, err = reader.Run(ctx, func(ctx context.Context, rows []map[string]any) error {
+		if pages++; pages == 2 {
+			return errSink
+		}
+		return nil
+	})
+	if !errors.Is(err, errSink) || result.Rows != 2 || result.Current != "2" {
+		t.Errorf("Run() = %+v, %v, want 2 rows and the sink error", result, err)
+	}
+	if checkpoint, _ := reader.Checkpoint(ctx); checkpoint != "2" {
+		t.Errorf("Checkpoint() = %q, want 2", checkpoint)
+	}
+}
+
+func TestIncrementalReader_CursorValue(t *testing.T) {
+	tests := []struct {
+		cursorType CursorType
+		checkpoint string
+		want       any
+		wantErr    bool
+	}{
+		{CursorInteger, "42", int64(42), false},
+		{CursorInteger, "abc", nil, true},
+		{CursorTimestamp, "2025-03-01T08:30:00.123456Z", "2025-03-01T08:30:00.123456Z", false},
+		{CursorTimestamp, "2025-03-01 08:30:00", "2025-03-01 08:30:00", false},
+		{CursorTimestamp, "yesterday", nil, true},
+		{CursorString, "01HZX", "01HZX", false},
+	}
+	for _, tt := range tests {
+		reader := NewIncrementalReader(&QueryBuilder{}, CursorSpec{Column: "c", Type: tt.cursorType}, nil)
+		got, err := reader.cursorValue(tt.checkpoint)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("cursorValue(%s, %q) = %v, %v", tt.cursorType, tt.checkpoint, got, err)
+		}
+	}
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+	store := NewFileCheckpointStore(t.TempDir())
+	ctx := context.Background()
+
+	if _, err := store.Load(ctx, "a/b"); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Load() error = %v, want ErrNotFound", err)
+	}
+	for _, value := range []string{"1", "2"} {
+		if err := store.Save(ctx, "a/b", value); err != nil {
+			t.Fatalf("Save() unexpected error = %v", err)
+		}
+	}
+	if value, err := store.Load(ctx, "a/b"); err != nil || value != "2" {
+		t.Errorf("Load() = %q, %v, want 2", value, err)
+	}
+}
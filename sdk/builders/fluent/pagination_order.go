@@ -0,0 +1,49 @@
+package fluent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// stableOrder returns the query ordered deterministically for pagination, as set
+// by Configuration.PaginationOrder. A query without OrderBy is ordered by the
+// primary key of the table when the column metadata identifies one; otherwise
+// it is reported or refused. Queries with an OrderBy are returned as they are.
+func (qb *QueryBuilder) stableOrder(ctx context.Context) (*QueryBuilder, error) {
+	config := qb.client.GetConfig()
+	mode := config.PaginationOrder
+	if mode == utils.PaginationOrderOff || len(qb.orderBy) > 0 || qb.orderChecked {
+		return qb, nil
+	}
+	if mode != utils.PaginationOrderWarn && mode != utils.PaginationOrderStrict {
+		return nil, fmt.Errorf("%w: unknown pagination order mode %q", utils.ErrInvalidRequest, mode)
+	}
+	if err := qb.validate(); err != nil {
+		return nil, err
+	}
+
+	qb = qb.clone()
+	qb.orderChecked = true
+	columns, err := qb.fetchColumns(ctx)
+	if err != nil && mode == utils.PaginationOrderStrict {
+		return nil, fmt.Errorf("failed to fetch columns for the pagination order: %w", err)
+	}
+	for _, column := range columns {
+		if column.PrimaryKey {
+			qb.orderBy = append(qb.orderBy, builders.OrderClause{Column: column.Name, Direction: "ASC"})
+		}
+	}
+	if len(qb.orderBy) > 0 {
+		return qb, nil
+	}
+
+	table := qb.catalogName + "." + qb.schemaName + "." + qb.tableName
+	if mode == utils.PaginationOrderStrict {
+		return nil, fmt.Errorf("%w: %s is paginated without OrderBy and has no known primary key", utils.ErrInvalidRequest, table)
+	}
+	config.Warn("%s is paginated without OrderBy and has no known primary key: pages may overlap or skip rows", table)
+	return qb, nil
+}
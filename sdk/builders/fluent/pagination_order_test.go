@@ -0,0 +1,113 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// paginatedTable serves the given columns and two pages of rows, recording the
+// order of the row requests.
+func paginatedTable(columns string, orders *[]string) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		body := `[{"id": 1}, {"id": 2}]`
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/columns"):
+			body = columns
+		case req.URL.Query().Get("__offset") == "2" || req.URL.Query().Has("id.gt"):
+			body = `[{"id": 3}]`
+		}
+		if !strings.HasSuffix(req.URL.Path, "/columns") {
+			*orders = append(*orders, req.URL.Query().Get("order"))
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+}
+
+func TestQueryBuilder_PaginationOrderPrimaryKey(t *testing.T) {
+	var orders []string
+	columns := `{"columns": [{"name": "region", "primary_key": true}, {"name": "id", "is_primary_key": true}, {"name": "name"}]}`
+	config := utils.Configuration{DataDockID: "dd", PaginationOrder: utils.PaginationOrderStrict}
+	qb := newTestQueryBuilder(config, paginatedTable(columns, &orders)).
+		Catalog("cat").Schema("schema").Table("users").Limit(2)
+
+	count := 0
+	for _, err := range qb.Iter(context.Background()) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		count++
+	}
+	if count != 3 || len(orders) != 2 {
+		t.Fatalf("Unexpected pagination: %d rows, %d pages", count, len(orders))
+	}
+	for _, order := range orders {
+		if order != "region.asc,id.asc" {
+			t.Errorf("Expected the primary key as tie-breaker, got %q", order)
+		}
+	}
+	if len(qb.orderBy) != 0 {
+		t.Errorf("Expected Iter to leave the builder untouched, got %v", qb.orderBy)
+	}
+}
+
+func TestQueryBuilder_PaginationOrderStrict(t *testing.T) {
+	var orders []string
+	config := utils.Configuration{DataDockID: "dd", PaginationOrder: utils.PaginationOrderStrict}
+	qb := newTestQueryBuilder(config, paginatedTable(`{"columns": [{"name": "id"}]}`, &orders)).
+		Catalog("cat").Schema("schema").Table("users")
+
+	if _, err := qb.Offset(10).Get(context.Background()); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest without primary key, got %v", err)
+	}
+	for _, err := range qb.Iter(context.Background()) {
+		if !errors.Is(err, utils.ErrInvalidRequest) {
+			t.Errorf("Expected Iter to fail without primary key, got %v", err)
+		}
+	}
+
+	if _, err := qb.Limit(10).Get(context.Background()); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected the first page to be checked too, got %v", err)
+	}
+	if len(orders) != 0 {
+		t.Errorf("Expected no row request, got %d", len(orders))
+	}
+
+	// Ordered and unpaginated queries are not checked
+	if _, err := qb.OrderBy("name", "ASC").Offset(10).Get(context.Background()); err != nil {
+		t.Errorf("Expected an ordered query to pass, got %v", err)
+	}
+	if _, err := qb.Get(context.Background()); err != nil {
+		t.Errorf("Expected an unpaginated query to pass, got %v", err)
+	}
+}
+
+func TestQueryBuilder_PaginationOrderWarn(t *testing.T) {
+	var orders, warnings []string
+	config := utils.Configuration{
+		DataDockID:      "dd",
+		PaginationOrder: utils.PaginationOrderWarn,
+		OnWarning:       func(message string) { warnings = append(warnings, message) },
+	}
+	qb := newTestQueryBuilder(config, paginatedTable(`{"columns": [{"name": "id"}]}`, &orders)).
+		Catalog("cat").Schema("schema").Table("users").Limit(2)
+
+	count := 0
+	for _, err := range qb.Iter(context.Background()) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		count++
+	}
+	if count != 3 || len(orders) != 2 || orders[0] != "" {
+		t.Errorf("Expected the query to run unordered, got %d rows, orders %q", count, orders)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "cat.schema.users") {
+		t.Errorf("Expected a single warning for the iteration, got %q", warnings)
+	}
+}
@@ -0,0 +1,176 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"sync"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// ScanOptions configures ParallelScan.
+type ScanOptions struct {
+	// KeyColumn is an integer column used to split the table, ideally its primary key (required).
+	KeyColumn string
+	// Partitions is the number of key ranges scanned (default 4).
+	Partitions int
+	// Concurrency bounds the number of ranges scanned at the same time (default Partitions).
+	Concurrency int
+}
+
+// parallelScanBuffer is the number of rows buffered between the range scans and the consumer.
+const parallelScanBuffer = 256
+
+// ParallelScan returns an iterator over every row matching the query, fetched by
+// concurrent range queries on the key column. The minimum and maximum key are
+// queried first, then the range between them is split into equal partitions scanned
+// page by page (see Iter; Limit sets the page size) with bounded concurrency.
+//
+// Rows of different partitions are interleaved: ordering is not preserved and any
+// OrderBy of the query is replaced by the key ordering of each partition. The scan
+// stops at the first error, or when the loop is exited.
+//
+// Example:
+//
+//	for row, err := range qb.Limit(5000).ParallelScan(ctx, fluent.ScanOptions{Partitions: 8, KeyColumn: "id"}) {
+//	    if err != nil { ... }
+//	}
+func (qb *QueryBuilder) ParallelScan(ctx context.Context, opts ScanOptions) iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		if opts.KeyColumn == "" {
+			yield(nil, fmt.Errorf("%w: parallel scan requires a key column", utils.ErrInvalidRequest))
+			return
+		}
+		if opts.Partitions <= 0 {
+			opts.Partitions = 4
+		}
+		if opts.Concurrency <= 0 || opts.Concurrency > opts.Partitions {
+			opts.Concurrency = opts.Partitions
+		}
+
+		low, high, err := qb.keyBounds(ctx, opts.KeyColumn)
+		if errors.Is(err, utils.ErrNotFound) {
+			return // No matching rows
+		}
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type result struct {
+			row map[string]any
+			err error
+		}
+		results := make(chan result, parallelScanBuffer)
+		slots := make(chan struct{}, opts.Concurrency)
+		var wg sync.WaitGroup
+		for _, partition := range splitKeyRange(low, high, opts.Partitions) {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				select {
+				case slots <- struct{}{}:
+					defer func() { <-slots }()
+				case <-ctx.Done():
+					return
+				}
+
+				scan := qb.Where(opts.KeyColumn, ">=", partition[0]).Where(opts.KeyColumn, "<=", partition[1])
+				scan.orderBy = []builders.OrderClause{{Column: opts.KeyColumn, Direction: "ASC"}}
+				for row, err := range scan.Iter(ctx) {
+					select {
+					case results <- result{row, err}:
+					case <-ctx.Done():
+						return
+					}
+					if err != nil {
+						return
+					}
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+
+		for r := range results {
+			if !yield(r.row, r.err) || r.err != nil {
+				return
+			}
+		}
+	}
+}
+
+// keyBounds returns the smallest and largest value of an integer key column.
+func (qb *QueryBuilder) keyBounds(ctx context.Context, column string) (low, high int64, err error) {
+	bound := func(direction string) (int64, error) {
+		query := qb.clone()
+		query.selectCols = []string{column}
+		query.orderBy = []builders.OrderClause{{Column: column, Direction: direction}}
+		query.limitVal = 1
+
+		// The row is read as decoded, not through First, to keep json.Number exact
+		resp, err := query.Get(utils.ContextWithoutTransformers(ctx))
+		if err != nil {
+			return 0, err
+		}
+		rows, err := resp.Rows()
+		if err != nil {
+			return 0, err
+		}
+		if len(rows) == 0 {
+			return 0, fmt.Errorf("%w: query returned no rows", utils.ErrNotFound)
+		}
+		row := rows[0]
+		switch value := row[column].(type) {
+		case float64:
+			if value == math.Trunc(value) {
+				return int64(value), nil
+			}
+		case json.Number: // Configuration.UseNumber
+			if n, err := value.Int64(); err == nil {
+				return n, nil
+			}
+		}
+		return 0, fmt.Errorf("%w: key column %s must hold integers, got %v", utils.ErrInvalidRequest, column, row[column])
+	}
+
+	if low, err = bound("ASC"); err != nil {
+		return 0, 0, err
+	}
+	if high, err = bound("DESC"); err != nil {
+		return 0, 0, err
+	}
+	return low, high, nil
+}
+
+// splitKeyRange splits [low, high] into at most n contiguous inclusive ranges.
+func splitKeyRange(low, high int64, n int) [][2]int64 {
+	span := uint64(high-low) + 1
+	step := span / uint64(n)
+	if span%uint64(n) != 0 {
+		step++
+	}
+
+	ranges := make([][2]int64, 0, n)
+	for start := low; ; {
+		end := high
+		if uint64(high-start) >= step {
+			end = start + int64(step) - 1
+		}
+		ranges = append(ranges, [2]int64{start, end})
+		if end == high {
+			return ranges
+		}
+		start = end + 1
+	}
+}
@@ -0,0 +1,89 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestQueryBuilder_ParallelScan(t *testing.T) {
+	for _, useNumber := range []bool{false, true} {
+		t.Run(fmt.Sprintf("UseNumber=%v", useNumber), func(t *testing.T) {
+			testParallelScan(t, useNumber)
+		})
+	}
+}
+
+func testParallelScan(t *testing.T, useNumber bool) {
+	var mu sync.Mutex
+	var ranges []string
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd", UseNumber: useNumber}, func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		low, high := 1, 10
+		if query.Get("__limit") == "1" {
+			// Key bounds lookup
+			value := low
+			if query.Get("order") == "id.desc" {
+				value = high
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"id": ` + strconv.Itoa(value) + `}]`))}, nil
+		}
+
+		from, _ := strconv.Atoi(query.Get("id.gte"))
+		to, _ := strconv.Atoi(query.Get("id.lte"))
+		if after := query.Get("id.gt"); after != "" {
+			from, _ = strconv.Atoi(after)
+			from++
+		} else {
+			mu.Lock()
+			ranges = append(ranges, query.Get("id.gte")+"-"+query.Get("id.lte"))
+			mu.Unlock()
+		}
+		rows := []map[string]any{}
+		for id := from; id <= to && len(rows) < 2; id++ {
+			rows = append(rows, map[string]any{"id": id})
+		}
+		body, _ := json.Marshal(rows)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(body)))}, nil
+	}).Catalog("cat").Schema("schema").Table("t").Limit(2)
+
+	seen := map[string]bool{}
+	for row, err := range qb.ParallelScan(context.Background(), ScanOptions{KeyColumn: "id", Partitions: 3, Concurrency: 2}) {
+		if err != nil {
+			t.Fatalf("ParallelScan() unexpected error = %v", err)
+		}
+		seen[fmt.Sprint(row["id"])] = true
+	}
+
+	if len(seen) != 10 {
+		t.Errorf("Expected 10 distinct rows, got %v", seen)
+	}
+	if len(ranges) != 3 || !strings.Contains(strings.Join(ranges, " "), "9-10") {
+		t.Errorf("Unexpected ranges %v", ranges)
+	}
+}
+
+func TestSplitKeyRange(t *testing.T) {
+	got := splitKeyRange(1, 10, 3)
+	expected := [][2]int64{{1, 4}, {5, 8}, {9, 10}}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range got {
+		if got[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, got)
+		}
+	}
+
+	if got := splitKeyRange(5, 6, 8); len(got) != 2 {
+		t.Errorf("Expected one range per key, got %v", got)
+	}
+}
@@ -0,0 +1,163 @@
+package fluent
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Param is a placeholder used as a Where value in a prepared query.
+// It is replaced at execution time by the value bound under the same name.
+type Param string
+
+// PreparedQuery is an immutable query template. Every method returns a new
+// PreparedQuery and leaves the receiver untouched, so a template can be shared
+// and executed concurrently from multiple goroutines.
+//
+// Example:
+//
+//	byStatus := client.PrepareQuery("orders-by-status").
+//	    Catalog("sales").
+//	    Schema("public").
+//	    Table("orders").
+//	    Where("status", "=", fluent.Param("status")).
+//	    Limit(100)
+//
+//	resp, err := byStatus.Bind("status", "active").Get(ctx)
+type PreparedQuery struct {
+	name     string
+	template *QueryBuilder
+	binds    map[string]any
+}
+
+// NewPreparedQuery creates a new, empty PreparedQuery. The name identifies the
+// template in error messages.
+func NewPreparedQuery(client builders.ClientInterface, name string) *PreparedQuery {
+	return &PreparedQuery{
+		name:     name,
+		template: NewQueryBuilder(client),
+	}
+}
+
+// Name returns the name of the template.
+func (p *PreparedQuery) Name() string {
+	return p.name
+}
+
+// with returns a copy of the template using the given query builder.
+func (p *PreparedQuery) with(template *QueryBuilder) *PreparedQuery {
+	return &PreparedQuery{name: p.name, template: template, binds: p.binds}
+}
+
+// DataDock sets the data dock ID of the template.
+func (p *PreparedQuery) DataDock(dataDockID string) *PreparedQuery {
+	return p.with(p.template.DataDock(dataDockID))
+}
+
+// Catalog sets the catalog name of the template.
+func (p *PreparedQuery) Catalog(name string) *PreparedQuery {
+	return p.with(p.template.Catalog(name))
+}
+
+// Schema sets the schema name of the template.
+func (p *PreparedQuery) Schema(name string) *PreparedQuery {
+	return p.with(p.template.Schema(name))
+}
+
+// Table sets the table name of the template.
+func (p *PreparedQuery) Table(name string) *PreparedQuery {
+	return p.with(p.template.Table(name))
+}
+
+// Select adds columns to retrieve.
+func (p *PreparedQuery) Select(columns ...string) *PreparedQuery {
+	return p.with(p.template.Select(columns...))
+}
+
+// Where adds a filter condition. The value may be a Param bound at execution time.
+func (p *PreparedQuery) Where(column, operator string, value interface{}) *PreparedQuery {
+	return p.with(p.template.Where(column, operator, value))
+}
+
+// OrderBy adds an ORDER BY clause.
+func (p *PreparedQuery) OrderBy(column, direction string) *PreparedQuery {
+	return p.with(p.template.OrderBy(column, direction))
+}
+
+// Limit sets the maximum number of rows to return.
+func (p *PreparedQuery) Limit(n int) *PreparedQuery {
+	return p.with(p.template.Limit(n))
+}
+
+// Offset sets the number of rows to skip.
+func (p *PreparedQuery) Offset(n int) *PreparedQuery {
+	return p.with(p.template.Offset(n))
+}
+
+// RawParams adds custom query parameters.
+func (p *PreparedQuery) RawParams(params url.Values) *PreparedQuery {
+	return p.with(p.template.RawParams(params))
+}
+
+// Header adds an HTTP header sent with every execution.
+func (p *PreparedQuery) Header(key, value string) *PreparedQuery {
+	return p.with(p.template.Header(key, value))
+}
+
+// Bind returns a copy of the template with the named parameter bound to value.
+func (p *PreparedQuery) Bind(name string, value any) *PreparedQuery {
+	binds := make(map[string]any, len(p.binds)+1)
+	for k, v := range p.binds {
+		binds[k] = v
+	}
+	binds[name] = value
+	return &PreparedQuery{name: p.name, template: p.template, binds: binds}
+}
+
+// Query returns a new QueryBuilder with every parameter replaced by its bound value.
+// The returned builder is independent from the template and can be modified freely.
+func (p *PreparedQuery) Query() (*QueryBuilder, error) {
+	qb := p.template.clone()
+	for i, filter := range qb.filters {
+		param, ok := filter.Value.(Param)
+		if !ok {
+			continue
+		}
+		value, bound := p.binds[string(param)]
+		if !bound {
+			return nil, fmt.Errorf("%w: prepared query %q: parameter %q is not bound", utils.ErrInvalidRequest, p.name, param)
+		}
+		qb.filters[i].Value = value
+	}
+	return qb, nil
+}
+
+// Get executes the template with the bound parameters.
+func (p *PreparedQuery) Get(ctx context.Context) (*utils.Response, error) {
+	qb, err := p.Query()
+	if err != nil {
+		return nil, err
+	}
+	return qb.Get(ctx)
+}
+
+// First executes the template and decodes the first row into dest.
+func (p *PreparedQuery) First(ctx context.Context, dest any) error {
+	qb, err := p.Query()
+	if err != nil {
+		return err
+	}
+	return qb.First(ctx, dest)
+}
+
+// Count executes the template and returns the number of matching rows.
+func (p *PreparedQuery) Count(ctx context.Context) (int, error) {
+	qb, err := p.Query()
+	if err != nil {
+		return 0, err
+	}
+	return qb.Count(ctx)
+}
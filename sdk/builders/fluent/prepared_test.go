@@ -0,0 +1,87 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestPreparedQuery_ConcurrentBind(t *testing.T) {
+	var mu sync.Mutex
+	seen := map[string]int{}
+	client := &mockClient{
+		config: utils.Configuration{BaseURL: "https://test.example.com", Token: "test-token"},
+		handler: func(req *http.Request) (*http.Response, error) {
+			query := req.URL.Query()
+			if query.Get("__limit") != "5" {
+				t.Errorf("Expected __limit=5, got %s", query.Get("__limit"))
+			}
+			mu.Lock()
+			seen[query.Get("status.eq")]++
+			mu.Unlock()
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[]`))}, nil
+		},
+	}
+
+	template := NewPreparedQuery(client, "orders-by-status").
+		DataDock("dd").
+		Catalog("sales").
+		Schema("public").
+		Table("orders").
+		Where("status", "=", Param("status")).
+		Limit(5)
+
+	var wg sync.WaitGroup
+	for _, status := range []string{"active", "closed", "active", "pending"} {
+		wg.Add(1)
+		go func(status string) {
+			defer wg.Done()
+			if _, err := template.Bind("status", status).Get(context.Background()); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}(status)
+	}
+	wg.Wait()
+
+	if seen["active"] != 2 || seen["closed"] != 1 || seen["pending"] != 1 {
+		t.Errorf("Unexpected bound values: %v", seen)
+	}
+	if template.template.filters[0].Value != Param("status") {
+		t.Errorf("Expected the template to keep its placeholder, got %v", template.template.filters[0].Value)
+	}
+}
+
+func TestPreparedQuery_Immutable(t *testing.T) {
+	base := NewPreparedQuery(&mockClient{config: utils.Configuration{}}, "base").
+		DataDock("dd").Catalog("sales").Schema("public")
+
+	orders := base.Table("orders")
+	users := base.Table("users").Where("id", "=", 1)
+
+	if base.template.tableName != "" || len(base.template.filters) != 0 {
+		t.Errorf("Expected the base template to be untouched, got %+v", base.template)
+	}
+	if orders.template.tableName != "orders" || len(orders.template.filters) != 0 {
+		t.Errorf("Unexpected orders template: %+v", orders.template)
+	}
+	if users.template.tableName != "users" || len(users.template.filters) != 1 {
+		t.Errorf("Unexpected users template: %+v", users.template)
+	}
+}
+
+func TestPreparedQuery_UnboundParam(t *testing.T) {
+	_, err := NewPreparedQuery(&mockClient{config: utils.Configuration{}}, "orders-by-status").
+		DataDock("dd").Catalog("sales").Schema("public").Table("orders").
+		Where("status", "=", Param("status")).
+		Get(context.Background())
+
+	if !errors.Is(err, utils.ErrInvalidRequest) || !strings.Contains(err.Error(), `"status"`) {
+		t.Errorf("Expected ErrInvalidRequest naming the unbound parameter, got %v", err)
+	}
+}
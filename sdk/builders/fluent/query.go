@@ -2,16 +2,23 @@ package fluent
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
 )
 
 // QueryBuilder provides a fluent interface for building and executing queries.
+// It has value semantics: every chaining method returns a new builder and leaves
+// the receiver untouched, so a partial query can be stored and branched safely.
 type QueryBuilder struct {
 	client builders.ClientInterface
 	errors []error
@@ -28,7 +35,26 @@ type QueryBuilder struct {
 	orderBy    []builders.OrderClause
 	limitVal   int
 	offsetVal  int
+	cursor     string
 	rawParams  url.Values
+
+	// Extra HTTP headers sent with the request
+	headers http.Header
+
+	// validateSchema checks Post and Put payloads against the table columns
+	validateSchema bool
+
+	// maxScannedBytes refuses queries estimated to scan more (0 = no limit)
+	maxScannedBytes int64
+
+	// timeLocation formats time filters as wall-clock times of this location (nil = UTC)
+	timeLocation *time.Location
+
+	// encryption encrypts some columns client-side (nil = none)
+	encryption *fieldEncryption
+
+	// orderChecked is set once the pagination order has been checked (see stableOrder)
+	orderChecked bool
 }
 
 // NewQueryBuilder creates a new QueryBuilder instance.
@@ -47,6 +73,7 @@ func NewQueryBuilder(client interface {
 // DataDock sets the data dock ID for the query.
 // If not called, uses the DataDockID from client configuration.
 func (qb *QueryBuilder) DataDock(dataDockID string) *QueryBuilder {
+	qb = qb.clone()
 	if dataDockID == "" {
 		qb.errors = append(qb.errors, fmt.Errorf("data dock ID cannot be empty"))
 	}
@@ -56,6 +83,7 @@ func (qb *QueryBuilder) DataDock(dataDockID string) *QueryBuilder {
 
 // Catalog sets the catalog name for the query.
 func (qb *QueryBuilder) Catalog(name string) *QueryBuilder {
+	qb = qb.clone()
 	if name == "" {
 		qb.errors = append(qb.errors, fmt.Errorf("catalog name cannot be empty"))
 	}
@@ -65,6 +93,7 @@ func (qb *QueryBuilder) Catalog(name string) *QueryBuilder {
 
 // Schema sets the schema name for the query.
 func (qb *QueryBuilder) Schema(name string) *QueryBuilder {
+	qb = qb.clone()
 	if name == "" {
 		qb.errors = append(qb.errors, fmt.Errorf("schema name cannot be empty"))
 	}
@@ -74,6 +103,7 @@ func (qb *QueryBuilder) Schema(name string) *QueryBuilder {
 
 // Table sets the table name for the query.
 func (qb *QueryBuilder) Table(name string) *QueryBuilder {
+	qb = qb.clone()
 	if name == "" {
 		qb.errors = append(qb.errors, fmt.Errorf("table name cannot be empty"))
 	}
@@ -84,6 +114,7 @@ func (qb *QueryBuilder) Table(name string) *QueryBuilder {
 // Select specifies which columns to retrieve.
 // Can be called multiple times to add more columns.
 func (qb *QueryBuilder) Select(columns ...string) *QueryBuilder {
+	qb = qb.clone()
 	qb.selectCols = append(qb.selectCols, columns...)
 	return qb
 }
@@ -91,6 +122,7 @@ func (qb *QueryBuilder) Select(columns ...string) *QueryBuilder {
 // Where adds a filter condition to the query.
 // Supported operators: =, !=, >, >=, <, <=, LIKE, NOT_LIKE, CONTAINS, IEQ, ILIKE, ICONTAINS, IN
 func (qb *QueryBuilder) Where(column, operator string, value interface{}) *QueryBuilder {
+	qb = qb.clone()
 	validOperators := map[string]bool{
 		"=": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true,
 		"LIKE": true, "NOT_LIKE": true, "CONTAINS": true,
@@ -113,6 +145,7 @@ func (qb *QueryBuilder) Where(column, operator string, value interface{}) *Query
 // OrderBy adds an ORDER BY clause to the query.
 // Direction should be "ASC" or "DESC" (defaults to "ASC" if empty).
 func (qb *QueryBuilder) OrderBy(column, direction string) *QueryBuilder {
+	qb = qb.clone()
 	if direction == "" {
 		direction = "ASC"
 	}
@@ -132,6 +165,7 @@ func (qb *QueryBuilder) OrderBy(column, direction string) *QueryBuilder {
 
 // Limit sets the maximum number of rows to return.
 func (qb *QueryBuilder) Limit(n int) *QueryBuilder {
+	qb = qb.clone()
 	if n < 0 {
 		qb.errors = append(qb.errors, fmt.Errorf("limit cannot be negative"))
 		return qb
@@ -142,6 +176,7 @@ func (qb *QueryBuilder) Limit(n int) *QueryBuilder {
 
 // Offset sets the number of rows to skip.
 func (qb *QueryBuilder) Offset(n int) *QueryBuilder {
+	qb = qb.clone()
 	if n < 0 {
 		qb.errors = append(qb.errors, fmt.Errorf("offset cannot be negative"))
 		return qb
@@ -152,15 +187,78 @@ func (qb *QueryBuilder) Offset(n int) *QueryBuilder {
 
 // RawParams allows adding custom query parameters.
 // This is an escape hatch for advanced use cases.
+//
+// Builder methods take precedence: a raw __select, order, __limit, __offset or
+// __cursor is only sent when the matching method is not used, and a raw __offset
+// is dropped with a cursor. These parameters keep the last raw value given. Other
+// parameters accumulate their values, each value once; raw filters (e.g. "id.gt")
+// are sent along with the Where filters.
 func (qb *QueryBuilder) RawParams(params url.Values) *QueryBuilder {
-	for key, values := range params {
-		for _, value := range values {
-			qb.rawParams.Add(key, value)
-		}
+	qb = qb.clone()
+	builders.MergeRawParams(qb.rawParams, params)
+	return qb
+}
+
+// Header adds an HTTP header to the request (e.g. a correlation ID or tenant header).
+// Headers set here override client-wide headers with the same name.
+func (qb *QueryBuilder) Header(key, value string) *QueryBuilder {
+	qb = qb.clone()
+	if key == "" {
+		qb.errors = append(qb.errors, fmt.Errorf("header name cannot be empty"))
+		return qb
+	}
+	if qb.headers == nil {
+		qb.headers = http.Header{}
+	}
+	qb.headers.Set(key, value)
+	return qb
+}
+
+// IdempotencyKey sets the Idempotency-Key header so that a retried Post, Put or
+// Delete is applied only once by the platform.
+func (qb *QueryBuilder) IdempotencyKey(key string) *QueryBuilder {
+	qb = qb.clone()
+	if key == "" {
+		qb.errors = append(qb.errors, fmt.Errorf("idempotency key cannot be empty"))
+		return qb
 	}
+	return qb.Header("Idempotency-Key", key)
+}
+
+// ValidateAgainstSchema enables checking Post and Put payloads against the table
+// columns before sending them. Unknown columns and values of an incompatible type
+// are reported as a *builders.SchemaValidationError listing every faulty field,
+// instead of an opaque 400 from the server. The columns are fetched on each call.
+func (qb *QueryBuilder) ValidateAgainstSchema(enabled bool) *QueryBuilder {
+	qb = qb.clone()
+	qb.validateSchema = enabled
 	return qb
 }
 
+// checkSchema validates a payload against the table columns when enabled.
+func (qb *QueryBuilder) checkSchema(ctx context.Context, data interface{}) error {
+	if !qb.validateSchema {
+		return nil
+	}
+	columns, err := qb.fetchColumns(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch columns for schema validation: %w", err)
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("%w: no column metadata returned for %s.%s.%s", utils.ErrAPIError, qb.catalogName, qb.schemaName, qb.tableName)
+	}
+	return builders.ValidateRows(qb.catalogName+"."+qb.schemaName+"."+qb.tableName, columns, data)
+}
+
+// fetchColumns retrieves the column metadata of the table.
+func (qb *QueryBuilder) fetchColumns(ctx context.Context) ([]builders.Column, error) {
+	resp, err := qb.do(utils.ContextWithoutTransformers(ctx), "GET", qb.buildEndpoint()+"/columns", nil)
+	if err != nil {
+		return nil, err
+	}
+	return builders.ParseColumns(resp.Data), nil
+}
+
 // validate checks that all required fields are set.
 func (qb *QueryBuilder) validate() error {
 	// Check for accumulated errors during building
@@ -185,32 +283,91 @@ func (qb *QueryBuilder) validate() error {
 	if qb.tableName == "" {
 		return fmt.Errorf("%w: table name is required", utils.ErrInvalidRequest)
 	}
+	if err := qb.encryption.checkQuery(qb.filters, qb.orderBy); err != nil {
+		return err
+	}
 
-	return nil
+	return qb.validateCursor()
 }
 
 // buildEndpoint constructs the API endpoint URL.
 func (qb *QueryBuilder) buildEndpoint() string {
+	var endpoint strings.Builder
+	qb.writeEndpoint(&endpoint)
+	return endpoint.String()
+}
+
+// writeEndpoint writes the API endpoint URL.
+func (qb *QueryBuilder) writeEndpoint(b *strings.Builder) {
 	// Use url.PathEscape for each segment to prevent injection
-	return fmt.Sprintf(
-		"%s/%s/openapi/%s/%s/%s",
-		strings.TrimRight(qb.client.GetConfig().BaseURL, "/"),
-		url.PathEscape(qb.dataDockID),
-		url.PathEscape(qb.catalogName),
-		url.PathEscape(qb.schemaName),
-		url.PathEscape(qb.tableName),
-	)
+	b.WriteString(strings.TrimRight(qb.client.GetConfig().BaseURL, "/"))
+	for _, segment := range [...]string{qb.dataDockID, "openapi", qb.catalogName, qb.schemaName, qb.tableName} {
+		b.WriteByte('/')
+		b.WriteString(url.PathEscape(segment))
+	}
+}
+
+// queryURLBuffer holds the scratch space of queryURL.
+type queryURLBuffer struct {
+	params url.Values
+	keys   []string
+}
+
+// queryURLBuffers recycles the parameter maps of the requests, which pollers
+// issue at high frequency.
+var queryURLBuffers = sync.Pool{
+	New: func() any { return &queryURLBuffer{params: url.Values{}} },
+}
+
+// queryURL returns the endpoint URL with the encoded query parameters, built in
+// a single buffer. The parameters are encoded like url.Values.Encode.
+func (qb *QueryBuilder) queryURL() string {
+	buf := queryURLBuffers.Get().(*queryURLBuffer)
+	defer func() {
+		clear(buf.params)
+		buf.keys = buf.keys[:0]
+		queryURLBuffers.Put(buf)
+	}()
+	qb.appendParams(buf.params)
+
+	var b strings.Builder
+	b.Grow(256)
+	qb.writeEndpoint(&b)
+	if len(buf.params) == 0 {
+		return b.String()
+	}
+
+	for key := range buf.params {
+		buf.keys = append(buf.keys, key)
+	}
+	slices.Sort(buf.keys)
+	separator := byte('?')
+	for _, key := range buf.keys {
+		escapedKey := url.QueryEscape(key)
+		for _, value := range buf.params[key] {
+			b.WriteByte(separator)
+			separator = '&'
+			b.WriteString(escapedKey)
+			b.WriteByte('=')
+			b.WriteString(url.QueryEscape(value))
+		}
+	}
+	return b.String()
 }
 
 // buildParams constructs the query parameters.
 func (qb *QueryBuilder) buildParams() url.Values {
-	params := url.Values{}
+	// Sized for the raw params, the filters and select, order, limit, offset and cursor
+	params := make(url.Values, len(qb.rawParams)+len(qb.filters)+5)
+	qb.appendParams(params)
+	return params
+}
 
-	// Copy raw params first (they can be overridden)
+// appendParams adds the query parameters to an empty map.
+func (qb *QueryBuilder) appendParams(params url.Values) {
+	// Copy raw params first (the builder parameters below replace them)
 	for key, values := range qb.rawParams {
-		for _, value := range values {
-			params.Add(key, value)
-		}
+		params[key] = append(params[key], values...)
 	}
 
 	// Add SELECT columns
@@ -219,38 +376,26 @@ func (qb *QueryBuilder) buildParams() url.Values {
 	}
 
 	// Add WHERE filters: column.op=value (e.g. commune.eq=75111)
-	operatorMap := map[string]string{
-		"=":         "eq",
-		"!=":        "ne",
-		">":         "gt",
-		">=":        "gte",
-		"<":         "lt",
-		"<=":        "lte",
-		"LIKE":      "like",
-		"NOT_LIKE":  "not_like",
-		"CONTAINS":  "contains",
-		"IEQ":       "ieq",
-		"ILIKE":     "ilike",
-		"ICONTAINS": "icontains",
-		"IN":        "in",
-	}
 	for _, filter := range qb.filters {
-		op := operatorMap[filter.Operator]
-		paramName := fmt.Sprintf("%s.%s", filter.Column, op)
-		params.Add(paramName, fmt.Sprintf("%v", filter.Value))
+		paramName := filter.Column + "." + filterOperators[filter.Operator]
+		params.Add(paramName, builders.FormatFilterValue(filter.Value, qb.timeLocation))
 	}
 
 	// Add ORDER BY
 	if len(qb.orderBy) > 0 {
-		var orderParts []string
-		for _, order := range qb.orderBy {
-			if order.Direction == "DESC" {
-				orderParts = append(orderParts, fmt.Sprintf("%s.desc", order.Column))
+		var order strings.Builder
+		for i, clause := range qb.orderBy {
+			if i > 0 {
+				order.WriteByte(',')
+			}
+			order.WriteString(clause.Column)
+			if clause.Direction == "DESC" {
+				order.WriteString(".desc")
 			} else {
-				orderParts = append(orderParts, fmt.Sprintf("%s.asc", order.Column))
+				order.WriteString(".asc")
 			}
 		}
-		params.Set("order", strings.Join(orderParts, ","))
+		params.Set("order", order.String())
 	}
 
 	// Add LIMIT
@@ -263,7 +408,25 @@ func (qb *QueryBuilder) buildParams() url.Values {
 		params.Set("__offset", strconv.Itoa(qb.offsetVal))
 	}
 
-	return params
+	// Add cursor (replaces OFFSET)
+	qb.applyCursor(params)
+}
+
+// filterOperators maps Where operators to their query parameter suffix.
+var filterOperators = map[string]string{
+	"=":         "eq",
+	"!=":        "ne",
+	">":         "gt",
+	">=":        "gte",
+	"<":         "lt",
+	"<=":        "lte",
+	"LIKE":      "like",
+	"NOT_LIKE":  "not_like",
+	"CONTAINS":  "contains",
+	"IEQ":       "ieq",
+	"ILIKE":     "ilike",
+	"ICONTAINS": "icontains",
+	"IN":        "in",
 }
 
 // Get executes the query and returns the results.
@@ -273,81 +436,150 @@ func (qb *QueryBuilder) Get(ctx context.Context) (*utils.Response, error) {
 	if err := qb.validate(); err != nil {
 		return nil, err
 	}
-
-	// Build endpoint and parameters
-	endpoint := qb.buildEndpoint()
-	params := qb.buildParams()
-
-	// Add parameters to endpoint
-	if len(params) > 0 {
-		endpoint += "?" + params.Encode()
+	if err := qb.checkCost(ctx); err != nil {
+		return nil, err
+	}
+	if (qb.limitVal > 0 || qb.offsetVal > 0) && qb.cursor == "" {
+		// The first page is ordered as well, so that the next ones follow it
+		var err error
+		if qb, err = qb.stableOrder(ctx); err != nil {
+			return nil, err
+		}
 	}
 
 	// Execute the request
-	return qb.client.Do(ctx, "GET", endpoint, nil)
+	resp, err := qb.do(ctx, "GET", qb.queryURL(), nil)
+	if err != nil {
+		return resp, err
+	}
+	if err := qb.encryption.decryptResponse(ctx, resp, qb.client.GetConfig().UseNumber); err != nil {
+		return resp, err
+	}
+	qb.setNextCursor(resp)
+	return resp, nil
 }
 
-// Count returns the count of rows matching the query.
-// Similar to Get() but requests only the count.
+// CountMode selects how the server computes Count results.
+type CountMode string
+
+const (
+	CountExact     CountMode = "exact"     // Exact count (full scan)
+	CountPlanned   CountMode = "planned"   // Planner estimate, fast but approximate
+	CountEstimated CountMode = "estimated" // Exact for small results, planner estimate above a threshold
+)
+
+// Count returns the exact count of rows matching the query.
+// See CountWithMode for how the count is obtained.
 func (qb *QueryBuilder) Count(ctx context.Context) (int, error) {
+	return qb.CountWithMode(ctx, CountExact)
+}
+
+// CountWithMode returns the count of rows matching the query using the given mode.
+// The count is first requested with a HEAD request and read from the Content-Range
+// (or X-Total-Count) header. When HEAD is not supported or carries no count, a GET
+// with no rows is issued and the count is read from its headers, then from a
+// "count" key in the body.
+func (qb *QueryBuilder) CountWithMode(ctx context.Context, mode CountMode) (int, error) {
+	if mode != CountExact && mode != CountPlanned && mode != CountEstimated {
+		return 0, fmt.Errorf("%w: invalid count mode '%s'", utils.ErrInvalidRequest, mode)
+	}
+
 	// Validate the query
 	if err := qb.validate(); err != nil {
 		return 0, err
 	}
+	err := builders.CheckCapabilities(ctx, qb.client, func(caps *utils.Capabilities) error {
+		if !caps.SupportsCountMode(string(mode)) {
+			return caps.Unsupported("count mode " + string(mode))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
 
 	// Build endpoint and parameters
-	endpoint := qb.buildEndpoint()
 	params := qb.buildParams()
-
-	// Add count parameter (API-specific)
-	params.Set("count", "exact")
+	params.Set("count", string(mode))
 	params.Set("__limit", "0")
+	params.Del("__offset")
+	endpoint := qb.buildEndpoint() + "?" + params.Encode()
 
-	endpoint += "?" + params.Encode()
+	headers := qb.headers.Clone()
+	if headers == nil {
+		headers = http.Header{}
+	}
+	headers.Set("Prefer", "count="+string(mode))
+	ctx = utils.ContextWithHeaders(ctx, headers)
 
-	// Execute the request
-	resp, err := qb.client.Do(ctx, "GET", endpoint, nil)
-	if err != nil {
+	resp, err := qb.do(ctx, "HEAD", endpoint, nil)
+	if err == nil && resp.TotalCount >= 0 {
+		return int(resp.TotalCount), nil
+	}
+	// Servers rejecting HEAD answer 400/405; anything else is a real failure
+	if err != nil && !errors.Is(err, utils.ErrInvalidRequest) {
 		return 0, err
 	}
 
-	// Extract count from response (adjust based on actual API response format)
-	if countVal, ok := resp.Data.(map[string]interface{})["count"]; ok {
-		if count, ok := countVal.(float64); ok {
+	resp, err = qb.do(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	if resp.TotalCount >= 0 {
+		return int(resp.TotalCount), nil
+	}
+	if data, ok := resp.GetDataAsMap(); ok {
+		if count, ok := utils.Float64(data["count"]); ok {
 			return int(count), nil
 		}
 	}
 
-	return 0, fmt.Errorf("unable to extract count from response")
+	return 0, fmt.Errorf("%w: unable to extract count from response", utils.ErrAPIError)
 }
 
-// Post executes a POST request to insert data.
+// Post executes a POST request to insert data. Structs are mapped to columns
+// following their hyperfluid struct tags (see utils.StructTag).
 func (qb *QueryBuilder) Post(ctx context.Context, data interface{}) (*utils.Response, error) {
 	if err := qb.validate(); err != nil {
 		return nil, err
 	}
 
+	data, err := qb.encryption.encryptRows(ctx, utils.RowsFromStructs(data))
+	if err != nil {
+		return nil, err
+	}
+	if err := qb.checkSchema(ctx, data); err != nil {
+		return nil, err
+	}
+
 	endpoint := qb.buildEndpoint()
-	body := utils.JsonMarshal(data)
+	body, err := utils.EncodeBody(qb.client.GetConfig(), data)
+	if err != nil {
+		return nil, err
+	}
 
-	return qb.client.Do(ctx, "POST", endpoint, body)
+	return qb.do(ctx, "POST", endpoint, body)
 }
 
-// Put executes a PUT request to update data.
+// Put executes a PUT request to update data. Structs are mapped like in Post.
 func (qb *QueryBuilder) Put(ctx context.Context, data interface{}) (*utils.Response, error) {
 	if err := qb.validate(); err != nil {
 		return nil, err
 	}
-
-	endpoint := qb.buildEndpoint()
-	params := qb.buildParams()
-
-	if len(params) > 0 {
-		endpoint += "?" + params.Encode()
+	data, err := qb.encryption.encryptRows(ctx, utils.RowsFromStructs(data))
+	if err != nil {
+		return nil, err
+	}
+	if err := qb.checkSchema(ctx, data); err != nil {
+		return nil, err
 	}
 
-	body := utils.JsonMarshal(data)
-	return qb.client.Do(ctx, "PUT", endpoint, body)
+	endpoint := qb.queryURL()
+	body, err := utils.EncodeBody(qb.client.GetConfig(), data)
+	if err != nil {
+		return nil, err
+	}
+	return qb.do(ctx, "PUT", endpoint, body)
 }
 
 // Delete executes a DELETE request.
@@ -356,12 +588,53 @@ func (qb *QueryBuilder) Delete(ctx context.Context) (*utils.Response, error) {
 		return nil, err
 	}
 
-	endpoint := qb.buildEndpoint()
-	params := qb.buildParams()
+	return qb.do(ctx, "DELETE", qb.queryURL(), nil)
+}
+
+// do executes a request with the builder headers, waking the datadock up if needed.
+func (qb *QueryBuilder) do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	if err := qb.checkFilterOperators(ctx); err != nil {
+		return nil, err
+	}
+	ctx = utils.ContextWithHeaders(ctx, qb.headers)
+	return builders.DoWithWakeUp(ctx, qb.client, qb.dataDockID, method, endpoint, body)
+}
 
-	if len(params) > 0 {
-		endpoint += "?" + params.Encode()
+// checkFilterOperators fails with ErrUnsupportedFeature when the server reported
+// that it does not support one of the filter operators.
+func (qb *QueryBuilder) checkFilterOperators(ctx context.Context) error {
+	if len(qb.filters) == 0 {
+		return nil
 	}
+	return builders.CheckCapabilities(ctx, qb.client, func(caps *utils.Capabilities) error {
+		for _, filter := range qb.filters {
+			if !caps.SupportsFilterOperator(filterOperators[filter.Operator]) {
+				return caps.Unsupported("filter operator " + filter.Operator)
+			}
+		}
+		return nil
+	})
+}
 
-	return qb.client.Do(ctx, "DELETE", endpoint, nil)
+// clone returns a deep copy of the builder.
+func (qb *QueryBuilder) clone() *QueryBuilder {
+	next := *qb
+	next.errors = append([]error(nil), qb.errors...)
+	next.selectCols = append([]string(nil), qb.selectCols...)
+	next.filters = append([]builders.Filter(nil), qb.filters...)
+	next.orderBy = append([]builders.OrderClause(nil), qb.orderBy...)
+	next.rawParams = cloneValues(qb.rawParams)
+	if qb.headers != nil {
+		next.headers = qb.headers.Clone()
+	}
+	return &next
+}
+
+// cloneValues deep-copies url.Values, always returning a non-nil map.
+func cloneValues(values url.Values) url.Values {
+	cloned := make(url.Values, len(values))
+	for key, vals := range values {
+		cloned[key] = append([]string(nil), vals...)
+	}
+	return cloned
 }
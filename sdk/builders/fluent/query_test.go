@@ -3,11 +3,16 @@ package fluent
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"net/url"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
 )
 
@@ -352,6 +357,71 @@ func TestQueryBuilder_RawParams(t *testing.T) {
 	}
 }
 
+func TestQueryBuilder_RawParamsPrecedence(t *testing.T) {
+	base := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, nil).
+		Catalog("cat").Schema("schema").Table("users")
+	cursor := encodeKeysetCursor(keysetCursor{Column: "id", Direction: "ASC", Value: 10})
+
+	tests := []struct {
+		name  string
+		query *QueryBuilder
+		want  url.Values
+	}{
+		{
+			name:  "raw values only",
+			query: base.RawParams(url.Values{"__limit": {"5"}, "__select": {"id"}, "order": {"id.desc"}, "__offset": {"20"}}),
+			want:  url.Values{"__limit": {"5"}, "__select": {"id"}, "order": {"id.desc"}, "__offset": {"20"}},
+		},
+		{
+			name:  "select",
+			query: base.RawParams(url.Values{"__select": {"id"}}).Select("name"),
+			want:  url.Values{"__select": {"name"}},
+		},
+		{
+			name:  "order",
+			query: base.OrderBy("name", "DESC").RawParams(url.Values{"order": {"id.asc"}}),
+			want:  url.Values{"order": {"name.desc"}},
+		},
+		{
+			name:  "limit",
+			query: base.RawParams(url.Values{"__limit": {"5"}}).Limit(10),
+			want:  url.Values{"__limit": {"10"}},
+		},
+		{
+			name:  "offset",
+			query: base.Offset(30).RawParams(url.Values{"__offset": {"20"}}),
+			want:  url.Values{"__offset": {"30"}},
+		},
+		{
+			name:  "opaque cursor",
+			query: base.RawParams(url.Values{"__cursor": {"raw"}, "__offset": {"20"}}).After("server-token"),
+			want:  url.Values{"__cursor": {"server-token"}},
+		},
+		{
+			name:  "keyset cursor",
+			query: base.OrderBy("id", "ASC").RawParams(url.Values{"__offset": {"20"}}).After(cursor),
+			want:  url.Values{"order": {"id.asc"}, "id.gt": {"10"}},
+		},
+		{
+			name:  "last raw value",
+			query: base.RawParams(url.Values{"__limit": {"5", "6"}}).RawParams(url.Values{"__limit": {"7"}}),
+			want:  url.Values{"__limit": {"7"}},
+		},
+		{
+			name:  "filters",
+			query: base.RawParams(url.Values{"id.gt": {"1"}}).RawParams(url.Values{"id.gt": {"1"}, "id.lt": {"9"}}).Where("id", ">", 2),
+			want:  url.Values{"id.gt": {"1", "2"}, "id.lt": {"9"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.buildParams(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("buildParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestQueryBuilder_OperatorEncoding(t *testing.T) {
 	testOperatorsTable := []struct {
 		operator   string
@@ -516,6 +586,324 @@ func TestQueryBuilder_OrderByDefaultDirection(t *testing.T) {
 	}
 }
 
+func TestQueryBuilder_First(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "dd"}, func(req *http.Request) (*http.Response, error) {
+		if got := req.URL.Query().Get("__limit"); got != "1" {
+			t.Errorf("Expected __limit=1, got %s", got)
+		}
+		body := `[{"id": 7, "name": "alice"}]`
+		if req.URL.Query().Get("name.eq") == "nobody" {
+			body = `[]`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}).Catalog("cat").Schema("schema").Table("users").Limit(50)
+
+	var user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := qb.First(context.Background(), &user); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.ID != 7 || user.Name != "alice" {
+		t.Errorf("Unexpected row: %+v", user)
+	}
+	if qb.limitVal != 50 {
+		t.Errorf("Expected First to restore the limit, got %d", qb.limitVal)
+	}
+
+	err := qb.Where("name", "=", "nobody").First(context.Background(), &user)
+	if !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestQueryBuilder_Pluck(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "dd"}, func(req *http.Request) (*http.Response, error) {
+		if got := req.URL.Query().Get("__select"); got != "id" {
+			t.Errorf("Expected __select=id, got %s", got)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"id": 1}, {"id": 2}, {"id": 3}]`))}, nil
+	}).Catalog("cat").Schema("schema").Table("users").Select("id", "name")
+
+	ids, err := PluckAs[int64](context.Background(), qb, "id")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+		t.Errorf("Unexpected values: %v", ids)
+	}
+	if len(qb.selectCols) != 2 {
+		t.Errorf("Expected Pluck to restore the selected columns, got %v", qb.selectCols)
+	}
+}
+
+func TestQueryBuilder_IterKeyset(t *testing.T) {
+	var requests []url.Values
+	qb := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "dd"}, func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		requests = append(requests, query)
+		body := `[{"id": 1}, {"id": 2}]`
+		switch query.Get("id.gt") {
+		case "2":
+			body = `[{"id": 3}, {"id": 4}]`
+		case "4":
+			body = `[{"id": 5}]`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}).Catalog("cat").Schema("schema").Table("users").OrderBy("id", "ASC").Limit(2)
+
+	var ids []float64
+	for row, err := range qb.Iter(context.Background()) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		ids = append(ids, row["id"].(float64))
+	}
+
+	if len(ids) != 5 || ids[4] != 5 {
+		t.Errorf("Unexpected rows: %v", ids)
+	}
+	if len(requests) != 3 {
+		t.Fatalf("Expected 3 requests, got %d", len(requests))
+	}
+	for _, query := range requests {
+		if query.Has("__offset") {
+			t.Errorf("Expected keyset pagination without offsets, got %v", query)
+		}
+	}
+	if qb.cursor != "" {
+		t.Errorf("Expected Iter to restore the cursor, got %q", qb.cursor)
+	}
+}
+
+func TestQueryBuilder_IterOffsetFallback(t *testing.T) {
+	var offsets []string
+	qb := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "dd"}, func(req *http.Request) (*http.Response, error) {
+		offset := req.URL.Query().Get("__offset")
+		offsets = append(offsets, offset)
+		body := `[{"id": 1}, {"id": 2}]`
+		if offset == "2" {
+			body = `[{"id": 3}]`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}).Catalog("cat").Schema("schema").Table("users").Limit(2)
+
+	count := 0
+	for _, err := range qb.Iter(context.Background()) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		count++
+	}
+	if count != 3 || len(offsets) != 2 || offsets[1] != "2" {
+		t.Errorf("Unexpected pagination: %d rows, offsets %v", count, offsets)
+	}
+}
+
+func TestQueryBuilder_AfterCursorMismatch(t *testing.T) {
+	cursor := encodeKeysetCursor(keysetCursor{Column: "id", Direction: "ASC", Value: 10})
+
+	_, err := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "dd"}, nil).
+		Catalog("cat").Schema("schema").Table("users").
+		OrderBy("name", "ASC").
+		After(cursor).
+		Get(context.Background())
+
+	if !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for a cursor issued for another ordering, got %v", err)
+	}
+}
+
+func TestQueryBuilder_ValidateAgainstSchema(t *testing.T) {
+	var methods []string
+	qb := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "dd"}, func(req *http.Request) (*http.Response, error) {
+		methods = append(methods, req.Method+" "+req.URL.Path)
+		body := `{}`
+		if strings.HasSuffix(req.URL.Path, "/columns") {
+			body = `{"columns": [
+				{"name": "id", "data_type": "bigint"},
+				{"name": "name", "data_type": "varchar(255)"},
+				{"name": "price", "data_type": "decimal(10,2)"},
+				{"name": "active", "data_type": "boolean"},
+				{"name": "attrs", "data_type": "jsonb"}
+			]}`
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}).Catalog("cat").Schema("schema").Table("products").ValidateAgainstSchema(true)
+
+	_, err := qb.Post(context.Background(), []map[string]any{
+		{"id": 1, "name": "ok", "price": "9.99", "active": true, "attrs": map[string]any{"a": 1}},
+		{"id": 1.5, "name": 42, "colour": "red", "active": nil},
+	})
+	var schemaErr *builders.SchemaValidationError
+	if !errors.As(err, &schemaErr) || !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Fatalf("Expected a SchemaValidationError, got %v", err)
+	}
+	if len(schemaErr.Errors) != 3 {
+		t.Fatalf("Expected 3 field errors, got %v", schemaErr.Errors)
+	}
+	expected := []string{
+		"row 1: colour: unknown column",
+		"row 1: id: expected an integer for type bigint, got number",
+		"row 1: name: expected a string for type varchar(255), got number",
+	}
+	for i, fieldErr := range schemaErr.Errors {
+		if fieldErr.String() != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], fieldErr.String())
+		}
+	}
+	if len(methods) != 1 {
+		t.Errorf("Expected only the columns request, got %v", methods)
+	}
+
+	methods = nil
+	type product struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	if _, err := qb.Put(context.Background(), product{ID: 1, Name: "renamed"}); err != nil {
+		t.Fatalf("Expected valid payload to be sent, got %v", err)
+	}
+	if len(methods) != 2 || !strings.HasPrefix(methods[1], "PUT ") {
+		t.Errorf("Expected columns request then PUT, got %v", methods)
+	}
+}
+
+// bodyRecorder is a client recording the body of the last request.
+type bodyRecorder struct {
+	config utils.Configuration
+	body   []byte
+}
+
+func (b *bodyRecorder) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	b.body = body
+	return &utils.Response{Status: utils.StatusOK}, nil
+}
+
+func (b *bodyRecorder) GetConfig() utils.Configuration {
+	return b.config
+}
+
+func TestQueryBuilder_PostStructTags(t *testing.T) {
+	type audit struct {
+		CreatedBy string `hyperfluid:"created_by"`
+	}
+	type order struct {
+		audit
+		ID        int64      `hyperfluid:"order_id"`
+		Note      *string    `hyperfluid:"note"`
+		Discount  float64    `hyperfluid:"discount,omitempty"`
+		ShippedOn time.Time  `hyperfluid:"shipped_on,date"`
+		PaidAt    *time.Time `hyperfluid:"paid_at,omitempty"`
+		Status    string     `json:"status"`
+		Internal  string     `hyperfluid:"-"`
+		Total     float64
+	}
+
+	client := &bodyRecorder{config: utils.Configuration{DataDockID: "dd"}}
+	qb := NewQueryBuilder(client).Catalog("cat").Schema("schema").Table("orders")
+	shipped := time.Date(2024, 3, 1, 15, 4, 5, 0, time.UTC)
+
+	_, err := qb.Post(context.Background(), []*order{
+		{audit: audit{CreatedBy: "alice"}, ID: 1, ShippedOn: shipped, Status: "paid", Internal: "x", Total: 10},
+		{ID: 2, Discount: 0.5, PaidAt: &shipped},
+	})
+	if err != nil {
+		t.Fatalf("Post() unexpected error = %v", err)
+	}
+
+	var rows []map[string]any
+	if err := json.Unmarshal(client.body, &rows); err != nil {
+		t.Fatalf("Invalid body %s: %v", client.body, err)
+	}
+	expected := []map[string]any{
+		{"created_by": "alice", "order_id": float64(1), "note": nil, "shipped_on": "2024-03-01", "status": "paid", "Total": float64(10)},
+		{"created_by": "", "order_id": float64(2), "note": nil, "discount": 0.5, "shipped_on": "0001-01-01", "paid_at": "2024-03-01T15:04:05Z", "status": "", "Total": float64(0)},
+	}
+	for i := range expected {
+		if len(rows[i]) != len(expected[i]) {
+			t.Errorf("Row %d: expected %v, got %v", i, expected[i], rows[i])
+		}
+		for key, value := range expected[i] {
+			if rows[i][key] != value {
+				t.Errorf("Row %d: expected %s=%v, got %v", i, key, value, rows[i][key])
+			}
+		}
+	}
+}
+
+func TestQueryBuilder_PostEncoder(t *testing.T) {
+	type event struct {
+		At time.Time `hyperfluid:"at"`
+	}
+	at := time.Date(2024, 3, 1, 15, 4, 5, 0, time.UTC)
+
+	client := &bodyRecorder{config: utils.Configuration{
+		DataDockID: "dd",
+		Encoder:    utils.JSONEncoder{TimeFormat: time.DateTime},
+	}}
+	qb := NewQueryBuilder(client).Catalog("cat").Schema("schema").Table("events")
+	if _, err := qb.Post(context.Background(), event{At: at}); err != nil {
+		t.Fatalf("Post() unexpected error = %v", err)
+	}
+	if string(client.body) != `{"at":"2024-03-01 15:04:05"}` {
+		t.Errorf("Expected the custom time format, got %s", client.body)
+	}
+
+	client.body = nil
+	_, err := qb.Put(context.Background(), map[string]any{"callback": func() {}})
+	if !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for an unencodable body, got %v", err)
+	}
+	if client.body != nil {
+		t.Errorf("Expected no request to be sent, got %s", client.body)
+	}
+
+	failing := errors.New("encoder failure")
+	client.config.Encoder = utils.EncoderFunc(func(any) ([]byte, error) { return nil, failing })
+	if _, err := qb.Post(context.Background(), map[string]any{"id": 1}); !errors.Is(err, failing) {
+		t.Errorf("Expected the encoder error, got %v", err)
+	}
+}
+
+func TestQueryBuilder_Branching(t *testing.T) {
+	base := newTestQueryBuilder(utils.Configuration{Token: "test-token", DataDockID: "dd"}, nil).
+		Catalog("sales").
+		Schema("public").
+		Where("region", "=", "eu")
+
+	orders := base.Table("orders").Where("total", ">", 100)
+	users := base.Table("users").Limit(5)
+
+	if base.tableName != "" || len(base.filters) != 1 || base.limitVal != 0 {
+		t.Errorf("Expected the base builder to be untouched, got table=%q filters=%d limit=%d", base.tableName, len(base.filters), base.limitVal)
+	}
+	if orders.tableName != "orders" || len(orders.filters) != 2 || orders.limitVal != 0 {
+		t.Errorf("Unexpected orders builder: table=%q filters=%d limit=%d", orders.tableName, len(orders.filters), orders.limitVal)
+	}
+	if users.tableName != "users" || len(users.filters) != 1 || users.limitVal != 5 {
+		t.Errorf("Unexpected users builder: table=%q filters=%d limit=%d", users.tableName, len(users.filters), users.limitVal)
+	}
+}
+
+func BenchmarkQueryBuilder_Chain(b *testing.B) {
+	client := &mockClient{config: utils.Configuration{BaseURL: "https://test.example.com", DataDockID: "dd"}}
+	b.ReportAllocs()
+	for b.Loop() {
+		NewQueryBuilder(client).
+			Catalog("sales").
+			Schema("public").
+			Table("orders").
+			Select("id", "customer", "total").
+			Where("status", "=", "completed").
+			Where("total", ">", 1000).
+			OrderBy("created_at", "DESC").
+			Limit(100).
+			buildParams()
+	}
+}
+
 // Test helper to create a mock QueryBuilder
 type mockClient struct {
 	config  utils.Configuration
@@ -560,7 +948,7 @@ func (m *mockClient) Do(ctx context.Context, method, endpoint string, body []byt
 	// Parse successful response
 	var parsedBody any
 	if len(bodyBytes) > 0 {
-		if err := json.Unmarshal(bodyBytes, &parsedBody); err != nil {
+		if parsedBody, err = utils.DecodeJSON(bodyBytes, m.config.UseNumber); err != nil {
 			return nil, err
 		}
 	}
@@ -583,3 +971,48 @@ func newTestQueryBuilder(config utils.Configuration, handler func(*http.Request)
 		handler: handler,
 	})
 }
+
+func TestQueryBuilder_QueryURL(t *testing.T) {
+	base := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, nil).
+		Catalog("sales").Schema("my schema").Table("orders")
+	queries := []*QueryBuilder{
+		base,
+		base.Select("id", "name").
+			Where("name", "=", "a&b c").
+			Where("id", ">", 10).
+			Where("id", "<", 100).
+			OrderBy("id", "DESC").
+			Limit(5).
+			RawParams(url.Values{"z": {"1", "2"}, "a+b": {"?"}}),
+	}
+	for _, qb := range queries {
+		want := qb.buildEndpoint()
+		if params := qb.buildParams(); len(params) > 0 {
+			want += "?" + params.Encode()
+		}
+		// Twice, to cover the recycled buffers
+		for range 2 {
+			if got := qb.queryURL(); got != want {
+				t.Errorf("queryURL() = %q, want %q", got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkQueryBuilder_BuildParams(b *testing.B) {
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, nil).
+		Catalog("sales").Schema("public").Table("orders").
+		Select("id", "customer", "status", "total", "created_at").
+		Where("status", "=", "shipped").
+		Where("total", ">=", 100.5).
+		Where("created_at", ">", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)).
+		Where("customer", "LIKE", "acme%").
+		OrderBy("created_at", "DESC").
+		OrderBy("id", "ASC").
+		Limit(100).
+		Offset(200)
+	b.ReportAllocs()
+	for b.Loop() {
+		_ = qb.buildParams().Encode()
+	}
+}
@@ -0,0 +1,232 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// LifecycleRule expires the objects of a bucket. Zero durations are not set.
+type LifecycleRule struct {
+	ID     string // Rule identifier (required)
+	Prefix string // Objects the rule applies to (default: all)
+
+	ExpirationDays            int32 // Delete objects this many days after creation
+	NoncurrentExpirationDays  int32 // Delete noncurrent versions this many days after they become noncurrent
+	AbortIncompleteUploadDays int32 // Abort multipart uploads left incomplete for this many days
+
+	Disabled bool
+}
+
+// BucketInfo describes a bucket.
+type BucketInfo struct {
+	Name       string
+	Region     string          // Location constraint; empty for the default region
+	Versioning string          // "Enabled", "Suspended" or empty when never enabled
+	Lifecycle  []LifecycleRule // Empty when the bucket has no lifecycle configuration
+}
+
+// BucketExists reports whether the bucket exists and is accessible.
+// A missing bucket is reported as (false, nil); any other failure is returned as an error.
+func (s *S3Builder) BucketExists(ctx context.Context) (bool, error) {
+	if err := s.validateList(ctx); err != nil {
+		return false, err
+	}
+
+	_, err := s.s3Client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(s.bucket)})
+	if isS3Code(err, "NotFound", "NoSuchBucket") {
+		return false, nil
+	}
+	if err != nil {
+		return false, s3Error(err, "failed to check bucket %s", s.bucket)
+	}
+	return true, nil
+}
+
+// CreateBucket creates the bucket in the configured MinIO region. Creating a
+// bucket the caller already owns succeeds, with "created" set to false in the
+// response data, so that provisioning scripts can be re-run.
+func (s *S3Builder) CreateBucket(ctx context.Context) (*utils.Response, error) {
+	if err := s.validateList(ctx); err != nil {
+		return nil, err
+	}
+
+	input := &s3.CreateBucketInput{Bucket: aws.String(s.bucket)}
+	if region := getEnvOrConfig(s.client.GetConfig(), "MINIO_REGION", ""); region != "" && region != "us-east-1" {
+		input.CreateBucketConfiguration = &types.CreateBucketConfiguration{
+			LocationConstraint: types.BucketLocationConstraint(region),
+		}
+	}
+
+	created := true
+	_, err := s.s3Client.CreateBucket(ctx, input)
+	if isS3Code(err, "BucketAlreadyOwnedByYou") {
+		created, err = false, nil
+	}
+	if err != nil {
+		err = s3Error(err, "failed to create bucket %s", s.bucket)
+		return &utils.Response{
+			Status:   utils.StatusError,
+			Error:    err.Error(),
+			HTTPCode: http.StatusInternalServerError,
+		}, err
+	}
+
+	return &utils.Response{
+		Status: utils.StatusOK,
+		Data: map[string]interface{}{
+			"bucket":  s.bucket,
+			"created": created,
+		},
+		HTTPCode: http.StatusOK,
+	}, nil
+}
+
+// SetLifecycle replaces the lifecycle configuration of the bucket with rules.
+// An empty rule list removes the lifecycle configuration.
+func (s *S3Builder) SetLifecycle(ctx context.Context, rules []LifecycleRule) (*utils.Response, error) {
+	if err := s.validateList(ctx); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if len(rules) == 0 {
+		_, err = s.s3Client.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{Bucket: aws.String(s.bucket)})
+	} else {
+		lifecycle := make([]types.LifecycleRule, 0, len(rules))
+		for _, rule := range rules {
+			converted, convErr := rule.toS3()
+			if convErr != nil {
+				return nil, convErr
+			}
+			lifecycle = append(lifecycle, converted)
+		}
+		_, err = s.s3Client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+			Bucket:                 aws.String(s.bucket),
+			LifecycleConfiguration: &types.BucketLifecycleConfiguration{Rules: lifecycle},
+		})
+	}
+	if err != nil {
+		err = s3Error(err, "failed to set the lifecycle of bucket %s", s.bucket)
+		return &utils.Response{
+			Status:   utils.StatusError,
+			Error:    err.Error(),
+			HTTPCode: http.StatusInternalServerError,
+		}, err
+	}
+
+	return &utils.Response{
+		Status: utils.StatusOK,
+		Data: map[string]interface{}{
+			"bucket": s.bucket,
+			"rules":  len(rules),
+		},
+		HTTPCode: http.StatusOK,
+	}, nil
+}
+
+// GetBucketInfo retrieves the region, versioning status and lifecycle rules of the bucket.
+func (s *S3Builder) GetBucketInfo(ctx context.Context) (*BucketInfo, error) {
+	if err := s.validateList(ctx); err != nil {
+		return nil, err
+	}
+	bucket := aws.String(s.bucket)
+
+	location, err := s.s3Client.GetBucketLocation(ctx, &s3.GetBucketLocationInput{Bucket: bucket})
+	if err != nil {
+		return nil, s3Error(err, "failed to get the location of bucket %s", s.bucket)
+	}
+	versioning, err := s.s3Client.GetBucketVersioning(ctx, &s3.GetBucketVersioningInput{Bucket: bucket})
+	if err != nil {
+		return nil, s3Error(err, "failed to get the versioning of bucket %s", s.bucket)
+	}
+	info := &BucketInfo{
+		Name:       s.bucket,
+		Region:     string(location.LocationConstraint),
+		Versioning: string(versioning.Status),
+	}
+
+	lifecycle, err := s.s3Client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: bucket})
+	if isS3Code(err, "NoSuchLifecycleConfiguration") {
+		return info, nil
+	}
+	if err != nil {
+		return nil, s3Error(err, "failed to get the lifecycle of bucket %s", s.bucket)
+	}
+	for _, rule := range lifecycle.Rules {
+		info.Lifecycle = append(info.Lifecycle, lifecycleRuleFromS3(rule))
+	}
+	return info, nil
+}
+
+// toS3 converts the rule into its S3 representation.
+func (r LifecycleRule) toS3() (types.LifecycleRule, error) {
+	if r.ID == "" {
+		return types.LifecycleRule{}, fmt.Errorf("%w: lifecycle rule ID required", utils.ErrInvalidRequest)
+	}
+	if r.ExpirationDays <= 0 && r.NoncurrentExpirationDays <= 0 && r.AbortIncompleteUploadDays <= 0 {
+		return types.LifecycleRule{}, fmt.Errorf("%w: lifecycle rule %s has no action", utils.ErrInvalidRequest, r.ID)
+	}
+
+	rule := types.LifecycleRule{
+		ID:     aws.String(r.ID),
+		Status: types.ExpirationStatusEnabled,
+		Filter: &types.LifecycleRuleFilter{Prefix: aws.String(r.Prefix)},
+	}
+	if r.Disabled {
+		rule.Status = types.ExpirationStatusDisabled
+	}
+	if r.ExpirationDays > 0 {
+		rule.Expiration = &types.LifecycleExpiration{Days: aws.Int32(r.ExpirationDays)}
+	}
+	if r.NoncurrentExpirationDays > 0 {
+		rule.NoncurrentVersionExpiration = &types.NoncurrentVersionExpiration{NoncurrentDays: aws.Int32(r.NoncurrentExpirationDays)}
+	}
+	if r.AbortIncompleteUploadDays > 0 {
+		rule.AbortIncompleteMultipartUpload = &types.AbortIncompleteMultipartUpload{DaysAfterInitiation: aws.Int32(r.AbortIncompleteUploadDays)}
+	}
+	return rule, nil
+}
+
+// lifecycleRuleFromS3 converts an S3 lifecycle rule. Actions without an SDK
+// equivalent (transitions, tag filters) are dropped.
+func lifecycleRuleFromS3(rule types.LifecycleRule) LifecycleRule {
+	converted := LifecycleRule{
+		ID:       aws.ToString(rule.ID),
+		Disabled: rule.Status != types.ExpirationStatusEnabled,
+	}
+	if rule.Filter != nil {
+		converted.Prefix = aws.ToString(rule.Filter.Prefix)
+	}
+	if rule.Expiration != nil {
+		converted.ExpirationDays = aws.ToInt32(rule.Expiration.Days)
+	}
+	if rule.NoncurrentVersionExpiration != nil {
+		converted.NoncurrentExpirationDays = aws.ToInt32(rule.NoncurrentVersionExpiration.NoncurrentDays)
+	}
+	if rule.AbortIncompleteMultipartUpload != nil {
+		converted.AbortIncompleteUploadDays = aws.ToInt32(rule.AbortIncompleteMultipartUpload.DaysAfterInitiation)
+	}
+	return converted
+}
+
+// isS3Code reports whether err is an S3 API error with one of the given codes.
+func isS3Code(err error, codes ...string) bool {
+	var apiErr smithy.APIError
+	if err == nil || !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.ErrorCode() == code {
+			return true
+		}
+	}
+	return false
+}
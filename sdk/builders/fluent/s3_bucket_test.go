@@ -0,0 +1,131 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// fakeBuckets serves the bucket administration requests of a path-style S3 endpoint.
+type fakeBuckets struct {
+	mu        sync.Mutex
+	buckets   map[string]string // Bucket name to lifecycle configuration XML
+	locations []string
+}
+
+func (f *fakeBuckets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	bucket := strings.Trim(r.URL.Path, "/")
+	query := r.URL.Query()
+	lifecycle, exists := f.buckets[bucket]
+
+	s3Fail := func(status int, code string) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, "<Error><Code>"+code+"</Code><Message>"+code+"</Message></Error>")
+	}
+	switch {
+	case r.Method == "PUT" && query.Has("lifecycle"):
+		f.buckets[bucket] = string(body)
+	case r.Method == "DELETE" && query.Has("lifecycle"):
+		f.buckets[bucket] = ""
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == "PUT":
+		if exists {
+			s3Fail(http.StatusConflict, "BucketAlreadyOwnedByYou")
+			return
+		}
+		f.buckets[bucket] = ""
+		f.locations = append(f.locations, string(body))
+	case !exists:
+		if r.Method == "HEAD" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s3Fail(http.StatusNotFound, "NoSuchBucket")
+	case r.Method == "HEAD":
+	case query.Has("location"):
+		_, _ = io.WriteString(w, `<LocationConstraint>eu-west-3</LocationConstraint>`)
+	case query.Has("versioning"):
+		_, _ = io.WriteString(w, `<VersioningConfiguration><Status>Enabled</Status></VersioningConfiguration>`)
+	case query.Has("lifecycle"):
+		if lifecycle == "" {
+			s3Fail(http.StatusNotFound, "NoSuchLifecycleConfiguration")
+			return
+		}
+		_, _ = io.WriteString(w, lifecycle)
+	default:
+		s3Fail(http.StatusBadRequest, "InvalidRequest")
+	}
+}
+
+func TestS3Builder_BucketAdministration(t *testing.T) {
+	setupFakeS3Env(t)
+	fake := &fakeBuckets{buckets: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	client := &mockClient{config: utils.Configuration{
+		MinIOEndpoint:  server.URL,
+		MinIORegion:    "eu-west-3",
+		MinIOAccessKey: "access",
+		MinIOSecretKey: "secret",
+	}}
+	bucket := func(name string) *S3Builder {
+		builder, err := NewS3Builder(client)
+		if err != nil {
+			t.Fatalf("NewS3Builder() error = %v", err)
+		}
+		return builder.Bucket(name)
+	}
+	ctx := context.Background()
+
+	if exists, err := bucket("raw").BucketExists(ctx); err != nil || exists {
+		t.Fatalf("BucketExists() = %v, %v before creation", exists, err)
+	}
+	if _, err := bucket("raw").GetBucketInfo(ctx); !errors.Is(err, utils.ErrBucketNotFound) {
+		t.Errorf("GetBucketInfo() error = %v, want ErrBucketNotFound", err)
+	}
+
+	resp, err := bucket("raw").CreateBucket(ctx)
+	if err != nil || resp.Data.(map[string]interface{})["created"] != true {
+		t.Fatalf("CreateBucket() = %v, %v", resp, err)
+	}
+	if len(fake.locations) != 1 || !strings.Contains(fake.locations[0], "eu-west-3") {
+		t.Errorf("Expected the bucket to be created in the configured region, got %v", fake.locations)
+	}
+	resp, err = bucket("raw").CreateBucket(ctx)
+	if err != nil || resp.Data.(map[string]interface{})["created"] != false {
+		t.Errorf("CreateBucket() on an existing bucket = %v, %v", resp, err)
+	}
+	if exists, err := bucket("raw").BucketExists(ctx); err != nil || !exists {
+		t.Errorf("BucketExists() = %v, %v after creation", exists, err)
+	}
+
+	info, err := bucket("raw").GetBucketInfo(ctx)
+	if err != nil || info.Region != "eu-west-3" || info.Versioning != "Enabled" || len(info.Lifecycle) != 0 {
+		t.Fatalf("GetBucketInfo() = %+v, %v", info, err)
+	}
+
+	rules := []LifecycleRule{{ID: "tmp", Prefix: "tmp/", ExpirationDays: 7, AbortIncompleteUploadDays: 1}}
+	if _, err := bucket("raw").SetLifecycle(ctx, rules); err != nil {
+		t.Fatalf("SetLifecycle() error = %v", err)
+	}
+	info, err = bucket("raw").GetBucketInfo(ctx)
+	if err != nil || len(info.Lifecycle) != 1 || info.Lifecycle[0] != rules[0] {
+		t.Errorf("GetBucketInfo() lifecycle = %+v, %v, want %+v", info, err, rules)
+	}
+
+	if _, err := bucket("raw").SetLifecycle(ctx, []LifecycleRule{{ID: "noop"}}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("SetLifecycle() without action error = %v, want ErrInvalidRequest", err)
+	}
+}
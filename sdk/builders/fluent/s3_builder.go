@@ -2,18 +2,22 @@ package fluent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
 )
 
@@ -36,6 +40,13 @@ type S3Builder struct {
 
 	stsMethod   string // "oidc" or ""
 	oidcEnabled bool
+
+	// Upload and download options, see s3_options.go
+	sse               types.ServerSideEncryption
+	sseCustomerKey    string // Base64 encoded SSE-C key
+	sseCustomerKeyMD5 string
+	checksum          S3Checksum
+	storageClass      types.StorageClass
 }
 
 // NewS3Builder creates a new S3Builder instance configured for MinIO
@@ -81,10 +92,16 @@ func newS3BuilderWithStaticCreds(client interface {
 		return nil, fmt.Errorf("MINIO_SECRET_KEY is required")
 	}
 
+	httpClient, err := s3HTTPClient(client)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx := context.Background()
 	awsCfg, err := config.LoadDefaultConfig(ctx,
 		config.WithRegion(cfg.MinIORegion),
 		config.WithBaseEndpoint(cfg.MinIOEndpoint),
+		config.WithHTTPClient(httpClient),
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
 			cfg.MinIOAccessKey,
 			cfg.MinIOSecretKey,
@@ -114,9 +131,15 @@ func newS3BuilderWithOIDC(client interface {
 	cfg := client.GetConfig()
 	ctx := context.Background()
 
+	httpClient, err := s3HTTPClient(client)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create base config with anonymous credentials for STS
 	awsCfg, err := config.LoadDefaultConfig(ctx,
 		config.WithRegion(cfg.MinIORegion),
+		config.WithHTTPClient(httpClient),
 		config.WithCredentialsProvider(aws.AnonymousCredentials{}),
 	)
 	if err != nil {
@@ -151,6 +174,27 @@ func newS3BuilderWithOIDC(client interface {
 	}, nil
 }
 
+// s3TransportWrapper is implemented by clients observing the S3 traffic, such as
+// sdk.Client which counts the bytes transferred.
+type s3TransportWrapper interface {
+	S3Transport(base http.RoundTripper) http.RoundTripper
+}
+
+// s3HTTPClient returns an HTTP client sharing the SDK transport (TLS, pooling) for S3 and STS calls.
+// No overall timeout is set so that large objects can be streamed.
+func s3HTTPClient(client interface {
+	GetConfig() utils.Configuration
+}) (*http.Client, error) {
+	transport, err := utils.ClientTransport(client.GetConfig())
+	if err != nil {
+		return nil, err
+	}
+	if wrapper, ok := client.(s3TransportWrapper); ok {
+		return &http.Client{Transport: wrapper.S3Transport(transport)}, nil
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
 // isHTTPS checks if endpoint uses HTTPS
 func isHTTPS(endpoint string) (bool, error) {
 	URL, err := url.Parse(endpoint)
@@ -162,6 +206,7 @@ func isHTTPS(endpoint string) (bool, error) {
 
 // OIDC sets OIDC JWT token for AssumeRoleWithWebIdentity
 func (s *S3Builder) OIDC(idToken string) *S3Builder {
+	s = s.clone()
 	if !s.oidcEnabled {
 		s.errors = append(
 			s.errors,
@@ -181,6 +226,7 @@ func (s *S3Builder) OIDC(idToken string) *S3Builder {
 
 // RoleArn sets the role ARN for AssumeRoleWithWebIdentity
 func (s *S3Builder) RoleArn(roleArn string) *S3Builder {
+	s = s.clone()
 	if roleArn == "" {
 		s.errors = append(s.errors, fmt.Errorf("role ARN cannot be empty"))
 	}
@@ -190,6 +236,7 @@ func (s *S3Builder) RoleArn(roleArn string) *S3Builder {
 
 // SessionName sets the session name
 func (s *S3Builder) SessionName(sessionName string) *S3Builder {
+	s = s.clone()
 	s.sessionName = sessionName
 	return s
 }
@@ -227,11 +274,14 @@ func (s *S3Builder) assumeRoleWithWebIdentity(ctx context.Context) error {
 	// Call STS
 	output, err := s.stsClient.AssumeRoleWithWebIdentity(ctx, input)
 	if err != nil {
-		return fmt.Errorf("AssumeRoleWithWebIdentity failed: %w", err)
+		if s3ErrorClass(err) == utils.ErrTokenExpired {
+			return fmt.Errorf("%w: %w: AssumeRoleWithWebIdentity failed: %w", utils.ErrSTSFailed, utils.ErrTokenExpired, err)
+		}
+		return fmt.Errorf("%w: AssumeRoleWithWebIdentity failed: %w", utils.ErrSTSFailed, err)
 	}
 
 	if output.Credentials == nil {
-		return fmt.Errorf("STS returned no credentials")
+		return fmt.Errorf("%w: STS returned no credentials", utils.ErrSTSFailed)
 	}
 
 	// Extract temporary credentials
@@ -249,11 +299,17 @@ func (s *S3Builder) assumeRoleWithWebIdentity(ctx context.Context) error {
 
 	// Get MinIO config
 	cfg := s.client.GetConfig()
+	httpClient, err := s3HTTPClient(s.client)
+	if err != nil {
+		return err
+	}
+
 	// Recreate AWS config with new credentials
 	ctx2 := context.Background()
 	awsCfg, err := config.LoadDefaultConfig(ctx2,
 		config.WithRegion(cfg.MinIORegion),
 		config.WithBaseEndpoint(cfg.MinIOEndpoint),
+		config.WithHTTPClient(httpClient),
 		config.WithCredentialsProvider(staticCreds),
 	)
 	if err != nil {
@@ -274,6 +330,39 @@ func (s *S3Builder) assumeRoleWithWebIdentity(ctx context.Context) error {
 	return nil
 }
 
+// s3Error describes a failed S3 or STS call, wrapping the SDK error matching its
+// AWS error code (utils.ErrObjectNotFound, utils.ErrBucketNotFound...) when there is one.
+func s3Error(err error, format string, args ...any) error {
+	message := fmt.Sprintf(format, args...)
+	if class := s3ErrorClass(err); class != nil {
+		return fmt.Errorf("%w: %s: %w", class, message, err)
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+// s3ErrorClass maps the AWS error code of err to an SDK error, or nil.
+func s3ErrorClass(err error) error {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+	switch apiErr.ErrorCode() {
+	case "NoSuchKey", "NotFound": // NotFound: HEAD requests carry no error body
+		return utils.ErrObjectNotFound
+	case "NoSuchBucket":
+		return utils.ErrBucketNotFound
+	case "ExpiredToken", "ExpiredTokenException", "TokenRefreshRequired":
+		return utils.ErrTokenExpired
+	case "AccessDenied", "InvalidAccessKeyId", "SignatureDoesNotMatch":
+		return utils.ErrPermissionDenied
+	}
+	// MinIO reports expired web identity tokens as invalid parameters
+	if strings.Contains(strings.ToLower(apiErr.ErrorMessage()), "expired") {
+		return utils.ErrTokenExpired
+	}
+	return nil
+}
+
 // Helper function to get config from environment or Configuration struct
 func getEnvOrConfig(cfg utils.Configuration, key, fallback string) string {
 	if value := os.Getenv(key); value != "" {
@@ -308,6 +397,7 @@ func getEnvOrConfig(cfg utils.Configuration, key, fallback string) string {
 
 // Bucket sets the S3 bucket name
 func (s *S3Builder) Bucket(bucket string) *S3Builder {
+	s = s.clone()
 	if bucket == "" {
 		s.errors = append(s.errors, fmt.Errorf("bucket name cannot be empty"))
 	}
@@ -317,6 +407,7 @@ func (s *S3Builder) Bucket(bucket string) *S3Builder {
 
 // Key sets the S3 object key (file path)
 func (s *S3Builder) Key(key string) *S3Builder {
+	s = s.clone()
 	if key == "" {
 		s.errors = append(s.errors, fmt.Errorf("object key cannot be empty"))
 	}
@@ -324,6 +415,14 @@ func (s *S3Builder) Key(key string) *S3Builder {
 	return s
 }
 
+// clone returns a copy of the builder that can be modified independently, so that
+// a partially configured builder can be reused as a template.
+func (s *S3Builder) clone() *S3Builder {
+	next := *s
+	next.errors = append([]error(nil), s.errors...)
+	return &next
+}
+
 // validate checks that all required fields are set and runs STS if needed
 func (s *S3Builder) validate(ctx context.Context) error {
 	if len(s.errors) > 0 {
@@ -355,18 +454,22 @@ type S3Object struct {
 	Body         io.ReadCloser // stream the content
 }
 
-// Get retrieves the object from MinIO and returns a stream
+// Get retrieves the object from MinIO and returns a stream. The SSE-C key must be
+// set for objects uploaded with SSEC; with Checksum, the stored checksums are verified.
 func (s *S3Builder) Get(ctx context.Context) (*S3Object, error) {
 	if err := s.validate(ctx); err != nil {
 		return nil, err
 	}
 
-	result, err := s.s3Client.GetObject(ctx, &s3.GetObjectInput{
+	input := &s3.GetObjectInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(s.key),
-	})
+	}
+	s.applyGetOptions(input)
+
+	result, err := s.s3Client.GetObject(ctx, input)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get object from MinIO: %w", err)
+		return nil, s3Error(err, "failed to get object from MinIO")
 	}
 
 	// Return a struct with Body as io.ReadCloser for streaming
@@ -414,9 +517,10 @@ func (s *S3Builder) List(ctx context.Context, prefix string) (*utils.Response, e
 
 	result, err := s.s3Client.ListObjectsV2(ctx, input)
 	if err != nil {
+		err = s3Error(err, "failed to list objects from MinIO")
 		return &utils.Response{
 			Status:   utils.StatusError,
-			Error:    fmt.Sprintf("failed to list objects from MinIO: %v", err),
+			Error:    err.Error(),
 			HTTPCode: http.StatusInternalServerError,
 		}, err
 	}
@@ -446,3 +550,45 @@ func (s *S3Builder) List(ctx context.Context, prefix string) (*utils.Response, e
 		HTTPCode: http.StatusOK,
 	}, nil
 }
+
+// Put uploads the content of body to the object. The content type is optional.
+// Encryption, checksum and storage class options (SSES3, SSEC, Checksum,
+// StorageClass) apply to the upload.
+func (s *S3Builder) Put(ctx context.Context, body io.Reader, contentType string) (*utils.Response, error) {
+	if err := s.validate(ctx); err != nil {
+		return nil, err
+	}
+
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key),
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	body, err := s.applyPutOptions(input, body)
+	if err != nil {
+		return nil, err
+	}
+	input.Body = body
+
+	result, err := s.s3Client.PutObject(ctx, input)
+	if err != nil {
+		err = s3Error(err, "failed to put object to MinIO")
+		return &utils.Response{
+			Status:   utils.StatusError,
+			Error:    err.Error(),
+			HTTPCode: http.StatusInternalServerError,
+		}, err
+	}
+
+	return &utils.Response{
+		Status: utils.StatusOK,
+		Data: map[string]interface{}{
+			"bucket": s.bucket,
+			"key":    s.key,
+			"etag":   aws.ToString(result.ETag),
+		},
+		HTTPCode: http.StatusOK,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/smithy-go"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestS3Builder_ErrorClasses(t *testing.T) {
+	setupFakeS3Env(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		code, status := "NoSuchKey", http.StatusNotFound
+		switch r.URL.Path {
+		case "/missing-bucket/", "/missing-bucket":
+			code = "NoSuchBucket"
+		case "/locked/report.csv":
+			code, status = "AccessDenied", http.StatusForbidden
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, `<Error><Code>%s</Code><Message>%s</Message></Error>`, code, code)
+	}))
+	defer server.Close()
+
+	client := &mockClient{config: utils.Configuration{
+		MinIOEndpoint:  server.URL,
+		MinIORegion:    "us-east-1",
+		MinIOAccessKey: "access",
+		MinIOSecretKey: "secret",
+	}}
+	newBuilder := func() *S3Builder {
+		builder, err := NewS3Builder(client)
+		if err != nil {
+			t.Fatalf("NewS3Builder() error = %v", err)
+		}
+		return builder
+	}
+	ctx := context.Background()
+
+	_, err := newBuilder().Bucket("data").Key("missing.csv").Get(ctx)
+	if !errors.Is(err, utils.ErrObjectNotFound) {
+		t.Errorf("Get() error = %v, want ErrObjectNotFound", err)
+	}
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) || apiErr.ErrorCode() != "NoSuchKey" {
+		t.Errorf("expected the AWS error to stay in the chain, got %v", err)
+	}
+
+	if _, err := newBuilder().Bucket("missing-bucket").List(ctx, ""); !errors.Is(err, utils.ErrBucketNotFound) {
+		t.Errorf("List() error = %v, want ErrBucketNotFound", err)
+	}
+	if _, err := newBuilder().Bucket("locked").Key("report.csv").Get(ctx); !errors.Is(err, utils.ErrPermissionDenied) {
+		t.Errorf("Get() error = %v, want ErrPermissionDenied", err)
+	}
+}
+
+func TestS3Builder_ChainCopiesBuilder(t *testing.T) {
+	setupFakeS3Env(t)
+	client := &mockClient{config: utils.Configuration{
+		MinIOEndpoint:  "http://localhost:9000",
+		MinIORegion:    "us-east-1",
+		MinIOAccessKey: "access",
+		MinIOSecretKey: "secret",
+	}}
+	template, err := NewS3Builder(client)
+	if err != nil {
+		t.Fatalf("NewS3Builder() error = %v", err)
+	}
+	template = template.Bucket("data").SSES3()
+
+	first := template.Key("a.csv").Checksum(ChecksumSHA256)
+	second := template.Key("b.csv").StorageClass("")
+	if template.key != "" || template.checksum != "" || len(template.errors) != 0 {
+		t.Errorf("the template was modified: key=%q checksum=%q errors=%v", template.key, template.checksum, template.errors)
+	}
+	if first.bucket != "data" || first.key != "a.csv" || first.checksum != ChecksumSHA256 || len(first.errors) != 0 {
+		t.Errorf("unexpected first builder: %+v", first)
+	}
+	if second.key != "b.csv" || second.checksum != "" || len(second.errors) != 1 {
+		t.Errorf("unexpected second builder: %+v", second)
+	}
+}
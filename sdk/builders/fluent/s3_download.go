@@ -0,0 +1,315 @@
+package fluent
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Download defaults.
+const (
+	defaultDownloadPartSize    = 8 << 20
+	defaultDownloadConcurrency = 4
+	defaultDownloadRetries     = 2
+)
+
+// DownloadOptions configures DownloadTo.
+type DownloadOptions struct {
+	Concurrency int   // Parts downloaded at once (default 4)
+	PartSize    int64 // Size of the ranged requests (default 8 MiB)
+	Retries     int   // Retries of a failed part before giving up (default 2)
+	// Resume continues an interrupted download from its state file instead of
+	// starting over. It is ignored when the object changed since, or when the
+	// ".part" file is missing or does not have the size of the object.
+	Resume bool
+}
+
+// DownloadResult describes a completed download.
+type DownloadResult struct {
+	Bucket  string
+	Key     string
+	Path    string
+	Size    int64
+	ETag    string
+	Resumed int64 // Bytes reused from an interrupted download
+	// Verified is true when the content was checked against the ETag. Objects
+	// uploaded in several parts or encrypted have ETags that cannot be recomputed:
+	// only their size is checked.
+	Verified bool
+}
+
+// downloadState is persisted next to the partial file to resume a download.
+type downloadState struct {
+	ETag     string `json:"etag"`
+	Size     int64  `json:"size"`
+	PartSize int64  `json:"part_size"`
+	Done     []bool `json:"done"`
+}
+
+// DownloadTo downloads the object to a local file with concurrent ranged
+// requests. The content is written to "<path>.part" and its progress to
+// "<path>.part.json"; the file is renamed to path once every part is downloaded
+// and checked, and the state file is removed. Parts are requested with the ETag
+// of the object, so a download fails instead of mixing two versions when the
+// object is replaced meanwhile.
+//
+// Example:
+//
+//	result, err := s3.Bucket("models").Key("llama/weights.bin").
+//	    DownloadTo(ctx, "/data/weights.bin", fluent.DownloadOptions{Concurrency: 8, Resume: true})
+func (s *S3Builder) DownloadTo(ctx context.Context, path string, opts DownloadOptions) (*DownloadResult, error) {
+	if err := s.validate(ctx); err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, fmt.Errorf("%w: download path required", utils.ErrInvalidRequest)
+	}
+	if opts.PartSize <= 0 {
+		opts.PartSize = defaultDownloadPartSize
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultDownloadConcurrency
+	}
+	if opts.Retries <= 0 {
+		opts.Retries = defaultDownloadRetries
+	}
+
+	headInput := &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.key),
+	}
+	s.applyHeadOptions(headInput)
+	head, err := s.s3Client.HeadObject(ctx, headInput)
+	if err != nil {
+		return nil, s3Error(err, "failed to get object %s from MinIO", s.key)
+	}
+	size := aws.ToInt64(head.ContentLength)
+	result := &DownloadResult{Bucket: s.bucket, Key: s.key, Path: path, Size: size, ETag: aws.ToString(head.ETag)}
+
+	partPath, statePath := path+".part", path+".part.json"
+	state := &downloadState{ETag: result.ETag, Size: size, PartSize: opts.PartSize}
+	if opts.Resume {
+		// The parts are only trusted when the file they were written to is still there
+		previous, ok := loadDownloadState(statePath)
+		info, err := os.Stat(partPath)
+		if ok && err == nil && info.Size() == size && previous.ETag == state.ETag && previous.Size == size && previous.PartSize == opts.PartSize {
+			state = previous
+		}
+	}
+	parts := int((size + opts.PartSize - 1) / opts.PartSize)
+	if len(state.Done) != parts {
+		state.Done = make([]bool, parts)
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY
+	if !anyDone(state.Done) {
+		flags |= os.O_TRUNC
+	}
+	file, err := os.OpenFile(partPath, flags, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", partPath, err)
+	}
+	defer func() { _ = file.Close() }()
+	if err := file.Truncate(size); err != nil {
+		return nil, fmt.Errorf("failed to allocate %s: %w", partPath, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var firstErr error
+	sem := make(chan struct{}, opts.Concurrency)
+	var wg sync.WaitGroup
+	for part, done := range state.Done {
+		if done {
+			result.Resumed += partLength(part, size, opts.PartSize)
+			continue
+		}
+		wg.Add(1)
+		go func(part int) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			err := s.downloadPart(ctx, file, part, size, opts, result.ETag)
+			if err == nil {
+				// The part must be on disk before the state says it is
+				if err = file.Sync(); err != nil {
+					err = fmt.Errorf("failed to write %s: %w", partPath, err)
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			state.Done[part] = true
+			if err := saveDownloadState(statePath, state); err != nil && firstErr == nil {
+				firstErr = err
+				cancel()
+			}
+		}(part)
+	}
+	wg.Wait()
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	if err := file.Sync(); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", partPath, err)
+	}
+	// The ETag of an encrypted object is not the MD5 of its content
+	etag := result.ETag
+	if head.ServerSideEncryption != "" || head.SSECustomerAlgorithm != nil {
+		etag = ""
+	}
+	if result.Verified, err = verifyDownload(partPath, size, etag); err != nil {
+		_ = os.Remove(statePath) // The parts cannot be trusted: start over next time
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", partPath, err)
+	}
+	if err := os.Rename(partPath, path); err != nil {
+		return nil, fmt.Errorf("failed to move the download to %s: %w", path, err)
+	}
+	_ = os.Remove(statePath)
+	return result, nil
+}
+
+// downloadPart fetches one part into the file, retrying failed attempts.
+func (s *S3Builder) downloadPart(ctx context.Context, file *os.File, part int, size int64, opts DownloadOptions, etag string) error {
+	start := int64(part) * opts.PartSize
+	length := partLength(part, size, opts.PartSize)
+
+	var err error
+	for attempt := 0; attempt <= opts.Retries; attempt++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var output *s3.GetObjectOutput
+		input := &s3.GetObjectInput{
+			Bucket:  aws.String(s.bucket),
+			Key:     aws.String(s.key),
+			Range:   aws.String(fmt.Sprintf("bytes=%d-%d", start, start+length-1)),
+			IfMatch: aws.String(etag),
+		}
+		s.applyGetOptions(input)
+		input.ChecksumMode = "" // Stored checksums cover the whole object, not ranges
+		output, err = s.s3Client.GetObject(ctx, input)
+		if err != nil {
+			if isS3Code(err, "PreconditionFailed") {
+				return fmt.Errorf("object %s changed during the download: %w", s.key, err)
+			}
+			err = s3Error(err, "failed to download part %d of %s", part, s.key)
+			continue
+		}
+		var written int64
+		written, err = io.Copy(io.NewOffsetWriter(file, start), io.LimitReader(output.Body, length))
+		_ = output.Body.Close()
+		if err == nil && written != length {
+			err = fmt.Errorf("part %d of %s: got %d bytes, want %d", part, s.key, written, length)
+		}
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// partLength returns the size of a part; the last one may be shorter.
+func partLength(part int, size, partSize int64) int64 {
+	return min(partSize, size-int64(part)*partSize)
+}
+
+// verifyDownload checks the size of the downloaded file and, for objects uploaded
+// in one part, its MD5 against the ETag. It reports whether the content was checked.
+func verifyDownload(path string, size int64, etag string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = file.Close() }()
+
+	info, err := file.Stat()
+	if err != nil {
+		return false, err
+	}
+	if info.Size() != size {
+		return false, fmt.Errorf("downloaded %d bytes, want %d", info.Size(), size)
+	}
+
+	expected := strings.Trim(etag, `"`)
+	if len(expected) != md5.Size*2 { // Multipart ETags end with "-<parts>"
+		return false, nil
+	}
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return false, err
+	}
+	if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, expected) {
+		return false, fmt.Errorf("checksum mismatch: got MD5 %s, want %s", actual, expected)
+	}
+	return true, nil
+}
+
+func loadDownloadState(path string) (*downloadState, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var state downloadState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, false
+	}
+	return &state, true
+}
+
+// saveDownloadState writes the state atomically, so that an interruption never
+// leaves a truncated state file.
+func saveDownloadState(path string, state *downloadState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to save the download state: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Join(fmt.Errorf("failed to save the download state: %w", err), os.Remove(tmp))
+	}
+	return nil
+}
+
+func anyDone(done []bool) bool {
+	for _, d := range done {
+		if d {
+			return true
+		}
+	}
+	return false
+}
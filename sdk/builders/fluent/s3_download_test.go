@@ -0,0 +1,127 @@
+package fluent
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestS3Builder_DownloadTo(t *testing.T) {
+	setupFakeS3Env(t)
+	content := strings.Repeat("0123456789", 10) // 100 bytes, 10 parts of 10 bytes
+	sum := md5.Sum([]byte(content))
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+
+	var mu sync.Mutex
+	ranges := map[string]int{}
+	failing := "bytes=50-59"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", etag)
+		if r.Method == "HEAD" {
+			w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+			return
+		}
+		if r.Header.Get("If-Match") != etag {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		rng := r.Header.Get("Range")
+		mu.Lock()
+		ranges[rng]++
+		fail := rng == failing
+		mu.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `<Error><Code>BadDigest</Code><Message>corrupted</Message></Error>`)
+			return
+		}
+		var start, end int
+		_, _ = fmt.Sscanf(rng, "bytes=%d-%d", &start, &end)
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, len(content)))
+		w.WriteHeader(http.StatusPartialContent)
+		_, _ = w.Write([]byte(content[start : end+1]))
+	}))
+	defer server.Close()
+
+	builder, err := NewS3Builder(&mockClient{config: utils.Configuration{
+		MinIOEndpoint:  server.URL,
+		MinIORegion:    "us-east-1",
+		MinIOAccessKey: "access",
+		MinIOSecretKey: "secret",
+	}})
+	if err != nil {
+		t.Fatalf("NewS3Builder() error = %v", err)
+	}
+	object := builder.Bucket("models").Key("weights.bin")
+	path := filepath.Join(t.TempDir(), "weights.bin")
+	opts := DownloadOptions{Concurrency: 1, PartSize: 10, Retries: 1, Resume: true}
+
+	if _, err := object.DownloadTo(context.Background(), path, opts); err == nil {
+		t.Fatal("Expected the download to fail on the broken part")
+	}
+	if _, err := os.Stat(path + ".part.json"); err != nil {
+		t.Fatalf("Expected a download state after the failure: %v", err)
+	}
+
+	mu.Lock()
+	failing = ""
+	firstRun := ranges
+	ranges = map[string]int{}
+	mu.Unlock()
+	result, err := object.DownloadTo(context.Background(), path, opts)
+	if err != nil {
+		t.Fatalf("DownloadTo() resume error = %v", err)
+	}
+	if firstRun["bytes=50-59"] != 2 {
+		t.Errorf("Expected the broken part to be retried once, got %v", firstRun)
+	}
+	for rng := range ranges {
+		if firstRun[rng] > 0 && rng != "bytes=50-59" {
+			t.Errorf("Expected downloaded part %s not to be requested again", rng)
+		}
+	}
+	if result.Resumed != int64(10*(10-len(ranges))) || !result.Verified || result.Size != 100 {
+		t.Errorf("Unexpected result: %+v after requesting %v", result, ranges)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != content {
+		t.Errorf("Unexpected downloaded content: %q, %v", data, err)
+	}
+	if _, err := os.Stat(path + ".part.json"); !os.IsNotExist(err) {
+		t.Errorf("Expected the download state to be removed, got %v", err)
+	}
+
+	// A state whose parts file is gone is not trusted
+	mu.Lock()
+	failing = "bytes=50-59"
+	mu.Unlock()
+	if _, err := object.DownloadTo(context.Background(), path, opts); err == nil {
+		t.Fatal("Expected the download to fail on the broken part")
+	}
+	if err := os.Remove(path + ".part"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	mu.Lock()
+	failing = ""
+	ranges = map[string]int{}
+	mu.Unlock()
+	result, err = object.DownloadTo(context.Background(), path, opts)
+	if err != nil {
+		t.Fatalf("DownloadTo() restart error = %v", err)
+	}
+	if result.Resumed != 0 || len(ranges) != 10 {
+		t.Errorf("Expected the download to start over, resumed %d bytes after requesting %v", result.Resumed, ranges)
+	}
+	if data, err := os.ReadFile(path); err != nil || string(data) != content {
+		t.Errorf("Unexpected downloaded content: %q, %v", data, err)
+	}
+}
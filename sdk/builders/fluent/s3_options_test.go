@@ -0,0 +1,88 @@
+package fluent
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestS3Builder_PutOptions(t *testing.T) {
+	setupFakeS3Env(t)
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+		w.Header().Set("ETag", `"etag"`)
+		if r.Method == "GET" {
+			_, _ = w.Write([]byte("payload"))
+		}
+	}))
+	defer server.Close()
+
+	client := &mockClient{config: utils.Configuration{
+		MinIOEndpoint:  server.URL,
+		MinIORegion:    "us-east-1",
+		MinIOAccessKey: "access",
+		MinIOSecretKey: "secret",
+	}}
+	newBuilder := func() *S3Builder {
+		builder, err := NewS3Builder(client)
+		if err != nil {
+			t.Fatalf("NewS3Builder() error = %v", err)
+		}
+		return builder.Bucket("vault").Key("records.csv")
+	}
+	ctx := context.Background()
+	key := bytes.Repeat([]byte{7}, 32)
+	keyMD5 := md5.Sum(key)
+
+	// A non-seekable body is buffered to be hashed
+	body := struct{ *strings.Reader }{strings.NewReader("payload")}
+	if _, err := newBuilder().SSEC(key).Checksum(ChecksumSHA256).StorageClass("REDUCED_REDUNDANCY").Put(ctx, body, "text/csv"); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	sha := sha256.Sum256([]byte("payload"))
+	put := headers[0]
+	for header, want := range map[string]string{
+		"X-Amz-Server-Side-Encryption-Customer-Algorithm": "AES256",
+		"X-Amz-Server-Side-Encryption-Customer-Key":       base64.StdEncoding.EncodeToString(key),
+		"X-Amz-Server-Side-Encryption-Customer-Key-Md5":   base64.StdEncoding.EncodeToString(keyMD5[:]),
+		"X-Amz-Checksum-Sha256":                           base64.StdEncoding.EncodeToString(sha[:]),
+		"X-Amz-Storage-Class":                             "REDUCED_REDUNDANCY",
+	} {
+		if got := put.Get(header); got != want {
+			t.Errorf("PUT %s = %q, want %q", header, got, want)
+		}
+	}
+
+	if _, err := newBuilder().SSES3().Checksum(ChecksumMD5).Put(ctx, strings.NewReader("payload"), ""); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	md5Sum := md5.Sum([]byte("payload"))
+	if got := headers[1].Get("Content-Md5"); got != base64.StdEncoding.EncodeToString(md5Sum[:]) {
+		t.Errorf("PUT Content-MD5 = %q", got)
+	}
+	if got := headers[1].Get("X-Amz-Server-Side-Encryption"); got != "AES256" {
+		t.Errorf("PUT X-Amz-Server-Side-Encryption = %q", got)
+	}
+
+	obj, err := newBuilder().SSEC(key).Checksum(ChecksumSHA256).Get(ctx)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	_ = obj.Body.Close()
+	if get := headers[2]; get.Get("X-Amz-Server-Side-Encryption-Customer-Key") == "" || get.Get("X-Amz-Checksum-Mode") != "ENABLED" {
+		t.Errorf("Expected GET to send the SSE-C key and enable checksums, got %v", get)
+	}
+
+	if _, err := newBuilder().SSEC([]byte("short")).Put(ctx, strings.NewReader("payload"), ""); err == nil {
+		t.Error("Expected an invalid SSE-C key to fail")
+	}
+}
@@ -0,0 +1,107 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// SampleMethod selects how Sample picks rows.
+type SampleMethod string
+
+const (
+	// SampleFirst returns the first rows of the query, in query order. This is the cheapest method.
+	SampleFirst SampleMethod = "first"
+	// SampleRandom asks the server for a random sample (TABLESAMPLE) and falls back
+	// to reservoir sampling when the backend does not support it.
+	SampleRandom SampleMethod = "random"
+	// SampleReservoir draws a uniform random sample on the client over a scan of every
+	// matching row. Memory is bounded by the sample size, but the whole result is read.
+	SampleReservoir SampleMethod = "reservoir"
+)
+
+// SampleSpec configures Sample.
+type SampleSpec struct {
+	Method SampleMethod // Default SampleRandom
+	Size   int          // Number of rows (required)
+	Seed   uint64       // Seed of client-side sampling, 0 for a random seed
+}
+
+// Sample returns at most spec.Size rows matching the query, e.g. for a quick look
+// at a large table.
+//
+// Example:
+//
+//	rows, err := client.Catalog("sales").Schema("public").Table("orders").
+//	    Sample(ctx, fluent.SampleSpec{Method: fluent.SampleRandom, Size: 1000})
+func (qb *QueryBuilder) Sample(ctx context.Context, spec SampleSpec) ([]map[string]any, error) {
+	if spec.Size <= 0 {
+		return nil, fmt.Errorf("%w: sample size must be positive", utils.ErrInvalidRequest)
+	}
+	if spec.Method == "" {
+		spec.Method = SampleRandom
+	}
+
+	switch spec.Method {
+	case SampleFirst:
+		resp, err := qb.Limit(spec.Size).Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return resp.Rows()
+	case SampleRandom:
+		rows, err := qb.serverSample(ctx, spec.Size)
+		if !errors.Is(err, utils.ErrInvalidRequest) || qb.validate() != nil {
+			return rows, err
+		}
+		// TABLESAMPLE is not supported by the backend
+		return qb.reservoirSample(ctx, spec)
+	case SampleReservoir:
+		return qb.reservoirSample(ctx, spec)
+	}
+	return nil, fmt.Errorf("%w: unsupported sample method %q", utils.ErrInvalidRequest, spec.Method)
+}
+
+// serverSample requests a random sample computed by the backend.
+func (qb *QueryBuilder) serverSample(ctx context.Context, size int) ([]map[string]any, error) {
+	sample := qb.clone()
+	sample.rawParams.Set("__sample", strconv.Itoa(size))
+	sample.limitVal = size
+	sample.offsetVal = 0
+	sample.cursor = ""
+	sample.orderChecked = true // A random sample is not paginated
+
+	resp, err := sample.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Rows()
+}
+
+// reservoirSample draws a uniform sample over every matching row (algorithm R).
+func (qb *QueryBuilder) reservoirSample(ctx context.Context, spec SampleSpec) ([]map[string]any, error) {
+	seed := spec.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	random := rand.New(rand.NewPCG(seed, seed))
+
+	reservoir := make([]map[string]any, 0, spec.Size)
+	seen := 0
+	for row, err := range qb.Iter(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		seen++
+		if len(reservoir) < spec.Size {
+			reservoir = append(reservoir, row)
+		} else if i := random.IntN(seen); i < spec.Size {
+			reservoir[i] = row
+		}
+	}
+	return reservoir, nil
+}
@@ -0,0 +1,72 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestQueryBuilder_SampleFallsBackToReservoir(t *testing.T) {
+	sampleRequested := false
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		if query.Has("__sample") {
+			sampleRequested = true
+			return nil, fmt.Errorf("%w: unknown parameter __sample", utils.ErrInvalidRequest)
+		}
+		offset, _ := strconv.Atoi(query.Get("__offset"))
+		rows := []map[string]any{}
+		for id := offset + 1; id <= min(offset+3, 10); id++ {
+			rows = append(rows, map[string]any{"id": id})
+		}
+		body, _ := json.Marshal(rows)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(body)))}, nil
+	}).Catalog("cat").Schema("schema").Table("t").Limit(3)
+
+	rows, err := qb.Sample(context.Background(), SampleSpec{Size: 4, Seed: 42})
+	if err != nil {
+		t.Fatalf("Sample() unexpected error = %v", err)
+	}
+	if !sampleRequested {
+		t.Error("Expected a server-side sample to be requested first")
+	}
+
+	seen := map[float64]bool{}
+	for _, row := range rows {
+		id := row["id"].(float64)
+		if id < 1 || id > 10 || seen[id] {
+			t.Errorf("Unexpected sample %v", rows)
+		}
+		seen[id] = true
+	}
+	if len(rows) != 4 {
+		t.Errorf("Expected 4 rows, got %d", len(rows))
+	}
+}
+
+func TestQueryBuilder_SampleFromServer(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		if query.Get("__sample") != "2" || query.Get("__limit") != "2" {
+			t.Errorf("Unexpected query %s", req.URL.RawQuery)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"id": 7}, {"id": 3}]`))}, nil
+	}).Catalog("cat").Schema("schema").Table("t")
+
+	rows, err := qb.Sample(context.Background(), SampleSpec{Method: SampleRandom, Size: 2})
+	if err != nil || len(rows) != 2 {
+		t.Fatalf("Sample() = %v, %v", rows, err)
+	}
+
+	if _, err := qb.Sample(context.Background(), SampleSpec{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for an empty sample, got %v", err)
+	}
+}
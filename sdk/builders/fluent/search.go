@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
 )
 
@@ -67,6 +68,7 @@ type SearchResults struct {
 }
 
 // SearchBuilder provides a fluent interface for building and executing full-text search queries.
+// Chaining methods return a new builder and leave the receiver untouched.
 type SearchBuilder struct {
 	client interface {
 		Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error)
@@ -82,6 +84,11 @@ type SearchBuilder struct {
 	tableName      string
 	columnsToIndex []string
 	limitVal       int
+
+	// Structured query clauses, see search_query.go
+	must    []SearchClause
+	should  []SearchClause
+	mustNot []SearchClause
 }
 
 // NewSearchBuilder creates a new SearchBuilder instance.
@@ -100,6 +107,7 @@ func NewSearchBuilder(client interface {
 
 // Query sets the search query string.
 func (sb *SearchBuilder) Query(query string) *SearchBuilder {
+	sb = sb.clone()
 	if query == "" {
 		sb.errors = append(sb.errors, fmt.Errorf("search query cannot be empty"))
 	}
@@ -109,6 +117,7 @@ func (sb *SearchBuilder) Query(query string) *SearchBuilder {
 
 // DataDock sets the data dock ID for the search.
 func (sb *SearchBuilder) DataDock(dataDockID string) *SearchBuilder {
+	sb = sb.clone()
 	if dataDockID == "" {
 		sb.errors = append(sb.errors, fmt.Errorf("data dock ID cannot be empty"))
 	}
@@ -118,6 +127,7 @@ func (sb *SearchBuilder) DataDock(dataDockID string) *SearchBuilder {
 
 // Catalog sets the catalog name for the search.
 func (sb *SearchBuilder) Catalog(name string) *SearchBuilder {
+	sb = sb.clone()
 	if name == "" {
 		sb.errors = append(sb.errors, fmt.Errorf("catalog name cannot be empty"))
 	}
@@ -127,6 +137,7 @@ func (sb *SearchBuilder) Catalog(name string) *SearchBuilder {
 
 // Schema sets the schema name for the search.
 func (sb *SearchBuilder) Schema(name string) *SearchBuilder {
+	sb = sb.clone()
 	if name == "" {
 		sb.errors = append(sb.errors, fmt.Errorf("schema name cannot be empty"))
 	}
@@ -136,6 +147,7 @@ func (sb *SearchBuilder) Schema(name string) *SearchBuilder {
 
 // Table sets the table name for the search.
 func (sb *SearchBuilder) Table(name string) *SearchBuilder {
+	sb = sb.clone()
 	if name == "" {
 		sb.errors = append(sb.errors, fmt.Errorf("table name cannot be empty"))
 	}
@@ -146,12 +158,14 @@ func (sb *SearchBuilder) Table(name string) *SearchBuilder {
 // Columns sets the columns to index for the search.
 // Can be called multiple times to add more columns.
 func (sb *SearchBuilder) Columns(columns ...string) *SearchBuilder {
+	sb = sb.clone()
 	sb.columnsToIndex = append(sb.columnsToIndex, columns...)
 	return sb
 }
 
 // Limit sets the maximum number of results to return.
 func (sb *SearchBuilder) Limit(n int) *SearchBuilder {
+	sb = sb.clone()
 	if n <= 0 {
 		sb.errors = append(sb.errors, fmt.Errorf("limit must be greater than 0"))
 		return sb
@@ -172,7 +186,7 @@ func (sb *SearchBuilder) validate() error {
 	}
 
 	// Check required fields
-	if sb.searchQuery == "" {
+	if sb.searchQuery == "" && len(sb.must) == 0 && len(sb.should) == 0 {
 		return fmt.Errorf("%w: search query is required", utils.ErrInvalidRequest)
 	}
 	if sb.dataDockID == "" {
@@ -203,7 +217,7 @@ func (sb *SearchBuilder) Execute(ctx context.Context) (*SearchResults, error) {
 
 	// Build the request body
 	requestBody := map[string]interface{}{
-		"query":            sb.searchQuery,
+		"query":            sb.queryString(),
 		"data_dock_id":     sb.dataDockID,
 		"catalog":          sb.catalogName,
 		"schema":           sb.schemaName,
@@ -212,11 +226,18 @@ func (sb *SearchBuilder) Execute(ctx context.Context) (*SearchResults, error) {
 		"columns_to_index": sb.columnsToIndex,
 	}
 
+	if err := builders.RequireFeature(ctx, sb.client, utils.FeatureSearch, "full-text search"); err != nil {
+		return nil, err
+	}
+
 	// Build endpoint
 	endpoint := fmt.Sprintf("%s/api/search", sb.client.GetConfig().BaseURL)
 
 	// Marshal request body
-	body := utils.JsonMarshal(requestBody)
+	body, err := utils.EncodeBody(sb.client.GetConfig(), requestBody)
+	if err != nil {
+		return nil, err
+	}
 
 	// Execute the request
 	resp, err := sb.client.Do(ctx, "POST", endpoint, body)
@@ -237,3 +258,14 @@ func (sb *SearchBuilder) Execute(ctx context.Context) (*SearchResults, error) {
 
 	return searchResults, nil
 }
+
+// clone returns a copy of the builder that can be modified independently.
+func (sb *SearchBuilder) clone() *SearchBuilder {
+	next := *sb
+	next.errors = append([]error(nil), sb.errors...)
+	next.columnsToIndex = append([]string(nil), sb.columnsToIndex...)
+	next.must = append([]SearchClause(nil), sb.must...)
+	next.should = append([]SearchClause(nil), sb.should...)
+	next.mustNot = append([]SearchClause(nil), sb.mustNot...)
+	return &next
+}
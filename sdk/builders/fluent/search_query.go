@@ -0,0 +1,158 @@
+package fluent
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxFuzzyDistance is the largest edit distance accepted by the search engine.
+const maxFuzzyDistance = 2
+
+// searchSpecialChars are escaped in terms so that they are matched literally.
+const searchSpecialChars = `+-&|!(){}[]^"~*?:\/`
+
+// SearchClause is a term, phrase or fuzzy term of a structured search query.
+// Build clauses with Term, Phrase and Fuzzy, optionally restricted to a column
+// with InColumn, and combine them with SearchBuilder.Must, Should and MustNot.
+type SearchClause struct {
+	text     string
+	distance int // Fuzzy edit distance, -1 for exact matching
+	phrase   bool
+	column   string
+	err      error
+}
+
+// Term matches documents containing the word. Query syntax characters are matched literally.
+func Term(word string) SearchClause {
+	clause := SearchClause{text: word, distance: -1}
+	if strings.TrimSpace(word) == "" {
+		clause.err = fmt.Errorf("search term cannot be empty")
+	} else if strings.ContainsAny(word, " \t\n") {
+		clause.err = fmt.Errorf("search term %q contains spaces, use Phrase", word)
+	}
+	return clause
+}
+
+// Phrase matches documents containing the words next to each other, in order.
+func Phrase(words string) SearchClause {
+	clause := SearchClause{text: strings.Join(strings.Fields(words), " "), distance: -1, phrase: true}
+	if clause.text == "" {
+		clause.err = fmt.Errorf("search phrase cannot be empty")
+	}
+	return clause
+}
+
+// Fuzzy matches documents containing the word with at most distance edits
+// (insertions, deletions, substitutions or transpositions), between 0 and 2.
+func Fuzzy(word string, distance int) SearchClause {
+	clause := Term(word)
+	clause.distance = distance
+	if clause.err == nil && (distance < 0 || distance > maxFuzzyDistance) {
+		clause.err = fmt.Errorf("fuzzy distance must be between 0 and %d, got %d", maxFuzzyDistance, distance)
+	}
+	return clause
+}
+
+// InColumn restricts the clause to one of the indexed columns.
+func (c SearchClause) InColumn(column string) SearchClause {
+	if column == "" && c.err == nil {
+		c.err = fmt.Errorf("search clause column cannot be empty")
+	}
+	c.column = column
+	return c
+}
+
+// String renders the clause in the search query syntax.
+func (c SearchClause) String() string {
+	var text string
+	if c.phrase {
+		text = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(c.text) + `"`
+	} else {
+		var escaped strings.Builder
+		for _, r := range c.text {
+			if strings.ContainsRune(searchSpecialChars, r) {
+				escaped.WriteByte('\\')
+			}
+			escaped.WriteRune(r)
+		}
+		text = escaped.String()
+		if c.distance >= 0 {
+			text += fmt.Sprintf("~%d", c.distance)
+		}
+	}
+	if c.column != "" {
+		text = c.column + ":" + text
+	}
+	return text
+}
+
+// Must requires every clause to match.
+func (sb *SearchBuilder) Must(clauses ...SearchClause) *SearchBuilder {
+	sb = sb.clone()
+	sb.must = sb.addClauses(sb.must, clauses)
+	return sb
+}
+
+// Should ranks documents matching the clauses higher. When the search has no
+// Must clause and no Query, documents must match at least one Should clause.
+func (sb *SearchBuilder) Should(clauses ...SearchClause) *SearchBuilder {
+	sb = sb.clone()
+	sb.should = sb.addClauses(sb.should, clauses)
+	return sb
+}
+
+// MustNot excludes documents matching any of the clauses.
+func (sb *SearchBuilder) MustNot(clauses ...SearchClause) *SearchBuilder {
+	sb = sb.clone()
+	sb.mustNot = sb.addClauses(sb.mustNot, clauses)
+	return sb
+}
+
+// Phrase requires the words to appear next to each other, in order.
+// It is a shorthand for Must(Phrase(words)).
+func (sb *SearchBuilder) Phrase(words string) *SearchBuilder {
+	return sb.Must(Phrase(words))
+}
+
+// Fuzzy requires a word within distance edits of term.
+// It is a shorthand for Must(Fuzzy(term, distance)).
+func (sb *SearchBuilder) Fuzzy(term string, distance int) *SearchBuilder {
+	return sb.Must(Fuzzy(term, distance))
+}
+
+// addClauses appends clauses to a clause list, recording invalid clauses as
+// builder errors. The builder must already be a clone.
+func (sb *SearchBuilder) addClauses(list, clauses []SearchClause) []SearchClause {
+	for _, clause := range clauses {
+		if clause.err != nil {
+			sb.errors = append(sb.errors, clause.err)
+			continue
+		}
+		list = append(list, clause)
+	}
+	return list
+}
+
+// queryString combines the free-text query and the clauses into the query
+// string sent to the search API. The free-text query is passed as is, and must
+// match like a Must clause when clauses are set.
+func (sb *SearchBuilder) queryString() string {
+	parts := make([]string, 0, 1+len(sb.must)+len(sb.should)+len(sb.mustNot))
+	if sb.searchQuery != "" {
+		query := sb.searchQuery
+		if len(sb.must)+len(sb.should)+len(sb.mustNot) > 0 {
+			query = "+(" + query + ")"
+		}
+		parts = append(parts, query)
+	}
+	for _, clause := range sb.must {
+		parts = append(parts, "+"+clause.String())
+	}
+	for _, clause := range sb.should {
+		parts = append(parts, clause.String())
+	}
+	for _, clause := range sb.mustNot {
+		parts = append(parts, "-"+clause.String())
+	}
+	return strings.Join(parts, " ")
+}
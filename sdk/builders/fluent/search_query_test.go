@@ -0,0 +1,51 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestSearchBuilder_Clauses(t *testing.T) {
+	recorder := &bodyRecorder{config: utils.Configuration{DataDockID: "dd"}}
+	search := NewSearchBuilder(recorder).Catalog("c").Schema("s").Table("docs").Columns("content")
+
+	// The recorder returns no results: only the request body is checked
+	_, _ = search.
+		Phrase(`data "lake" house`).
+		Fuzzy("kubernetes", 1).
+		Should(Term("c++").InColumn("title")).
+		MustNot(Term("draft")).
+		Execute(context.Background())
+
+	var body map[string]any
+	if err := json.Unmarshal(recorder.body, &body); err != nil {
+		t.Fatalf("invalid request body: %v", err)
+	}
+	want := `+"data \"lake\" house" +kubernetes~1 title:c\+\+ -draft`
+	if body["query"] != want {
+		t.Errorf("query = %q, want %q", body["query"], want)
+	}
+
+	if got := search.Query("lakehouse OR warehouse").MustNot(Term("draft")).queryString(); got != "+(lakehouse OR warehouse) -draft" {
+		t.Errorf("Expected the free-text query to stay required, got %q", got)
+	}
+}
+
+func TestSearchBuilder_ClauseValidation(t *testing.T) {
+	search := NewSearchBuilder(&bodyRecorder{config: utils.Configuration{DataDockID: "dd"}}).
+		Catalog("c").Schema("s").Table("docs").Columns("content")
+
+	for name, invalid := range map[string]*SearchBuilder{
+		"only must not":  search.MustNot(Term("draft")),
+		"fuzzy distance": search.Fuzzy("kubernetes", 3),
+		"term spaces":    search.Must(Term("two words")),
+		"empty phrase":   search.Phrase("  "),
+	} {
+		if _, err := invalid.Execute(context.Background()); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+}
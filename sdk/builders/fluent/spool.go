@@ -0,0 +1,153 @@
+package fluent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"sync"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// SpoolOptions configures Spool.
+type SpoolOptions struct {
+	// Dir is the directory of the spool file (default os.TempDir()).
+	Dir string
+	// MaxBytes bounds the size of the spool file; 0 means no limit.
+	MaxBytes int64
+}
+
+// SpooledRows is a result set stored in a temporary file, one JSON object per line.
+// Rows can be iterated any number of times, concurrently; Close removes the file.
+type SpooledRows struct {
+	path      string
+	rows      int
+	size      int64
+	useNumber bool
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Spool fetches every row matching the query page by page (see Iter; Limit sets
+// the page size) into a temporary file, so that results larger than memory can be
+// processed in several passes. Only the current page is held in memory. When the
+// file would exceed opts.MaxBytes, the spooling stops with utils.ErrResponseTooLarge.
+//
+// Example:
+//
+//	spool, err := qb.Limit(5000).Spool(ctx, fluent.SpoolOptions{Dir: "/scratch", MaxBytes: 10 << 30})
+//	if err != nil { ... }
+//	defer spool.Close()
+//	for row, err := range spool.Rows() { ... } // First pass
+//	for row, err := range spool.Rows() { ... } // Second pass
+func (qb *QueryBuilder) Spool(ctx context.Context, opts SpoolOptions) (*SpooledRows, error) {
+	file, err := os.CreateTemp(opts.Dir, "hyperfluid-spool-*.jsonl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spool file: %w", err)
+	}
+	spool := &SpooledRows{path: file.Name(), useNumber: qb.client.GetConfig().UseNumber}
+
+	err = spool.write(file, qb.Iter(ctx), opts.MaxBytes)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to write spool file: %w", closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(spool.path)
+		return nil, err
+	}
+	return spool, nil
+}
+
+func (s *SpooledRows) write(file *os.File, rows iter.Seq2[map[string]any, error], maxBytes int64) error {
+	w := bufio.NewWriter(file)
+	for row, err := range rows {
+		if err != nil {
+			return err
+		}
+		line, err := json.Marshal(row)
+		if err != nil {
+			return fmt.Errorf("failed to encode row: %w", err)
+		}
+		line = append(line, '\n')
+		if maxBytes > 0 && s.size+int64(len(line)) > maxBytes {
+			return fmt.Errorf("%w: spooled rows exceed %d bytes", utils.ErrResponseTooLarge, maxBytes)
+		}
+		if _, err := w.Write(line); err != nil {
+			return fmt.Errorf("failed to write spool file: %w", err)
+		}
+		s.rows++
+		s.size += int64(len(line))
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write spool file: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of spooled rows.
+func (s *SpooledRows) Len() int {
+	return s.rows
+}
+
+// Size returns the size of the spool file in bytes.
+func (s *SpooledRows) Size() int64 {
+	return s.size
+}
+
+// Rows returns an iterator over the spooled rows, read back from the file in order.
+// Numbers decode as json.Number when the client is configured with UseNumber.
+func (s *SpooledRows) Rows() iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		s.mu.Lock()
+		closed := s.closed
+		s.mu.Unlock()
+		if closed {
+			yield(nil, errors.New("spooled rows are closed"))
+			return
+		}
+
+		file, err := os.Open(s.path)
+		if err != nil {
+			yield(nil, fmt.Errorf("failed to open spool file: %w", err))
+			return
+		}
+		defer func() { _ = file.Close() }()
+
+		decoder := json.NewDecoder(bufio.NewReader(file))
+		if s.useNumber {
+			decoder.UseNumber()
+		}
+		for {
+			var row map[string]any
+			if err := decoder.Decode(&row); err == io.EOF {
+				return
+			} else if err != nil {
+				yield(nil, fmt.Errorf("failed to read spool file: %w", err))
+				return
+			}
+			if !yield(row, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Close removes the spool file. Iterations in progress may fail.
+func (s *SpooledRows) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
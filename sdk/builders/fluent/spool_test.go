@@ -0,0 +1,75 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func newSpoolTestQuery(rows int) *QueryBuilder {
+	return newTestQueryBuilder(utils.Configuration{DataDockID: "dd", UseNumber: true}, func(req *http.Request) (*http.Response, error) {
+		limit, _ := strconv.Atoi(req.URL.Query().Get("__limit"))
+		offset, _ := strconv.Atoi(req.URL.Query().Get("__offset"))
+		page := []map[string]any{}
+		for id := offset + 1; id <= rows && len(page) < limit; id++ {
+			page = append(page, map[string]any{"id": id, "name": "row " + strconv.Itoa(id)})
+		}
+		body, _ := json.Marshal(page)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(body)))}, nil
+	}).Catalog("c").Schema("s").Table("t").Limit(2)
+}
+
+func TestQueryBuilder_Spool(t *testing.T) {
+	dir := t.TempDir()
+	spool, err := newSpoolTestQuery(5).Spool(context.Background(), SpoolOptions{Dir: dir})
+	if err != nil {
+		t.Fatalf("Spool() unexpected error = %v", err)
+	}
+	if spool.Len() != 5 || spool.Size() == 0 {
+		t.Errorf("Len() = %d, Size() = %d", spool.Len(), spool.Size())
+	}
+
+	for pass := 0; pass < 2; pass++ {
+		var ids []string
+		for row, err := range spool.Rows() {
+			if err != nil {
+				t.Fatalf("Rows() unexpected error = %v", err)
+			}
+			ids = append(ids, row["id"].(json.Number).String())
+		}
+		if strings.Join(ids, ",") != "1,2,3,4,5" {
+			t.Errorf("pass %d read %v", pass, ids)
+		}
+	}
+
+	if err := spool.Close(); err != nil {
+		t.Fatalf("Close() unexpected error = %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected the spool file to be removed, found %d files", len(entries))
+	}
+	for _, err := range spool.Rows() {
+		if err == nil {
+			t.Error("Expected an error iterating closed spooled rows")
+		}
+	}
+}
+
+func TestQueryBuilder_SpoolMaxBytes(t *testing.T) {
+	dir := t.TempDir()
+	_, err := newSpoolTestQuery(100).Spool(context.Background(), SpoolOptions{Dir: dir, MaxBytes: 100})
+	if !errors.Is(err, utils.ErrResponseTooLarge) {
+		t.Fatalf("Expected ErrResponseTooLarge, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected the partial spool file to be removed, found %d files", len(entries))
+	}
+}
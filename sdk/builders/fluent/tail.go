@@ -0,0 +1,171 @@
+package fluent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// defaultTailPollInterval is the delay between two polls of Tail once caught up.
+const defaultTailPollInterval = 5 * time.Second
+
+// TailOptions configures Tail.
+type TailOptions struct {
+	// CursorColumn is a strictly increasing column, such as an identity column
+	// (required). Rows are read in its order; a row inserted with a value lower
+	// than or equal to the last one read is never seen, so timestamps only work
+	// when they are unique and assigned in insertion order.
+	CursorColumn string
+	// PollInterval is the delay between two polls once caught up (default 5s).
+	PollInterval time.Duration
+	// StartAfter is the cursor value to resume from, e.g. the cursor column of the
+	// last row processed before a restart. When nil, only rows inserted after Tail
+	// is called are returned, unless FromBeginning is set.
+	StartAfter any
+	// FromBeginning returns the existing rows before the new ones.
+	FromBeginning bool
+}
+
+// TailEvent is a row returned by Tail, or an error. Failed polls are retried
+// after PollInterval: an error does not end the tail.
+type TailEvent struct {
+	Row map[string]any
+	Err error
+}
+
+// Tail watches an append-only table and returns a channel receiving the rows
+// matching the query as they are inserted. The table is polled with a keyset
+// cursor on opts.CursorColumn: pages are read back to back while rows are
+// pending, then every PollInterval. Limit sets the page size (default 100); any
+// OrderBy is replaced by the cursor ordering, and the cursor column is added to a
+// Select that leaves it out.
+//
+// The channel is closed when ctx is done, or after a single error event when the
+// query or the options are invalid.
+//
+// Example:
+//
+//	for event := range client.Catalog("ops").Schema("public").Table("events").
+//	    Where("level", "=", "error").
+//	    Tail(ctx, fluent.TailOptions{CursorColumn: "id", PollInterval: time.Second}) {
+//	    if event.Err != nil { log.Print(event.Err); continue }
+//	    handle(event.Row)
+//	}
+func (qb *QueryBuilder) Tail(ctx context.Context, opts TailOptions) <-chan TailEvent {
+	events := make(chan TailEvent)
+	go func() {
+		defer close(events)
+		send := func(event TailEvent) bool {
+			select {
+			case events <- event:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		page, err := qb.tailQuery(opts)
+		if err != nil {
+			send(TailEvent{Err: err})
+			return
+		}
+		if opts.PollInterval <= 0 {
+			opts.PollInterval = defaultTailPollInterval
+		}
+
+		last := opts.StartAfter
+		started := last != nil || opts.FromBeginning
+		for ctx.Err() == nil {
+			if !started {
+				// Start from the current end of the table
+				last, err = qb.tailEnd(ctx, opts.CursorColumn)
+				started = err == nil
+			} else {
+				var rows []map[string]any
+				rows, err = page.tailPage(ctx, opts.CursorColumn, last)
+				for _, row := range rows {
+					value := row[opts.CursorColumn]
+					if value == nil {
+						// The tail could not advance past this row: it would read it again forever
+						send(TailEvent{Err: fmt.Errorf("%w: cursor column %s is NULL or missing in the rows", utils.ErrInvalidRequest, opts.CursorColumn)})
+						return
+					}
+					if !send(TailEvent{Row: row}) {
+						return
+					}
+					last = value
+				}
+				if err == nil && len(rows) == page.limitVal {
+					continue // More rows are pending
+				}
+			}
+			if err != nil && !send(TailEvent{Err: err}) {
+				return
+			}
+
+			select {
+			case <-time.After(opts.PollInterval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+// tailQuery validates the query and options of Tail and returns the paged query.
+func (qb *QueryBuilder) tailQuery(opts TailOptions) (*QueryBuilder, error) {
+	if opts.CursorColumn == "" {
+		return nil, fmt.Errorf("%w: tail requires a cursor column", utils.ErrInvalidRequest)
+	}
+	page := qb.clone()
+	page.orderBy = []builders.OrderClause{{Column: opts.CursorColumn, Direction: "ASC"}}
+	if len(page.selectCols) > 0 && !slices.Contains(page.selectCols, opts.CursorColumn) {
+		page.selectCols = append(page.selectCols, opts.CursorColumn)
+	}
+	page.cursor = ""
+	page.offsetVal = 0
+	if page.limitVal <= 0 {
+		page.limitVal = defaultIterPageSize
+	}
+	if err := page.validate(); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// tailPage returns the rows following the cursor value, or the first rows when it is nil.
+func (qb *QueryBuilder) tailPage(ctx context.Context, column string, after any) ([]map[string]any, error) {
+	page := qb
+	if after != nil {
+		page = qb.clone()
+		page.cursor = encodeKeysetCursor(keysetCursor{Column: column, Direction: "ASC", Value: after})
+	}
+	resp, err := page.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Rows()
+}
+
+// tailEnd returns the largest value of the cursor column matching the query, or
+// nil when no row matches.
+func (qb *QueryBuilder) tailEnd(ctx context.Context, column string) (any, error) {
+	end := qb.clone()
+	end.selectCols = []string{column}
+	end.orderBy = []builders.OrderClause{{Column: column, Direction: "DESC"}}
+
+	var row map[string]any
+	if err := end.First(utils.ContextWithoutTransformers(ctx), &row); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return row[column], nil
+}
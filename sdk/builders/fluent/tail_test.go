@@ -0,0 +1,124 @@
+package fluent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestQueryBuilder_Tail(t *testing.T) {
+	var mu sync.Mutex
+	table := []int{1, 2, 3}
+	located := make(chan struct{})
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		query := req.URL.Query()
+		limit, _ := strconv.Atoi(query.Get("__limit"))
+		rows := []map[string]any{}
+		if query.Get("order") == "id.desc" {
+			rows = append(rows, map[string]any{"id": table[len(table)-1]})
+			close(located)
+		} else {
+			after, _ := strconv.Atoi(query.Get("id.gt"))
+			for _, id := range table {
+				if id > after && len(rows) < limit {
+					rows = append(rows, map[string]any{"id": id})
+				}
+			}
+		}
+		body, _ := json.Marshal(rows)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(body)))}, nil
+	}).Catalog("c").Schema("s").Table("events").Limit(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := qb.Tail(ctx, TailOptions{CursorColumn: "id", PollInterval: 10 * time.Millisecond})
+
+	<-located // Insert once the tail located the end of the table
+	mu.Lock()
+	table = append(table, 4, 5, 6)
+	mu.Unlock()
+
+	var ids []string
+	for event := range events {
+		if event.Err != nil {
+			t.Fatalf("Tail() unexpected error = %v", event.Err)
+		}
+		ids = append(ids, strconv.Itoa(int(event.Row["id"].(float64))))
+		if len(ids) == 3 {
+			cancel()
+		}
+	}
+	if got := strings.Join(ids, ","); got != "4,5,6" {
+		t.Errorf("Expected only the new rows, got %s", got)
+	}
+}
+
+func TestQueryBuilder_TailValidation(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, nil).Catalog("c").Schema("s").Table("events")
+
+	events := qb.Tail(context.Background(), TailOptions{})
+	if event, ok := <-events; !ok || event.Err == nil {
+		t.Fatalf("Expected an error event without cursor column, got %+v", event)
+	}
+	if _, ok := <-events; ok {
+		t.Error("Expected the channel to be closed after the validation error")
+	}
+}
+
+func TestQueryBuilder_TailSelect(t *testing.T) {
+	var selects []string
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		selects = append(selects, query.Get("__select"))
+		rows := []map[string]any{}
+		if query.Get("id.gt") == "" {
+			rows = append(rows, map[string]any{"msg": "a", "id": 1}, map[string]any{"msg": "b", "id": 2})
+		}
+		body, _ := json.Marshal(rows)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(string(body)))}, nil
+	}).Catalog("c").Schema("s").Table("events").Select("msg").Limit(2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var msgs []string
+	for event := range qb.Tail(ctx, TailOptions{CursorColumn: "id", FromBeginning: true, PollInterval: 10 * time.Millisecond}) {
+		if event.Err != nil {
+			t.Fatalf("Tail() unexpected error = %v", event.Err)
+		}
+		msgs = append(msgs, event.Row["msg"].(string))
+		if len(msgs) == 2 {
+			cancel()
+		}
+	}
+	if got := strings.Join(msgs, ","); got != "a,b" {
+		t.Errorf("Expected each row once, got %s", got)
+	}
+	if selects[0] != "msg,id" {
+		t.Errorf("Expected the cursor column to be selected, got %q", selects[0])
+	}
+}
+
+func TestQueryBuilder_TailMissingCursor(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[{"msg": "a", "id": null}]`))}, nil
+	}).Catalog("c").Schema("s").Table("events")
+
+	events := qb.Tail(context.Background(), TailOptions{CursorColumn: "id", FromBeginning: true})
+	if event, ok := <-events; !ok || !errors.Is(event.Err, utils.ErrInvalidRequest) {
+		t.Fatalf("Expected an error event for a NULL cursor, got %+v", event)
+	}
+	if _, ok := <-events; ok {
+		t.Error("Expected the channel to be closed after the cursor error")
+	}
+}
@@ -0,0 +1,64 @@
+package fluent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// First executes the query with a limit of 1 and decodes the first row into dest.
+// Returns utils.ErrNotFound when no row matches.
+func (qb *QueryBuilder) First(ctx context.Context, dest any) error {
+	first := qb.clone()
+	first.limitVal = 1
+
+	resp, err := first.Get(ctx)
+	if err != nil {
+		return err
+	}
+	return utils.FirstRow(resp, dest)
+}
+
+// Pluck executes the query selecting only the given column and returns its values.
+// Use PluckAs to get a typed slice.
+func (qb *QueryBuilder) Pluck(ctx context.Context, column string) ([]any, error) {
+	if column == "" {
+		return nil, fmt.Errorf("%w: pluck column cannot be empty", utils.ErrInvalidRequest)
+	}
+
+	pluck := qb.clone()
+	pluck.selectCols = []string{column}
+
+	resp, err := pluck.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ColumnValues(resp, column)
+}
+
+// PluckAs is like Pluck but decodes the values into a slice of T.
+//
+//	ids, err := fluent.PluckAs[int64](ctx, qb, "id")
+func PluckAs[T any](ctx context.Context, qb *QueryBuilder, column string) ([]T, error) {
+	values, err := qb.Pluck(ctx, column)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertValues[T](values)
+}
+
+// Scan executes the query and decodes the rows into dest, a pointer to a slice of
+// structs mapped with the hyperfluid struct tags. NULL columns become nil pointers
+// or invalid sql.Null* values; unknown columns are ignored. Use utils.RowScanner
+// with utils.ScanStrict on the response for strict decoding.
+//
+//	var orders []Order
+//	err := qb.Where("status", "=", "paid").Scan(ctx, &orders)
+func (qb *QueryBuilder) Scan(ctx context.Context, dest any) error {
+	resp, err := qb.Get(ctx)
+	if err != nil {
+		return err
+	}
+	return utils.RowScanner{}.Scan(resp, dest)
+}
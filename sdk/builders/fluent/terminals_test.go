@@ -0,0 +1,94 @@
+package fluent
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+type scannedOrder struct {
+	ID       int64          `hyperfluid:"order_id"`
+	Note     sql.NullString `hyperfluid:"note"`
+	Discount *float64       `hyperfluid:"discount"`
+	PaidAt   sql.NullTime   `hyperfluid:"paid_at"`
+	Shipped  *time.Time     `hyperfluid:"shipped_on"`
+	Quantity int            `hyperfluid:"quantity"`
+}
+
+func rowsHandler(body string) func(*http.Request) (*http.Response, error) {
+	return func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	}
+}
+
+func TestQueryBuilder_Scan(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, rowsHandler(`[
+		{"order_id": 1, "note": "gift", "discount": 0.5, "paid_at": "2024-03-01T15:04:05Z", "shipped_on": "2024-03-02", "quantity": "3", "extra": true},
+		{"order_id": 2, "note": null, "discount": null, "paid_at": null, "shipped_on": null, "quantity": null}
+	]`)).Catalog("c").Schema("s").Table("orders")
+
+	var orders []*scannedOrder
+	if err := qb.Scan(context.Background(), &orders); err != nil {
+		t.Fatalf("Scan() unexpected error = %v", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("Expected 2 orders, got %d", len(orders))
+	}
+
+	first, second := orders[0], orders[1]
+	if first.ID != 1 || first.Note.String != "gift" || !first.Note.Valid || *first.Discount != 0.5 || first.Quantity != 3 {
+		t.Errorf("Unexpected first order: %+v", first)
+	}
+	if !first.PaidAt.Valid || !first.PaidAt.Time.Equal(time.Date(2024, 3, 1, 15, 4, 5, 0, time.UTC)) {
+		t.Errorf("Unexpected paid_at: %+v", first.PaidAt)
+	}
+	if first.Shipped == nil || first.Shipped.Format(time.DateOnly) != "2024-03-02" {
+		t.Errorf("Unexpected shipped_on: %v", first.Shipped)
+	}
+	if second.Note.Valid || second.Discount != nil || second.PaidAt.Valid || second.Shipped != nil || second.Quantity != 0 {
+		t.Errorf("Expected NULL columns to be empty, got %+v", second)
+	}
+}
+
+func TestRowScanner_Strict(t *testing.T) {
+	strict := utils.RowScanner{Mode: utils.ScanStrict}
+	valid := map[string]any{"order_id": float64(1), "note": nil, "discount": nil, "paid_at": nil, "shipped_on": nil, "quantity": float64(3)}
+
+	var order scannedOrder
+	if err := strict.ScanRow(valid, &order); err != nil || order.Quantity != 3 {
+		t.Fatalf("ScanRow() = %+v, %v", order, err)
+	}
+
+	invalid := map[string]func(row map[string]any){
+		"unknown column":   func(row map[string]any) { row["extra"] = true },
+		"missing column":   func(row map[string]any) { delete(row, "quantity") },
+		"NULL in int":      func(row map[string]any) { row["quantity"] = nil },
+		"string as number": func(row map[string]any) { row["quantity"] = "3" },
+		"fraction as int":  func(row map[string]any) { row["quantity"] = 1.5 },
+	}
+	for name, change := range invalid {
+		row := make(map[string]any, len(valid))
+		for column, value := range valid {
+			row[column] = value
+		}
+		change(row)
+		if err := strict.ScanRow(row, &scannedOrder{}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	var quantity sql.NullInt64
+	if err := utils.ScanColumn(valid, "quantity", &quantity); err != nil || quantity.Int64 != 3 || !quantity.Valid {
+		t.Errorf("ScanColumn() = %+v, %v", quantity, err)
+	}
+	var note *string
+	if err := utils.ScanColumn(valid, "note", &note); err != nil || note != nil {
+		t.Errorf("ScanColumn() on NULL = %v, %v", note, err)
+	}
+}
@@ -0,0 +1,57 @@
+package fluent
+
+import (
+	"fmt"
+	"time"
+)
+
+// timeOperators are the operators that apply to timestamp columns.
+var timeOperators = map[string]bool{"=": true, "!=": true, ">": true, ">=": true, "<": true, "<=": true}
+
+// WhereTime adds a filter on a timestamp column. The time is sent in ISO 8601, in
+// UTC unless TimeLocation is set. Supported operators: =, !=, >, >=, <, <=.
+//
+// Example:
+//
+//	qb.WhereTime("created_at", ">=", time.Now().Add(-24*time.Hour))
+func (qb *QueryBuilder) WhereTime(column, operator string, t time.Time) *QueryBuilder {
+	if !timeOperators[operator] {
+		qb = qb.clone()
+		qb.errors = append(qb.errors, fmt.Errorf("invalid time operator '%s'", operator))
+		return qb
+	}
+	if t.IsZero() {
+		qb = qb.clone()
+		qb.errors = append(qb.errors, fmt.Errorf("time filter on '%s' cannot be zero", column))
+		return qb
+	}
+	return qb.Where(column, operator, t)
+}
+
+// Between keeps the rows whose timestamp column is within [from, to], bounds included.
+//
+// Example:
+//
+//	qb.Between("created_at", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Now())
+func (qb *QueryBuilder) Between(column string, from, to time.Time) *QueryBuilder {
+	if to.Before(from) {
+		qb = qb.clone()
+		qb.errors = append(qb.errors, fmt.Errorf("time range on '%s' ends before it starts", column))
+		return qb
+	}
+	return qb.WhereTime(column, ">=", from).WhereTime(column, "<=", to)
+}
+
+// TimeLocation sends the time filters as wall-clock times of loc, without offset,
+// for timestamp columns without time zone that store local times. By default, times
+// are sent in UTC with a Z suffix. nil restores the default.
+//
+// Example:
+//
+//	paris, _ := time.LoadLocation("Europe/Paris")
+//	qb.TimeLocation(paris).WhereTime("opened_at", ">=", openingTime)
+func (qb *QueryBuilder) TimeLocation(loc *time.Location) *QueryBuilder {
+	qb = qb.clone()
+	qb.timeLocation = loc
+	return qb
+}
@@ -0,0 +1,52 @@
+package fluent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestQueryBuilder_TimeFilters(t *testing.T) {
+	cet := time.FixedZone("CET", 3600)
+	from := time.Date(2025, 3, 1, 9, 30, 0, 0, cet)
+	to := time.Date(2025, 3, 2, 0, 0, 0, 500_000_000, time.UTC)
+
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, nil).
+		Catalog("c").Schema("s").Table("events")
+
+	params := qb.Between("created_at", from, to).buildParams()
+	if got := params.Get("created_at.gte"); got != "2025-03-01T08:30:00Z" {
+		t.Errorf("Expected the lower bound in UTC, got %q", got)
+	}
+	if got := params.Get("created_at.lte"); got != "2025-03-02T00:00:00.5Z" {
+		t.Errorf("Expected the upper bound in UTC, got %q", got)
+	}
+
+	// Plain Where with a time value is formatted the same way
+	if got := qb.Where("created_at", ">", from).buildParams().Get("created_at.gt"); got != "2025-03-01T08:30:00Z" {
+		t.Errorf("Expected Where to format times in ISO 8601, got %q", got)
+	}
+
+	local := qb.TimeLocation(cet).WhereTime("opened_at", "<", to).buildParams()
+	if got := local.Get("opened_at.lt"); got != "2025-03-02T01:00:00.5" {
+		t.Errorf("Expected a wall-clock time without offset, got %q", got)
+	}
+}
+
+func TestQueryBuilder_TimeFiltersValidation(t *testing.T) {
+	qb := newTestQueryBuilder(utils.Configuration{DataDockID: "dd"}, nil).
+		Catalog("c").Schema("s").Table("events")
+	now := time.Now()
+
+	for name, invalid := range map[string]*QueryBuilder{
+		"operator": qb.WhereTime("created_at", "LIKE", now),
+		"zero":     qb.WhereTime("created_at", ">", time.Time{}),
+		"range":    qb.Between("created_at", now, now.Add(-time.Hour)),
+	} {
+		if _, err := invalid.Get(context.Background()); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// APIKeySpec describes an API key to create.
+type APIKeySpec struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes,omitempty"`
+	ExpiresInDays int      `json:"expires_in_days,omitempty"` // 0 for a key that never expires
+}
+
+// APIKey is an API key of an organization. The key itself is only returned when
+// it is created or rotated (see APIKeyCredentials).
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// APIKeyCredentials is an API key along with its secret value, returned once by
+// Create and Rotate.
+type APIKeyCredentials struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// APIKeysBuilder manages the API keys of an organization, through the control plane.
+// Available methods:
+//   - Create(ctx, spec) - Create an API key and return its secret value
+//   - List(ctx) - List the API keys, without their secret values
+//   - Rotate(ctx, id) - Replace a key by a new one with the same name and scopes
+//   - Delete(ctx, id) - Revoke an API key
+type APIKeysBuilder struct {
+	client builders.ClientInterface
+	orgID  string
+}
+
+// APIKeys returns a builder managing the API keys of this organization.
+func (o *OrgBuilder) APIKeys() *APIKeysBuilder {
+	return &APIKeysBuilder{client: o.Client, orgID: o.OrgID}
+}
+
+// Create creates an API key. The returned key cannot be retrieved later.
+//
+// Example:
+//
+//	creds, err := client.Org(orgID).APIKeys().Create(ctx, progressive.APIKeySpec{Name: "etl", ExpiresInDays: 90})
+func (k *APIKeysBuilder) Create(ctx context.Context, spec APIKeySpec) (*APIKeyCredentials, error) {
+	if spec.Name == "" {
+		return nil, fmt.Errorf("%w: API key name is required", utils.ErrInvalidRequest)
+	}
+	if spec.ExpiresInDays < 0 {
+		return nil, fmt.Errorf("%w: API key expiration must not be negative", utils.ErrInvalidRequest)
+	}
+
+	body, err := utils.EncodeBody(k.client.GetConfig(), spec)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := k.client.Do(ctx, "POST", k.endpoint(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds APIKeyCredentials
+	if err := utils.UnmarshalData(resp.Data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to decode API key: %w", err)
+	}
+	if creds.Key == "" {
+		return nil, fmt.Errorf("%w: no secret returned for API key %s", utils.ErrAPIError, creds.ID)
+	}
+	return &creds, nil
+}
+
+// List retrieves every API key of this organization.
+func (k *APIKeysBuilder) List(ctx context.Context) ([]APIKey, error) {
+	resp, err := k.client.Do(ctx, "GET", k.endpoint(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var keys []APIKey
+	if err := utils.UnmarshalData(resp.Data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to decode API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Rotate creates a new key with the name, scopes and validity period of an existing
+// one, then revokes the existing key. Deploy the new key before the tokens obtained
+// with the previous one expire.
+//
+// When the previous key cannot be revoked, the new key is returned along with the
+// error: it is valid, and the previous key should be deleted again.
+func (k *APIKeysBuilder) Rotate(ctx context.Context, keyID string) (*APIKeyCredentials, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("%w: API key ID is required", utils.ErrInvalidRequest)
+	}
+
+	keys, err := k.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var previous *APIKey
+	for i := range keys {
+		if keys[i].ID == keyID {
+			previous = &keys[i]
+			break
+		}
+	}
+	if previous == nil {
+		return nil, fmt.Errorf("%w: API key %s", utils.ErrNotFound, keyID)
+	}
+
+	spec := APIKeySpec{Name: previous.Name, Scopes: previous.Scopes}
+	if previous.ExpiresAt != nil {
+		validity := previous.ExpiresAt.Sub(previous.CreatedAt)
+		spec.ExpiresInDays = int((validity + 24*time.Hour - 1) / (24 * time.Hour))
+	}
+	creds, err := k.Create(ctx, spec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := k.Delete(ctx, keyID); err != nil {
+		return creds, fmt.Errorf("failed to revoke the previous API key %s: %w", keyID, err)
+	}
+	return creds, nil
+}
+
+// Delete revokes an API key.
+func (k *APIKeysBuilder) Delete(ctx context.Context, keyID string) (*utils.Response, error) {
+	if keyID == "" {
+		return nil, fmt.Errorf("%w: API key ID is required", utils.ErrInvalidRequest)
+	}
+	return k.client.Do(ctx, "DELETE", k.endpoint()+"/"+url.PathEscape(keyID), nil)
+}
+
+func (k *APIKeysBuilder) endpoint() string {
+	return fmt.Sprintf("%s/api/v1/organizations/%s/api-keys",
+		controlPlaneURL(k.client),
+		url.PathEscape(k.orgID),
+	)
+}
@@ -0,0 +1,63 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestAPIKeysBuilder(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"POST /api/v1/organizations/org-1/api-keys":      `{"id": "k2", "name": "etl", "key": "hf_s2", "key_prefix": "hf_s", "scopes": ["read"], "created_at": "2026-01-01T00:00:00Z"}`,
+		"GET /api/v1/organizations/org-1/api-keys":       `[{"id": "k1", "name": "etl", "key_prefix": "hf_s", "scopes": ["read"], "created_at": "2025-01-01T00:00:00Z", "expires_at": "2025-03-31T12:00:00Z"}]`,
+		"DELETE /api/v1/organizations/org-1/api-keys/k1": `{}`,
+	}}
+	keys := (&OrgBuilder{Client: client, OrgID: "org-1"}).APIKeys()
+	ctx := context.Background()
+
+	creds, err := keys.Create(ctx, APIKeySpec{Name: "etl", Scopes: []string{"read"}})
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if creds.ID != "k2" || creds.Key != "hf_s2" || len(creds.Scopes) != 1 {
+		t.Errorf("Unexpected credentials %+v", creds)
+	}
+
+	list, err := keys.List(ctx)
+	if err != nil || len(list) != 1 || list[0].KeyPrefix != "hf_s" || list[0].ExpiresAt == nil {
+		t.Errorf("List() = %+v, %v", list, err)
+	}
+
+	// Rotating creates a key with the same name, scopes and validity, then revokes the previous one
+	client.requests, client.bodies = nil, nil
+	creds, err = keys.Rotate(ctx, "k1")
+	if err != nil || creds.Key != "hf_s2" {
+		t.Fatalf("Rotate() = %+v, %v", creds, err)
+	}
+	if len(client.requests) != 3 || client.requests[2] != "https://api.test/api/v1/organizations/org-1/api-keys/k1" {
+		t.Errorf("Unexpected requests %v", client.requests)
+	}
+	if body := string(client.bodies[1]); body != `{"name":"etl","scopes":["read"],"expires_in_days":90}` {
+		t.Errorf("Unexpected rotation request %s", body)
+	}
+
+	if _, err := keys.Rotate(ctx, "missing"); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown key, got %v", err)
+	}
+	if _, err := keys.Create(ctx, APIKeySpec{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest without a name, got %v", err)
+	}
+}
+
+func TestAPIKeysBuilder_CreateWithoutSecret(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"POST /api/v1/organizations/org-1/api-keys": `{"id": "k1", "name": "etl"}`,
+	}}
+	keys := (&OrgBuilder{Client: client, OrgID: "org-1"}).APIKeys()
+
+	if _, err := keys.Create(context.Background(), APIKeySpec{Name: "etl"}); !errors.Is(err, utils.ErrAPIError) {
+		t.Errorf("Expected ErrAPIError when no secret is returned, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// AuditEntry is a platform audit event.
+type AuditEntry struct {
+	ID           string                 `json:"id"`
+	Timestamp    time.Time              `json:"timestamp"`
+	OrgID        string                 `json:"org_id,omitempty"`
+	Actor        string                 `json:"actor"`
+	ActorType    string                 `json:"actor_type,omitempty"` // e.g. "user", "service_account"
+	Action       string                 `json:"action"`               // e.g. "datadock.wake_up"
+	ResourceType string                 `json:"resource_type"`
+	ResourceID   string                 `json:"resource_id,omitempty"`
+	Outcome      string                 `json:"outcome,omitempty"` // e.g. "success", "denied"
+	IPAddress    string                 `json:"ip_address,omitempty"`
+	Details      map[string]interface{} `json:"details,omitempty"`
+}
+
+// AuditFilter restricts the audit events returned by AuditLogs.
+// All fields are optional; zero times leave the range open.
+type AuditFilter struct {
+	From         time.Time
+	To           time.Time
+	Actor        string
+	Action       string
+	ResourceType string
+
+	PageSize int // Events fetched per request (default 100)
+	MaxItems int // Stop after this many events (0 = all)
+}
+
+func (f AuditFilter) params() url.Values {
+	params := url.Values{}
+	if !f.From.IsZero() {
+		params.Set("from", f.From.UTC().Format(time.RFC3339))
+	}
+	if !f.To.IsZero() {
+		params.Set("to", f.To.UTC().Format(time.RFC3339))
+	}
+	if f.Actor != "" {
+		params.Set("actor", f.Actor)
+	}
+	if f.Action != "" {
+		params.Set("action", f.Action)
+	}
+	if f.ResourceType != "" {
+		params.Set("resource_type", f.ResourceType)
+	}
+	return params
+}
+
+// AuditLogs retrieves the audit events of this organization matching the filter,
+// following pagination until every event (or MaxItems) has been fetched.
+func (o *OrgBuilder) AuditLogs(ctx context.Context, filter AuditFilter) ([]AuditEntry, error) {
+	endpoint := fmt.Sprintf("%s/%s/audit-logs",
+		o.Client.GetConfig().BaseURL,
+		url.PathEscape(o.OrgID),
+	)
+	return listAll[AuditEntry](ctx, o.Client, endpoint, "audit_logs", filter.params(), filter.PageSize, filter.MaxItems)
+}
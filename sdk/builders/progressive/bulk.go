@@ -0,0 +1,128 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// defaultBulkConcurrency bounds the datadocks processed at once by bulk operations.
+const defaultBulkConcurrency = 4
+
+// BulkOptions selects the datadocks of a bulk operation and bounds its concurrency.
+type BulkOptions struct {
+	Filter      ListOptions // Datadocks to process (default: all of the organization)
+	Concurrency int         // Datadocks processed at once (default 4)
+}
+
+// DataDockResult is the outcome of a bulk operation on one datadock.
+type DataDockResult struct {
+	DataDock DataDock
+	Response *utils.Response
+	Err      error
+}
+
+// BulkError reports the datadocks a bulk operation failed on. errors.Is and
+// errors.As see through it to the error of each datadock.
+type BulkError struct {
+	Failed []DataDockResult
+	Total  int // Datadocks processed
+}
+
+func (e *BulkError) Error() string {
+	messages := make([]string, 0, len(e.Failed))
+	for _, result := range e.Failed {
+		messages = append(messages, fmt.Sprintf("%s: %v", result.DataDock.ID, result.Err))
+	}
+	return fmt.Sprintf("%d of %d datadocks failed: %s", len(e.Failed), e.Total, strings.Join(messages, "; "))
+}
+
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, result := range e.Failed {
+		errs = append(errs, result.Err)
+	}
+	return errs
+}
+
+// ForEachDataDock runs fn on every datadock of the organization matching
+// opts.Filter, at most opts.Concurrency at a time. A failing datadock does not stop
+// the others: the results are returned in listing order, along with a *BulkError
+// listing the failures.
+//
+// Example:
+//
+//	results, err := client.Org(orgID).ForEachDataDock(ctx, progressive.BulkOptions{Concurrency: 8},
+//	    func(ctx context.Context, dock *progressive.DataDockBuilder) (*utils.Response, error) {
+//	        return nil, dock.WakeUpAndWait(ctx)
+//	    })
+func (o *OrgBuilder) ForEachDataDock(ctx context.Context, opts BulkOptions, fn func(ctx context.Context, dock *DataDockBuilder) (*utils.Response, error)) ([]DataDockResult, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBulkConcurrency
+	}
+
+	dataDocks, err := o.DataDocks(ctx, opts.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list datadocks: %w", err)
+	}
+
+	results := make([]DataDockResult, len(dataDocks))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, dataDock := range dataDocks {
+		results[i].DataDock = dataDock
+		wg.Add(1)
+		go func(result *DataDockResult) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				result.Err = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+
+			dock := o.Harbor(result.DataDock.HarborID).DataDock(result.DataDock.ID)
+			result.Response, result.Err = fn(ctx, dock)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	bulkErr := &BulkError{Total: len(results)}
+	for _, result := range results {
+		if result.Err != nil {
+			bulkErr.Failed = append(bulkErr.Failed, result)
+		}
+	}
+	if len(bulkErr.Failed) > 0 {
+		return results, bulkErr
+	}
+	return results, nil
+}
+
+// SleepAll puts the matching datadocks of the organization to sleep.
+func (o *OrgBuilder) SleepAll(ctx context.Context, opts BulkOptions) ([]DataDockResult, error) {
+	return o.ForEachDataDock(ctx, opts, func(ctx context.Context, dock *DataDockBuilder) (*utils.Response, error) {
+		return dock.Sleep(ctx)
+	})
+}
+
+// WakeAll wakes the matching datadocks of the organization up, without waiting
+// for them to be online.
+func (o *OrgBuilder) WakeAll(ctx context.Context, opts BulkOptions) ([]DataDockResult, error) {
+	return o.ForEachDataDock(ctx, opts, func(ctx context.Context, dock *DataDockBuilder) (*utils.Response, error) {
+		return dock.WakeUp(ctx)
+	})
+}
+
+// RefreshAll refreshes the catalog metadata of the matching datadocks of the
+// organization, reporting the outcome per datadock (unlike RefreshAllDataDocks).
+func (o *OrgBuilder) RefreshAll(ctx context.Context, opts BulkOptions) ([]DataDockResult, error) {
+	return o.ForEachDataDock(ctx, opts, func(ctx context.Context, dock *DataDockBuilder) (*utils.Response, error) {
+		return dock.RefreshCatalog(ctx)
+	})
+}
@@ -0,0 +1,59 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestOrgBuilder_SleepAll(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /org-1/data-docks":      `[{"id": "dd1", "harbor_id": "h1"}, {"id": "dd2", "harbor_id": "h1"}, {"id": "dd3", "harbor_id": "h2"}]`,
+		"POST /data-docks/dd1/sleep": `{"status": "sleeping"}`,
+		"POST /data-docks/dd3/sleep": `{"status": "sleeping"}`,
+	}}
+	org := &OrgBuilder{Client: client, OrgID: "org-1"}
+
+	results, err := org.SleepAll(context.Background(), BulkOptions{Concurrency: 2})
+
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("SleepAll() error = %v, want a *BulkError", err)
+	}
+	if bulkErr.Total != 3 || len(bulkErr.Failed) != 1 || bulkErr.Failed[0].DataDock.ID != "dd2" {
+		t.Errorf("unexpected failures: %v", bulkErr)
+	}
+	if !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("errors.Is(err, ErrNotFound) = false, want the error of dd2 to be visible")
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	for i, id := range []string{"dd1", "dd2", "dd3"} {
+		if results[i].DataDock.ID != id {
+			t.Errorf("results[%d] is %s, want %s (listing order)", i, results[i].DataDock.ID, id)
+		}
+	}
+	if results[0].Err != nil || results[0].Response == nil || results[2].Err != nil {
+		t.Errorf("dd1 and dd3 should have succeeded: %+v", results)
+	}
+}
+
+func TestOrgBuilder_ForEachDataDockAllSucceed(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /org-1/data-docks":                `[{"id": "dd1"}, {"id": "dd2"}]`,
+		"POST /data-docks/dd1/catalog/refresh": `{}`,
+		"POST /data-docks/dd2/catalog/refresh": `{}`,
+	}}
+
+	results, err := (&OrgBuilder{Client: client, OrgID: "org-1"}).RefreshAll(context.Background(), BulkOptions{})
+	if err != nil {
+		t.Fatalf("RefreshAll() unexpected error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Errorf("expected 2 results, got %d", len(results))
+	}
+}
@@ -12,6 +12,9 @@ import (
 // Available methods:
 //   - Schema(name) - Navigate to a specific schema
 //   - ListSchemas(ctx) - List all schemas in this catalog
+//   - ListSchemasDetailed(ctx) - List schemas with their comment, table count and refresh time
+//   - Exists(ctx) - Check that this catalog exists
+//   - GetMetadata(ctx) / SetMetadata(ctx, fields) - Read and update the description, owner and tags
 type CatalogBuilder struct {
 	client      builders.ClientInterface
 	orgID       string
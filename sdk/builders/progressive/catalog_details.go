@@ -0,0 +1,132 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// SchemaInfo describes a schema as reported by the catalog metadata.
+// Fields the catalog endpoint does not provide are left zero.
+type SchemaInfo struct {
+	Name          string    `json:"name"`
+	Comment       string    `json:"comment,omitempty"`
+	TableCount    int       `json:"table_count"`
+	LastRefreshed time.Time `json:"last_refreshed,omitzero"` // Last catalog refresh
+}
+
+// TableInfo describes a table as reported by the catalog metadata.
+// Fields the catalog endpoint does not provide are left zero.
+type TableInfo struct {
+	Name          string    `json:"name"`
+	Type          string    `json:"type,omitempty"` // e.g. "TABLE", "VIEW"
+	Comment       string    `json:"comment,omitempty"`
+	ColumnCount   int       `json:"column_count"`
+	RowEstimate   int64     `json:"row_estimate,omitempty"`  // Planner estimate, 0 when unknown
+	LastRefreshed time.Time `json:"last_refreshed,omitzero"` // Last catalog refresh
+}
+
+// ListSchemasDetailed is like ListSchemas but returns the comment, table count and
+// last refresh time of each schema.
+func (c *CatalogBuilder) ListSchemasDetailed(ctx context.Context) ([]SchemaInfo, error) {
+	catalog, err := fetchCatalogEntry(ctx, c.client, c.dataDockID, c.catalogName)
+	if err != nil || catalog == nil {
+		return nil, err
+	}
+
+	refreshed := refreshTime(catalog, time.Time{})
+	var schemas []SchemaInfo
+	for _, sch := range extractItems(catalog["schemas"]) {
+		schemas = append(schemas, SchemaInfo{
+			Name:          stringField(sch, "schema_name", "name"),
+			Comment:       stringField(sch, "comment", "description"),
+			TableCount:    len(extractItems(sch["tables"])),
+			LastRefreshed: refreshTime(sch, refreshed),
+		})
+	}
+	return schemas, nil
+}
+
+// ListTablesDetailed is like ListTables but returns the type, comment, column
+// count, row estimate and last refresh time of each table.
+func (s *SchemaBuilder) ListTablesDetailed(ctx context.Context) ([]TableInfo, error) {
+	catalog, err := fetchCatalogEntry(ctx, s.client, s.dataDockID, s.catalogName)
+	if err != nil || catalog == nil {
+		return nil, err
+	}
+
+	refreshed := refreshTime(catalog, time.Time{})
+	var tables []TableInfo
+	for _, sch := range extractItems(catalog["schemas"]) {
+		if sch["schema_name"] != s.schemaName {
+			continue
+		}
+		schemaRefreshed := refreshTime(sch, refreshed)
+		for _, tbl := range extractItems(sch["tables"]) {
+			columnCount, ok := numberField(tbl, "column_count")
+			if !ok {
+				columnCount = float64(len(extractItems(tbl["columns"])))
+			}
+			rowEstimate, _ := numberField(tbl, "row_estimate", "estimated_rows", "row_count")
+			tables = append(tables, TableInfo{
+				Name:          stringField(tbl, "table_name", "name"),
+				Type:          stringField(tbl, "table_type", "type"),
+				Comment:       stringField(tbl, "comment", "description"),
+				ColumnCount:   int(columnCount),
+				RowEstimate:   int64(rowEstimate),
+				LastRefreshed: refreshTime(tbl, schemaRefreshed),
+			})
+		}
+	}
+	return tables, nil
+}
+
+// fetchCatalogEntry fetches the catalog metadata of a datadock and returns the
+// entry of the named catalog, or nil when the datadock has no such catalog.
+func fetchCatalogEntry(ctx context.Context, client builders.ClientInterface, dataDockID, catalogName string) (map[string]interface{}, error) {
+	endpoint := fmt.Sprintf("%s/data-docks/%s/catalog",
+		client.GetConfig().BaseURL,
+		url.PathEscape(dataDockID),
+	)
+
+	resp, err := client.Do(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cat := range extractItems(resp.Data, "catalogs") {
+		if cat["catalog_name"] == catalogName {
+			return cat, nil
+		}
+	}
+	return nil, nil
+}
+
+// numberField returns the first numeric value found under the given keys.
+func numberField(m map[string]interface{}, keys ...string) (float64, bool) {
+	for _, key := range keys {
+		if value, ok := utils.Float64(m[key]); ok {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+// refreshTime returns the last refresh time of a catalog entry, or inherited when
+// the entry has none.
+func refreshTime(m map[string]interface{}, inherited time.Time) time.Time {
+	value := stringField(m, "last_refreshed_at", "refreshed_at", "last_refresh", "updated_at")
+	if value == "" {
+		return inherited
+	}
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return inherited
+}
@@ -0,0 +1,50 @@
+package progressive
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCatalogDetailedListings(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /data-docks/dd1/catalog": `{"catalogs": [{"catalog_name": "sales", "last_refreshed_at": "2025-01-02T03:04:05Z", "schemas": [
+			{"schema_name": "public", "comment": "Main schema", "tables": [
+				{"table_name": "orders", "table_type": "TABLE", "comment": "Customer orders", "column_count": 12, "estimated_rows": 1500000},
+				{"table_name": "open_orders", "table_type": "VIEW", "columns": [{"name": "id"}, {"name": "total"}], "last_refreshed_at": "2025-02-01T00:00:00Z"}
+			]},
+			{"schema_name": "staging", "tables": []}
+		]}]}`,
+	}}
+	catalog := &CatalogBuilder{client: client, orgID: "org-1", dataDockID: "dd1", catalogName: "sales"}
+	catalogRefresh := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	schemas, err := catalog.ListSchemasDetailed(context.Background())
+	if err != nil {
+		t.Fatalf("ListSchemasDetailed() unexpected error = %v", err)
+	}
+	want := []SchemaInfo{
+		{Name: "public", Comment: "Main schema", TableCount: 2, LastRefreshed: catalogRefresh},
+		{Name: "staging", LastRefreshed: catalogRefresh},
+	}
+	if len(schemas) != 2 || schemas[0] != want[0] || schemas[1] != want[1] {
+		t.Errorf("ListSchemasDetailed() = %+v, want %+v", schemas, want)
+	}
+
+	tables, err := catalog.Schema("public").ListTablesDetailed(context.Background())
+	if err != nil {
+		t.Fatalf("ListTablesDetailed() unexpected error = %v", err)
+	}
+	wantTables := []TableInfo{
+		{Name: "orders", Type: "TABLE", Comment: "Customer orders", ColumnCount: 12, RowEstimate: 1500000, LastRefreshed: catalogRefresh},
+		{Name: "open_orders", Type: "VIEW", ColumnCount: 2, LastRefreshed: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	if len(tables) != 2 || tables[0] != wantTables[0] || tables[1] != wantTables[1] {
+		t.Errorf("ListTablesDetailed() = %+v, want %+v", tables, wantTables)
+	}
+
+	missing, err := (&CatalogBuilder{client: client, dataDockID: "dd1", catalogName: "hr"}).ListSchemasDetailed(context.Background())
+	if err != nil || missing != nil {
+		t.Errorf("Expected no schemas for an unknown catalog, got %v, %v", missing, err)
+	}
+}
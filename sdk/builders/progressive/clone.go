@@ -0,0 +1,83 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// CloneTo creates a copy of this datadock in a harbor, e.g. to stamp out a
+// staging environment from production. The configuration is copied by the
+// platform, connector secrets included, so they never transit through the caller;
+// the data is not copied. overrides replaces fields of the copied configuration
+// ("name" at least, when cloning in the same harbor).
+//
+// Example:
+//
+//	staging, err := prod.CloneTo(ctx, stagingHarborID, map[string]any{"name": "warehouse-staging"})
+func (d *DataDockBuilder) CloneTo(ctx context.Context, harborID string, overrides map[string]any) (*DataDock, error) {
+	if harborID == "" {
+		return nil, fmt.Errorf("%w: target harbor ID is required", utils.ErrInvalidRequest)
+	}
+	if harborID == d.harborID && overrides["name"] == nil {
+		return nil, fmt.Errorf("%w: a clone in the same harbor needs a new name", utils.ErrInvalidRequest)
+	}
+
+	endpoint := fmt.Sprintf("%s/data-docks/%s/clone",
+		d.client.GetConfig().BaseURL,
+		url.PathEscape(d.dataDockID),
+	)
+	body, err := utils.EncodeBody(d.client.GetConfig(), map[string]any{"harbor_id": harborID, "overrides": overrides})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.client.Do(ctx, "POST", endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCreatedDataDock(resp)
+}
+
+// CreateDataDockFromTemplate creates a datadock from a template of this
+// organization. vars fill the variables of the template, such as "harbor_id" and
+// "name"; the secrets referenced by the template are resolved by the platform.
+//
+// Example:
+//
+//	dock, err := client.Org(orgID).CreateDataDockFromTemplate(ctx, "postgres-replica", map[string]string{
+//	    "harbor_id": stagingHarborID,
+//	    "name":      "replica-staging",
+//	})
+func (o *OrgBuilder) CreateDataDockFromTemplate(ctx context.Context, templateName string, vars map[string]string) (*DataDock, error) {
+	if templateName == "" {
+		return nil, fmt.Errorf("%w: template name is required", utils.ErrInvalidRequest)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/data-dock-templates/%s/instantiate",
+		o.Client.GetConfig().BaseURL,
+		url.PathEscape(o.OrgID),
+		url.PathEscape(templateName),
+	)
+	body, err := utils.EncodeBody(o.Client.GetConfig(), map[string]any{"variables": vars})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := o.Client.Do(ctx, "POST", endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCreatedDataDock(resp)
+}
+
+func decodeCreatedDataDock(resp *utils.Response) (*DataDock, error) {
+	var dataDock DataDock
+	if err := utils.UnmarshalData(resp.Data, &dataDock); err != nil {
+		return nil, fmt.Errorf("failed to decode datadock: %w", err)
+	}
+	if dataDock.ID == "" {
+		return nil, fmt.Errorf("%w: no datadock ID returned", utils.ErrAPIError)
+	}
+	return &dataDock, nil
+}
@@ -0,0 +1,52 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestDataDockBuilder_CloneTo(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"POST /data-docks/dd1/clone": `{"id": "dd2", "name": "warehouse-staging", "harbor_id": "h2"}`,
+	}}
+	prod := (&HarborBuilder{client: client, harborID: "h1"}).DataDock("dd1")
+	ctx := context.Background()
+
+	clone, err := prod.CloneTo(ctx, "h2", nil)
+	if err != nil {
+		t.Fatalf("CloneTo() unexpected error = %v", err)
+	}
+	if clone.ID != "dd2" || clone.HarborID != "h2" {
+		t.Errorf("Unexpected clone %+v", clone)
+	}
+
+	if _, err := prod.CloneTo(ctx, "h1", nil); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for a clone in the same harbor without a name, got %v", err)
+	}
+	if _, err := prod.CloneTo(ctx, "", nil); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest without a harbor, got %v", err)
+	}
+	if len(client.requests) != 1 {
+		t.Errorf("Expected invalid clones to be rejected before any request, got %v", client.requests)
+	}
+}
+
+func TestOrgBuilder_CreateDataDockFromTemplate(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"POST /org-1/data-dock-templates/postgres%20replica/instantiate": `{"id": "dd3", "name": "replica"}`,
+		"POST /org-1/data-dock-templates/broken/instantiate":             `{"status": "accepted"}`,
+	}}
+	org := &OrgBuilder{Client: client, OrgID: "org-1"}
+	ctx := context.Background()
+
+	dock, err := org.CreateDataDockFromTemplate(ctx, "postgres replica", map[string]string{"harbor_id": "h2", "name": "replica"})
+	if err != nil || dock.ID != "dd3" {
+		t.Fatalf("CreateDataDockFromTemplate() = %+v, %v", dock, err)
+	}
+	if _, err := org.CreateDataDockFromTemplate(ctx, "broken", nil); !errors.Is(err, utils.ErrAPIError) {
+		t.Errorf("Expected ErrAPIError without a datadock ID, got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Datadock kinds reported in ConnectionInfo.Kind.
+const (
+	DataDockKindTrino  = "TrinoInternal"
+	DataDockKindCephS3 = "CephRgwInternal"
+)
+
+// ConnectionInfo holds the endpoints of the engines behind a datadock, for
+// handing the connection off to BI tools and other clients. Engines the datadock
+// does not expose are nil.
+type ConnectionInfo struct {
+	DataDockID string
+	Kind       string // DataDockKindTrino or DataDockKindCephS3
+
+	Trino    *TrinoConnection
+	Postgres *PostgresConnection
+	MinIO    *MinIOConnection
+
+	// RESTURL is the base URL of the OpenAPI layer, GraphQLURL the GraphQL endpoint of the datadock.
+	RESTURL    string
+	GraphQLURL string
+}
+
+// TrinoConnection is the Trino coordinator of a datadock.
+type TrinoConnection struct {
+	Host string
+	Port int
+}
+
+// HTTPURL returns the base URL of the Trino REST protocol.
+func (t TrinoConnection) HTTPURL() string {
+	return "https://" + net.JoinHostPort(t.Host, strconv.Itoa(t.Port))
+}
+
+// JDBCURL returns the JDBC URL of the Trino driver, e.g. for DBeaver or Tableau.
+func (t TrinoConnection) JDBCURL() string {
+	return "jdbc:trino://" + net.JoinHostPort(t.Host, strconv.Itoa(t.Port)) + "?SSL=true"
+}
+
+// PostgresConnection is the PostgreSQL wire-protocol endpoint serving a datadock.
+// Clients authenticate with a Hyperfluid access token as password.
+type PostgresConnection struct {
+	Host     string
+	Port     int
+	Database string // The datadock ID
+}
+
+// DSN returns a libpq connection URL without credentials.
+func (p PostgresConnection) DSN() string {
+	return (&url.URL{
+		Scheme:   "postgres",
+		Host:     net.JoinHostPort(p.Host, strconv.Itoa(p.Port)),
+		Path:     "/" + p.Database,
+		RawQuery: "sslmode=require",
+	}).String()
+}
+
+// MinIOConnection is the S3-compatible endpoint of a datadock.
+type MinIOConnection struct {
+	Endpoint string // host:port
+	UseSSL   bool
+	Region   string
+}
+
+// ConnectionInfo assembles the connection details of the datadock from its
+// control plane metadata and the Bifrost endpoints of the platform.
+//
+// Example:
+//
+//	info, err := client.Org(orgID).Harbor(harborID).DataDock(dataDockID).ConnectionInfo(ctx)
+//	if info.Trino != nil {
+//	    fmt.Println(info.Trino.JDBCURL())
+//	}
+func (d *DataDockBuilder) ConnectionInfo(ctx context.Context) (*ConnectionInfo, error) {
+	cfg := d.client.GetConfig()
+	// The metadata is decoded here, not handed to the caller
+	ctx = utils.ContextWithoutTransformers(ctx)
+	resp, err := d.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var details struct {
+		Host           string `json:"host"`
+		Port           int    `json:"port"`
+		ConnectionKind struct {
+			Type string `json:"type"`
+		} `json:"connection_kind"`
+	}
+	if err := utils.UnmarshalData(resp.Data, &details); err != nil {
+		return nil, fmt.Errorf("failed to decode datadock: %w", err)
+	}
+
+	info := &ConnectionInfo{
+		DataDockID: d.dataDockID,
+		Kind:       details.ConnectionKind.Type,
+		RESTURL:    cfg.BaseURL,
+	}
+	switch info.Kind {
+	case DataDockKindTrino:
+		info.Trino = &TrinoConnection{Host: details.Host, Port: details.Port}
+	case DataDockKindCephS3:
+		info.MinIO = &MinIOConnection{
+			Endpoint: net.JoinHostPort(details.Host, strconv.Itoa(details.Port)),
+			UseSSL:   true,
+			Region:   cfg.MinIORegion,
+		}
+	}
+	if info.MinIO == nil && cfg.MinIOEndpoint != "" {
+		info.MinIO = &MinIOConnection{Endpoint: cfg.MinIOEndpoint, UseSSL: cfg.MinIOUseSSL != "false", Region: cfg.MinIORegion}
+	}
+
+	if err := d.addBifrostEndpoints(ctx, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// controlPlaneURL returns the base URL of the control plane API: ControlPlaneURL,
+// or BaseURL when both are served together.
+func controlPlaneURL(client builders.ClientInterface) string {
+	cfg := client.GetConfig()
+	if cfg.ControlPlaneURL != "" {
+		return strings.TrimSuffix(cfg.ControlPlaneURL, "/")
+	}
+	return strings.TrimSuffix(cfg.BaseURL, "/")
+}
+
+// addBifrostEndpoints fills the REST, GraphQL and pgwire endpoints of the platform.
+// Platforms without the Bifrost info endpoint keep the configured BaseURL only.
+func (d *DataDockBuilder) addBifrostEndpoints(ctx context.Context, info *ConnectionInfo) error {
+	resp, err := d.client.Do(ctx, "GET", controlPlaneURL(d.client)+"/api/v1/bifrost/info", nil)
+	if errors.Is(err, utils.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var bifrost struct {
+		RESTURL            string `json:"rest_url"`
+		PgwireEndpoint     string `json:"pgwire_endpoint"`
+		GraphQLURLTemplate string `json:"graphql_url_template"`
+	}
+	if err := utils.UnmarshalData(resp.Data, &bifrost); err != nil {
+		return fmt.Errorf("failed to decode Bifrost endpoints: %w", err)
+	}
+
+	if bifrost.RESTURL != "" {
+		info.RESTURL = bifrost.RESTURL
+	}
+	if bifrost.GraphQLURLTemplate != "" {
+		info.GraphQLURL = strings.ReplaceAll(bifrost.GraphQLURLTemplate, "{data_dock_id}", d.dataDockID)
+	}
+	if bifrost.PgwireEndpoint != "" {
+		host, rawPort, err := net.SplitHostPort(bifrost.PgwireEndpoint)
+		port, portErr := strconv.Atoi(rawPort)
+		if err != nil || portErr != nil {
+			return fmt.Errorf("%w: invalid pgwire endpoint %q", utils.ErrAPIError, bifrost.PgwireEndpoint)
+		}
+		info.Postgres = &PostgresConnection{Host: host, Port: port, Database: d.dataDockID}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package progressive
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDataDockBuilder_ConnectionInfo(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /data-docks/dd1": `{"id": "dd1", "host": "trino.dd1.example.com", "port": 443,
+			"connection_kind": {"type": "TrinoInternal", "content": {"worker_replicas": 2}}}`,
+		"GET /api/v1/bifrost/info": `{"rest_url": "https://bifrost.example.com", "pgwire_endpoint": "pg.example.com:5432",
+			"graphql_url_template": "https://bifrost.example.com/{data_dock_id}/graphql"}`,
+	}}
+
+	info, err := (&OrgBuilder{Client: client, OrgID: "org-1"}).Harbor("h1").DataDock("dd1").ConnectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("ConnectionInfo() unexpected error = %v", err)
+	}
+	if info.Kind != DataDockKindTrino || info.Trino == nil || info.MinIO != nil {
+		t.Fatalf("unexpected connection info: %+v", info)
+	}
+	if got := info.Trino.JDBCURL(); got != "jdbc:trino://trino.dd1.example.com:443?SSL=true" {
+		t.Errorf("JDBCURL() = %q", got)
+	}
+	if got := info.Trino.HTTPURL(); got != "https://trino.dd1.example.com:443" {
+		t.Errorf("HTTPURL() = %q", got)
+	}
+	if info.Postgres == nil || info.Postgres.DSN() != "postgres://pg.example.com:5432/dd1?sslmode=require" {
+		t.Errorf("unexpected PostgreSQL connection: %+v", info.Postgres)
+	}
+	if info.RESTURL != "https://bifrost.example.com" || info.GraphQLURL != "https://bifrost.example.com/dd1/graphql" {
+		t.Errorf("unexpected Bifrost endpoints: %s %s", info.RESTURL, info.GraphQLURL)
+	}
+}
+
+func TestDataDockBuilder_ConnectionInfoObjectStore(t *testing.T) {
+	// A platform without the Bifrost info endpoint keeps the configured base URL
+	client := &fakeClient{responses: map[string]string{
+		"GET /data-docks/dd2": `{"id": "dd2", "host": "s3.example.com", "port": 443, "connection_kind": {"type": "CephRgwInternal"}}`,
+	}}
+
+	info, err := (&OrgBuilder{Client: client, OrgID: "org-1"}).Harbor("h1").DataDock("dd2").ConnectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("ConnectionInfo() unexpected error = %v", err)
+	}
+	if info.MinIO == nil || info.MinIO.Endpoint != "s3.example.com:443" || !info.MinIO.UseSSL {
+		t.Errorf("unexpected MinIO connection: %+v", info.MinIO)
+	}
+	if info.Trino != nil || info.Postgres != nil || info.RESTURL != "https://api.test" {
+		t.Errorf("unexpected connection info: %+v", info)
+	}
+}
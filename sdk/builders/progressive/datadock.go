@@ -15,10 +15,17 @@ import (
 //   - GetCatalog(ctx) - Get the full catalog metadata
 //   - RefreshCatalog(ctx) - Trigger catalog introspection
 //   - WakeUp(ctx) - Bring datadock online
+//   - WakeUpAndWait(ctx) - Bring datadock online and wait until it is ready
+//   - WaitForStatus(ctx, status, opts) - Wait until the datadock reports a status
 //   - Sleep(ctx) - Put datadock to sleep
+//   - GetSchedule(ctx) / SetSchedule(ctx, schedule) - Manage automatic wake-up / sleep windows
 //   - Get(ctx) - Get datadock details
 //   - Update(ctx, config) - Update datadock configuration
 //   - Delete(ctx) - Delete this datadock
+//   - CloneTo(ctx, harborID, overrides) - Copy this datadock's configuration into a new datadock
+//   - ConnectionInfo(ctx) - Trino, PostgreSQL and MinIO endpoints for external tools
+//   - SearchIndexes(ctx) / CreateSearchIndex(ctx, spec) / DeleteSearchIndex(ctx, id) - Manage search indexes
+//   - Views() - Manage and run saved queries
 type DataDockBuilder struct {
 	client     builders.ClientInterface
 	orgID      string
@@ -63,6 +70,18 @@ func (d *DataDockBuilder) WakeUp(ctx context.Context) (*utils.Response, error) {
 	return d.client.Do(ctx, "POST", endpoint, nil)
 }
 
+// WakeUpAndWait brings the datadock online and waits until it reports the Online status.
+// Waiting is bounded by Configuration.WakeUpTimeout (default 5 minutes) and ctx.
+func (d *DataDockBuilder) WakeUpAndWait(ctx context.Context) error {
+	return builders.WakeUpAndWait(ctx, d.client, d.dataDockID)
+}
+
+// WaitForStatus polls the datadock until it reports the given status (e.g. "Sleeping")
+// and returns its last details.
+func (d *DataDockBuilder) WaitForStatus(ctx context.Context, status string, opts utils.PollOptions) (map[string]any, error) {
+	return builders.WaitForDataDockStatus(ctx, d.client, d.dataDockID, status, opts)
+}
+
 // Sleep puts the datadock to sleep (cost optimization).
 func (d *DataDockBuilder) Sleep(ctx context.Context) (*utils.Response, error) {
 	endpoint := fmt.Sprintf("%s/data-docks/%s/sleep",
@@ -87,7 +106,10 @@ func (d *DataDockBuilder) Update(ctx context.Context, config map[string]interfac
 		d.client.GetConfig().BaseURL,
 		url.PathEscape(d.dataDockID),
 	)
-	body := utils.JsonMarshal(config)
+	body, err := utils.EncodeBody(d.client.GetConfig(), config)
+	if err != nil {
+		return nil, err
+	}
 	return d.client.Do(ctx, "PATCH", endpoint, body)
 }
 
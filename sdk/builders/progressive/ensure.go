@@ -0,0 +1,141 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// DataDockSpec describes a datadock to provision with EnsureDataDock.
+type DataDockSpec struct {
+	Name   string                 // Name identifying the datadock in its harbor (required)
+	Type   string                 // Datadock type (e.g. "TrinoInternal")
+	Config map[string]interface{} // Other creation fields, sent as-is
+}
+
+// body returns the creation payload of the spec.
+func (s DataDockSpec) body(harborID string) map[string]interface{} {
+	body := make(map[string]interface{}, len(s.Config)+3)
+	for key, value := range s.Config {
+		body[key] = value
+	}
+	body["name"] = s.Name
+	if s.Type != "" {
+		body["type"] = s.Type
+	}
+	body["harbor_id"] = harborID
+	return body
+}
+
+// EnsureHarbor returns the harbor of this organization named name, creating it if
+// it does not exist. created reports whether the harbor was created, so that
+// provisioning scripts can be re-run safely.
+//
+// Example:
+//
+//	harbor, created, err := client.Org(orgID).EnsureHarbor(ctx, "analytics")
+func (o *OrgBuilder) EnsureHarbor(ctx context.Context, name string) (harbor Harbor, created bool, err error) {
+	if name == "" {
+		return Harbor{}, false, fmt.Errorf("%w: harbor name is required", utils.ErrInvalidRequest)
+	}
+	find := func() (Harbor, bool, error) {
+		harbors, err := o.Harbors(ctx, ListOptions{Name: name})
+		if err != nil {
+			return Harbor{}, false, fmt.Errorf("failed to look up harbor %q: %w", name, err)
+		}
+		return findByName(harbors, name, "harbor", func(h Harbor) string { return h.Name })
+	}
+
+	if harbor, found, err := find(); err != nil || found {
+		return harbor, false, err
+	}
+
+	resp, err := o.CreateHarbor(ctx, name)
+	if err != nil {
+		if resp != nil && resp.HTTPCode == http.StatusConflict {
+			// Created concurrently by someone else
+			harbor, found, findErr := find()
+			if findErr == nil && found {
+				return harbor, false, nil
+			}
+		}
+		return Harbor{}, false, fmt.Errorf("failed to create harbor %q: %w", name, err)
+	}
+	if err := utils.UnmarshalData(resp.Data, &harbor); err != nil || harbor.ID == "" {
+		// The creation response does not describe the harbor: read it back
+		harbor, found, err := find()
+		if err == nil && !found {
+			err = fmt.Errorf("%w: harbor %q not found after creation", utils.ErrNotFound, name)
+		}
+		return harbor, true, err
+	}
+	return harbor, true, nil
+}
+
+// EnsureDataDock returns the datadock of this harbor named spec.Name, creating it
+// from spec if it does not exist. An existing datadock is returned as-is, even if
+// its configuration differs from spec. created reports whether the datadock was created.
+//
+// Example:
+//
+//	dock, created, err := harbor.EnsureDataDock(ctx, progressive.DataDockSpec{
+//	    Name: "warehouse",
+//	    Type: "TrinoInternal",
+//	})
+func (h *HarborBuilder) EnsureDataDock(ctx context.Context, spec DataDockSpec) (dataDock DataDock, created bool, err error) {
+	if spec.Name == "" {
+		return DataDock{}, false, fmt.Errorf("%w: datadock name is required", utils.ErrInvalidRequest)
+	}
+	find := func() (DataDock, bool, error) {
+		dataDocks, err := h.DataDocks(ctx, ListOptions{Name: spec.Name})
+		if err != nil {
+			return DataDock{}, false, fmt.Errorf("failed to look up datadock %q: %w", spec.Name, err)
+		}
+		return findByName(dataDocks, spec.Name, "datadock", func(d DataDock) string { return d.Name })
+	}
+
+	if dataDock, found, err := find(); err != nil || found {
+		return dataDock, false, err
+	}
+
+	resp, err := h.CreateDataDock(ctx, spec.body(h.harborID))
+	if err != nil {
+		if resp != nil && resp.HTTPCode == http.StatusConflict {
+			// Created concurrently by someone else
+			dataDock, found, findErr := find()
+			if findErr == nil && found {
+				return dataDock, false, nil
+			}
+		}
+		return DataDock{}, false, fmt.Errorf("failed to create datadock %q: %w", spec.Name, err)
+	}
+	if err := utils.UnmarshalData(resp.Data, &dataDock); err != nil || dataDock.ID == "" {
+		// The creation response does not describe the datadock: read it back
+		dataDock, found, err := find()
+		if err == nil && !found {
+			err = fmt.Errorf("%w: datadock %q not found after creation", utils.ErrNotFound, spec.Name)
+		}
+		return dataDock, true, err
+	}
+	return dataDock, true, nil
+}
+
+// findByName returns the item named exactly name. The server-side name filter may
+// match partially, so the names are compared again; several exact matches are an error.
+func findByName[T any](items []T, name, kind string, nameOf func(T) string) (T, bool, error) {
+	var match T
+	found := false
+	for _, item := range items {
+		if nameOf(item) != name {
+			continue
+		}
+		if found {
+			var zero T
+			return zero, false, fmt.Errorf("%w: several %ss are named %q", utils.ErrInvalidRequest, kind, name)
+		}
+		match, found = item, true
+	}
+	return match, found, nil
+}
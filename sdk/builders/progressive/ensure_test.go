@@ -0,0 +1,71 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestOrgBuilder_EnsureHarbor(t *testing.T) {
+	t.Run("existing harbor is returned", func(t *testing.T) {
+		client := &fakeClient{responses: map[string]string{
+			"GET /org-1/harbors": `[{"id": "h0", "name": "analytics-old"}, {"id": "h1", "name": "analytics"}]`,
+		}}
+		harbor, created, err := (&OrgBuilder{Client: client, OrgID: "org-1"}).EnsureHarbor(context.Background(), "analytics")
+		if err != nil {
+			t.Fatalf("EnsureHarbor() unexpected error = %v", err)
+		}
+		if created || harbor.ID != "h1" {
+			t.Errorf("EnsureHarbor() = %+v, created %v, want existing h1", harbor, created)
+		}
+		if len(client.requests) != 1 {
+			t.Errorf("expected only the lookup request, got %v", client.requests)
+		}
+	})
+
+	t.Run("missing harbor is created", func(t *testing.T) {
+		client := &fakeClient{responses: map[string]string{
+			"GET /org-1/harbors":  `[]`,
+			"POST /org-1/harbors": `{"id": "h2", "name": "analytics"}`,
+		}}
+		harbor, created, err := (&OrgBuilder{Client: client, OrgID: "org-1"}).EnsureHarbor(context.Background(), "analytics")
+		if err != nil {
+			t.Fatalf("EnsureHarbor() unexpected error = %v", err)
+		}
+		if !created || harbor.ID != "h2" {
+			t.Errorf("EnsureHarbor() = %+v, created %v, want created h2", harbor, created)
+		}
+	})
+
+	t.Run("ambiguous name", func(t *testing.T) {
+		client := &fakeClient{responses: map[string]string{
+			"GET /org-1/harbors": `[{"id": "h1", "name": "analytics"}, {"id": "h2", "name": "analytics"}]`,
+		}}
+		_, _, err := (&OrgBuilder{Client: client, OrgID: "org-1"}).EnsureHarbor(context.Background(), "analytics")
+		if !errors.Is(err, utils.ErrInvalidRequest) {
+			t.Errorf("EnsureHarbor() error = %v, want ErrInvalidRequest", err)
+		}
+	})
+}
+
+func TestHarborBuilder_EnsureDataDock(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /harbors/h1/data-docks": `[]`,
+		"POST /data-docks":           `{"id": "dd1", "name": "warehouse", "harbor_id": "h1", "type": "TrinoInternal"}`,
+	}}
+	harbor := (&OrgBuilder{Client: client, OrgID: "org-1"}).Harbor("h1")
+
+	dock, created, err := harbor.EnsureDataDock(context.Background(), DataDockSpec{Name: "warehouse", Type: "TrinoInternal"})
+	if err != nil {
+		t.Fatalf("EnsureDataDock() unexpected error = %v", err)
+	}
+	if !created || dock.ID != "dd1" || dock.HarborID != "h1" {
+		t.Errorf("EnsureDataDock() = %+v, created %v", dock, created)
+	}
+
+	if _, _, err := harbor.EnsureDataDock(context.Background(), DataDockSpec{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("EnsureDataDock() without name error = %v, want ErrInvalidRequest", err)
+	}
+}
@@ -0,0 +1,49 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestGrantsBuilder(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /api/v1/organizations/org-1/users/u1/grants":              `[{"id": "g1", "principal_type": "user", "principal_id": "u1", "effect": "allow", "role_id": "r1"}]`,
+		"POST /api/v1/organizations/org-1/service-accounts/sa1/grants": `{"id": "g2", "principal_type": "service_account", "principal_id": "sa1", "effect": "allow", "permission_id": "p1", "scope_path": "harbors/h1"}`,
+		"DELETE /api/v1/organizations/org-1/grants/g2":                 `{}`,
+	}}
+	org := &OrgBuilder{Client: client, OrgID: "org-1"}
+	ctx := context.Background()
+
+	grants, err := org.UserGrants("u1").List(ctx)
+	if err != nil {
+		t.Fatalf("List() unexpected error = %v", err)
+	}
+	if len(grants) != 1 || grants[0].ID != "g1" || grants[0].RoleID != "r1" || grants[0].Effect != EffectAllow {
+		t.Errorf("Unexpected grants %+v", grants)
+	}
+
+	grant, err := org.ServiceAccountGrants("sa1").Add(ctx, GrantSpec{PermissionID: "p1", ScopePath: "harbors/h1"})
+	if err != nil {
+		t.Fatalf("Add() unexpected error = %v", err)
+	}
+	if grant.ID != "g2" || grant.PrincipalType != "service_account" || grant.ScopePath != "harbors/h1" {
+		t.Errorf("Unexpected grant %+v", grant)
+	}
+
+	if _, err := org.ServiceAccountGrants("sa1").Remove(ctx, "g2"); err != nil {
+		t.Fatalf("Remove() unexpected error = %v", err)
+	}
+	if last := client.requests[len(client.requests)-1]; last != "https://api.test/api/v1/organizations/org-1/grants/g2" {
+		t.Errorf("Unexpected request %s", last)
+	}
+
+	if _, err := org.UserGrants("u1").Add(ctx, GrantSpec{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest without a permission, got %v", err)
+	}
+	if _, err := org.UserGrants("u1").Add(ctx, GrantSpec{PermissionID: "p1", Effect: "maybe"}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for an unknown effect, got %v", err)
+	}
+}
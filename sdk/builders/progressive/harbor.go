@@ -13,7 +13,9 @@ import (
 // Available methods:
 //   - DataDock(id) - Navigate to a specific datadock
 //   - ListDataDocks(ctx) - List all datadocks in this harbor
+//   - DataDocks(ctx, opts) - List datadocks as typed values, with filters and pagination
 //   - CreateDataDock(ctx, config) - Create a new datadock
+//   - EnsureDataDock(ctx, spec) - Get a datadock by name, creating it if missing
 //   - Delete(ctx) - Delete this harbor
 type HarborBuilder struct {
 	client   builders.ClientInterface
@@ -46,7 +48,10 @@ func (h *HarborBuilder) CreateDataDock(ctx context.Context, config map[string]in
 	config["harbor_id"] = h.harborID
 
 	endpoint := fmt.Sprintf("%s/data-docks", h.client.GetConfig().BaseURL)
-	body := utils.JsonMarshal(config)
+	body, err := utils.EncodeBody(h.client.GetConfig(), config)
+	if err != nil {
+		return nil, err
+	}
 	return h.client.Do(ctx, "POST", endpoint, body)
 }
 
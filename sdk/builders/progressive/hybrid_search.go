@@ -10,6 +10,7 @@ import (
 )
 
 // HybridSearchBuilder provides a progressive hybrid search interface starting from a DataDock.
+// Chaining methods return a new builder and leave the receiver untouched.
 type HybridSearchBuilder struct {
 	client builders.ClientInterface
 
@@ -29,54 +30,63 @@ type HybridSearchBuilder struct {
 
 // Catalog sets the catalog name for the search.
 func (b *HybridSearchBuilder) Catalog(name string) *HybridSearchBuilder {
+	b = b.clone()
 	b.catalogName = name
 	return b
 }
 
 // Schema sets the schema name for the search.
 func (b *HybridSearchBuilder) Schema(name string) *HybridSearchBuilder {
+	b = b.clone()
 	b.schemaName = name
 	return b
 }
 
 // Table sets the table name for the search.
 func (b *HybridSearchBuilder) Table(name string) *HybridSearchBuilder {
+	b = b.clone()
 	b.tableName = name
 	return b
 }
 
 // Columns sets the columns to index for the search.
 func (b *HybridSearchBuilder) Columns(columns ...string) *HybridSearchBuilder {
+	b = b.clone()
 	b.columnsToIndex = append(b.columnsToIndex, columns...)
 	return b
 }
 
 // ColumnWeights sets per-column FTS weights for biased keyword matching.
 func (b *HybridSearchBuilder) ColumnWeights(weights map[string]float32) *HybridSearchBuilder {
+	b = b.clone()
 	b.columnWeights = weights
 	return b
 }
 
 // Fusion sets the fusion configuration (strategy, rrf_k, alpha).
 func (b *HybridSearchBuilder) Fusion(config fluent.FusionConfig) *HybridSearchBuilder {
+	b = b.clone()
 	b.fusion = &config
 	return b
 }
 
 // FTSLimit sets the number of FTS candidates before fusion.
 func (b *HybridSearchBuilder) FTSLimit(n int) *HybridSearchBuilder {
+	b = b.clone()
 	b.ftsLimit = n
 	return b
 }
 
 // VectorLimit sets the number of vector candidates before fusion.
 func (b *HybridSearchBuilder) VectorLimit(n int) *HybridSearchBuilder {
+	b = b.clone()
 	b.vectorLimit = n
 	return b
 }
 
 // Limit sets the maximum number of final results to return.
 func (b *HybridSearchBuilder) Limit(n int) *HybridSearchBuilder {
+	b = b.clone()
 	b.limitVal = n
 	return b
 }
@@ -129,8 +139,15 @@ func (b *HybridSearchBuilder) Execute(ctx context.Context) (*fluent.HybridSearch
 		requestBody["vector_limit"] = b.vectorLimit
 	}
 
+	if err := builders.RequireFeature(ctx, b.client, utils.FeatureHybridSearch, "hybrid search"); err != nil {
+		return nil, err
+	}
+
 	endpoint := fmt.Sprintf("%s/api/hybrid-search", b.client.GetConfig().BaseURL)
-	body := utils.JsonMarshal(requestBody)
+	body, err := utils.EncodeBody(b.client.GetConfig(), requestBody)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := b.client.Do(ctx, "POST", endpoint, body)
 	if err != nil {
@@ -148,3 +165,11 @@ func (b *HybridSearchBuilder) Execute(ctx context.Context) (*fluent.HybridSearch
 
 	return results, nil
 }
+
+// clone returns a copy of the builder that can be modified independently.
+// Column weights and fusion settings are replaced, never modified, so they are shared.
+func (b *HybridSearchBuilder) clone() *HybridSearchBuilder {
+	next := *b
+	next.columnsToIndex = append([]string(nil), b.columnsToIndex...)
+	return &next
+}
@@ -0,0 +1,152 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// LineageDirection selects which side of the lineage graph to retrieve.
+type LineageDirection string
+
+const (
+	LineageUpstream   LineageDirection = "upstream"   // Tables the table is derived from
+	LineageDownstream LineageDirection = "downstream" // Tables derived from the table
+	LineageBoth       LineageDirection = "both"
+)
+
+// LineageOptions configures Lineage. All fields are optional.
+type LineageOptions struct {
+	Direction LineageDirection // Default LineageBoth
+	Depth     int              // Hops from the table, 0 for the server default
+}
+
+// LineageNode is a table of the lineage graph.
+type LineageNode struct {
+	ID         string `json:"id"`
+	DataDockID string `json:"data_dock_id,omitempty"`
+	Catalog    string `json:"catalog"`
+	Schema     string `json:"schema"`
+	Table      string `json:"table"`
+}
+
+// FullName returns "catalog.schema.table".
+func (n LineageNode) FullName() string {
+	return n.Catalog + "." + n.Schema + "." + n.Table
+}
+
+// LineageEdge is an operation that wrote To from From, as recorded by the platform.
+type LineageEdge struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Operation string    `json:"operation"` // e.g. "INSERT", "CREATE TABLE AS", "MERGE"
+	QueryID   string    `json:"query_id,omitempty"`
+	LastRunAt time.Time `json:"last_run_at"`
+}
+
+// LineageGraph is the lineage of a table. Root is the ID of the table.
+type LineageGraph struct {
+	Root  string        `json:"root"`
+	Nodes []LineageNode `json:"nodes"`
+	Edges []LineageEdge `json:"edges"`
+}
+
+// Node returns the node with the given ID.
+func (g *LineageGraph) Node(id string) (LineageNode, bool) {
+	for _, node := range g.Nodes {
+		if node.ID == id {
+			return node, true
+		}
+	}
+	return LineageNode{}, false
+}
+
+// Upstream returns the tables id is directly derived from.
+func (g *LineageGraph) Upstream(id string) []LineageNode {
+	return g.neighbors(id, func(e LineageEdge) (string, string) { return e.To, e.From })
+}
+
+// Downstream returns the tables directly derived from id.
+func (g *LineageGraph) Downstream(id string) []LineageNode {
+	return g.neighbors(id, func(e LineageEdge) (string, string) { return e.From, e.To })
+}
+
+// Impacted returns every table derived, directly or not, from the root, nearest first:
+// the tables affected by a change of the root table.
+func (g *LineageGraph) Impacted() []LineageNode {
+	var impacted []LineageNode
+	seen := map[string]bool{g.Root: true}
+	for queue := []string{g.Root}; len(queue) > 0; queue = queue[1:] {
+		for _, node := range g.Downstream(queue[0]) {
+			if !seen[node.ID] {
+				seen[node.ID] = true
+				impacted = append(impacted, node)
+				queue = append(queue, node.ID)
+			}
+		}
+	}
+	return impacted
+}
+
+// neighbors returns the nodes at the other end of the edges of id, each once.
+// ends returns the (id, neighbor) ends of an edge.
+func (g *LineageGraph) neighbors(id string, ends func(LineageEdge) (string, string)) []LineageNode {
+	var nodes []LineageNode
+	seen := map[string]bool{}
+	for _, edge := range g.Edges {
+		from, to := ends(edge)
+		if from != id || seen[to] {
+			continue
+		}
+		seen[to] = true
+		if node, ok := g.Node(to); ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// Lineage retrieves the upstream and/or downstream tables of this table and the
+// operations linking them, for impact analysis.
+//
+// Example:
+//
+//	graph, err := table.Lineage(ctx, progressive.LineageOptions{Direction: progressive.LineageDownstream, Depth: 3})
+//	for _, node := range graph.Impacted() {
+//	    fmt.Println(node.FullName())
+//	}
+func (t *TableQueryBuilder) Lineage(ctx context.Context, opts LineageOptions) (*LineageGraph, error) {
+	direction := opts.Direction
+	if direction == "" {
+		direction = LineageBoth
+	}
+	if direction != LineageUpstream && direction != LineageDownstream && direction != LineageBoth {
+		return nil, fmt.Errorf("%w: invalid lineage direction %q", utils.ErrInvalidRequest, direction)
+	}
+	if opts.Depth < 0 {
+		return nil, fmt.Errorf("%w: lineage depth must not be negative", utils.ErrInvalidRequest)
+	}
+
+	endpoint, err := catalogObjectEndpoint(t.client, t.dataDockID, "lineage", []string{t.catalogName, t.schemaName, t.tableName})
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{"direction": {string(direction)}}
+	if opts.Depth > 0 {
+		params.Set("depth", strconv.Itoa(opts.Depth))
+	}
+	resp, err := t.client.Do(ctx, "GET", endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var graph LineageGraph
+	if err := utils.UnmarshalData(resp.Data, &graph); err != nil {
+		return nil, fmt.Errorf("failed to decode lineage: %w", err)
+	}
+	return &graph, nil
+}
@@ -0,0 +1,49 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestTableQueryBuilder_Lineage(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /data-docks/dd1/lineage/sales/public/orders": `{
+			"root": "orders",
+			"nodes": [
+				{"id": "raw", "catalog": "lake", "schema": "raw", "table": "orders"},
+				{"id": "orders", "catalog": "sales", "schema": "public", "table": "orders"},
+				{"id": "daily", "catalog": "sales", "schema": "marts", "table": "daily_revenue"},
+				{"id": "report", "catalog": "sales", "schema": "marts", "table": "monthly_report"}
+			],
+			"edges": [
+				{"from": "raw", "to": "orders", "operation": "MERGE"},
+				{"from": "orders", "to": "daily", "operation": "INSERT"},
+				{"from": "daily", "to": "report", "operation": "CREATE TABLE AS"},
+				{"from": "orders", "to": "report", "operation": "INSERT"}
+			]
+		}`,
+	}}
+	table := (&DataDockBuilder{client: client, dataDockID: "dd1"}).Catalog("sales").Schema("public").Table("orders")
+
+	graph, err := table.Lineage(context.Background(), LineageOptions{Depth: 2})
+	if err != nil {
+		t.Fatalf("Lineage() unexpected error = %v", err)
+	}
+	if client.requests[0] != "https://api.test/data-docks/dd1/lineage/sales/public/orders?depth=2&direction=both" {
+		t.Errorf("Unexpected request %s", client.requests[0])
+	}
+	if upstream := graph.Upstream("orders"); len(upstream) != 1 || upstream[0].FullName() != "lake.raw.orders" {
+		t.Errorf("Upstream() = %+v", upstream)
+	}
+	impacted := graph.Impacted()
+	if len(impacted) != 2 || impacted[0].ID != "daily" || impacted[1].ID != "report" {
+		t.Errorf("Impacted() = %+v", impacted)
+	}
+
+	if _, err := table.Lineage(context.Background(), LineageOptions{Direction: "sideways"}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for an invalid direction, got %v", err)
+	}
+}
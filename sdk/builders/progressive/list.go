@@ -0,0 +1,145 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// defaultListPageSize is the page size used when ListOptions.PageSize is not set.
+const defaultListPageSize = 100
+
+// Harbor is a harbor as returned by the listing endpoints.
+type Harbor struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	OrgID     string `json:"org_id,omitempty"`
+	Status    string `json:"status,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// DataDock is a datadock as returned by the listing endpoints.
+type DataDock struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	HarborID  string `json:"harbor_id,omitempty"`
+	Type      string `json:"type,omitempty"`
+	Status    string `json:"status,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+}
+
+// ListOptions filters, sorts and paginates typed listings.
+// All fields are optional.
+type ListOptions struct {
+	Name   string // Filter on name
+	Status string // Filter on status (e.g. "running", "sleeping")
+	Type   string // Filter on type (datadocks only, e.g. "TrinoInternal")
+
+	SortBy   string // Field to sort on (e.g. "name", "created_at")
+	SortDesc bool   // Sort in descending order
+
+	PageSize int // Items fetched per request (default 100)
+	MaxItems int // Stop after this many items (0 = all)
+}
+
+// filters builds the filter and sort query parameters of the listing.
+func (o ListOptions) filters() url.Values {
+	params := url.Values{}
+	if o.Name != "" {
+		params.Set("name", o.Name)
+	}
+	if o.Status != "" {
+		params.Set("status", o.Status)
+	}
+	if o.Type != "" {
+		params.Set("type", o.Type)
+	}
+	if o.SortBy != "" {
+		direction := "asc"
+		if o.SortDesc {
+			direction = "desc"
+		}
+		params.Set("order", o.SortBy+"."+direction)
+	}
+	return params
+}
+
+// listAll fetches every page of a listing endpoint and decodes the items into T.
+// Pagination stops on a short page, when the server ignores the limit, or when it
+// ignores the offset and serves the same page again.
+func listAll[T any](ctx context.Context, client builders.ClientInterface, endpoint, listKey string, filters url.Values, pageSize, maxItems int) ([]T, error) {
+	if pageSize <= 0 {
+		pageSize = defaultListPageSize
+	}
+
+	var results []T
+	var previousFirst map[string]interface{}
+	for offset := 0; ; offset += pageSize {
+		params := url.Values{}
+		for key, values := range filters {
+			params[key] = values
+		}
+		params.Set("limit", strconv.Itoa(pageSize))
+		params.Set("offset", strconv.Itoa(offset))
+
+		resp, err := client.Do(ctx, "GET", endpoint+"?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		items := extractItems(resp.Data, listKey)
+		if len(items) > 0 {
+			if offset > 0 && reflect.DeepEqual(items[0], previousFirst) {
+				return results, nil
+			}
+			previousFirst = items[0]
+		}
+		for _, item := range items {
+			var value T
+			if err := utils.UnmarshalData(item, &value); err != nil {
+				return nil, fmt.Errorf("failed to decode %s item: %w", listKey, err)
+			}
+			results = append(results, value)
+			if maxItems > 0 && len(results) >= maxItems {
+				return results, nil
+			}
+		}
+
+		if len(items) != pageSize {
+			return results, nil
+		}
+	}
+}
+
+// Harbors retrieves the harbors of this organization as typed values.
+// Unlike ListHarbors, all pages are fetched and filters are applied server-side.
+func (o *OrgBuilder) Harbors(ctx context.Context, opts ListOptions) ([]Harbor, error) {
+	endpoint := fmt.Sprintf("%s/%s/harbors",
+		o.Client.GetConfig().BaseURL,
+		url.PathEscape(o.OrgID),
+	)
+	return listAll[Harbor](ctx, o.Client, endpoint, "harbors", opts.filters(), opts.PageSize, opts.MaxItems)
+}
+
+// DataDocks retrieves the datadocks of every harbor in this organization as typed values.
+func (o *OrgBuilder) DataDocks(ctx context.Context, opts ListOptions) ([]DataDock, error) {
+	endpoint := fmt.Sprintf("%s/%s/data-docks",
+		o.Client.GetConfig().BaseURL,
+		url.PathEscape(o.OrgID),
+	)
+	return listAll[DataDock](ctx, o.Client, endpoint, "data_docks", opts.filters(), opts.PageSize, opts.MaxItems)
+}
+
+// DataDocks retrieves the datadocks of this harbor as typed values.
+func (h *HarborBuilder) DataDocks(ctx context.Context, opts ListOptions) ([]DataDock, error) {
+	endpoint := fmt.Sprintf("%s/harbors/%s/data-docks",
+		h.client.GetConfig().BaseURL,
+		url.PathEscape(h.harborID),
+	)
+	return listAll[DataDock](ctx, h.client, endpoint, "data_docks", opts.filters(), opts.PageSize, opts.MaxItems)
+}
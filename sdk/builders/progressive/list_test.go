@@ -0,0 +1,44 @@
+package progressive
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestOrgBuilder_Harbors(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /org-1/harbors": `[{"id": "h1", "name": "Harbor 1"}, {"id": "h2", "name": "Harbor 2"}]`,
+	}}
+	org := &OrgBuilder{Client: client, OrgID: "org-1"}
+
+	harbors, err := org.Harbors(context.Background(), ListOptions{Name: "Harbor", SortBy: "name", PageSize: 5})
+	if err != nil {
+		t.Fatalf("Harbors() unexpected error = %v", err)
+	}
+	if len(harbors) != 2 || harbors[1].ID != "h2" {
+		t.Errorf("unexpected harbors: %+v", harbors)
+	}
+	if len(client.requests) != 1 || !strings.Contains(client.requests[0], "limit=5") || !strings.Contains(client.requests[0], "order=name.asc") {
+		t.Errorf("unexpected requests: %v", client.requests)
+	}
+}
+
+func TestOrgBuilder_HarborsOffsetIgnored(t *testing.T) {
+	// The fake client serves the same full page whatever the offset
+	client := &fakeClient{responses: map[string]string{
+		"GET /org-1/harbors": `[{"id": "h1", "name": "Harbor 1"}, {"id": "h2", "name": "Harbor 2"}]`,
+	}}
+	org := &OrgBuilder{Client: client, OrgID: "org-1"}
+
+	harbors, err := org.Harbors(context.Background(), ListOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("Harbors() unexpected error = %v", err)
+	}
+	if len(harbors) != 2 {
+		t.Errorf("expected the repeated page to be dropped, got %+v", harbors)
+	}
+	if len(client.requests) != 2 {
+		t.Errorf("expected pagination to stop on the repeated page, got %d requests", len(client.requests))
+	}
+}
@@ -0,0 +1,125 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Metadata annotates a catalog, a schema or a table for data governance.
+type Metadata struct {
+	Description string            `json:"description,omitempty"`
+	Owner       string            `json:"owner,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Properties  map[string]string `json:"properties,omitempty"` // Free-form annotations (e.g. "pii": "true")
+	UpdatedBy   string            `json:"updated_by,omitempty"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// GetMetadata retrieves the annotations of this catalog.
+func (c *CatalogBuilder) GetMetadata(ctx context.Context) (*Metadata, error) {
+	return getMetadata(ctx, c.client, c.dataDockID, c.catalogName)
+}
+
+// SetMetadata updates the annotations of this catalog. See TableQueryBuilder.SetMetadata.
+func (c *CatalogBuilder) SetMetadata(ctx context.Context, fields map[string]any) (*Metadata, error) {
+	return setMetadata(ctx, c.client, c.dataDockID, fields, c.catalogName)
+}
+
+// GetMetadata retrieves the annotations of this schema.
+func (s *SchemaBuilder) GetMetadata(ctx context.Context) (*Metadata, error) {
+	return getMetadata(ctx, s.client, s.dataDockID, s.catalogName, s.schemaName)
+}
+
+// SetMetadata updates the annotations of this schema. See TableQueryBuilder.SetMetadata.
+func (s *SchemaBuilder) SetMetadata(ctx context.Context, fields map[string]any) (*Metadata, error) {
+	return setMetadata(ctx, s.client, s.dataDockID, fields, s.catalogName, s.schemaName)
+}
+
+// GetMetadata retrieves the annotations of this table.
+func (t *TableQueryBuilder) GetMetadata(ctx context.Context) (*Metadata, error) {
+	return getMetadata(ctx, t.client, t.dataDockID, t.catalogName, t.schemaName, t.tableName)
+}
+
+// SetMetadata updates the annotations of this table and returns them. Only the
+// given fields ("description", "owner", "tags", "properties") are changed; a nil
+// value clears a field. Properties are merged with the existing ones.
+//
+// Example:
+//
+//	meta, err := table.SetMetadata(ctx, map[string]any{
+//	    "owner": "data-platform@example.com",
+//	    "tags":  []string{"pii", "gold"},
+//	})
+func (t *TableQueryBuilder) SetMetadata(ctx context.Context, fields map[string]any) (*Metadata, error) {
+	return setMetadata(ctx, t.client, t.dataDockID, fields, t.catalogName, t.schemaName, t.tableName)
+}
+
+// metadataFields are the fields accepted by SetMetadata.
+var metadataFields = map[string]bool{"description": true, "owner": true, "tags": true, "properties": true}
+
+func getMetadata(ctx context.Context, client builders.ClientInterface, dataDockID string, path ...string) (*Metadata, error) {
+	endpoint, err := catalogObjectEndpoint(client, dataDockID, "metadata", path)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMetadata(resp)
+}
+
+func setMetadata(ctx context.Context, client builders.ClientInterface, dataDockID string, fields map[string]any, path ...string) (*Metadata, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: no metadata to set", utils.ErrInvalidRequest)
+	}
+	for field := range fields {
+		if !metadataFields[field] {
+			return nil, fmt.Errorf("%w: unknown metadata field %q (expected description, owner, tags or properties)", utils.ErrInvalidRequest, field)
+		}
+	}
+	endpoint, err := catalogObjectEndpoint(client, dataDockID, "metadata", path)
+	if err != nil {
+		return nil, err
+	}
+	body, err := utils.EncodeBody(client.GetConfig(), fields)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(ctx, "PATCH", endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeMetadata(resp)
+}
+
+// catalogObjectEndpoint returns the endpoint of a resource (e.g. "metadata") of a
+// catalog, schema or table, given its path.
+func catalogObjectEndpoint(client builders.ClientInterface, dataDockID, resource string, path []string) (string, error) {
+	var endpoint strings.Builder
+	fmt.Fprintf(&endpoint, "%s/data-docks/%s/%s", client.GetConfig().BaseURL, url.PathEscape(dataDockID), resource)
+	for _, segment := range path {
+		if segment == "" {
+			return "", fmt.Errorf("%w: catalog, schema and table names are required", utils.ErrInvalidRequest)
+		}
+		endpoint.WriteByte('/')
+		endpoint.WriteString(url.PathEscape(segment))
+	}
+	return endpoint.String(), nil
+}
+
+func decodeMetadata(resp *utils.Response) (*Metadata, error) {
+	var metadata Metadata
+	if resp.Data != nil {
+		if err := utils.UnmarshalData(resp.Data, &metadata); err != nil {
+			return nil, fmt.Errorf("failed to decode metadata: %w", err)
+		}
+	}
+	return &metadata, nil
+}
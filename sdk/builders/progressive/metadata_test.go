@@ -0,0 +1,46 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestTableQueryBuilder_Metadata(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /data-docks/dd1/metadata/sales/public/orders":   `{"description": "Orders", "owner": "sales-team", "tags": ["gold"], "updated_at": "2025-03-01T10:00:00Z"}`,
+		"PATCH /data-docks/dd1/metadata/sales/public/orders": `{"description": "Orders", "owner": "data-platform", "tags": ["gold", "pii"]}`,
+		"PATCH /data-docks/dd1/metadata/sales":               `{"owner": "sales-team"}`,
+	}}
+	catalog := (&DataDockBuilder{client: client, dataDockID: "dd1"}).Catalog("sales")
+	table := catalog.Schema("public").Table("orders")
+	ctx := context.Background()
+
+	metadata, err := table.GetMetadata(ctx)
+	if err != nil {
+		t.Fatalf("GetMetadata() unexpected error = %v", err)
+	}
+	if metadata.Owner != "sales-team" || len(metadata.Tags) != 1 || metadata.UpdatedAt.IsZero() {
+		t.Errorf("Unexpected metadata %+v", metadata)
+	}
+
+	metadata, err = table.SetMetadata(ctx, map[string]any{"owner": "data-platform", "tags": []string{"gold", "pii"}})
+	if err != nil || metadata.Owner != "data-platform" || len(metadata.Tags) != 2 {
+		t.Errorf("SetMetadata() = %+v, %v", metadata, err)
+	}
+	if metadata, err := catalog.SetMetadata(ctx, map[string]any{"owner": "sales-team"}); err != nil || metadata.Owner != "sales-team" {
+		t.Errorf("Catalog SetMetadata() = %+v, %v", metadata, err)
+	}
+
+	if _, err := table.SetMetadata(ctx, map[string]any{"classification": "secret"}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for an unknown field, got %v", err)
+	}
+	if _, err := catalog.Schema("").GetMetadata(ctx); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest without a schema name, got %v", err)
+	}
+	if len(client.requests) != 3 {
+		t.Errorf("Expected invalid calls to be rejected before any request, got %v", client.requests)
+	}
+}
@@ -13,8 +13,21 @@ import (
 // Available methods:
 //   - Harbor(id) - Navigate to a specific harbor
 //   - ListHarbors(ctx) - List all harbors in this org
+//   - Harbors(ctx, opts) - List harbors as typed values, with filters and pagination
 //   - CreateHarbor(ctx, name) - Create a new harbor
+//   - EnsureHarbor(ctx, name) - Get a harbor by name, creating it if missing
+//   - CreateDataDockFromTemplate(ctx, template, vars) - Create a datadock from a template
 //   - ListDataDocks(ctx) - List all datadocks across all harbors
+//   - DataDocks(ctx, opts) - List datadocks as typed values, with filters and pagination
+//   - Tree(ctx) - Walk the full harbor/datadock/catalog/schema/table hierarchy
+//   - ForEachDataDock(ctx, opts, fn) - Run an operation on every datadock, with per-dock results
+//   - SleepAll(ctx, opts), WakeAll(ctx, opts), RefreshAll(ctx, opts) - Bulk datadock lifecycle
+//   - AuditLogs(ctx, filter) - Retrieve platform audit events
+//   - Quotas(ctx) - Report resource consumption against the subscription limits
+//   - Webhooks() - Manage webhook subscriptions
+//   - ServiceAccounts() - List service accounts and download their credentials
+//   - APIKeys() - Manage API keys and rotate them
+//   - UserGrants(id), ServiceAccountGrants(id) - Manage the grants of a user or a service account
 type OrgBuilder struct {
 	Client builders.ClientInterface
 	OrgID  string
@@ -44,9 +57,12 @@ func (o *OrgBuilder) CreateHarbor(ctx context.Context, name string) (*utils.Resp
 		o.Client.GetConfig().BaseURL,
 		url.PathEscape(o.OrgID),
 	)
-	body := utils.JsonMarshal(map[string]interface{}{
+	body, err := utils.EncodeBody(o.Client.GetConfig(), map[string]interface{}{
 		"name": name,
 	})
+	if err != nil {
+		return nil, err
+	}
 	return o.Client.Do(ctx, "POST", endpoint, body)
 }
 
@@ -0,0 +1,66 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Column describes a table column.
+type Column = builders.Column
+
+// openAPIEndpoint builds the Bifrost OpenAPI endpoint of a catalog, schema or table.
+func openAPIEndpoint(client builders.ClientInterface, orgID string, segments ...string) string {
+	endpoint := fmt.Sprintf("%s/%s/openapi", client.GetConfig().BaseURL, url.PathEscape(orgID))
+	for _, segment := range segments {
+		endpoint += "/" + url.PathEscape(segment)
+	}
+	return endpoint
+}
+
+// probe issues a lightweight GET and reports whether the resource exists.
+// A 404 is reported as (false, nil); any other failure is returned as an error.
+func probe(ctx context.Context, client builders.ClientInterface, endpoint string) (bool, error) {
+	_, err := client.Do(ctx, "GET", endpoint, nil)
+	if errors.Is(err, utils.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Exists reports whether this catalog exists, without fetching the catalog metadata.
+func (c *CatalogBuilder) Exists(ctx context.Context) (bool, error) {
+	return probe(ctx, c.client, openAPIEndpoint(c.client, c.orgID, c.catalogName))
+}
+
+// Exists reports whether this schema exists, without fetching the catalog metadata.
+func (s *SchemaBuilder) Exists(ctx context.Context) (bool, error) {
+	return probe(ctx, s.client, openAPIEndpoint(s.client, s.orgID, s.catalogName, s.schemaName))
+}
+
+// Exists reports whether this table exists. At most one row is requested.
+func (t *TableQueryBuilder) Exists(ctx context.Context) (bool, error) {
+	endpoint := openAPIEndpoint(t.client, t.orgID, t.catalogName, t.schemaName, t.tableName)
+	endpoint += "?" + url.Values{"__limit": {"1"}}.Encode()
+	return probe(utils.ContextWithHeaders(ctx, t.headers), t.client, endpoint)
+}
+
+// Columns retrieves the columns of this table.
+// Returns utils.ErrNotFound if the table does not exist.
+func (t *TableQueryBuilder) Columns(ctx context.Context) ([]Column, error) {
+	endpoint := openAPIEndpoint(t.client, t.orgID, t.catalogName, t.schemaName, t.tableName) + "/columns"
+
+	resp, err := t.client.Do(utils.ContextWithHeaders(ctx, t.headers), "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return builders.ParseColumns(resp.Data), nil
+}
@@ -0,0 +1,122 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestExistsProbes(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /org-1/openapi/sales":               `["public"]`,
+		"GET /org-1/openapi/sales/public":        `["orders"]`,
+		"GET /org-1/openapi/sales/public/orders": `[{"id": 1}]`,
+	}}
+	schema := &SchemaBuilder{client: client, orgID: "org-1", catalogName: "sales", schemaName: "public"}
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		probe func(context.Context) (bool, error)
+		want  bool
+	}{
+		{"catalog", (&CatalogBuilder{client: client, orgID: "org-1", catalogName: "sales"}).Exists, true},
+		{"missing catalog", (&CatalogBuilder{client: client, orgID: "org-1", catalogName: "hr"}).Exists, false},
+		{"schema", schema.Exists, true},
+		{"table", schema.Table("orders").Exists, true},
+		{"missing table", schema.Table("invoices").Exists, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.probe(ctx)
+			if err != nil {
+				t.Fatalf("Exists() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Exists() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTableQueryBuilder_Columns(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /org-1/openapi/sales/public/orders/columns": `{"columns": [{"name": "id", "data_type": "bigint"}, {"column_name": "total", "type": "double", "nullable": true}]}`,
+	}}
+	schema := &SchemaBuilder{client: client, orgID: "org-1", catalogName: "sales", schemaName: "public"}
+
+	columns, err := schema.Table("orders").Columns(context.Background())
+	if err != nil {
+		t.Fatalf("Columns() unexpected error = %v", err)
+	}
+	want := []Column{{Name: "id", DataType: "bigint"}, {Name: "total", DataType: "double", Nullable: true}}
+	if len(columns) != len(want) || columns[0] != want[0] || columns[1] != want[1] {
+		t.Errorf("Columns() = %+v, want %+v", columns, want)
+	}
+
+	if _, err := schema.Table("invoices").Columns(context.Background()); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a missing table, got %v", err)
+	}
+}
+
+func TestTableQueryBuilder_Branching(t *testing.T) {
+	schema := &SchemaBuilder{client: &fakeClient{}, orgID: "org-1", catalogName: "sales", schemaName: "public"}
+	base := schema.Table("orders").Where("region", "=", "eu")
+
+	recent := base.OrderBy("created_at", "DESC").Limit(10)
+	large := base.Where("total", ">", 1000)
+
+	if len(base.filters) != 1 || len(base.orderBy) != 0 || base.limitVal != 0 {
+		t.Errorf("Expected the base builder to be untouched, got %+v", base)
+	}
+	if len(recent.filters) != 1 || recent.limitVal != 10 {
+		t.Errorf("Unexpected recent builder: %+v", recent)
+	}
+	if len(large.filters) != 2 || len(large.orderBy) != 0 {
+		t.Errorf("Unexpected large builder: %+v", large)
+	}
+}
+
+func TestTableQueryBuilder_Profile(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /org-1/openapi/sales/public/orders": `[{"status": "paid", "total": 10}, {"status": "paid", "total": 2.5}, {"status": "open", "total": null}]`,
+	}}
+	schema := &SchemaBuilder{client: client, orgID: "org-1", catalogName: "sales", schemaName: "public"}
+
+	profiles, err := schema.Table("orders").Profile(context.Background())
+	if err != nil {
+		t.Fatalf("Profile() unexpected error = %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].Name != "status" || profiles[1].Name != "total" {
+		t.Fatalf("Profile() = %+v", profiles)
+	}
+
+	status, total := profiles[0], profiles[1]
+	if status.DistinctCount != 2 || status.TopValues[0] != (ValueCount{Value: "paid", Count: 2}) || status.Min != "open" || status.Sampled {
+		t.Errorf("unexpected status profile %+v", status)
+	}
+	if total.NullCount != 1 || total.Min != 2.5 || total.Max != float64(10) || total.Count != 3 {
+		t.Errorf("unexpected total profile %+v", total)
+	}
+}
+
+func TestTableQueryBuilder_ProfileFromServer(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /org-1/openapi/sales/public/orders/profile": `{"columns": [{"name": "status", "count": 1000000, "null_count": 4, "distinct_count": 3}]}`,
+	}}
+	schema := &SchemaBuilder{client: client, orgID: "org-1", catalogName: "sales", schemaName: "public"}
+
+	profiles, err := schema.Table("orders").Where("year", "=", 2024).Profile(context.Background(), "status")
+	if err != nil {
+		t.Fatalf("Profile() unexpected error = %v", err)
+	}
+	if len(profiles) != 1 || profiles[0].Count != 1000000 || profiles[0].DistinctCount != 3 {
+		t.Errorf("Profile() = %+v", profiles)
+	}
+	if len(client.requests) != 1 || !strings.Contains(client.requests[0], "columns=status") || !strings.Contains(client.requests[0], "year.eq=2024") {
+		t.Errorf("unexpected requests %v", client.requests)
+	}
+}
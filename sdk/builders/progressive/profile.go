@@ -0,0 +1,205 @@
+package progressive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+const (
+	// defaultProfileSampleSize is the number of rows scanned when the server cannot profile the table.
+	defaultProfileSampleSize = 10_000
+	// profilePageSize is the page size of the sampled scan.
+	profilePageSize = 1000
+	// profileTopValues is the number of most frequent values reported per column.
+	profileTopValues = 5
+)
+
+// ValueCount is a column value and its number of occurrences.
+type ValueCount struct {
+	Value any   `json:"value"`
+	Count int64 `json:"count"`
+}
+
+// ColumnProfile holds the statistics of one column.
+type ColumnProfile struct {
+	Name          string       `json:"name"`
+	Count         int64        `json:"count"` // Rows profiled
+	NullCount     int64        `json:"null_count"`
+	DistinctCount int64        `json:"distinct_count"` // A lower bound when Sampled
+	Min           any          `json:"min,omitempty"`
+	Max           any          `json:"max,omitempty"`
+	TopValues     []ValueCount `json:"top_values,omitempty"`
+
+	// Sampled reports that the statistics come from the first rows of the table only.
+	Sampled bool `json:"sampled,omitempty"`
+}
+
+// Profile returns statistics of the given columns (every column when none is given).
+// The statistics are computed by the server when it supports table profiling, and
+// otherwise on the client over a scan of at most 10,000 rows (or Limit rows when set).
+// Filters (Where) restrict the profiled rows in both cases.
+//
+// Example:
+//
+//	profiles, err := table.Where("year", "=", 2024).Profile(ctx, "status", "total")
+//	for _, p := range profiles {
+//	    fmt.Printf("%s: %d nulls, ~%d distinct, min=%v max=%v\n", p.Name, p.NullCount, p.DistinctCount, p.Min, p.Max)
+//	}
+func (t *TableQueryBuilder) Profile(ctx context.Context, columns ...string) ([]ColumnProfile, error) {
+	ctx = utils.ContextWithHeaders(ctx, t.headers)
+
+	profiles, err := t.serverProfile(ctx, columns)
+	if !errors.Is(err, utils.ErrNotFound) {
+		return profiles, err
+	}
+	return t.sampledProfile(ctx, columns)
+}
+
+// serverProfile asks the server for the statistics. ErrNotFound means profiling
+// is not supported, or that the table does not exist.
+func (t *TableQueryBuilder) serverProfile(ctx context.Context, columns []string) ([]ColumnProfile, error) {
+	params := t.filterParams()
+	if len(columns) > 0 {
+		params.Set("columns", strings.Join(columns, ","))
+	}
+	endpoint := openAPIEndpoint(t.client, t.orgID, t.catalogName, t.schemaName, t.tableName) + "/profile"
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	resp, err := t.client.Do(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data := resp.Data
+	if obj, ok := data.(map[string]any); ok {
+		data = obj["columns"]
+	}
+	var profiles []ColumnProfile
+	raw, _ := json.Marshal(data)
+	if err := json.Unmarshal(raw, &profiles); err != nil {
+		return nil, fmt.Errorf("%w: unexpected profile response: %w", utils.ErrAPIError, err)
+	}
+	return profiles, nil
+}
+
+// filterParams returns the WHERE parameters of the query.
+func (t *TableQueryBuilder) filterParams() url.Values {
+	filters := t.clone()
+	filters.selectCols, filters.orderBy, filters.limitVal, filters.offsetVal = nil, nil, 0, 0
+	return filters.buildParams()
+}
+
+// sampledProfile computes the statistics over the first rows of the table.
+func (t *TableQueryBuilder) sampledProfile(ctx context.Context, columns []string) ([]ColumnProfile, error) {
+	sampleSize := t.limitVal
+	if sampleSize <= 0 {
+		sampleSize = defaultProfileSampleSize
+	}
+
+	scan := t.clone()
+	scan.selectCols = columns
+	var rows []map[string]any
+	for len(rows) < sampleSize {
+		scan.offsetVal = t.offsetVal + len(rows)
+		scan.limitVal = min(profilePageSize, sampleSize-len(rows))
+		resp, err := scan.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		page, err := resp.Rows()
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, page...)
+		if len(page) < scan.limitVal {
+			break
+		}
+	}
+
+	if len(columns) == 0 {
+		seen := map[string]bool{}
+		for _, row := range rows {
+			for column := range row {
+				if !seen[column] {
+					seen[column] = true
+					columns = append(columns, column)
+				}
+			}
+		}
+		sort.Strings(columns)
+	}
+
+	profiles := make([]ColumnProfile, 0, len(columns))
+	for _, column := range columns {
+		profile := profileColumn(column, rows)
+		profile.Sampled = len(rows) == sampleSize
+		profiles = append(profiles, profile)
+	}
+	return profiles, nil
+}
+
+// profileColumn computes the statistics of one column over the given rows.
+func profileColumn(column string, rows []map[string]any) ColumnProfile {
+	profile := ColumnProfile{Name: column, Count: int64(len(rows))}
+	counts := map[string]*ValueCount{}
+	for _, row := range rows {
+		value := row[column]
+		if value == nil {
+			profile.NullCount++
+			continue
+		}
+
+		key := string(utils.JsonMarshal(value))
+		if counts[key] == nil {
+			counts[key] = &ValueCount{Value: value}
+		}
+		counts[key].Count++
+
+		if profile.Min == nil || lessValue(value, profile.Min) {
+			profile.Min = value
+		}
+		if profile.Max == nil || lessValue(profile.Max, value) {
+			profile.Max = value
+		}
+	}
+	profile.DistinctCount = int64(len(counts))
+
+	top := make([]ValueCount, 0, len(counts))
+	for _, count := range counts {
+		top = append(top, *count)
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Count != top[j].Count {
+			return top[i].Count > top[j].Count
+		}
+		return fmt.Sprint(top[i].Value) < fmt.Sprint(top[j].Value)
+	})
+	if len(top) > profileTopValues {
+		top = top[:profileTopValues]
+	}
+	profile.TopValues = top
+	return profile
+}
+
+// lessValue orders numbers and strings; values of other or mixed types are not ordered.
+func lessValue(a, b any) bool {
+	if x, ok := utils.Float64(a); ok {
+		y, ok := utils.Float64(b)
+		return ok && x < y
+	}
+	switch x := a.(type) {
+	case string:
+		y, ok := b.(string)
+		return ok && x < y
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Schedule is the automatic wake-up / sleep configuration of a datadock.
+// Cron expressions use the standard 5-field syntax and are evaluated in Timezone.
+type Schedule struct {
+	WakeCron  string `json:"wake_cron,omitempty"`  // e.g. "0 8 * * MON-FRI"
+	SleepCron string `json:"sleep_cron,omitempty"` // e.g. "0 20 * * MON-FRI"
+	Timezone  string `json:"timezone,omitempty"`   // IANA name, e.g. "Europe/Paris" (default UTC)
+	Enabled   bool   `json:"enabled"`
+}
+
+// Validate checks the cron expressions and the time zone of the schedule.
+func (s Schedule) Validate() error {
+	if s.WakeCron == "" && s.SleepCron == "" {
+		return fmt.Errorf("%w: schedule needs a wake or sleep cron expression", utils.ErrInvalidRequest)
+	}
+	for _, expr := range []string{s.WakeCron, s.SleepCron} {
+		if expr == "" {
+			continue
+		}
+		if err := utils.ValidateCron(expr); err != nil {
+			return err
+		}
+	}
+	if s.Timezone != "" {
+		if _, err := time.LoadLocation(s.Timezone); err != nil {
+			return fmt.Errorf("%w: unknown time zone %q", utils.ErrInvalidRequest, s.Timezone)
+		}
+	}
+	return nil
+}
+
+// GetSchedule retrieves the wake-up / sleep schedule of this datadock.
+func (d *DataDockBuilder) GetSchedule(ctx context.Context) (*Schedule, error) {
+	resp, err := d.client.Do(ctx, "GET", d.scheduleEndpoint(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedule Schedule
+	if err := utils.UnmarshalData(resp.Data, &schedule); err != nil {
+		return nil, fmt.Errorf("failed to decode schedule: %w", err)
+	}
+	return &schedule, nil
+}
+
+// SetSchedule validates and replaces the wake-up / sleep schedule of this datadock.
+func (d *DataDockBuilder) SetSchedule(ctx context.Context, schedule Schedule) (*utils.Response, error) {
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+	body, err := utils.EncodeBody(d.client.GetConfig(), schedule)
+	if err != nil {
+		return nil, err
+	}
+	return d.client.Do(ctx, "PUT", d.scheduleEndpoint(), body)
+}
+
+// DeleteSchedule removes the schedule of this datadock; it then only sleeps or wakes up on demand.
+func (d *DataDockBuilder) DeleteSchedule(ctx context.Context) (*utils.Response, error) {
+	return d.client.Do(ctx, "DELETE", d.scheduleEndpoint(), nil)
+}
+
+func (d *DataDockBuilder) scheduleEndpoint() string {
+	return fmt.Sprintf("%s/data-docks/%s/schedule",
+		d.client.GetConfig().BaseURL,
+		url.PathEscape(d.dataDockID),
+	)
+}
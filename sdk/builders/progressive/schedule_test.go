@@ -0,0 +1,64 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestSchedule_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule Schedule
+		wantErr  bool
+	}{
+		{"weekdays", Schedule{WakeCron: "0 8 * * MON-FRI", SleepCron: "30 19 * * 1-5", Timezone: "UTC"}, false},
+		{"steps and lists", Schedule{SleepCron: "*/15 0,12 1-15/2 JAN-JUN *"}, false},
+		{"macro", Schedule{WakeCron: "@daily"}, false},
+		{"empty", Schedule{}, true},
+		{"too few fields", Schedule{WakeCron: "0 8 * *"}, true},
+		{"minute out of range", Schedule{WakeCron: "60 8 * * *"}, true},
+		{"reversed range", Schedule{SleepCron: "0 20-8 * * *"}, true},
+		{"bad step", Schedule{SleepCron: "*/0 * * * *"}, true},
+		{"unknown time zone", Schedule{WakeCron: "0 8 * * *", Timezone: "Mars/Olympus"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, utils.ErrInvalidRequest) {
+				t.Errorf("Expected ErrInvalidRequest, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDataDockBuilder_Schedule(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /data-docks/dd1/schedule": `{"wake_cron": "0 8 * * 1-5", "sleep_cron": "0 20 * * 1-5", "timezone": "Europe/Paris", "enabled": true}`,
+		"PUT /data-docks/dd1/schedule": `{}`,
+	}}
+	dock := &DataDockBuilder{client: client, dataDockID: "dd1"}
+
+	schedule, err := dock.GetSchedule(context.Background())
+	if err != nil {
+		t.Fatalf("GetSchedule() unexpected error = %v", err)
+	}
+	if schedule.WakeCron != "0 8 * * 1-5" || schedule.Timezone != "Europe/Paris" || !schedule.Enabled {
+		t.Errorf("Unexpected schedule: %+v", schedule)
+	}
+
+	if _, err := dock.SetSchedule(context.Background(), Schedule{WakeCron: "0 25 * * *"}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected invalid schedule to be rejected before any request, got %v", err)
+	}
+	if len(client.requests) != 1 {
+		t.Errorf("Expected a single request, got %v", client.requests)
+	}
+	if _, err := dock.SetSchedule(context.Background(), *schedule); err != nil {
+		t.Errorf("SetSchedule() unexpected error = %v", err)
+	}
+}
@@ -12,6 +12,9 @@ import (
 // Available methods:
 //   - Table(name) - Navigate to a specific table (returns TableQueryBuilder for querying)
 //   - ListTables(ctx) - List all tables in this schema
+//   - ListTablesDetailed(ctx) - List tables with their comment, column count, row estimate and refresh time
+//   - Exists(ctx) - Check that this schema exists
+//   - GetMetadata(ctx) / SetMetadata(ctx, fields) - Read and update the description, owner and tags
 type SchemaBuilder struct {
 	client      builders.ClientInterface
 	orgID       string
@@ -26,6 +29,7 @@ func (s *SchemaBuilder) Table(tableName string) *TableQueryBuilder {
 	return &TableQueryBuilder{
 		client:      s.client,
 		orgID:       s.orgID,
+		dataDockID:  s.dataDockID,
 		catalogName: s.catalogName,
 		schemaName:  s.schemaName,
 		tableName:   tableName,
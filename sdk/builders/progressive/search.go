@@ -9,6 +9,7 @@ import (
 )
 
 // SearchBuilder provides a progressive search interface starting from a DataDock.
+// Chaining methods return a new builder and leave the receiver untouched.
 type SearchBuilder struct {
 	client builders.ClientInterface
 
@@ -26,18 +27,21 @@ type SearchBuilder struct {
 
 // Catalog sets the catalog name for the search.
 func (sb *SearchBuilder) Catalog(name string) *SearchBuilder {
+	sb = sb.clone()
 	sb.catalogName = name
 	return sb
 }
 
 // Schema sets the schema name for the search.
 func (sb *SearchBuilder) Schema(name string) *SearchBuilder {
+	sb = sb.clone()
 	sb.schemaName = name
 	return sb
 }
 
 // Table sets the table name for the search.
 func (sb *SearchBuilder) Table(name string) *SearchBuilder {
+	sb = sb.clone()
 	sb.tableName = name
 	return sb
 }
@@ -45,12 +49,14 @@ func (sb *SearchBuilder) Table(name string) *SearchBuilder {
 // Columns sets the columns to index for the search.
 // Can be called multiple times to add more columns.
 func (sb *SearchBuilder) Columns(columns ...string) *SearchBuilder {
+	sb = sb.clone()
 	sb.columnsToIndex = append(sb.columnsToIndex, columns...)
 	return sb
 }
 
 // Limit sets the maximum number of results to return.
 func (sb *SearchBuilder) Limit(n int) *SearchBuilder {
+	sb = sb.clone()
 	sb.limitVal = n
 	return sb
 }
@@ -88,12 +94,26 @@ func (sb *SearchBuilder) Execute(ctx context.Context) (*utils.Response, error) {
 		"columns_to_index": sb.columnsToIndex,
 	}
 
+	if err := builders.RequireFeature(ctx, sb.client, utils.FeatureSearch, "full-text search"); err != nil {
+		return nil, err
+	}
+
 	// Build endpoint
 	endpoint := fmt.Sprintf("%s/api/search", sb.client.GetConfig().BaseURL)
 
 	// Marshal request body
-	body := utils.JsonMarshal(requestBody)
+	body, err := utils.EncodeBody(sb.client.GetConfig(), requestBody)
+	if err != nil {
+		return nil, err
+	}
 
 	// Execute the request
 	return sb.client.Do(ctx, "POST", endpoint, body)
 }
+
+// clone returns a copy of the builder that can be modified independently.
+func (sb *SearchBuilder) clone() *SearchBuilder {
+	next := *sb
+	next.columnsToIndex = append([]string(nil), sb.columnsToIndex...)
+	return &next
+}
@@ -0,0 +1,86 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// SearchIndexSpec describes a persistent full-text search index to create on a table.
+type SearchIndexSpec struct {
+	Name    string   `json:"name"`
+	Catalog string   `json:"catalog"`
+	Schema  string   `json:"schema"`
+	Table   string   `json:"table"`
+	Columns []string `json:"columns"`
+}
+
+// Validate checks that the spec names an index, a table and at least one column.
+func (s SearchIndexSpec) Validate() error {
+	switch {
+	case s.Name == "":
+		return fmt.Errorf("%w: search index name is required", utils.ErrInvalidRequest)
+	case s.Catalog == "" || s.Schema == "" || s.Table == "":
+		return fmt.Errorf("%w: search index %q needs a catalog, schema and table", utils.ErrInvalidRequest, s.Name)
+	case len(s.Columns) == 0:
+		return fmt.Errorf("%w: search index %q needs at least one column", utils.ErrInvalidRequest, s.Name)
+	}
+	return nil
+}
+
+// SearchIndex is a search index of a datadock.
+type SearchIndex struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Catalog   string    `json:"catalog"`
+	Schema    string    `json:"schema"`
+	Table     string    `json:"table"`
+	Columns   []string  `json:"columns"`
+	Status    string    `json:"status,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SearchIndexes retrieves the search indexes of this datadock.
+func (d *DataDockBuilder) SearchIndexes(ctx context.Context) ([]SearchIndex, error) {
+	return listAll[SearchIndex](ctx, d.client, d.searchIndexesEndpoint(), "search_indexes", nil, 0, 0)
+}
+
+// CreateSearchIndex validates the spec and creates a search index on this datadock.
+func (d *DataDockBuilder) CreateSearchIndex(ctx context.Context, spec SearchIndexSpec) (*SearchIndex, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := utils.EncodeBody(d.client.GetConfig(), spec)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := d.client.Do(ctx, "POST", d.searchIndexesEndpoint(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var index SearchIndex
+	if err := utils.UnmarshalData(resp.Data, &index); err != nil {
+		return nil, fmt.Errorf("failed to decode search index: %w", err)
+	}
+	return &index, nil
+}
+
+// DeleteSearchIndex removes a search index of this datadock.
+func (d *DataDockBuilder) DeleteSearchIndex(ctx context.Context, indexID string) (*utils.Response, error) {
+	if indexID == "" {
+		return nil, fmt.Errorf("%w: search index ID is required", utils.ErrInvalidRequest)
+	}
+	return d.client.Do(ctx, "DELETE", d.searchIndexesEndpoint()+"/"+url.PathEscape(indexID), nil)
+}
+
+func (d *DataDockBuilder) searchIndexesEndpoint() string {
+	return fmt.Sprintf("%s/data-docks/%s/search-indexes",
+		d.client.GetConfig().BaseURL,
+		url.PathEscape(d.dataDockID),
+	)
+}
@@ -0,0 +1,98 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// ServiceAccount is a service account of an organization. Its secret is only
+// returned by Credentials.
+type ServiceAccount struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description,omitempty"`
+	ClientID       string    `json:"iam_sa_client_id"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ServiceAccountCredentials are the credentials of a service account. Marshaled to
+// JSON, they are read by sdk.LoadServiceAccount.
+type ServiceAccountCredentials struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+	AuthURI      string `json:"auth_uri"`
+	TokenURI     string `json:"token_uri"`
+	Issuer       string `json:"issuer"`
+	Org          string `json:"org"`
+	OrgID        string `json:"org_id"`
+	APIURL       string `json:"api_url"`
+}
+
+// ServiceAccountsBuilder reads the service accounts of an organization, through the
+// control plane.
+// Available methods:
+//   - List(ctx) - List the service accounts, without their secrets
+//   - Credentials(ctx, id) - Download the credentials of a service account
+type ServiceAccountsBuilder struct {
+	client builders.ClientInterface
+	orgID  string
+}
+
+// ServiceAccounts returns a builder reading the service accounts of this organization.
+func (o *OrgBuilder) ServiceAccounts() *ServiceAccountsBuilder {
+	return &ServiceAccountsBuilder{client: o.Client, orgID: o.OrgID}
+}
+
+// List retrieves every service account of this organization.
+func (s *ServiceAccountsBuilder) List(ctx context.Context) ([]ServiceAccount, error) {
+	resp, err := s.client.Do(ctx, "GET", s.endpoint(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		ServiceAccounts []ServiceAccount `json:"service_accounts"`
+	}
+	if err := utils.UnmarshalData(resp.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode service accounts: %w", err)
+	}
+	return data.ServiceAccounts, nil
+}
+
+// Credentials downloads the credentials of a service account.
+//
+// Example:
+//
+//	creds, err := client.Org(orgID).ServiceAccounts().Credentials(ctx, serviceAccountID)
+//	raw, _ := json.Marshal(creds) // service_account.json, for sdk.LoadServiceAccount
+func (s *ServiceAccountsBuilder) Credentials(ctx context.Context, serviceAccountID string) (*ServiceAccountCredentials, error) {
+	if serviceAccountID == "" {
+		return nil, fmt.Errorf("%w: service account ID is required", utils.ErrInvalidRequest)
+	}
+	resp, err := s.client.Do(ctx, "GET", s.endpoint()+"/"+url.PathEscape(serviceAccountID)+"/download", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var creds ServiceAccountCredentials
+	if err := utils.UnmarshalData(resp.Data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to decode service account credentials: %w", err)
+	}
+	if creds.ClientSecret == "" {
+		return nil, fmt.Errorf("%w: no secret returned for service account %s", utils.ErrAPIError, serviceAccountID)
+	}
+	return &creds, nil
+}
+
+func (s *ServiceAccountsBuilder) endpoint() string {
+	return fmt.Sprintf("%s/api/v1/orgs/%s/service-accounts",
+		controlPlaneURL(s.client),
+		url.PathEscape(s.orgID),
+	)
+}
@@ -0,0 +1,47 @@
+package progressive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestServiceAccountsBuilder(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /api/v1/orgs/org-1/service-accounts":              `{"service_accounts": [{"id": "sa1", "name": "etl", "iam_sa_client_id": "org-1-etl"}]}`,
+		"GET /api/v1/orgs/org-1/service-accounts/sa1/download": `{"client_id": "org-1-etl", "client_secret": "s1", "issuer": "https://auth.test/realms/org-1", "token_uri": "https://auth.test/realms/org-1/protocol/openid-connect/token"}`,
+		"GET /api/v1/orgs/org-1/service-accounts/sa2/download": `{"client_id": "org-1-other"}`,
+	}}
+	accounts := (&OrgBuilder{Client: client, OrgID: "org-1"}).ServiceAccounts()
+	ctx := context.Background()
+
+	list, err := accounts.List(ctx)
+	if err != nil || len(list) != 1 || list[0].ClientID != "org-1-etl" {
+		t.Errorf("List() = %+v, %v", list, err)
+	}
+
+	creds, err := accounts.Credentials(ctx, "sa1")
+	if err != nil {
+		t.Fatalf("Credentials() unexpected error = %v", err)
+	}
+	// The credentials are in the service_account.json format
+	var file struct {
+		ClientID     string `json:"client_id"`
+		ClientSecret string `json:"client_secret"`
+		Issuer       string `json:"issuer"`
+	}
+	raw, _ := json.Marshal(creds)
+	if err := json.Unmarshal(raw, &file); err != nil || file.ClientID != "org-1-etl" || file.ClientSecret != "s1" || file.Issuer == "" {
+		t.Errorf("Unexpected service account file %s", raw)
+	}
+
+	if _, err := accounts.Credentials(ctx, "sa2"); !errors.Is(err, utils.ErrAPIError) {
+		t.Errorf("Expected ErrAPIError when no secret is returned, got %v", err)
+	}
+	if _, err := accounts.Credentials(ctx, ""); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest without an ID, got %v", err)
+	}
+}
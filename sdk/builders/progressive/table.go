@@ -3,8 +3,10 @@ package progressive
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
@@ -13,9 +15,11 @@ import (
 // TableQueryBuilder combines table navigation with query building.
 // This is the final level where you can build queries AND execute them.
 // Inherits all query building methods from the original QueryBuilder.
+// Like QueryBuilder, chaining methods return a new builder and leave the receiver untouched.
 type TableQueryBuilder struct {
-	client builders.ClientInterface
-	orgID  string
+	client     builders.ClientInterface
+	orgID      string
+	dataDockID string
 
 	// Table location
 	catalogName string
@@ -29,17 +33,23 @@ type TableQueryBuilder struct {
 	limitVal   int
 	offsetVal  int
 	rawParams  url.Values
+	headers    http.Header
+
+	// timeLocation formats time filters as wall-clock times of this location (nil = UTC)
+	timeLocation *time.Location
 }
 
 // Query building methods - same as original QueryBuilder
 // These return *TableQueryBuilder for chaining
 
 func (t *TableQueryBuilder) Select(columns ...string) *TableQueryBuilder {
+	t = t.clone()
 	t.selectCols = append(t.selectCols, columns...)
 	return t
 }
 
 func (t *TableQueryBuilder) Where(column, operator string, value interface{}) *TableQueryBuilder {
+	t = t.clone()
 	t.filters = append(t.filters, builders.Filter{
 		Column:   column,
 		Operator: operator,
@@ -48,7 +58,26 @@ func (t *TableQueryBuilder) Where(column, operator string, value interface{}) *T
 	return t
 }
 
+// WhereTime adds a filter on a timestamp column, sent in ISO 8601 (UTC unless TimeLocation is set).
+func (t *TableQueryBuilder) WhereTime(column, operator string, value time.Time) *TableQueryBuilder {
+	return t.Where(column, operator, value)
+}
+
+// Between keeps the rows whose timestamp column is within [from, to], bounds included.
+func (t *TableQueryBuilder) Between(column string, from, to time.Time) *TableQueryBuilder {
+	return t.Where(column, ">=", from).Where(column, "<=", to)
+}
+
+// TimeLocation sends the time filters as wall-clock times of loc, without offset,
+// for timestamp columns without time zone. nil restores UTC.
+func (t *TableQueryBuilder) TimeLocation(loc *time.Location) *TableQueryBuilder {
+	t = t.clone()
+	t.timeLocation = loc
+	return t
+}
+
 func (t *TableQueryBuilder) OrderBy(column, direction string) *TableQueryBuilder {
+	t = t.clone()
 	if direction == "" {
 		direction = "ASC"
 	}
@@ -60,21 +89,32 @@ func (t *TableQueryBuilder) OrderBy(column, direction string) *TableQueryBuilder
 }
 
 func (t *TableQueryBuilder) Limit(n int) *TableQueryBuilder {
+	t = t.clone()
 	t.limitVal = n
 	return t
 }
 
 func (t *TableQueryBuilder) Offset(n int) *TableQueryBuilder {
+	t = t.clone()
 	t.offsetVal = n
 	return t
 }
 
+// RawParams adds custom query parameters. Builder methods take precedence over
+// them, as with QueryBuilder.RawParams.
 func (t *TableQueryBuilder) RawParams(params url.Values) *TableQueryBuilder {
-	for key, values := range params {
-		for _, value := range values {
-			t.rawParams.Add(key, value)
-		}
+	t = t.clone()
+	builders.MergeRawParams(t.rawParams, params)
+	return t
+}
+
+// Header adds an HTTP header to the request (e.g. a correlation ID or tenant header).
+func (t *TableQueryBuilder) Header(key, value string) *TableQueryBuilder {
+	t = t.clone()
+	if t.headers == nil {
+		t.headers = http.Header{}
 	}
+	t.headers.Set(key, value)
 	return t
 }
 
@@ -98,7 +138,7 @@ func (t *TableQueryBuilder) Get(ctx context.Context) (*utils.Response, error) {
 		endpoint += "?" + params.Encode()
 	}
 
-	return t.client.Do(ctx, "GET", endpoint, nil)
+	return builders.DoWithWakeUp(utils.ContextWithHeaders(ctx, t.headers), t.client, t.dataDockID, "GET", endpoint, nil)
 }
 
 // buildParams constructs query parameters (same as QueryBuilder)
@@ -136,7 +176,7 @@ func (t *TableQueryBuilder) buildParams() url.Values {
 	for _, filter := range t.filters {
 		op := operatorMap[filter.Operator]
 		paramName := fmt.Sprintf("%s.%s", filter.Column, op)
-		params.Add(paramName, fmt.Sprintf("%v", filter.Value))
+		params.Add(paramName, builders.FormatFilterValue(filter.Value, t.timeLocation))
 	}
 
 	// Add ORDER BY
@@ -164,3 +204,19 @@ func (t *TableQueryBuilder) buildParams() url.Values {
 
 	return params
 }
+
+// clone returns a deep copy of the builder.
+func (t *TableQueryBuilder) clone() *TableQueryBuilder {
+	next := *t
+	next.selectCols = append([]string(nil), t.selectCols...)
+	next.filters = append([]builders.Filter(nil), t.filters...)
+	next.orderBy = append([]builders.OrderClause(nil), t.orderBy...)
+	next.rawParams = make(url.Values, len(t.rawParams))
+	for key, values := range t.rawParams {
+		next.rawParams[key] = append([]string(nil), values...)
+	}
+	if t.headers != nil {
+		next.headers = t.headers.Clone()
+	}
+	return &next
+}
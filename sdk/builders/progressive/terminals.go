@@ -0,0 +1,47 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// First executes the query with a limit of 1 and decodes the first row into dest.
+// Returns utils.ErrNotFound when no row matches.
+func (t *TableQueryBuilder) First(ctx context.Context, dest any) error {
+	first := t.clone()
+	first.limitVal = 1
+
+	resp, err := first.Get(ctx)
+	if err != nil {
+		return err
+	}
+	return utils.FirstRow(resp, dest)
+}
+
+// Pluck executes the query selecting only the given column and returns its values.
+// Use PluckAs to get a typed slice.
+func (t *TableQueryBuilder) Pluck(ctx context.Context, column string) ([]any, error) {
+	if column == "" {
+		return nil, fmt.Errorf("%w: pluck column cannot be empty", utils.ErrInvalidRequest)
+	}
+
+	pluck := t.clone()
+	pluck.selectCols = []string{column}
+
+	resp, err := pluck.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ColumnValues(resp, column)
+}
+
+// PluckAs is like Pluck but decodes the values into a slice of T.
+func PluckAs[T any](ctx context.Context, t *TableQueryBuilder, column string) ([]T, error) {
+	values, err := t.Pluck(ctx, column)
+	if err != nil {
+		return nil, err
+	}
+	return utils.ConvertValues[T](values)
+}
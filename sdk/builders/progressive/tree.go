@@ -0,0 +1,179 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// defaultTreeConcurrency bounds the number of concurrent requests issued by Tree.
+const defaultTreeConcurrency = 8
+
+// OrgTree is the full resource hierarchy of an organization.
+type OrgTree struct {
+	OrgID   string       `json:"org_id"`
+	Harbors []HarborNode `json:"harbors"`
+}
+
+// HarborNode is a harbor and its datadocks.
+type HarborNode struct {
+	ID        string         `json:"id"`
+	Name      string         `json:"name"`
+	DataDocks []DataDockNode `json:"data_docks"`
+}
+
+// DataDockNode is a datadock and its catalogs.
+// Error is set when the catalog could not be fetched (e.g. the datadock is asleep);
+// the rest of the tree is still returned.
+type DataDockNode struct {
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Catalogs []CatalogNode `json:"catalogs"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// CatalogNode is a catalog and its schemas.
+type CatalogNode struct {
+	Name    string       `json:"name"`
+	Schemas []SchemaNode `json:"schemas"`
+}
+
+// SchemaNode is a schema and its tables.
+type SchemaNode struct {
+	Name   string      `json:"name"`
+	Tables []TableNode `json:"tables"`
+}
+
+// TableNode is a table.
+type TableNode struct {
+	Name string `json:"name"`
+}
+
+// Tree walks the organization and returns its harbors, datadocks, catalogs,
+// schemas and tables. Requests are issued concurrently with bounded parallelism.
+func (o *OrgBuilder) Tree(ctx context.Context) (*OrgTree, error) {
+	return o.TreeWithConcurrency(ctx, defaultTreeConcurrency)
+}
+
+// TreeWithConcurrency is like Tree but allows at most `concurrency` requests in flight.
+func (o *OrgBuilder) TreeWithConcurrency(ctx context.Context, concurrency int) (*OrgTree, error) {
+	if concurrency <= 0 {
+		concurrency = defaultTreeConcurrency
+	}
+
+	harbors, err := o.Harbors(ctx, ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list harbors: %w", err)
+	}
+
+	tree := &OrgTree{OrgID: o.OrgID}
+	for _, harbor := range harbors {
+		tree.Harbors = append(tree.Harbors, HarborNode{ID: harbor.ID, Name: harbor.Name})
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	sem := make(chan struct{}, concurrency)
+
+	// Level 1: datadocks of every harbor. Any failure aborts the walk.
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	for i := range tree.Harbors {
+		wg.Add(1)
+		go func(harbor *HarborNode) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			dataDocks, err := o.Harbor(harbor.ID).DataDocks(ctx, ListOptions{})
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = fmt.Errorf("failed to list datadocks of harbor %s: %w", harbor.ID, err)
+					cancel()
+				})
+				return
+			}
+			for _, dataDock := range dataDocks {
+				harbor.DataDocks = append(harbor.DataDocks, DataDockNode{ID: dataDock.ID, Name: dataDock.Name})
+			}
+		}(&tree.Harbors[i])
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	// Level 2: catalog metadata of every datadock. Failures are recorded per datadock.
+	for i := range tree.Harbors {
+		harbor := &tree.Harbors[i]
+		for j := range harbor.DataDocks {
+			wg.Add(1)
+			go func(harborID string, dataDock *DataDockNode) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				resp, err := o.Harbor(harborID).DataDock(dataDock.ID).GetCatalog(ctx)
+				if err != nil {
+					dataDock.Error = err.Error()
+					return
+				}
+				dataDock.Catalogs = parseCatalogTree(resp.Data)
+			}(harbor.ID, &harbor.DataDocks[j])
+		}
+	}
+	wg.Wait()
+
+	return tree, nil
+}
+
+// parseCatalogTree converts the raw catalog metadata of a datadock into catalog nodes.
+func parseCatalogTree(data interface{}) []CatalogNode {
+	var catalogs []CatalogNode
+	for _, cat := range extractItems(data, "catalogs") {
+		catalog := CatalogNode{Name: stringField(cat, "catalog_name")}
+		for _, sch := range extractItems(cat["schemas"]) {
+			schema := SchemaNode{Name: stringField(sch, "schema_name")}
+			for _, tbl := range extractItems(sch["tables"]) {
+				schema.Tables = append(schema.Tables, TableNode{Name: stringField(tbl, "table_name")})
+			}
+			catalog.Schemas = append(catalog.Schemas, schema)
+		}
+		catalogs = append(catalogs, catalog)
+	}
+	return catalogs
+}
+
+// extractItems returns the objects of a list payload. The list may be the payload
+// itself or be wrapped in an object under one of the given keys.
+func extractItems(data interface{}, keys ...string) []map[string]interface{} {
+	list, ok := data.([]interface{})
+	if !ok {
+		if obj, isMap := data.(map[string]interface{}); isMap {
+			for _, key := range append(append([]string{}, keys...), "items", "data") {
+				if list, ok = obj[key].([]interface{}); ok {
+					break
+				}
+			}
+		}
+	}
+
+	items := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		if m, ok := item.(map[string]interface{}); ok {
+			items = append(items, m)
+		}
+	}
+	return items
+}
+
+// stringField returns the first non-empty string value found under the given keys.
+func stringField(m map[string]interface{}, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := m[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,173 @@
+package progressive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// fakeClient serves canned JSON payloads keyed by "METHOD path" (query string ignored)
+// and records the requested endpoints.
+type fakeClient struct {
+	responses map[string]string
+	requests  []string
+	bodies    [][]byte
+	mu        sync.Mutex
+}
+
+func (f *fakeClient) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	f.mu.Lock()
+	f.requests = append(f.requests, endpoint)
+	f.bodies = append(f.bodies, body)
+	f.mu.Unlock()
+
+	path, _, _ := strings.Cut(strings.TrimPrefix(endpoint, f.GetConfig().BaseURL), "?")
+	payload, ok := f.responses[method+" "+path]
+	if !ok {
+		return &utils.Response{Status: utils.StatusError, HTTPCode: 404}, fmt.Errorf("%w: %s", utils.ErrNotFound, path)
+	}
+	var data any
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return nil, err
+	}
+	return &utils.Response{Status: utils.StatusOK, Data: data, HTTPCode: 200}, nil
+}
+
+func (f *fakeClient) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://api.test"}
+}
+
+func TestOrgBuilder_Tree(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /org-1/harbors":          `[{"id": "h1", "name": "Harbor 1"}]`,
+		"GET /harbors/h1/data-docks":  `[{"id": "dd1", "name": "Dock 1"}, {"id": "dd2", "name": "Sleeping"}]`,
+		"GET /data-docks/dd1/catalog": `{"catalogs": [{"catalog_name": "sales", "schemas": [{"schema_name": "public", "tables": [{"table_name": "orders"}]}]}]}`,
+	}}
+
+	tree, err := (&OrgBuilder{Client: client, OrgID: "org-1"}).Tree(context.Background())
+	if err != nil {
+		t.Fatalf("Tree() unexpected error = %v", err)
+	}
+
+	if len(tree.Harbors) != 1 || len(tree.Harbors[0].DataDocks) != 2 {
+		t.Fatalf("unexpected tree shape: %+v", tree)
+	}
+	dock := tree.Harbors[0].DataDocks[0]
+	if dock.Name != "Dock 1" || len(dock.Catalogs) != 1 {
+		t.Fatalf("unexpected datadock node: %+v", dock)
+	}
+	if got := dock.Catalogs[0].Schemas[0].Tables[0].Name; got != "orders" {
+		t.Errorf("table name = %q, want %q", got, "orders")
+	}
+	if tree.Harbors[0].DataDocks[1].Error == "" {
+		t.Error("expected an error on the datadock whose catalog could not be fetched")
+	}
+}
+
+func TestOrgBuilder_DataDocksFiltersAndPagination(t *testing.T) {
+	pages := map[string]string{
+		"0": `[{"id": "dd1", "name": "a", "type": "TrinoInternal"}, {"id": "dd2", "name": "b", "type": "TrinoInternal"}]`,
+		"2": `[{"id": "dd3", "name": "c", "type": "TrinoInternal"}]`,
+	}
+	client := &pagedClient{pages: pages}
+
+	docks, err := (&OrgBuilder{Client: client, OrgID: "org-1"}).DataDocks(context.Background(), ListOptions{
+		Type:     "TrinoInternal",
+		SortBy:   "name",
+		SortDesc: true,
+		PageSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("DataDocks() unexpected error = %v", err)
+	}
+	if len(docks) != 3 || docks[2].ID != "dd3" || docks[0].Type != "TrinoInternal" {
+		t.Fatalf("unexpected datadocks: %+v", docks)
+	}
+	if len(client.queries) != 2 {
+		t.Fatalf("expected 2 page requests, got %d", len(client.queries))
+	}
+	if q := client.queries[0]; q.Get("type") != "TrinoInternal" || q.Get("order") != "name.desc" || q.Get("limit") != "2" {
+		t.Errorf("unexpected query parameters: %v", q)
+	}
+}
+
+// pagedClient serves pages keyed by the offset query parameter.
+type pagedClient struct {
+	pages   map[string]string
+	queries []url.Values
+}
+
+func (p *pagedClient) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	p.queries = append(p.queries, parsed.Query())
+
+	var data any = []any{}
+	if payload, ok := p.pages[parsed.Query().Get("offset")]; ok {
+		if err := json.Unmarshal([]byte(payload), &data); err != nil {
+			return nil, err
+		}
+	}
+	return &utils.Response{Status: utils.StatusOK, Data: data, HTTPCode: 200}, nil
+}
+
+func (p *pagedClient) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://api.test"}
+}
+
+func TestOrgBuilder_AuditLogs(t *testing.T) {
+	client := &pagedClient{pages: map[string]string{
+		"0": `{"audit_logs": [{"id": "a1", "timestamp": "2024-05-01T10:00:00Z", "actor": "alice", "action": "datadock.wake_up", "resource_type": "datadock", "resource_id": "dd1"}]}`,
+	}}
+
+	entries, err := (&OrgBuilder{Client: client, OrgID: "org-1"}).AuditLogs(context.Background(), AuditFilter{
+		From:         time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Action:       "datadock.wake_up",
+		ResourceType: "datadock",
+	})
+	if err != nil {
+		t.Fatalf("AuditLogs() unexpected error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Actor != "alice" || entries[0].Timestamp.Hour() != 10 {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	q := client.queries[0]
+	if q.Get("from") != "2024-05-01T00:00:00Z" || q.Get("action") != "datadock.wake_up" || q.Get("resource_type") != "datadock" || q.Has("to") {
+		t.Errorf("unexpected query parameters: %v", q)
+	}
+}
+
+func TestWebhooksBuilder(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"POST /org-1/webhooks":       `{"id": "wh1", "url": "https://example.com/hook", "events": ["datadock.status_changed"], "active": true}`,
+		"GET /org-1/webhooks":        `{"webhooks": [{"id": "wh1", "url": "https://example.com/hook"}]}`,
+		"DELETE /org-1/webhooks/wh1": `{}`,
+	}}
+	webhooks := (&OrgBuilder{Client: client, OrgID: "org-1"}).Webhooks()
+	ctx := context.Background()
+
+	if _, err := webhooks.Create(ctx, WebhookSpec{URL: "example.com/hook", Events: []string{WebhookEventCatalogRefreshed}}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected relative URL to be rejected, got %v", err)
+	}
+	webhook, err := webhooks.Create(ctx, WebhookSpec{URL: "https://example.com/hook", Events: []string{WebhookEventDataDockStatusChanged}, Secret: "s3cret"})
+	if err != nil || webhook.ID != "wh1" || !webhook.Active {
+		t.Fatalf("Create() = %+v, %v", webhook, err)
+	}
+	list, err := webhooks.List(ctx)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("List() = %+v, %v", list, err)
+	}
+	if _, err := webhooks.Delete(ctx, "wh1"); err != nil {
+		t.Errorf("Delete() unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Statuses of a quota.
+const (
+	QuotaOK       = "ok"
+	QuotaWarning  = "warning"
+	QuotaExceeded = "exceeded"
+)
+
+// QuotaProfile holds the limits of the subscription of an organization.
+type QuotaProfile struct {
+	ID                     string `json:"id"`
+	Name                   string `json:"name"`
+	Slug                   string `json:"slug"`
+	Description            string `json:"description,omitempty"`
+	MaxHarbors             int    `json:"max_harbors"`
+	MaxDataDocks           int    `json:"max_data_docks"`
+	MaxPipelines           int    `json:"max_pipelines"`
+	MaxModelServings       int    `json:"max_model_servings"`
+	MaxUsers               int    `json:"max_users"`
+	MaxAPIKeys             int    `json:"max_api_keys"`
+	MaxServiceAccounts     int    `json:"max_service_accounts"`
+	MaxStorageGB           int    `json:"max_storage_gb"`
+	MaxVCPU                int    `json:"max_vcpu"`
+	MaxRAMGB               int    `json:"max_ram_gb"`
+	MaxPipelineRunsMonthly int    `json:"max_pipeline_runs_monthly"`
+	MaxLLMTokensMonthly    int64  `json:"max_llm_tokens_monthly"`
+	MaxAPICallsDaily       int64  `json:"max_api_calls_daily"`
+}
+
+// Quota is the consumption of a resource of an organization against its limit.
+type Quota struct {
+	// Resource is a resource type ("harbor", "data_dock", "user", "api_key"...) or,
+	// for capacity quotas, a compute dimension ("vcpu", "ram", "storage").
+	Resource   string  `json:"resource"`
+	Status     string  `json:"status"` // QuotaOK, QuotaWarning or QuotaExceeded
+	Current    int64   `json:"current"`
+	Requested  int64   `json:"requested,omitempty"` // Capacity reserved by pending operations
+	Limit      int64   `json:"limit"`               // Negative when unlimited
+	Percentage float64 `json:"percentage"`          // -1 when unlimited
+	Unit       string  `json:"unit,omitempty"`      // Capacity quotas: millicores, MiB or GiB
+}
+
+// Remaining returns what is left of the quota, or -1 when it is unlimited.
+func (q Quota) Remaining() int64 {
+	if q.Limit < 0 {
+		return -1
+	}
+	if used := q.Current + q.Requested; used < q.Limit {
+		return q.Limit - used
+	}
+	return 0
+}
+
+// Exceeded reports whether the consumption reached the limit.
+func (q Quota) Exceeded() bool {
+	return q.Status == QuotaExceeded
+}
+
+// Quotas is the quota profile of an organization and its consumption.
+type Quotas struct {
+	Profile QuotaProfile
+	// Usage counts the resources of the organization (harbors, datadocks, users...).
+	Usage []Quota
+	// Capacity is the compute reserved by the organization (vCPU, RAM, storage).
+	Capacity []Quota
+}
+
+// Exceeded returns the quotas whose limit is reached.
+func (q *Quotas) Exceeded() []Quota {
+	var exceeded []Quota
+	for _, quota := range append(append([]Quota{}, q.Usage...), q.Capacity...) {
+		if quota.Exceeded() {
+			exceeded = append(exceeded, quota)
+		}
+	}
+	return exceeded
+}
+
+// Quotas retrieves the quota profile of this organization and its current consumption,
+// from the control plane.
+//
+// Example:
+//
+//	quotas, err := client.Org(orgID).Quotas(ctx)
+//	for _, quota := range quotas.Exceeded() {
+//	    log.Printf("%s quota reached: %d/%d", quota.Resource, quota.Current, quota.Limit)
+//	}
+func (o *OrgBuilder) Quotas(ctx context.Context) (*Quotas, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/orgs/%s/quotas",
+		controlPlaneURL(o.Client),
+		url.PathEscape(o.OrgID),
+	)
+	resp, err := o.Client.Do(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		Profile QuotaProfile `json:"profile"`
+		Usage   []struct {
+			Quota
+			ResourceType string `json:"resource_type"`
+		} `json:"usage"`
+		Capacity []struct {
+			Quota
+			Dimension string `json:"dimension"`
+		} `json:"capacity"`
+	}
+	if err := utils.UnmarshalData(resp.Data, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode quotas: %w", err)
+	}
+
+	quotas := &Quotas{Profile: data.Profile}
+	for _, item := range data.Usage {
+		item.Quota.Resource = item.ResourceType
+		quotas.Usage = append(quotas.Usage, item.Quota)
+	}
+	for _, item := range data.Capacity {
+		item.Quota.Resource = item.Dimension
+		quotas.Capacity = append(quotas.Capacity, item.Quota)
+	}
+	return quotas, nil
+}
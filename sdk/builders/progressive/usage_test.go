@@ -0,0 +1,45 @@
+package progressive
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOrgBuilder_Quotas(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"GET /api/v1/orgs/org-1/quotas": `{
+			"profile": {"id": "p1", "slug": "team", "max_data_docks": 10, "max_vcpu": 8},
+			"usage": [
+				{"resource_type": "data_dock", "status": "exceeded", "current": 10, "limit": 10, "percentage": 100},
+				{"resource_type": "user", "status": "ok", "current": 3, "limit": -1, "percentage": -1}
+			],
+			"capacity": [
+				{"dimension": "vcpu", "status": "warning", "current": 6000, "requested": 1000, "limit": 8000, "percentage": 87.5, "unit": "millicores"}
+			]}`,
+	}}
+
+	quotas, err := (&OrgBuilder{Client: client, OrgID: "org-1"}).Quotas(context.Background())
+	if err != nil {
+		t.Fatalf("Quotas() unexpected error = %v", err)
+	}
+	if quotas.Profile.Slug != "team" || quotas.Profile.MaxDataDocks != 10 {
+		t.Errorf("unexpected profile: %+v", quotas.Profile)
+	}
+	if len(quotas.Usage) != 2 || len(quotas.Capacity) != 1 {
+		t.Fatalf("unexpected quotas: %+v", quotas)
+	}
+
+	docks, users, vcpu := quotas.Usage[0], quotas.Usage[1], quotas.Capacity[0]
+	if docks.Resource != "data_dock" || !docks.Exceeded() || docks.Remaining() != 0 {
+		t.Errorf("datadock quota should be exhausted: %+v", docks)
+	}
+	if users.Exceeded() || users.Remaining() != -1 {
+		t.Errorf("unlimited quota reported as limited: %+v", users)
+	}
+	if vcpu.Resource != "vcpu" || vcpu.Unit != "millicores" || vcpu.Remaining() != 1000 {
+		t.Errorf("unexpected capacity quota: %+v", vcpu)
+	}
+	if exceeded := quotas.Exceeded(); len(exceeded) != 1 || exceeded[0].Resource != "data_dock" {
+		t.Errorf("Exceeded() = %+v", exceeded)
+	}
+}
@@ -0,0 +1,164 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// View is a saved query of a datadock, stored on the platform.
+type View struct {
+	ID          string    `json:"id"`
+	DataDockID  string    `json:"data_dock_id"`
+	Name        string    `json:"title"`
+	SQL         string    `json:"sql_text"`
+	Description string    `json:"description,omitempty"`
+	Visibility  string    `json:"visibility,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ViewsBuilder manages the saved queries of a datadock, through the control plane,
+// so shared query logic lives on the platform.
+// Available methods:
+//   - Create(ctx, name, sql) - Save a query
+//   - List(ctx) - List the saved queries
+//   - Get(ctx, id) - Get a saved query and its SQL
+//   - Update(ctx, id, sql) - Replace the SQL of a saved query
+//   - Delete(ctx, id) - Delete a saved query
+//   - Run(ctx, view) - Execute a saved query
+type ViewsBuilder struct {
+	client     builders.ClientInterface
+	dataDockID string
+}
+
+// Views returns a builder managing the saved queries of this datadock.
+func (d *DataDockBuilder) Views() *ViewsBuilder {
+	return &ViewsBuilder{client: d.client, dataDockID: d.dataDockID}
+}
+
+// Create saves sql under name. The SQL is validated by the server.
+//
+// Example:
+//
+//	view, err := dock.Views().Create(ctx, "Active customers",
+//	    "SELECT * FROM sales.public.customers WHERE churned_at IS NULL")
+//	resp, err := dock.Views().Run(ctx, *view)
+func (v *ViewsBuilder) Create(ctx context.Context, name, sql string) (*View, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("%w: view name is required", utils.ErrInvalidRequest)
+	}
+	if strings.TrimSpace(sql) == "" {
+		return nil, fmt.Errorf("%w: view SQL is required", utils.ErrInvalidRequest)
+	}
+
+	body, err := utils.EncodeBody(v.client.GetConfig(), map[string]string{"data_dock_id": v.dataDockID, "title": name, "sql_text": sql})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.client.Do(ctx, "POST", v.endpoint(), body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeView(resp)
+}
+
+// List retrieves every saved query of this datadock.
+func (v *ViewsBuilder) List(ctx context.Context) ([]View, error) {
+	params := url.Values{"data_dock_id": {v.dataDockID}}
+	resp, err := v.client.Do(ctx, "GET", v.endpoint()+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var views []View
+	if err := utils.UnmarshalData(resp.Data, &views); err != nil {
+		return nil, fmt.Errorf("failed to decode views: %w", err)
+	}
+	return views, nil
+}
+
+// Get retrieves a saved query, given its ID.
+func (v *ViewsBuilder) Get(ctx context.Context, viewID string) (*View, error) {
+	endpoint, err := v.viewEndpoint(viewID)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.client.Do(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decodeView(resp)
+}
+
+// Update replaces the SQL of a saved query, given its ID.
+func (v *ViewsBuilder) Update(ctx context.Context, viewID, sql string) (*View, error) {
+	endpoint, err := v.viewEndpoint(viewID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(sql) == "" {
+		return nil, fmt.Errorf("%w: view SQL is required", utils.ErrInvalidRequest)
+	}
+
+	body, err := utils.EncodeBody(v.client.GetConfig(), map[string]string{"sql_text": sql})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := v.client.Do(ctx, "PUT", endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeView(resp)
+}
+
+// Delete removes a saved query, given its ID.
+func (v *ViewsBuilder) Delete(ctx context.Context, viewID string) (*utils.Response, error) {
+	endpoint, err := v.viewEndpoint(viewID)
+	if err != nil {
+		return nil, err
+	}
+	return v.client.Do(ctx, "DELETE", endpoint, nil)
+}
+
+// Run executes a saved query on its datadock, through the SQL execution endpoint.
+func (v *ViewsBuilder) Run(ctx context.Context, view View) (*utils.Response, error) {
+	if strings.TrimSpace(view.SQL) == "" {
+		return nil, fmt.Errorf("%w: view SQL is required", utils.ErrInvalidRequest)
+	}
+	dataDockID := view.DataDockID
+	if dataDockID == "" {
+		dataDockID = v.dataDockID
+	}
+
+	body, err := utils.EncodeBody(v.client.GetConfig(), map[string]string{"data_dock_id": dataDockID, "sql": view.SQL})
+	if err != nil {
+		return nil, err
+	}
+	return v.client.Do(ctx, "POST", controlPlaneURL(v.client)+"/api/v1/tiny-query/execute", body)
+}
+
+func (v *ViewsBuilder) endpoint() string {
+	return controlPlaneURL(v.client) + "/api/v1/tiny-query/queries"
+}
+
+func (v *ViewsBuilder) viewEndpoint(viewID string) (string, error) {
+	if viewID == "" {
+		return "", fmt.Errorf("%w: view ID is required", utils.ErrInvalidRequest)
+	}
+	return v.endpoint() + "/" + url.PathEscape(viewID), nil
+}
+
+func decodeView(resp *utils.Response) (*View, error) {
+	var view View
+	if err := utils.UnmarshalData(resp.Data, &view); err != nil {
+		return nil, fmt.Errorf("failed to decode view: %w", err)
+	}
+	return &view, nil
+}
@@ -0,0 +1,62 @@
+package progressive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestViewsBuilder(t *testing.T) {
+	client := &fakeClient{responses: map[string]string{
+		"POST /api/v1/tiny-query/queries":      `{"id": "q1", "data_dock_id": "dd1", "title": "Active", "sql_text": "SELECT 1", "created_by": "u1"}`,
+		"GET /api/v1/tiny-query/queries":       `[{"id": "q1", "data_dock_id": "dd1", "title": "Active", "sql_text": "SELECT 1"}]`,
+		"PUT /api/v1/tiny-query/queries/q1":    `{"id": "q1", "data_dock_id": "dd1", "title": "Active", "sql_text": "SELECT 2"}`,
+		"DELETE /api/v1/tiny-query/queries/q1": `{}`,
+		"POST /api/v1/tiny-query/execute":      `{"query_id": "t1", "columns": [{"name": "_col0"}], "rows": [[1]]}`,
+	}}
+	views := (&OrgBuilder{Client: client, OrgID: "org-1"}).Harbor("h1").DataDock("dd1").Views()
+	ctx := context.Background()
+
+	view, err := views.Create(ctx, "Active", "SELECT 1")
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if view.ID != "q1" || view.Name != "Active" || view.CreatedBy != "u1" {
+		t.Errorf("Unexpected view %+v", view)
+	}
+	if body := string(client.bodies[0]); body != `{"data_dock_id":"dd1","sql_text":"SELECT 1","title":"Active"}` {
+		t.Errorf("Unexpected create request %s", body)
+	}
+
+	list, err := views.List(ctx)
+	if err != nil || len(list) != 1 || list[0].SQL != "SELECT 1" {
+		t.Errorf("List() = %+v, %v", list, err)
+	}
+	if last := client.requests[len(client.requests)-1]; last != "https://api.test/api/v1/tiny-query/queries?data_dock_id=dd1" {
+		t.Errorf("Unexpected request %s", last)
+	}
+
+	if view, err := views.Update(ctx, "q1", "SELECT 2"); err != nil || view.SQL != "SELECT 2" {
+		t.Errorf("Update() = %+v, %v", view, err)
+	}
+
+	resp, err := views.Run(ctx, list[0])
+	if err != nil || resp.Data == nil {
+		t.Errorf("Run() = %v, %v", resp, err)
+	}
+	if body := string(client.bodies[len(client.bodies)-1]); body != `{"data_dock_id":"dd1","sql":"SELECT 1"}` {
+		t.Errorf("Unexpected execute request %s", body)
+	}
+
+	if _, err := views.Delete(ctx, "q1"); err != nil {
+		t.Errorf("Delete() unexpected error = %v", err)
+	}
+	if _, err := views.Create(ctx, "", "SELECT 1"); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest without a name, got %v", err)
+	}
+	if _, err := views.Delete(ctx, ""); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest without an ID, got %v", err)
+	}
+}
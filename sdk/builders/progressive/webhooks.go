@@ -0,0 +1,98 @@
+package progressive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Platform events that can be delivered to a webhook.
+const (
+	WebhookEventDataDockStatusChanged = "datadock.status_changed"
+	WebhookEventCatalogRefreshed      = "catalog.refresh_completed"
+	WebhookEventArchiveFinished       = "archive.operation_finished"
+)
+
+// WebhookSpec describes a webhook subscription to create.
+// Secret is used by the platform to sign deliveries (see sdk.VerifyWebhookRequest).
+type WebhookSpec struct {
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+	Secret      string   `json:"secret,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// Webhook is a registered webhook subscription.
+type Webhook struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	Events      []string  `json:"events"`
+	Description string    `json:"description,omitempty"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// WebhooksBuilder manages the webhook subscriptions of an organization.
+// Available methods:
+//   - Create(ctx, spec) - Register a webhook
+//   - List(ctx) - List registered webhooks
+//   - Delete(ctx, id) - Remove a webhook
+type WebhooksBuilder struct {
+	client builders.ClientInterface
+	orgID  string
+}
+
+// Webhooks returns a builder managing the webhook subscriptions of this organization.
+func (o *OrgBuilder) Webhooks() *WebhooksBuilder {
+	return &WebhooksBuilder{client: o.Client, orgID: o.OrgID}
+}
+
+// Create registers a webhook for the given events.
+func (w *WebhooksBuilder) Create(ctx context.Context, spec WebhookSpec) (*Webhook, error) {
+	parsed, err := url.Parse(spec.URL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return nil, fmt.Errorf("%w: webhook URL must be an absolute http(s) URL", utils.ErrInvalidRequest)
+	}
+	if len(spec.Events) == 0 {
+		return nil, fmt.Errorf("%w: webhook needs at least one event", utils.ErrInvalidRequest)
+	}
+
+	body, err := utils.EncodeBody(w.client.GetConfig(), spec)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := w.client.Do(ctx, "POST", w.endpoint(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhook Webhook
+	if err := utils.UnmarshalData(resp.Data, &webhook); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook: %w", err)
+	}
+	return &webhook, nil
+}
+
+// List retrieves every webhook registered for this organization.
+func (w *WebhooksBuilder) List(ctx context.Context) ([]Webhook, error) {
+	return listAll[Webhook](ctx, w.client, w.endpoint(), "webhooks", nil, 0, 0)
+}
+
+// Delete removes a webhook.
+func (w *WebhooksBuilder) Delete(ctx context.Context, webhookID string) (*utils.Response, error) {
+	if webhookID == "" {
+		return nil, fmt.Errorf("%w: webhook ID is required", utils.ErrInvalidRequest)
+	}
+	return w.client.Do(ctx, "DELETE", w.endpoint()+"/"+url.PathEscape(webhookID), nil)
+}
+
+func (w *WebhooksBuilder) endpoint() string {
+	return fmt.Sprintf("%s/%s/webhooks",
+		w.client.GetConfig().BaseURL,
+		url.PathEscape(w.orgID),
+	)
+}
@@ -0,0 +1,210 @@
+package builders
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// maxReportedFieldErrors bounds the number of field errors listed in the error message.
+const maxReportedFieldErrors = 10
+
+// Column describes a table column.
+type Column struct {
+	Name       string `json:"name"`
+	DataType   string `json:"data_type"`
+	Nullable   bool   `json:"nullable,omitempty"`
+	PrimaryKey bool   `json:"primary_key,omitempty"` // Part of the table primary key
+}
+
+// ParseColumns converts the payload of a table columns endpoint into columns.
+// The list may be the payload itself or be wrapped under "columns", "items" or "data".
+func ParseColumns(data any) []Column {
+	list, ok := data.([]any)
+	if obj, isMap := data.(map[string]any); !ok && isMap {
+		for _, key := range []string{"columns", "items", "data"} {
+			if list, ok = obj[key].([]any); ok {
+				break
+			}
+		}
+	}
+
+	columns := make([]Column, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		column := Column{Name: firstString(m, "name", "column_name"), DataType: firstString(m, "data_type", "type")}
+		column.Nullable, _ = m["nullable"].(bool)
+		for _, key := range []string{"primary_key", "is_primary_key"} {
+			if primary, _ := m[key].(bool); primary {
+				column.PrimaryKey = true
+			}
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+func firstString(m map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if value, ok := m[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// FieldError is a schema violation of one field of a payload row.
+type FieldError struct {
+	Row     int // Index of the row in the payload, 0 for a single object
+	Column  string
+	Message string
+}
+
+func (e FieldError) String() string {
+	return fmt.Sprintf("row %d: %s: %s", e.Row, e.Column, e.Message)
+}
+
+// SchemaValidationError lists the fields of a payload that do not match the table
+// columns. It wraps utils.ErrInvalidRequest.
+type SchemaValidationError struct {
+	Table  string
+	Errors []FieldError
+}
+
+func (e *SchemaValidationError) Error() string {
+	messages := make([]string, 0, maxReportedFieldErrors)
+	for i, fieldErr := range e.Errors {
+		if i == maxReportedFieldErrors {
+			messages = append(messages, fmt.Sprintf("and %d more", len(e.Errors)-i))
+			break
+		}
+		messages = append(messages, fieldErr.String())
+	}
+	return fmt.Sprintf("%v: payload does not match the schema of %s: %s", utils.ErrInvalidRequest, e.Table, strings.Join(messages, "; "))
+}
+
+func (e *SchemaValidationError) Unwrap() error {
+	return utils.ErrInvalidRequest
+}
+
+// ValidateRows checks that every key of the payload rows is a column of the table
+// and that its value has a compatible JSON type. The payload is a row (struct or
+// map) or a slice of rows. Null values and columns of unknown types are accepted.
+func ValidateRows(table string, columns []Column, payload any) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%w: payload is not JSON serializable: %w", utils.ErrInvalidRequest, err)
+	}
+	var decoded any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return fmt.Errorf("%w: payload is not JSON serializable: %w", utils.ErrInvalidRequest, err)
+	}
+	rows, isList := decoded.([]any)
+	if !isList {
+		rows = []any{decoded}
+	}
+
+	types := make(map[string]string, len(columns))
+	for _, column := range columns {
+		types[column.Name] = column.DataType
+	}
+
+	var fieldErrors []FieldError
+	for i, item := range rows {
+		row, ok := item.(map[string]any)
+		if !ok {
+			fieldErrors = append(fieldErrors, FieldError{Row: i, Message: "row is not an object"})
+			continue
+		}
+		for _, key := range sortedKeys(row) {
+			dataType, known := types[key]
+			if !known {
+				fieldErrors = append(fieldErrors, FieldError{Row: i, Column: key, Message: "unknown column"})
+				continue
+			}
+			if message := checkType(dataType, row[key]); message != "" {
+				fieldErrors = append(fieldErrors, FieldError{Row: i, Column: key, Message: message})
+			}
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return &SchemaValidationError{Table: table, Errors: fieldErrors}
+	}
+	return nil
+}
+
+// checkType returns a description of the mismatch between a JSON value and a SQL type,
+// or "" when they are compatible.
+func checkType(dataType string, value any) string {
+	if value == nil {
+		return ""
+	}
+	base := strings.ToLower(strings.TrimSpace(dataType))
+	if i := strings.IndexAny(base, "( "); i >= 0 {
+		base = base[:i]
+	}
+
+	var expected string
+	ok := true
+	switch base {
+	case "int", "integer", "bigint", "smallint", "tinyint", "int2", "int4", "int8", "serial", "bigserial", "smallserial":
+		expected = "an integer"
+		number, isNumber := value.(float64)
+		ok = isNumber && number == math.Trunc(number)
+	case "real", "double", "float", "float4", "float8":
+		expected = "a number"
+		_, ok = value.(float64)
+	case "decimal", "numeric":
+		// Decimals may be sent as strings to keep their precision
+		expected = "a number"
+		switch value.(type) {
+		case float64, string:
+		default:
+			ok = false
+		}
+	case "bool", "boolean":
+		expected = "a boolean"
+		_, ok = value.(bool)
+	case "varchar", "char", "character", "text", "string", "uuid", "date", "time", "timestamp", "timestamptz", "interval":
+		expected = "a string"
+		_, ok = value.(string)
+	}
+
+	if ok {
+		return ""
+	}
+	return fmt.Sprintf("expected %s for type %s, got %s", expected, dataType, jsonTypeName(value))
+}
+
+func jsonTypeName(value any) string {
+	switch value.(type) {
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
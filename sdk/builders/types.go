@@ -2,6 +2,11 @@ package builders
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
 
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
 )
@@ -13,6 +18,67 @@ type Filter struct {
 	Value    interface{}
 }
 
+// localTimeLayout is ISO 8601 without offset, for wall-clock times.
+const localTimeLayout = "2006-01-02T15:04:05.999999999"
+
+// FormatFilterValue formats a filter value for the query string. Times are sent in
+// ISO 8601, in UTC with a Z suffix; when loc is set, they are sent as wall-clock
+// times of loc without offset instead, for timestamp columns without time zone.
+func FormatFilterValue(value any, loc *time.Location) string {
+	var t time.Time
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return fmt.Sprintf("%v", value)
+		}
+		t = *v
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+	if loc == nil {
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	return t.In(loc).Format(localTimeLayout)
+}
+
+// builderParams are the query parameters set by builder methods (Select, OrderBy,
+// Limit, Offset, After, Export, Sample). They take a single value.
+var builderParams = map[string]bool{
+	"__select": true, "order": true, "__limit": true, "__offset": true,
+	"__cursor": true, "__format": true, "__sample": true,
+}
+
+// MergeRawParams adds raw query parameters to dst. A parameter set by a builder
+// method keeps a single value, the last given; builders set them after the raw
+// parameters, so the builder methods take precedence. Other parameters, such as
+// filters, accumulate their values, each value once.
+func MergeRawParams(dst, params url.Values) {
+	for key, values := range params {
+		if len(values) == 0 {
+			continue
+		}
+		if builderParams[key] {
+			dst[key] = []string{values[len(values)-1]}
+			continue
+		}
+		for _, value := range values {
+			if !slices.Contains(dst[key], value) {
+				dst[key] = append(dst[key], value)
+			}
+		}
+	}
+}
+
 // OrderClause represents an ORDER BY clause.
 type OrderClause struct {
 	Column    string
@@ -0,0 +1,72 @@
+package builders
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// dataDockOnline is the status reported by a datadock ready to serve queries.
+const dataDockOnline = "Online"
+
+// DoWithWakeUp executes a request against a datadock. When the datadock is asleep
+// and Configuration.AutoWakeUp is enabled, it wakes the datadock up, waits until it
+// is online and retries the request once.
+func DoWithWakeUp(ctx context.Context, client ClientInterface, dataDockID, method, endpoint string, body []byte) (*utils.Response, error) {
+	resp, err := client.Do(ctx, method, endpoint, body)
+	if !errors.Is(err, utils.ErrDataDockAsleep) || !client.GetConfig().AutoWakeUp || dataDockID == "" {
+		return resp, err
+	}
+
+	if wakeErr := WakeUpAndWait(ctx, client, dataDockID); wakeErr != nil {
+		return resp, fmt.Errorf("%w (automatic wake-up failed: %w)", err, wakeErr)
+	}
+	return client.Do(ctx, method, endpoint, body)
+}
+
+// WakeUpAndWait wakes the datadock up and polls its status until it is online.
+// Waiting is bounded by Configuration.WakeUpTimeout and the context.
+func WakeUpAndWait(ctx context.Context, client ClientInterface, dataDockID string) error {
+	config := client.GetConfig()
+	timeout := config.WakeUpTimeout
+	if timeout <= 0 {
+		timeout = utils.DefaultWakeUpTimeout
+	}
+	interval := config.WakeUpPollInterval
+	if interval <= 0 {
+		interval = utils.DefaultWakeUpPollInterval
+	}
+
+	if _, err := client.Do(ctx, "POST", dataDockEndpoint(config, dataDockID)+"/wake-up", nil); err != nil {
+		return fmt.Errorf("failed to wake up datadock %s: %w", dataDockID, err)
+	}
+	_, err := WaitForDataDockStatus(ctx, client, dataDockID, dataDockOnline, utils.PollOptions{Interval: interval, Timeout: timeout})
+	return err
+}
+
+// WaitForDataDockStatus polls a datadock until it reports the given status and
+// returns its last details.
+func WaitForDataDockStatus(ctx context.Context, client ClientInterface, dataDockID, status string, opts utils.PollOptions) (map[string]any, error) {
+	endpoint := dataDockEndpoint(client.GetConfig(), dataDockID)
+	var fetchErr error
+	data, err := utils.Poll(ctx, func(ctx context.Context) (map[string]any, bool, error) {
+		resp, err := client.Do(ctx, "GET", endpoint, nil)
+		if err != nil {
+			fetchErr = fmt.Errorf("failed to get status of datadock %s: %w", dataDockID, err)
+			return nil, false, fetchErr
+		}
+		data, _ := resp.GetDataAsMap()
+		return data, data["status"] == status, nil
+	}, opts)
+	if err != nil && err != fetchErr {
+		return data, fmt.Errorf("datadock %s did not reach status %s: %w", dataDockID, status, err)
+	}
+	return data, err
+}
+
+func dataDockEndpoint(config utils.Configuration, dataDockID string) string {
+	return fmt.Sprintf("%s/data-docks/%s", config.BaseURL, url.PathEscape(dataDockID))
+}
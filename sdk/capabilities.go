@@ -0,0 +1,126 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// capabilitiesPath is the Bifrost feature flags endpoint of the control plane.
+const capabilitiesPath = "/api/v1/bifrost/features"
+
+// capabilityRetryInterval is how long a failed discovery is cached before the
+// next attempt, so that requests are not slowed down by a failing endpoint.
+var capabilityRetryInterval = time.Minute
+
+// capabilityCache holds the discovered capabilities; shared with derived clients.
+type capabilityCache struct {
+	mu           sync.Mutex
+	capabilities *utils.Capabilities
+	err          error // Last discovery failure, until retryAt
+	retryAt      time.Time
+}
+
+// bifrostFeatures is the feature flags document of the control plane.
+type bifrostFeatures struct {
+	FullTextSearch bool `json:"full_text_search_enabled"`
+	VectorSearch   bool `json:"vector_search_enabled"`
+	OpenAPI        bool `json:"openapi_enabled"`
+	GraphQL        bool `json:"graphql_enabled"`
+	PostgreSQL     bool `json:"postgresql_enabled"`
+	MCP            bool `json:"mcp_enabled"`
+}
+
+// Capabilities returns the optional features enabled on the platform, read from the
+// Bifrost feature flags of the control plane (/api/v1/bifrost/features). The result
+// is cached for the lifetime of the client; a failed discovery is retried after a
+// minute.
+//
+// Once discovered, builders fail with utils.ErrUnsupportedFeature before sending a
+// request the deployment cannot serve; set Configuration.DiscoverCapabilities to
+// discover them automatically on first use. Deployments without the endpoint report
+// Discovered == false, in which case every feature is assumed to be available. The
+// feature flags do not list filter operators, count modes or export formats: they
+// are all assumed to be available.
+//
+// Example:
+//
+//	caps, err := client.Capabilities(ctx)
+//	if err == nil && !caps.SupportsFeature(utils.FeatureHybridSearch) {
+//	    // Fall back to full-text search
+//	}
+func (c *Client) Capabilities(ctx context.Context) (*utils.Capabilities, error) {
+	cache := c.capabilities
+	if cache == nil {
+		// Client not built by NewClient: nothing to cache, assume a legacy deployment
+		return &utils.Capabilities{}, nil
+	}
+	cache.mu.Lock()
+	capabilities, err := cache.capabilities, cache.err
+	if err != nil && !time.Now().Before(cache.retryAt) {
+		err = nil
+	}
+	cache.mu.Unlock()
+	if capabilities != nil || err != nil {
+		return capabilities, err
+	}
+
+	// Concurrent first calls may both fetch the document, the results are the same
+	capabilities, err = c.fetchCapabilities(ctx)
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if err != nil {
+		cache.err, cache.retryAt = err, time.Now().Add(capabilityRetryInterval)
+		return nil, err
+	}
+	cache.capabilities, cache.err = capabilities, nil
+	return capabilities, nil
+}
+
+// fetchCapabilities reads the feature flags of the control plane.
+func (c *Client) fetchCapabilities(ctx context.Context) (*utils.Capabilities, error) {
+	endpoint := strings.TrimSuffix(c.controlPlaneBaseURL(ctx), "/") + capabilitiesPath
+	resp, err := c.Do(utils.ContextWithoutTransformers(ctx), "GET", endpoint, nil)
+	switch {
+	case errors.Is(err, utils.ErrNotFound), errors.Is(err, utils.ErrInvalidRequest):
+		// Deployment predating the feature flags endpoint
+		return &utils.Capabilities{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to discover capabilities: %w", err)
+	}
+
+	var flags bifrostFeatures
+	if err := utils.UnmarshalData(resp.Data, &flags); err != nil {
+		return nil, fmt.Errorf("%w: invalid feature flags document: %w", utils.ErrAPIError, err)
+	}
+	capabilities := &utils.Capabilities{Features: []string{}, Discovered: true}
+	enabled := func(on bool, features ...string) {
+		if on {
+			capabilities.Features = append(capabilities.Features, features...)
+		}
+	}
+	enabled(flags.FullTextSearch, utils.FeatureSearch)
+	enabled(flags.VectorSearch, utils.FeatureEmbeddings)
+	enabled(flags.FullTextSearch && flags.VectorSearch, utils.FeatureHybridSearch)
+	// The REST interface serves cost estimates; EstimateCost falls back to the planner without them
+	enabled(flags.OpenAPI, utils.FeatureOpenAPI, utils.FeatureCostEstimate)
+	enabled(flags.GraphQL, utils.FeatureGraphQL)
+	enabled(flags.PostgreSQL, utils.FeaturePostgreSQL)
+	enabled(flags.MCP, utils.FeatureMCP)
+	return capabilities, nil
+}
+
+// CachedCapabilities returns the capabilities discovered so far, or nil.
+func (c *Client) CachedCapabilities() *utils.Capabilities {
+	if c.capabilities == nil {
+		return nil
+	}
+	c.capabilities.mu.Lock()
+	defer c.capabilities.mu.Unlock()
+	return c.capabilities.capabilities
+}
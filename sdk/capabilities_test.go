@@ -0,0 +1,138 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// newCapabilitiesServer serves the given feature flags document (404 when empty,
+// 500 when "error") and counts the requests of each path.
+func newCapabilitiesServer(t *testing.T, document string, calls map[string]int) *httptest.Server {
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls[r.URL.Path]++
+		mu.Unlock()
+		if r.URL.Path == capabilitiesPath {
+			switch document {
+			case "":
+				http.NotFound(w, r)
+			case "error":
+				http.Error(w, "unavailable", http.StatusInternalServerError)
+			default:
+				_, _ = w.Write([]byte(document))
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCapabilities_BuildersRejectUnsupportedFeatures(t *testing.T) {
+	calls := map[string]int{}
+	server := newCapabilitiesServer(t, `{
+		"postgresql_enabled": true,
+		"graphql_enabled": false,
+		"openapi_enabled": true,
+		"mcp_enabled": false,
+		"vector_search_enabled": true,
+		"full_text_search_enabled": false
+	}`, calls)
+	client := NewClient(utils.Configuration{
+		BaseURL: server.URL, OrgID: "org", DataDockID: "dd", Token: "t", DiscoverCapabilities: true,
+	})
+	ctx := context.Background()
+	table := client.Catalog("c").Schema("s").Table("t")
+
+	// Filter operators are not reported by the feature flags: every one is allowed
+	if _, err := table.Where("name", "ILIKE", "a%").Get(ctx); err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	_, err := client.Search().Query("x").Catalog("c").Schema("s").Table("t").Columns("name").Execute(ctx)
+	if !errors.Is(err, utils.ErrUnsupportedFeature) {
+		t.Errorf("Search() error = %v, want ErrUnsupportedFeature", err)
+	}
+
+	if calls[capabilitiesPath] != 1 {
+		t.Errorf("capabilities fetched %d times, want 1", calls[capabilitiesPath])
+	}
+	if calls["/dd/openapi/c/s/t"] != 1 {
+		t.Errorf("table queried %d times, want 1 (unsupported queries must not be sent)", calls["/dd/openapi/c/s/t"])
+	}
+
+	caps, err := client.Capabilities(ctx)
+	if err != nil || !caps.Discovered || !caps.SupportsFeature(utils.FeatureEmbeddings) || caps.SupportsFeature(utils.FeatureHybridSearch) || !caps.SupportsFeature(utils.FeaturePostgreSQL) {
+		t.Errorf("Capabilities() = %+v, %v", caps, err)
+	}
+}
+
+func TestCapabilities_FailedDiscoveryIsCached(t *testing.T) {
+	calls := map[string]int{}
+	defer func(interval time.Duration) { capabilityRetryInterval = interval }(capabilityRetryInterval)
+	capabilityRetryInterval = 100 * time.Millisecond
+	server := newCapabilitiesServer(t, "error", calls)
+	client := NewClient(utils.Configuration{
+		BaseURL: server.URL, OrgID: "org", DataDockID: "dd", Token: "t", DiscoverCapabilities: true,
+	})
+	ctx := context.Background()
+
+	// Discovery failures do not block the queries, nor are they repeated by each of them
+	for range 3 {
+		if _, err := client.Catalog("c").Schema("s").Table("t").Where("id", ">", 1).Get(ctx); err != nil {
+			t.Fatalf("Get() unexpected error = %v", err)
+		}
+	}
+	if calls[capabilitiesPath] != 1 {
+		t.Errorf("capabilities fetched %d times, want 1", calls[capabilitiesPath])
+	}
+
+	time.Sleep(capabilityRetryInterval)
+	if _, err := client.Capabilities(ctx); err == nil {
+		t.Error("Capabilities() expected the discovery error")
+	}
+	if calls[capabilitiesPath] != 2 {
+		t.Errorf("capabilities fetched %d times, want a retry once the interval elapsed", calls[capabilitiesPath])
+	}
+}
+
+func TestCapabilities_LegacyServerAllowsEverything(t *testing.T) {
+	calls := map[string]int{}
+	server := newCapabilitiesServer(t, "", calls)
+	client := NewClient(utils.Configuration{
+		BaseURL: server.URL, OrgID: "org", DataDockID: "dd", Token: "t", DiscoverCapabilities: true,
+	})
+
+	ctx := context.Background()
+	if _, err := client.Catalog("c").Schema("s").Table("t").Where("name", "ILIKE", "a%").Get(ctx); err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	caps, err := client.Capabilities(ctx)
+	if err != nil || caps.Discovered || !caps.SupportsFeature(utils.FeatureHybridSearch) {
+		t.Errorf("Capabilities() = %+v, %v, want undiscovered capabilities allowing everything", caps, err)
+	}
+	if calls[capabilitiesPath] != 1 {
+		t.Errorf("capabilities fetched %d times, want 1", calls[capabilitiesPath])
+	}
+}
+
+func TestCapabilities_NotDiscoveredByDefault(t *testing.T) {
+	calls := map[string]int{}
+	server := newCapabilitiesServer(t, `{"full_text_search_enabled": false}`, calls)
+	client := NewClient(utils.Configuration{BaseURL: server.URL, OrgID: "org", DataDockID: "dd", Token: "t"})
+
+	if _, err := client.Catalog("c").Schema("s").Table("t").Where("id", ">", 1).Get(context.Background()); err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if calls[capabilitiesPath] != 0 {
+		t.Errorf("capabilities fetched %d times without DiscoverCapabilities, want 0", calls[capabilitiesPath])
+	}
+}
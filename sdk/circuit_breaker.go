@@ -0,0 +1,125 @@
+package sdk
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// circuitBreaker tracks consecutive failures per host and fails fast while a
+// host's circuit is open. A nil *circuitBreaker is disabled.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	onChange  func(host string, state utils.CircuitState)
+	hosts     map[string]*hostCircuit
+}
+
+type hostCircuit struct {
+	state    utils.CircuitState
+	failures int
+	openedAt time.Time
+}
+
+// newCircuitBreaker returns nil when the circuit breaker is disabled in the configuration.
+func newCircuitBreaker(cfg utils.Configuration) *circuitBreaker {
+	if cfg.CircuitBreakerThreshold <= 0 {
+		return nil
+	}
+	cooldown := cfg.CircuitBreakerCooldown
+	if cooldown <= 0 {
+		cooldown = utils.DefaultCircuitBreakerCooldown
+	}
+	return &circuitBreaker{
+		threshold: cfg.CircuitBreakerThreshold,
+		cooldown:  cooldown,
+		onChange:  cfg.OnCircuitStateChange,
+		hosts:     make(map[string]*hostCircuit),
+	}
+}
+
+// allow returns utils.ErrCircuitOpen if requests to host must fail fast.
+// Once the cool-down has elapsed, a single trial request is let through.
+func (cb *circuitBreaker) allow(host string) error {
+	if cb == nil {
+		return nil
+	}
+
+	cb.mu.Lock()
+	notify, err := cb.allowLocked(host)
+	cb.mu.Unlock()
+	notify()
+	return err
+}
+
+func (cb *circuitBreaker) allowLocked(host string) (func(), error) {
+	circuit := cb.hosts[host]
+	if circuit == nil {
+		return func() {}, nil
+	}
+
+	switch circuit.state {
+	case utils.CircuitOpen:
+		if time.Since(circuit.openedAt) < cb.cooldown {
+			return func() {}, utils.ErrCircuitOpen
+		}
+		circuit.openedAt = time.Now()
+		return cb.setState(host, circuit, utils.CircuitHalfOpen), nil
+	case utils.CircuitHalfOpen:
+		// A trial request is already in flight, unless it never reported back
+		if time.Since(circuit.openedAt) < cb.cooldown {
+			return func() {}, utils.ErrCircuitOpen
+		}
+		circuit.openedAt = time.Now()
+	}
+	return func() {}, nil
+}
+
+// record reports the outcome of a request to host.
+func (cb *circuitBreaker) record(host string, success bool) {
+	if cb == nil {
+		return
+	}
+
+	cb.mu.Lock()
+	notify := cb.recordLocked(host, success)
+	cb.mu.Unlock()
+	notify()
+}
+
+func (cb *circuitBreaker) recordLocked(host string, success bool) func() {
+	circuit := cb.hosts[host]
+	if circuit == nil {
+		circuit = &hostCircuit{state: utils.CircuitClosed}
+		cb.hosts[host] = circuit
+	}
+
+	if success {
+		circuit.failures = 0
+		if circuit.state != utils.CircuitClosed {
+			return cb.setState(host, circuit, utils.CircuitClosed)
+		}
+		return func() {}
+	}
+
+	circuit.failures++
+	if circuit.state == utils.CircuitHalfOpen || circuit.failures >= cb.threshold {
+		circuit.openedAt = time.Now()
+		if circuit.state != utils.CircuitOpen {
+			return cb.setState(host, circuit, utils.CircuitOpen)
+		}
+	}
+	return func() {}
+}
+
+// setState must be called with cb.mu held. It returns the notification of the
+// change, to call once cb.mu is released: OnCircuitStateChange may use the client.
+func (cb *circuitBreaker) setState(host string, circuit *hostCircuit, state utils.CircuitState) func() {
+	circuit.state = state
+	if cb.onChange == nil {
+		return func() {}
+	}
+	return func() { cb.onChange(host, state) }
+}
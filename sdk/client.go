@@ -7,6 +7,7 @@ import (
 
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/fluent"
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/progressive"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/trino"
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
 )
 
@@ -14,18 +15,92 @@ import (
 type Client struct {
 	config     utils.Configuration
 	httpClient *http.Client
+
+	// headers are added to every request issued by this client.
+	headers http.Header
+
+	// breaker is shared with derived clients; nil when disabled.
+	breaker *circuitBreaker
+
+	// retryBudget is shared with derived clients; nil when disabled.
+	retryBudget *retryBudget
+
+	// history records the last requests; shared with derived clients, nil when disabled.
+	history *requestHistory
+
+	// metrics counts requests, token refreshes and S3 transfers; shared with derived clients.
+	metrics *clientMetrics
+
+	// capabilities caches the platform capabilities; shared with derived clients.
+	capabilities *capabilityCache
+
+	// endpoints caches the discovered platform endpoints; shared with derived clients.
+	endpoints *endpointCache
+
+	// oidc caches the OpenID Connect metadata of the issuers; shared with derived clients.
+	oidc *oidcCache
+
+	// jwks caches the signing keys of the issuers; shared with derived clients.
+	jwks *jwksCache
+
+	// transformers rewrite successful responses; shared with derived clients.
+	transformers *responseTransformers
+
+	// subjectToken is set on clients derived through Impersonate.
+	subjectToken string
+
+	// serviceAccounts is set on clients created from service accounts; shared with derived clients.
+	serviceAccounts *serviceAccountSet
+
+	// federation is set on clients authenticated with an exchanged Kubernetes token.
+	federation *workloadIdentity
+
+	// lifecycle is closed by Close; derived clients have a child lifecycle.
+	lifecycle *clientLifecycle
+
+	// watcher reloads the service account files when they change; nil when disabled.
+	watcher *serviceAccountWatcher
+
+	// initErr holds a configuration error (e.g. unreadable CA certificate)
+	// detected while building the client. It is returned by every request.
+	initErr error
 }
 
 // NewClient creates a new Bifrost client with the provided configuration.
 func NewClient(config utils.Configuration) *Client {
 	// Create a copy of the configuration to avoid side effects
 	cfg := config
+	httpClient, err := utils.CreateHTTPClient(cfg)
+	if err == nil {
+		err = validateConfiguredQueryTags(cfg)
+	}
+	if err != nil {
+		return &Client{
+			config:       cfg,
+			httpClient:   &http.Client{Timeout: cfg.RequestTimeout},
+			metrics:      newClientMetrics(),
+			capabilities: &capabilityCache{},
+			endpoints:    &endpointCache{},
+			oidc:         &oidcCache{},
+			jwks:         &jwksCache{},
+			transformers: &responseTransformers{},
+			lifecycle:    newClientLifecycle(),
+			initErr:      err,
+		}
+	}
 	return &Client{
-		config: cfg,
-		httpClient: utils.CreateHTTPClientWithSettings(
-			cfg.SkipTLSVerify,
-			cfg.RequestTimeout,
-		),
+		config:       cfg,
+		httpClient:   httpClient,
+		breaker:      newCircuitBreaker(cfg),
+		retryBudget:  newRetryBudget(cfg),
+		history:      newRequestHistory(cfg),
+		metrics:      newClientMetrics(),
+		capabilities: &capabilityCache{},
+		endpoints:    &endpointCache{},
+		oidc:         &oidcCache{},
+		jwks:         &jwksCache{},
+		transformers: &responseTransformers{},
+		lifecycle:    newClientLifecycle(),
 	}
 }
 
@@ -62,7 +137,17 @@ func NewClientFromServiceAccount(sa *ServiceAccount, opts ServiceAccountOptions)
 		return nil, fmt.Errorf("failed to create configuration from service account: %w", err)
 	}
 
-	return NewClient(cfg), nil
+	accounts, err := newServiceAccountSet(append([]*ServiceAccount{sa}, opts.FallbackServiceAccounts...), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := NewClient(cfg)
+	if client.initErr != nil {
+		return nil, client.initErr
+	}
+	client.serviceAccounts = accounts
+	return client, nil
 }
 
 // NewClientFromServiceAccountFile creates a new Bifrost client by loading a ServiceAccount
@@ -82,7 +167,17 @@ func NewClientFromServiceAccountFile(path string, opts ServiceAccountOptions) (*
 	if err != nil {
 		return nil, err
 	}
-	return NewClientFromServiceAccount(sa, opts)
+	client, err := NewClientFromServiceAccount(sa, opts)
+	if err != nil {
+		return nil, err
+	}
+	client.serviceAccounts.paths[0] = path // See ReloadServiceAccount
+	if opts.WatchServiceAccountFile {
+		if err := client.watchServiceAccountFiles(utils.SecondsToDuration(opts.WatchInterval)); err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
 }
 
 // NewClientFromServiceAccountJSON creates a new Bifrost client by parsing a ServiceAccount
@@ -103,6 +198,42 @@ func NewClientFromServiceAccountJSON(jsonStr string, opts ServiceAccountOptions)
 	return NewClientFromServiceAccount(sa, opts)
 }
 
+// WithHeader returns a copy of the client that adds the given header to every request.
+// The original client is left untouched, so this can be used to derive per-tenant
+// or per-feature clients.
+//
+// Example:
+//
+//	tenantClient := client.WithHeader("X-Tenant-ID", tenantID)
+func (c *Client) WithHeader(key, value string) *Client {
+	derived := c.derive()
+	derived.headers = c.headers.Clone()
+	if derived.headers == nil {
+		derived.headers = http.Header{}
+	}
+	derived.headers.Set(key, value)
+	return &derived
+}
+
+// derive copies the client for a derived client: shared state is kept, the
+// lifecycle is a child of c's, and the service account watcher stays with c.
+func (c *Client) derive() Client {
+	derived := *c
+	derived.lifecycle = c.lifecycle.child()
+	derived.watcher = nil
+	return derived
+}
+
+// String describes the client without its credentials.
+func (c *Client) String() string {
+	return fmt.Sprintf("Client{BaseURL:%s OrgID:%s DataDockID:%s}", c.config.BaseURL, c.config.OrgID, c.config.DataDockID)
+}
+
+// GoString describes the client without its credentials, for the %#v verb.
+func (c *Client) GoString() string {
+	return fmt.Sprintf("&sdk.Client{config:%#v}", c.config)
+}
+
 // Do executes an HTTP request (implements the interface needed by builders)
 func (c *Client) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
 	return c.do(ctx, method, endpoint, body)
@@ -126,7 +257,27 @@ func (c *Client) Query() *fluent.QueryBuilder {
 	return fluent.NewQueryBuilder(c)
 }
 
+// PrepareQuery creates an immutable query template whose Where values can be
+// bound per execution. Templates are safe for concurrent use.
+// Example:
+//
+//	byStatus := client.PrepareQuery("orders-by-status").
+//	    Catalog("sales").
+//	    Schema("public").
+//	    Table("orders").
+//	    Where("status", "=", fluent.Param("status"))
+//
+//	resp, err := byStatus.Bind("status", "active").Get(ctx)
+func (c *Client) PrepareQuery(name string) *fluent.PreparedQuery {
+	return fluent.NewPreparedQuery(c, name)
+}
+
+// S3 creates a new S3Builder for MinIO operations. When the configuration names a
+// MinIOBucket but no access key, the bucket credentials are first fetched from the platform.
 func (c *Client) S3() (*fluent.S3Builder, error) {
+	if err := c.ensureS3Credentials(); err != nil {
+		return nil, err
+	}
 	return fluent.NewS3Builder(c)
 }
 
@@ -184,6 +335,27 @@ func (c *Client) OrgFromConfig() *progressive.OrgBuilder {
 	}
 }
 
+// OrgSources lists the datadocks of several organizations matching filter, as
+// sources for a fanned-out query or search (see fluent.QueryBuilder.FanOut).
+// Example:
+//
+//	sources, err := client.OrgSources(ctx, progressive.ListOptions{Status: "running"}, "org-a", "org-b")
+//	result, err := client.Query().Catalog("sales").Schema("public").Table("orders").
+//	    FanOut(ctx, sources, fluent.FanOutOptions{Concurrency: 8})
+func (c *Client) OrgSources(ctx context.Context, filter progressive.ListOptions, orgIDs ...string) ([]fluent.Source, error) {
+	var sources []fluent.Source
+	for _, orgID := range orgIDs {
+		dataDocks, err := c.Org(orgID).DataDocks(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list datadocks of organization %s: %w", orgID, err)
+		}
+		for _, dataDock := range dataDocks {
+			sources = append(sources, fluent.Source{OrgID: orgID, DataDockID: dataDock.ID})
+		}
+	}
+	return sources, nil
+}
+
 // Search creates a new SearchBuilder for full-text search queries.
 // Example:
 //
@@ -216,3 +388,36 @@ func (c *Client) Search() *fluent.SearchBuilder {
 func (c *Client) HybridSearch() *fluent.HybridSearchBuilder {
 	return fluent.NewHybridSearchBuilder(c)
 }
+
+// Embeddings creates a new EmbeddingsBuilder to generate embedding vectors with
+// the models served by the platform.
+// Example:
+//
+//	embeddings, err := client.Embeddings().Create(ctx, chunks, "bge-m3")
+func (c *Client) Embeddings() *fluent.EmbeddingsBuilder {
+	return fluent.NewEmbeddingsBuilder(c)
+}
+
+// Trino returns a client speaking the Trino REST protocol to the Trino coordinator
+// of the configured datadock, for analytics that outgrow the OpenAPI layer. The
+// coordinator is looked up from the datadock details on first use; use trino.New
+// to target another coordinator.
+// Example:
+//
+//	columns, rows, err := client.Trino().Catalog("sales", "public").
+//	    Query(ctx, "SELECT region, count(*) FROM orders GROUP BY region")
+func (c *Client) Trino() *trino.Client {
+	return trino.NewWithResolver(c, func(ctx context.Context) (string, error) {
+		if c.config.DataDockID == "" {
+			return "", fmt.Errorf("%w: DataDockID is required", utils.ErrInvalidConfiguration)
+		}
+		info, err := c.OrgFromConfig().Harbor("").DataDock(c.config.DataDockID).ConnectionInfo(ctx)
+		if err != nil {
+			return "", err
+		}
+		if info.Trino == nil {
+			return "", fmt.Errorf("%w: datadock %s is not a Trino datadock", utils.ErrUnsupportedFeature, c.config.DataDockID)
+		}
+		return info.Trino.HTTPURL(), nil
+	})
+}
@@ -1,13 +1,19 @@
 package sdk
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/fluent"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/progressive"
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
 )
 
@@ -27,6 +33,60 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestNewClient_SharesPooledTransport(t *testing.T) {
+	first := NewClient(utils.Configuration{BaseURL: "http://a", MaxIdleConnsPerHost: 64})
+	second := NewClient(utils.Configuration{BaseURL: "http://b", MaxIdleConnsPerHost: 64})
+	other := NewClient(utils.Configuration{BaseURL: "http://a", DisableKeepAlives: true})
+
+	if first.httpClient.Transport != second.httpClient.Transport {
+		t.Error("Expected clients with identical transport settings to share a transport")
+	}
+	if first.httpClient.Transport == other.httpClient.Transport {
+		t.Error("Expected clients with different transport settings to use distinct transports")
+	}
+
+	transport := first.httpClient.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 64 {
+		t.Errorf("Expected MaxIdleConnsPerHost 64, got %d", transport.MaxIdleConnsPerHost)
+	}
+	if !other.httpClient.Transport.(*http.Transport).DisableKeepAlives {
+		t.Error("Expected DisableKeepAlives to be applied")
+	}
+}
+
+func TestNewClient_InvalidCACert(t *testing.T) {
+	client := NewClient(utils.Configuration{
+		BaseURL:    "https://test.example.com",
+		DataDockID: "dd",
+		Token:      "test-token",
+		CACertPEM:  "not a certificate",
+	})
+
+	_, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background())
+	if !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+	}
+}
+
+func TestNewClient_ProxyURL(t *testing.T) {
+	client := NewClient(utils.Configuration{ProxyURL: "socks5://proxy.internal:1080"})
+	transport := client.httpClient.Transport.(*http.Transport)
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example.com", nil)
+	proxy, err := transport.Proxy(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if proxy == nil || proxy.String() != "socks5://proxy.internal:1080" {
+		t.Errorf("Expected socks5 proxy, got %v", proxy)
+	}
+
+	invalid := NewClient(utils.Configuration{ProxyURL: "ftp://proxy.internal"})
+	if !errors.Is(invalid.initErr, utils.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for unsupported scheme, got %v", invalid.initErr)
+	}
+}
+
 func TestCatalogMethod(t *testing.T) {
 	client := NewClient(utils.Configuration{DataDockID: "test-datadock"}) // Changed from OrgID
 	qb := client.Catalog("test-catalog")
@@ -171,6 +231,281 @@ func TestFluentAPI_ServerError_Retry(t *testing.T) {
 	}
 }
 
+func TestClient_GzipResponse(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(`{"data": "compressed"}`))
+	_ = gz.Close()
+
+	client := &Client{
+		config: utils.Configuration{Token: "test-token", DataDockID: "dd", BaseURL: "https://test.example.com"},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					if req.Header.Get("Accept-Encoding") != "gzip" {
+						t.Errorf("Expected Accept-Encoding gzip, got %q", req.Header.Get("Accept-Encoding"))
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Encoding": {"gzip"}},
+						Body:       io.NopCloser(bytes.NewReader(buf.Bytes())),
+					}, nil
+				},
+			},
+		},
+	}
+
+	resp, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.Data.(map[string]interface{})["data"] != "compressed" {
+		t.Errorf("Unexpected response data: %v", resp.Data)
+	}
+}
+
+func TestClient_MaxResponseBytes(t *testing.T) {
+	// Highly compressible: small on the wire, large once decompressed
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(`{"data": "` + strings.Repeat("x", 4096) + `"}`))
+	_ = gz.Close()
+
+	reqCount := 0
+	client := &Client{
+		config: utils.Configuration{Token: "test-token", DataDockID: "dd", BaseURL: "https://test.example.com", MaxRetries: 2, MaxResponseBytes: 1024},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					reqCount++
+					return &http.Response{
+						StatusCode:    http.StatusOK,
+						Header:        http.Header{"Content-Encoding": {"gzip"}},
+						Body:          io.NopCloser(bytes.NewReader(buf.Bytes())),
+						ContentLength: int64(buf.Len()),
+					}, nil
+				},
+			},
+		},
+	}
+
+	_, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background())
+	if !errors.Is(err, utils.ErrResponseTooLarge) {
+		t.Fatalf("Expected ErrResponseTooLarge, got %v", err)
+	}
+	if reqCount != 1 {
+		t.Errorf("Expected no retry, got %d requests", reqCount)
+	}
+
+	client.config.MaxResponseBytes = 8192
+	if _, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background()); err != nil {
+		t.Errorf("Expected a response under the limit to succeed, got %v", err)
+	}
+}
+
+func TestClient_GzipRequestAboveThreshold(t *testing.T) {
+	client := &Client{
+		config: utils.Configuration{
+			Token:                       "test-token",
+			DataDockID:                  "dd",
+			BaseURL:                     "https://test.example.com",
+			RequestCompressionThreshold: 10,
+		},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					if req.Header.Get("Content-Encoding") != "gzip" {
+						t.Fatalf("Expected Content-Encoding gzip, got %q", req.Header.Get("Content-Encoding"))
+					}
+					reader, err := gzip.NewReader(req.Body)
+					if err != nil {
+						t.Fatalf("Body is not gzipped: %v", err)
+					}
+					body, _ := io.ReadAll(reader)
+					if !strings.Contains(string(body), "a-long-enough-value") {
+						t.Errorf("Unexpected decompressed body: %s", body)
+					}
+					return &http.Response{
+						StatusCode: http.StatusCreated,
+						Body:       io.NopCloser(strings.NewReader(`{}`)),
+					}, nil
+				},
+			},
+		},
+	}
+
+	_, err := client.Catalog("c").Schema("s").Table("t").Post(context.Background(), map[string]string{"name": "a-long-enough-value"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestClient_CustomHeadersAndUserAgent(t *testing.T) {
+	base := &Client{
+		config: utils.Configuration{Token: "test-token", DataDockID: "dd", BaseURL: "https://test.example.com"},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					if !strings.HasPrefix(req.Header.Get("User-Agent"), "hyperfluid-sdk-go/") {
+						t.Errorf("Unexpected User-Agent %q", req.Header.Get("User-Agent"))
+					}
+					if req.Header.Get("X-Tenant-ID") != "tenant-a" {
+						t.Errorf("Expected client header X-Tenant-ID, got %q", req.Header.Get("X-Tenant-ID"))
+					}
+					if req.Header.Get("X-Correlation-ID") != "corr-1" {
+						t.Errorf("Expected query header X-Correlation-ID, got %q", req.Header.Get("X-Correlation-ID"))
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       io.NopCloser(strings.NewReader(`[]`)),
+					}, nil
+				},
+			},
+		},
+	}
+
+	client := base.WithHeader("X-Tenant-ID", "tenant-a")
+	if base.headers != nil {
+		t.Error("WithHeader should not modify the original client")
+	}
+
+	_, err := client.Catalog("c").Schema("s").Table("t").
+		Header("X-Correlation-ID", "corr-1").
+		Get(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestClient_RequestIDPropagation(t *testing.T) {
+	var sentIDs []string
+	client := &Client{
+		config: utils.Configuration{Token: "test-token", DataDockID: "dd", BaseURL: "https://test.example.com"},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					sentIDs = append(sentIDs, req.Header.Get("X-Request-ID"))
+					if len(sentIDs) == 1 {
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Header:     http.Header{"X-Request-Id": {"server-id"}},
+							Body:       io.NopCloser(strings.NewReader(`[]`)),
+						}, nil
+					}
+					return &http.Response{
+						StatusCode: http.StatusNotFound,
+						Body:       io.NopCloser(strings.NewReader("")),
+					}, nil
+				},
+			},
+		},
+	}
+
+	resp, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sentIDs[0] == "" {
+		t.Error("Expected a generated X-Request-ID header")
+	}
+	if resp.RequestID != "server-id" {
+		t.Errorf("Expected server request ID, got %q", resp.RequestID)
+	}
+
+	_, err = client.Catalog("c").Schema("s").Table("t").Header("X-Request-ID", "caller-id").Get(context.Background())
+	if !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if sentIDs[1] != "caller-id" {
+		t.Errorf("Expected caller request ID to be sent, got %q", sentIDs[1])
+	}
+	if utils.RequestIDFromError(err) != "caller-id" {
+		t.Errorf("Expected request ID in error, got %q", utils.RequestIDFromError(err))
+	}
+}
+
+func TestClient_AutoIdempotencyKeyStableAcrossRetries(t *testing.T) {
+	var keys []string
+	client := &Client{
+		config: utils.Configuration{
+			Token:               "test-token",
+			DataDockID:          "dd",
+			BaseURL:             "https://test.example.com",
+			MaxRetries:          1,
+			AutoIdempotencyKeys: true,
+		},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					keys = append(keys, req.Header.Get("Idempotency-Key"))
+					if len(keys) == 1 {
+						return nil, errors.New("connection reset")
+					}
+					return &http.Response{
+						StatusCode: http.StatusCreated,
+						Body:       io.NopCloser(strings.NewReader(`{}`)),
+					}, nil
+				},
+			},
+		},
+	}
+
+	if _, err := client.Catalog("c").Schema("s").Table("t").Post(context.Background(), map[string]int{"id": 1}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(keys) != 2 || keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("Expected the same non-empty key on both attempts, got %v", keys)
+	}
+
+	keys = nil
+	if _, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if keys[len(keys)-1] != "" {
+		t.Errorf("Expected no idempotency key on GET, got %q", keys[len(keys)-1])
+	}
+}
+
+func TestClient_CircuitBreakerOpens(t *testing.T) {
+	reqCount := 0
+	var states []utils.CircuitState
+	client := NewClient(utils.Configuration{
+		Token:                   "test-token",
+		DataDockID:              "dd",
+		BaseURL:                 "https://test.example.com",
+		CircuitBreakerThreshold: 2,
+		OnCircuitStateChange: func(host string, state utils.CircuitState) {
+			states = append(states, state)
+		},
+	})
+	client.httpClient = &http.Client{
+		Transport: &mockRoundTripper{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				reqCount++
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			},
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		_, _ = client.Catalog("c").Schema("s").Table("t").Get(context.Background())
+	}
+
+	_, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background())
+	if !errors.Is(err, utils.ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if reqCount != 2 {
+		t.Errorf("Expected 2 requests to reach the server, got %d", reqCount)
+	}
+	if len(states) != 1 || states[0] != utils.CircuitOpen {
+		t.Errorf("Expected a single transition to open, got %v", states)
+	}
+}
+
 // mockRoundTripper is used to mock HTTP responses in tests.
 type mockRoundTripper struct {
 	roundTripFunc func(req *http.Request) (*http.Response, error)
@@ -179,3 +514,338 @@ type mockRoundTripper struct {
 func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
 	return m.roundTripFunc(req)
 }
+
+func TestClient_ResultMetadata(t *testing.T) {
+	client := &Client{
+		config: utils.Configuration{Token: "test-token", DataDockID: "dd", BaseURL: "https://test.example.com"},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusPartialContent,
+						Header: http.Header{
+							"Content-Range": {"0-1/3573"},
+							"Server-Timing": {"db;dur=53, app;dur=47.2;desc=\"render\", cache"},
+						},
+						Body: io.NopCloser(strings.NewReader(`[{"id": 1}, {"id": 2}]`)),
+					}, nil
+				},
+			},
+		},
+	}
+
+	resp, err := client.Catalog("c").Schema("s").Table("t").Limit(2).Get(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.RowCount != 2 || resp.TotalCount != 3573 {
+		t.Errorf("Expected 2 of 3573 rows, got %d of %d", resp.RowCount, resp.TotalCount)
+	}
+	if resp.ServerTiming["db"] != 53*time.Millisecond || resp.ServerTiming["app"] != 47200*time.Microsecond {
+		t.Errorf("Unexpected server timing: %v", resp.ServerTiming)
+	}
+	if resp.Duration <= 0 {
+		t.Errorf("Expected a client-side duration, got %v", resp.Duration)
+	}
+}
+
+func TestClient_UseNumber(t *testing.T) {
+	const body = `[{"id": 9007199254740993, "price": 0.1}]`
+	newClient := func(useNumber bool) *Client {
+		return &Client{
+			config: utils.Configuration{Token: "test-token", DataDockID: "dd", BaseURL: "https://test.example.com", UseNumber: useNumber},
+			httpClient: &http.Client{
+				Transport: &mockRoundTripper{
+					roundTripFunc: func(req *http.Request) (*http.Response, error) {
+						return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+					},
+				},
+			},
+		}
+	}
+
+	resp, err := newClient(true).Catalog("c").Schema("s").Table("t").Get(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	rows, _ := resp.Rows()
+	if id, ok := rows[0]["id"].(json.Number); !ok || id.String() != "9007199254740993" {
+		t.Errorf("Expected the exact id as json.Number, got %#v", rows[0]["id"])
+	}
+	if price, ok := utils.Float64(rows[0]["price"]); !ok || price != 0.1 {
+		t.Errorf("Expected price 0.1, got %#v", rows[0]["price"])
+	}
+
+	// Default: float64, rounded above 2^53
+	resp, err = newClient(false).Catalog("c").Schema("s").Table("t").Get(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	rows, _ = resp.Rows()
+	if _, ok := rows[0]["id"].(float64); !ok {
+		t.Errorf("Expected float64 numbers by default, got %T", rows[0]["id"])
+	}
+}
+
+func TestTotalCountFromHeaders(t *testing.T) {
+	tests := []struct {
+		header http.Header
+		want   int64
+	}{
+		{http.Header{"Content-Range": {"0-24/3573"}}, 3573},
+		{http.Header{"Content-Range": {"*/42"}}, 42},
+		{http.Header{"Content-Range": {"items 0-9/100"}}, 100},
+		{http.Header{"Content-Range": {"0-24/*"}}, -1},
+		{http.Header{"X-Total-Count": {"7"}}, 7},
+		{http.Header{}, -1},
+	}
+	for _, tt := range tests {
+		if got := utils.TotalCountFromHeaders(tt.header); got != tt.want {
+			t.Errorf("TotalCountFromHeaders(%v) = %d, want %d", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestClient_CountFromContentRange(t *testing.T) {
+	var methods []string
+	client := &Client{
+		config: utils.Configuration{Token: "test-token", DataDockID: "dd", BaseURL: "https://test.example.com"},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					methods = append(methods, req.Method)
+					if got := req.Header.Get("Prefer"); got != "count=planned" {
+						t.Errorf("Expected Prefer: count=planned, got %q", got)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Range": {"*/42"}},
+						Body:       io.NopCloser(strings.NewReader("")),
+					}, nil
+				},
+			},
+		},
+	}
+
+	count, err := client.Catalog("c").Schema("s").Table("t").CountWithMode(context.Background(), fluent.CountPlanned)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != 42 {
+		t.Errorf("Expected 42, got %d", count)
+	}
+	if len(methods) != 1 || methods[0] != http.MethodHead {
+		t.Errorf("Expected a single HEAD request, got %v", methods)
+	}
+}
+
+func TestClient_CountGzipHead(t *testing.T) {
+	// Servers may label bodiless responses as gzip-encoded
+	client := &Client{
+		config: utils.Configuration{Token: "test-token", DataDockID: "dd", BaseURL: "https://test.example.com", MaxRetries: 2},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					if req.Method != http.MethodHead {
+						t.Errorf("Expected a HEAD request, got %s", req.Method)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Encoding": {"gzip"}, "Content-Range": {"0-0/42"}},
+						Body:       io.NopCloser(strings.NewReader("")),
+						Request:    req,
+					}, nil
+				},
+			},
+		},
+	}
+
+	count, err := client.Catalog("c").Schema("s").Table("t").Count(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != 42 {
+		t.Errorf("Expected 42, got %d", count)
+	}
+
+	resp, err := readResponseBody(&http.Response{
+		StatusCode: http.StatusNoContent,
+		Header:     http.Header{"Content-Encoding": {"gzip"}},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}, 0)
+	if err != nil || len(resp) != 0 {
+		t.Errorf("readResponseBody() of a 204 = %q, %v, want an empty body", resp, err)
+	}
+}
+
+func TestClient_CountFallsBackToGet(t *testing.T) {
+	var methods []string
+	client := &Client{
+		config: utils.Configuration{Token: "test-token", DataDockID: "dd", BaseURL: "https://test.example.com"},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					methods = append(methods, req.Method)
+					if req.Method == http.MethodHead {
+						return &http.Response{StatusCode: http.StatusMethodNotAllowed, Body: io.NopCloser(strings.NewReader(""))}, nil
+					}
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"count": 7}`))}, nil
+				},
+			},
+		},
+	}
+
+	count, err := client.Catalog("c").Schema("s").Table("t").Count(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != 7 {
+		t.Errorf("Expected 7, got %d", count)
+	}
+	if len(methods) != 2 || methods[1] != http.MethodGet {
+		t.Errorf("Expected HEAD then GET, got %v", methods)
+	}
+}
+
+func TestClient_AutoWakeUpRetriesQuery(t *testing.T) {
+	var calls []string
+	statusChecks := 0
+	queried := false
+	client := &Client{
+		config: utils.Configuration{
+			Token:              "test-token",
+			DataDockID:         "dd",
+			BaseURL:            "https://test.example.com",
+			AutoWakeUp:         true,
+			WakeUpPollInterval: time.Millisecond,
+		},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					calls = append(calls, req.Method+" "+req.URL.Path)
+					body, status := `[{"id": 1}]`, http.StatusOK
+					switch req.URL.Path {
+					case "/dd/openapi/c/s/t":
+						if !queried {
+							queried = true
+							body, status = `{"error": "DataDock is Sleeping"}`, http.StatusServiceUnavailable
+						}
+					case "/data-docks/dd/wake-up":
+						body = `{}`
+					case "/data-docks/dd":
+						statusChecks++
+						body = `{"status": "Pending"}`
+						if statusChecks > 1 {
+							body = `{"status": "Online"}`
+						}
+					}
+					return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}, nil
+				},
+			},
+		},
+	}
+
+	resp, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.RowCount != 1 {
+		t.Errorf("Expected the retried query result, got %v", resp.Data)
+	}
+	want := []string{"GET /dd/openapi/c/s/t", "POST /data-docks/dd/wake-up", "GET /data-docks/dd", "GET /data-docks/dd", "GET /dd/openapi/c/s/t"}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("Unexpected calls:\n got %v\nwant %v", calls, want)
+	}
+
+	// Without the opt-in flag the error is returned as is
+	queried = false
+	client.config.AutoWakeUp = false
+	_, err = client.Catalog("c").Schema("s").Table("t").Get(context.Background())
+	if !errors.Is(err, utils.ErrDataDockAsleep) {
+		t.Errorf("Expected ErrDataDockAsleep, got %v", err)
+	}
+}
+
+func TestClient_OrgSources(t *testing.T) {
+	client := &Client{
+		config: utils.Configuration{Token: "test-token", BaseURL: "https://test.example.com"},
+		httpClient: &http.Client{
+			Transport: &mockRoundTripper{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					orgID := strings.Split(strings.Trim(req.URL.Path, "/"), "/")[0]
+					body := `{"data_docks": [{"id": "` + orgID + `-dd1"}, {"id": "` + orgID + `-dd2"}]}`
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+				},
+			},
+		},
+	}
+
+	sources, err := client.OrgSources(context.Background(), progressive.ListOptions{}, "org-a", "org-b")
+	if err != nil {
+		t.Fatalf("OrgSources() unexpected error = %v", err)
+	}
+	if len(sources) != 4 || sources[0].OrgID != "org-a" || sources[3].String() != "org-b/org-b-dd2" {
+		t.Errorf("Unexpected sources: %v", sources)
+	}
+}
+
+func TestClient_CircuitBreakerCallbackUsesClient(t *testing.T) {
+	var client *Client
+	client = NewClient(utils.Configuration{
+		Token:                   "test-token",
+		DataDockID:              "dd",
+		BaseURL:                 "https://test.example.com",
+		CircuitBreakerThreshold: 1,
+		OnCircuitStateChange: func(host string, state utils.CircuitState) {
+			// Would deadlock if called with the breaker locked
+			_ = client.breaker.allow(host)
+		},
+	})
+	client.httpClient = &http.Client{
+		Transport: &mockRoundTripper{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Body:       io.NopCloser(strings.NewReader("")),
+				}, nil
+			},
+		},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = client.Catalog("c").Schema("s").Table("t").Get(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("OnCircuitStateChange was called with the circuit breaker locked")
+	}
+}
+
+func TestClient_CircuitBreakerIgnoresCancelledRequests(t *testing.T) {
+	client := NewClient(utils.Configuration{
+		Token:                   "test-token",
+		DataDockID:              "dd",
+		BaseURL:                 "https://test.example.com",
+		CircuitBreakerThreshold: 1,
+	})
+	client.httpClient = &http.Client{
+		Transport: &mockRoundTripper{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			},
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		_, err := client.Catalog("c").Schema("s").Table("t").Get(ctx)
+		cancel()
+		if errors.Is(err, utils.ErrCircuitOpen) {
+			t.Fatalf("Expected cancelled requests not to open the circuit, got %v", err)
+		}
+	}
+}
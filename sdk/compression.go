@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// shouldCompressRequest reports whether a request body must be gzipped
+// according to the configured threshold.
+func (c *Client) shouldCompressRequest(method string, body []byte) bool {
+	if c.config.RequestCompressionThreshold <= 0 || len(body) < c.config.RequestCompressionThreshold {
+		return false
+	}
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch
+}
+
+// gzipBody compresses a request body.
+func gzipBody(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(body); err != nil {
+		return nil, fmt.Errorf("failed to gzip request body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to gzip request body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// readResponseBody reads the full response body, transparently decompressing
+// gzip-encoded payloads. Responses without a body (HEAD, 204, 304 or empty) are
+// not decompressed, whatever their Content-Encoding. Bodies over maxBytes (when
+// positive) fail with ErrResponseTooLarge.
+func readResponseBody(resp *http.Response, maxBytes int64) ([]byte, error) {
+	if maxBytes > 0 && resp.ContentLength > maxBytes {
+		return nil, responseTooLarge(maxBytes)
+	}
+
+	var reader io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") && hasBody(resp) {
+		buffered := bufio.NewReader(resp.Body)
+		if _, err := buffered.Peek(1); err == io.EOF {
+			return []byte{}, nil
+		}
+		gzipReader, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress response body: %w", err)
+		}
+		defer func() { _ = gzipReader.Close() }()
+		reader = gzipReader
+	}
+	if maxBytes <= 0 {
+		return io.ReadAll(reader)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(reader, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > maxBytes {
+		return nil, responseTooLarge(maxBytes)
+	}
+	return body, nil
+}
+
+// hasBody reports whether the response may carry a body.
+func hasBody(resp *http.Response) bool {
+	if resp.Request != nil && resp.Request.Method == http.MethodHead {
+		return false
+	}
+	return resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotModified
+}
+
+func responseTooLarge(maxBytes int64) error {
+	return fmt.Errorf("%w: body exceeds the %d bytes limit (MaxResponseBytes), narrow the query with Select and Limit or page through it with Iter",
+		utils.ErrResponseTooLarge, maxBytes)
+}
@@ -2,7 +2,6 @@ package sdk
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"net/http"
 	"sync"
@@ -11,6 +10,7 @@ import (
 	"golang.org/x/oauth2/clientcredentials"
 
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/controlplaneapiclient"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
 )
 
 // ControlPlaneClient wraps the generated OpenAPI client with automatic OAuth2 token management.
@@ -72,8 +72,33 @@ func (c *Client) ControlPlane() (*ControlPlaneClient, error) {
 	return cp, nil
 }
 
+// controlPlaneBaseURL returns the Control Plane URL, defaulting to BaseURL. With
+// DiscoverEndpoints, the discovered control plane replaces the default.
+func (c *Client) controlPlaneBaseURL(ctx context.Context) string {
+	if c.config.DiscoverEndpoints {
+		authMutex.Lock()
+		_ = c.resolveEndpoints(ctx, false) // BaseURL is used when the discovery fails
+		authMutex.Unlock()
+	}
+	if c.config.ControlPlaneURL != "" {
+		return c.config.ControlPlaneURL
+	}
+	return c.config.BaseURL
+}
+
 // newControlPlaneClient creates a new ControlPlaneClient with OAuth2 authentication.
 func newControlPlaneClient(c *Client) (*ControlPlaneClient, error) {
+	if c.config.DiscoverEndpoints {
+		ctx, cancel := c.lifecycle.bind(context.Background())
+		authMutex.Lock()
+		err := c.resolveEndpoints(ctx, true)
+		authMutex.Unlock()
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if c.config.ControlPlaneURL == "" {
 		return nil, fmt.Errorf("ControlPlaneURL is not configured")
 	}
@@ -82,12 +107,16 @@ func newControlPlaneClient(c *Client) (*ControlPlaneClient, error) {
 		return nil, fmt.Errorf("keycloak client credentials are not configured")
 	}
 
-	if c.config.KeycloakBaseURL == "" || c.config.KeycloakRealm == "" {
+	if c.oidcIssuer() == "" {
 		return nil, fmt.Errorf("keycloak base URL or realm is not configured")
 	}
 
-	tokenURL := fmt.Sprintf("%s/realms/%s/protocol/openid-connect/token",
-		c.config.KeycloakBaseURL, c.config.KeycloakRealm)
+	discoveryCtx, cancel := c.lifecycle.bind(context.Background())
+	tokenURL, err := c.tokenEndpoint(discoveryCtx, "client_credentials")
+	cancel()
+	if err != nil {
+		return nil, err
+	}
 
 	// Configure OAuth2 Client Credentials
 	oauthConfig := &clientcredentials.Config{
@@ -97,10 +126,10 @@ func newControlPlaneClient(c *Client) (*ControlPlaneClient, error) {
 		Scopes:       []string{}, // Add scopes if needed
 	}
 
-	// Create a base HTTP client with TLS configuration
-	baseTransport := http.DefaultTransport.(*http.Transport).Clone()
-	if c.config.SkipTLSVerify {
-		baseTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	// Reuse the pooled transport (TLS and connection settings) of the SDK client
+	baseTransport, err := utils.ClientTransport(c.config)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create context with custom HTTP client for OAuth2 token requests
@@ -113,11 +142,15 @@ func newControlPlaneClient(c *Client) (*ControlPlaneClient, error) {
 	httpClient := oauthConfig.Client(ctx)
 	httpClient.Timeout = c.config.RequestTimeout
 
-	// Create the generated OpenAPI client
-	apiClient, err := controlplaneapiclient.NewClientWithResponses(
-		c.config.ControlPlaneURL,
+	opts := []controlplaneapiclient.ClientOption{
 		controlplaneapiclient.WithHTTPClient(httpClient),
-	)
+	}
+	if c.config.AutoIdempotencyKeys {
+		opts = append(opts, controlplaneapiclient.WithRequestEditorFn(autoIdempotencyKeyEditor))
+	}
+
+	// Create the generated OpenAPI client
+	apiClient, err := controlplaneapiclient.NewClientWithResponses(c.config.ControlPlaneURL, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create control plane client: %w", err)
 	}
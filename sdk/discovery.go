@@ -0,0 +1,165 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// wellKnownConfigurationPath is the endpoint publishing the platform endpoints.
+const wellKnownConfigurationPath = "/.well-known/hyperfluid-configuration"
+
+// maxDiscoveryDocumentBytes bounds the size of the discovery documents.
+const maxDiscoveryDocumentBytes = 1 << 20
+
+// PlatformEndpoints are the endpoints published by a platform next to its API.
+type PlatformEndpoints struct {
+	ControlPlaneURL string `json:"control_plane_url"`
+	// Issuer is the OpenID issuer of the platform tokens, e.g.
+	// "https://auth.hyperfluid.cloud/realms/my-org".
+	Issuer          string `json:"issuer"`
+	KeycloakBaseURL string `json:"keycloak_base_url"` // Derived from a Keycloak Issuer when not published
+	KeycloakRealm   string `json:"keycloak_realm"`    // Derived from a Keycloak Issuer when not published
+}
+
+// endpointCache holds the discovered endpoints; shared with derived clients.
+type endpointCache struct {
+	mu        sync.Mutex
+	endpoints *PlatformEndpoints
+}
+
+// Endpoints returns the control plane and authentication endpoints published by the
+// platform at /.well-known/hyperfluid-configuration on BaseURL. The request is not
+// authenticated. The result is cached for the lifetime of the client.
+//
+// With Configuration.DiscoverEndpoints, the client fills its empty ControlPlaneURL,
+// KeycloakBaseURL and KeycloakRealm (or OIDCIssuer, for other issuers) with them
+// before authenticating, so that BaseURL is the only URL to configure.
+//
+// Example:
+//
+//	endpoints, err := client.Endpoints(ctx)
+//	if err == nil {
+//	    fmt.Println(endpoints.ControlPlaneURL, endpoints.Issuer)
+//	}
+func (c *Client) Endpoints(ctx context.Context) (*PlatformEndpoints, error) {
+	cache := c.endpoints
+	if cache == nil {
+		// Client not built by NewClient: nothing to cache
+		return c.fetchEndpoints(ctx)
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.endpoints != nil {
+		return cache.endpoints, nil
+	}
+	endpoints, err := c.fetchEndpoints(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cache.endpoints = endpoints
+	return endpoints, nil
+}
+
+// fetchEndpoints reads the discovery document of the platform.
+func (c *Client) fetchEndpoints(ctx context.Context) (*PlatformEndpoints, error) {
+	if c.initErr != nil {
+		return nil, c.initErr
+	}
+	if c.config.BaseURL == "" {
+		return nil, fmt.Errorf("%w: BaseURL is required to discover the platform endpoints", utils.ErrInvalidConfiguration)
+	}
+	ctx, cancel := c.lifecycle.bind(ctx)
+	defer cancel()
+
+	status, body, err := c.getDocument(ctx, strings.TrimSuffix(c.config.BaseURL, "/")+wellKnownConfigurationPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover the platform endpoints: %w", err)
+	}
+	switch {
+	case status == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s does not publish its endpoints", utils.ErrNotFound, c.config.BaseURL)
+	case status != http.StatusOK:
+		return nil, fmt.Errorf("%w: endpoint discovery returned HTTP %d", utils.ErrAPIError, status)
+	}
+
+	var endpoints PlatformEndpoints
+	if err := json.Unmarshal(body, &endpoints); err != nil {
+		return nil, fmt.Errorf("%w: invalid discovery document: %w", utils.ErrAPIError, err)
+	}
+	// Issuers outside the Keycloak /realms/<realm> layout are left to OIDCIssuer
+	if endpoints.KeycloakBaseURL == "" && endpoints.KeycloakRealm == "" && endpoints.Issuer != "" {
+		if baseURL, realm, err := parseKeycloakURL(endpoints.Issuer); err == nil {
+			endpoints.KeycloakBaseURL, endpoints.KeycloakRealm = baseURL, realm
+		}
+	}
+	endpoints.ControlPlaneURL = strings.TrimSuffix(endpoints.ControlPlaneURL, "/")
+	endpoints.KeycloakBaseURL = strings.TrimSuffix(endpoints.KeycloakBaseURL, "/")
+	return &endpoints, nil
+}
+
+// resolveEndpoints fills the control plane and Keycloak settings left empty with
+// the discovered endpoints, when DiscoverEndpoints is set; a ControlPlaneURL equal
+// to BaseURL, the default of profiles and service accounts, is also replaced.
+// Settings configured explicitly are kept. A failed discovery is only an error when
+// Keycloak, or with controlPlane the control plane, is left unconfigured.
+// Must be called with authMutex held.
+func (c *Client) resolveEndpoints(ctx context.Context, controlPlane bool) error {
+	if !c.config.DiscoverEndpoints {
+		return nil
+	}
+	missingAuth := c.oidcIssuer() == ""
+	defaultControlPlane := c.config.ControlPlaneURL == "" || c.config.ControlPlaneURL == c.config.BaseURL
+	if !missingAuth && !defaultControlPlane {
+		return nil
+	}
+
+	endpoints, err := c.Endpoints(ctx)
+	if err != nil {
+		if missingAuth || (controlPlane && c.config.ControlPlaneURL == "") {
+			return err
+		}
+		return nil
+	}
+	if endpoints.ControlPlaneURL != "" && defaultControlPlane {
+		c.config.ControlPlaneURL = endpoints.ControlPlaneURL
+	}
+	if missingAuth && c.config.KeycloakBaseURL == "" && c.config.KeycloakRealm == "" {
+		if endpoints.KeycloakBaseURL != "" && endpoints.KeycloakRealm != "" {
+			c.config.KeycloakBaseURL = endpoints.KeycloakBaseURL
+			c.config.KeycloakRealm = endpoints.KeycloakRealm
+		} else {
+			c.config.OIDCIssuer = endpoints.Issuer
+		}
+	}
+	return nil
+}
+
+// getDocument fetches a public JSON document, such as a discovery document, without
+// the client's authentication, which may depend on it.
+func (c *Client) getDocument(ctx context.Context, url string) (status int, body []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: %w", utils.ErrInvalidConfiguration, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	c.applyHeaders(ctx, req)
+
+	transport, err := utils.ClientTransport(c.config)
+	if err != nil {
+		return 0, nil, err
+	}
+	resp, err := (&http.Client{Transport: transport, Timeout: c.config.RequestTimeout}).Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err = io.ReadAll(io.LimitReader(resp.Body, maxDiscoveryDocumentBytes))
+	return resp.StatusCode, body, err
+}
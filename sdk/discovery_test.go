@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// discoveryServer serves the discovery document (when set), a Keycloak realm and
+// the API, recording the paths requested.
+func discoveryServer(t *testing.T, document func(url string) string, paths *[]string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+		switch {
+		case r.URL.Path == wellKnownConfigurationPath:
+			if r.Header.Get("Authorization") != "" {
+				t.Error("discovery request must not be authenticated")
+			}
+			if document == nil {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(document(server.URL)))
+		case r.URL.Path == "/auth/realms/acme"+openIDConfigurationPath:
+			_, _ = w.Write([]byte(`{"token_endpoint": "` + server.URL + `/auth/realms/acme/protocol/openid-connect/token"}`))
+		case r.URL.Path == "/auth/realms/acme/protocol/openid-connect/token":
+			_, _ = w.Write([]byte(`{"access_token": "` + testJWT(time.Now().Add(time.Hour)) + `"}`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_Endpoints(t *testing.T) {
+	var paths []string
+	server := discoveryServer(t, func(url string) string {
+		return `{"control_plane_url": "` + url + `/cp/", "issuer": "` + url + `/realms/acme"}`
+	}, &paths)
+	client := NewClient(utils.Configuration{BaseURL: server.URL, Token: "t"})
+
+	for range 2 {
+		endpoints, err := client.Endpoints(context.Background())
+		if err != nil {
+			t.Fatalf("Endpoints() unexpected error = %v", err)
+		}
+		if endpoints.ControlPlaneURL != server.URL+"/cp" || endpoints.KeycloakBaseURL != server.URL || endpoints.KeycloakRealm != "acme" {
+			t.Errorf("Endpoints() = %+v, want the control plane and the realm of the issuer", endpoints)
+		}
+	}
+	if len(paths) != 1 {
+		t.Errorf("expected the document to be fetched once, got %v", paths)
+	}
+}
+
+func TestClient_DiscoverEndpointsConfiguresClient(t *testing.T) {
+	var paths []string
+	server := discoveryServer(t, func(url string) string {
+		return `{"control_plane_url": "` + url + `/cp", "keycloak_base_url": "` + url + `/auth", "keycloak_realm": "acme"}`
+	}, &paths)
+	client := NewClient(utils.Configuration{
+		BaseURL:              server.URL,
+		KeycloakClientID:     "sa",
+		KeycloakClientSecret: "secret",
+		DiscoverEndpoints:    true,
+		RequestTimeout:       5 * time.Second,
+	})
+
+	if _, err := client.Do(context.Background(), "GET", server.URL+"/api/rows", nil); err != nil {
+		t.Fatalf("Do() unexpected error = %v", err)
+	}
+	want := []string{wellKnownConfigurationPath, "/auth/realms/acme" + openIDConfigurationPath, "/auth/realms/acme/protocol/openid-connect/token", "/api/rows"}
+	if strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Errorf("requested %v, want %v", paths, want)
+	}
+	if got := client.sqlExecuteURL(context.Background()); got != server.URL+"/cp"+sqlExecutePath {
+		t.Errorf("sqlExecuteURL() = %q, want the discovered control plane", got)
+	}
+}
+
+func TestClient_DiscoverEndpointsKeepsConfiguredSettings(t *testing.T) {
+	var paths []string
+	server := discoveryServer(t, nil, &paths)
+
+	// Keycloak is configured: the missing document only leaves the default control plane
+	client := NewClient(utils.Configuration{
+		BaseURL:              server.URL,
+		ControlPlaneURL:      server.URL,
+		KeycloakBaseURL:      server.URL + "/auth",
+		KeycloakRealm:        "acme",
+		KeycloakClientID:     "sa",
+		KeycloakClientSecret: "secret",
+		DiscoverEndpoints:    true,
+	})
+	if _, err := client.Do(context.Background(), "GET", server.URL+"/api/rows", nil); err != nil {
+		t.Fatalf("Do() unexpected error = %v", err)
+	}
+	if got := client.GetConfig().ControlPlaneURL; got != server.URL {
+		t.Errorf("ControlPlaneURL = %q, want BaseURL", got)
+	}
+
+	// Keycloak cannot be found
+	client = NewClient(utils.Configuration{
+		BaseURL:              server.URL,
+		KeycloakClientID:     "sa",
+		KeycloakClientSecret: "secret",
+		DiscoverEndpoints:    true,
+	})
+	if _, err := client.Do(context.Background(), "GET", server.URL+"/api/rows", nil); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Do() error = %v, want ErrNotFound", err)
+	}
+}
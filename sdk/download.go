@@ -0,0 +1,52 @@
+package sdk
+
+import (
+	"context"
+	"io"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Download streams the body of a GET request to an endpoint returning binary
+// content, such as an export or an archive, instead of decoding it as JSON. The
+// request is authenticated and retried like the others until the response headers
+// arrive; the body is then read by the caller, who must close it. The body is not
+// bound to MaxResponseBytes nor to the request timeout, and closing the client
+// aborts the transfer.
+//
+// Example:
+//
+//	body, info, err := client.Download(ctx, endpoint)
+//	if err != nil {
+//	    return err
+//	}
+//	defer body.Close()
+//	file, _ := os.Create(info.FileName)
+//	_, err = io.Copy(file, body)
+func (c *Client) Download(ctx context.Context, endpoint string) (io.ReadCloser, utils.ContentInfo, error) {
+	ctx, cancel := c.lifecycle.bind(ctx)
+	stream := &responseStream{}
+	resp, err := c.doRequest(ctx, "GET", endpoint, nil, stream)
+	if err != nil {
+		cancel()
+		return nil, utils.ContentInfo{}, err
+	}
+
+	info := utils.ContentInfoFromHeaders(stream.resp.Header, stream.resp.ContentLength)
+	if info.RequestID == "" {
+		info.RequestID = resp.RequestID
+	}
+	return &downloadBody{ReadCloser: stream.resp.Body, cancel: cancel}, info, nil
+}
+
+// downloadBody releases the context of a download when closed.
+type downloadBody struct {
+	io.ReadCloser
+	cancel func()
+}
+
+func (b *downloadBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
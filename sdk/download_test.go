@@ -0,0 +1,106 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/fluent"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestClient_Download(t *testing.T) {
+	requests := 0
+	content := strings.Repeat("id,amount\n1,12.50\n", 100)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer t" {
+			t.Errorf("Expected the download to be authenticated, got %q", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="orders.csv"`)
+		w.Header().Set("Last-Modified", "Sat, 01 Mar 2025 10:15:30 GMT")
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	// The body is streamed, not bound to MaxResponseBytes
+	client := NewClient(utils.Configuration{BaseURL: server.URL, Token: "t", MaxRetries: 1, MaxResponseBytes: 64})
+	body, info, err := client.Download(context.Background(), server.URL+"/exports/orders.csv")
+	if err != nil {
+		t.Fatalf("Download() unexpected error = %v", err)
+	}
+	defer func() { _ = body.Close() }()
+	data, _ := io.ReadAll(body)
+	if string(data) != content || requests != 2 {
+		t.Errorf("Download() returned %d bytes after %d requests", len(data), requests)
+	}
+	if info.ContentType != "text/csv" || info.FileName != "orders.csv" || info.ContentLength != int64(len(content)) {
+		t.Errorf("Unexpected content info %+v", info)
+	}
+	if !info.LastModified.Equal(time.Date(2025, 3, 1, 10, 15, 30, 0, time.UTC)) || info.RequestID == "" {
+		t.Errorf("Unexpected content info %+v", info)
+	}
+}
+
+func TestClient_DownloadErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{BaseURL: server.URL, Token: "t"})
+	if _, _, err := client.Download(context.Background(), server.URL+"/missing"); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestQueryBuilder_Export(t *testing.T) {
+	calls := map[string]int{}
+	var exported string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		if r.URL.Path == capabilitiesPath {
+			_, _ = w.Write([]byte(`{"openapi_enabled": true, "full_text_search_enabled": true}`))
+			return
+		}
+		exported = r.URL.Path + "?" + r.URL.RawQuery
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte("id\n1\n"))
+	}))
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{BaseURL: server.URL, DataDockID: "dd", Token: "t", DiscoverCapabilities: true})
+	orders := client.Catalog("sales").Schema("public").Table("orders").Where("year", "=", 2024)
+	body, info, err := orders.Export(context.Background(), fluent.ExportCSV)
+	if err != nil {
+		t.Fatalf("Export() unexpected error = %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	_ = body.Close()
+	if string(data) != "id\n1\n" || info.ContentType != "text/csv" {
+		t.Errorf("Unexpected export %q (%+v)", data, info)
+	}
+	if exported != "/dd/openapi/sales/public/orders/export?__format=csv&year.eq=2024" {
+		t.Errorf("Unexpected export request %s", exported)
+	}
+
+	// The feature flags do not list the export formats: the server decides
+	body, _, err = orders.Export(context.Background(), "parquet")
+	if err != nil {
+		t.Fatalf("Export(parquet) unexpected error = %v", err)
+	}
+	_ = body.Close()
+	if calls[capabilitiesPath] != 1 || calls["/dd/openapi/sales/public/orders/export"] != 2 {
+		t.Errorf("Unexpected requests %v", calls)
+	}
+}
@@ -0,0 +1,345 @@
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+const (
+	// eventsInitialBackoff is the first reconnection delay of an event stream.
+	eventsInitialBackoff = time.Second
+	// eventsMaxBackoff caps the reconnection delay of an event stream.
+	eventsMaxBackoff = 30 * time.Second
+	// eventsBufferSize is the capacity of the channel delivering events.
+	eventsBufferSize = 64
+)
+
+// Event is a platform event, delivered by Events().Subscribe or by a webhook.
+type Event struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"` // e.g. "datadock.status_changed"
+	OrgID      string          `json:"org_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// EventFilter restricts the events delivered by a subscription.
+type EventFilter func(params url.Values)
+
+// WithEventTypes only delivers events of the given types.
+func WithEventTypes(types ...string) EventFilter {
+	return func(params url.Values) {
+		for _, eventType := range types {
+			params.Add("type", eventType)
+		}
+	}
+}
+
+// WithEventResource only delivers events about the given resource (e.g. "datadock", id).
+func WithEventResource(resourceType, resourceID string) EventFilter {
+	return func(params url.Values) {
+		params.Set("resource_type", resourceType)
+		if resourceID != "" {
+			params.Set("resource_id", resourceID)
+		}
+	}
+}
+
+// WithResumeToken resumes a stream after the given event ID (see Subscription.LastEventID).
+func WithResumeToken(token string) EventFilter {
+	return func(params url.Values) {
+		params.Set("after", token)
+	}
+}
+
+// EventsBuilder opens streams of platform events.
+type EventsBuilder struct {
+	client *Client
+}
+
+// Events returns a builder for subscribing to platform events of the configured organization.
+func (c *Client) Events() *EventsBuilder {
+	return &EventsBuilder{client: c}
+}
+
+// Subscription is an open event stream. Events are delivered on Events(); the
+// channel is closed when the context is cancelled, Close is called, or the stream
+// fails permanently (see Err).
+type Subscription struct {
+	events chan Event
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu          sync.Mutex
+	lastEventID string
+	err         error
+}
+
+// Subscribe opens a server-sent events stream of platform events.
+// The first connection is made synchronously so that configuration and
+// authentication errors are returned directly. Dropped connections are
+// re-established with exponential backoff, resuming after the last received event.
+//
+// Example:
+//
+//	sub, err := client.Events().Subscribe(ctx, sdk.WithEventTypes("catalog.refresh_completed"))
+//	if err != nil {
+//	    return err
+//	}
+//	defer sub.Close()
+//	for event := range sub.Events() {
+//	    log.Printf("%s: %s", event.Type, event.Data)
+//	}
+func (e *EventsBuilder) Subscribe(ctx context.Context, filters ...EventFilter) (*Subscription, error) {
+	c := e.client
+	if c.initErr != nil {
+		return nil, c.initErr
+	}
+	if c.config.OrgID == "" {
+		return nil, fmt.Errorf("%w: OrgID is required to subscribe to events", utils.ErrInvalidConfiguration)
+	}
+
+	params := url.Values{}
+	for _, filter := range filters {
+		filter(params)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	// Close cancels the subscription and waits for it to be released
+	released, err := c.lifecycle.track(cancel)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	sub := &Subscription{
+		events:      make(chan Event, eventsBufferSize),
+		cancel:      cancel,
+		done:        make(chan struct{}),
+		lastEventID: params.Get("after"),
+	}
+	params.Del("after")
+	endpoint := fmt.Sprintf("%s/%s/events", c.config.BaseURL, url.PathEscape(c.config.OrgID))
+
+	body, err := c.openEventStream(ctx, endpoint, params, sub.LastEventID())
+	if err != nil {
+		cancel()
+		released()
+		return nil, err
+	}
+	go func() {
+		defer released()
+		sub.run(ctx, c, endpoint, params, body)
+	}()
+	return sub, nil
+}
+
+// Events returns the channel on which events are delivered.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// LastEventID returns the ID of the last delivered event, usable with WithResumeToken.
+func (s *Subscription) LastEventID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastEventID
+}
+
+// Err returns the error that ended the subscription, if any.
+// It is only meaningful once the Events channel is closed.
+func (s *Subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close ends the subscription and waits for the stream to be released.
+func (s *Subscription) Close() {
+	s.cancel()
+	<-s.done
+}
+
+func (s *Subscription) run(ctx context.Context, c *Client, endpoint string, params url.Values, body io.ReadCloser) {
+	defer close(s.done)
+	defer close(s.events)
+
+	backoff := eventsInitialBackoff
+	for {
+		delivered, retry := s.consume(ctx, body)
+		_ = body.Close()
+		if delivered {
+			backoff = eventsInitialBackoff
+		}
+		if retry > 0 {
+			backoff = retry
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(backoff*2, eventsMaxBackoff)
+
+			var err error
+			body, err = c.openEventStream(ctx, endpoint, params, s.LastEventID())
+			if err == nil {
+				break
+			}
+			if isPermanentStreamError(err) {
+				s.mu.Lock()
+				s.err = err
+				s.mu.Unlock()
+				return
+			}
+		}
+	}
+}
+
+// consume reads server-sent events until the stream ends. It reports whether any
+// event was delivered and the reconnection delay requested by the server, if any.
+func (s *Subscription) consume(ctx context.Context, body io.Reader) (delivered bool, retry time.Duration) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1<<20)
+
+	var id, eventType string
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if data.Len() > 0 {
+				if !s.deliver(ctx, id, eventType, data.String()) {
+					return delivered, retry
+				}
+				delivered = true
+			}
+			id, eventType = "", ""
+			data.Reset()
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // heartbeat comment
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "id":
+			id = value
+		case "event":
+			eventType = value
+		case "data":
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms > 0 {
+				retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	return delivered, retry
+}
+
+// deliver decodes and sends an event, returning false if the subscription is closing.
+func (s *Subscription) deliver(ctx context.Context, id, eventType, data string) bool {
+	var event Event
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		event = Event{Data: json.RawMessage(strconv.Quote(data))}
+	}
+	if event.ID == "" {
+		event.ID = id
+	}
+	if event.Type == "" {
+		event.Type = eventType
+	}
+
+	select {
+	case s.events <- event:
+	case <-ctx.Done():
+		return false
+	}
+	if event.ID != "" {
+		s.mu.Lock()
+		s.lastEventID = event.ID
+		s.mu.Unlock()
+	}
+	return true
+}
+
+// openEventStream connects to the event stream and returns its body.
+func (c *Client) openEventStream(ctx context.Context, endpoint string, params url.Values, lastEventID string) (io.ReadCloser, error) {
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	return c.connectEventStream(ctx, endpoint, lastEventID, true)
+}
+
+func (c *Client) connectEventStream(ctx context.Context, endpoint, lastEventID string, refreshOnUnauthorized bool) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", utils.ErrInvalidRequest, err)
+	}
+
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.applyHeaders(ctx, req)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	// Streams are long-lived: reuse the transport but not the request timeout
+	resp, err := (&http.Client{Transport: c.httpClient.Transport}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return resp.Body, nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	_ = resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		if refreshOnUnauthorized && c.isKeycloakAuthMethodConfigured() {
+			if _, err := c.refreshToken(ctx); err == nil {
+				return c.connectEventStream(ctx, endpoint, lastEventID, false)
+			}
+		}
+		return nil, utils.ErrAuthenticationFailed
+	case http.StatusForbidden:
+		return nil, utils.ErrPermissionDenied
+	case http.StatusNotFound:
+		return nil, utils.ErrNotFound
+	}
+	return nil, fmt.Errorf("%w: event stream returned status %d: %s", utils.ErrAPIError, resp.StatusCode, string(body))
+}
+
+// isPermanentStreamError reports whether reconnecting cannot succeed.
+func isPermanentStreamError(err error) bool {
+	for _, permanent := range []error{utils.ErrAuthenticationFailed, utils.ErrPermissionDenied, utils.ErrNotFound, utils.ErrInvalidConfiguration, utils.ErrInvalidRequest} {
+		if errors.Is(err, permanent) {
+			return true
+		}
+	}
+	return false
+}
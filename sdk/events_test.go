@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestEvents_SubscribeResumesAfterDisconnect(t *testing.T) {
+	var mu sync.Mutex
+	var lastEventIDs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/org-1/events" || r.URL.Query().Get("type") != "datadock.status_changed" {
+			t.Errorf("Unexpected request: %s", r.URL)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Missing bearer token")
+		}
+		mu.Lock()
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		attempt := len(lastEventIDs)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		if attempt == 1 {
+			fmt.Fprint(w, "retry: 10\n: heartbeat\n\n")
+			fmt.Fprint(w, "id: 1\nevent: datadock.status_changed\ndata: {\"data\": {\"status\": \"Pending\"}}\n\n")
+			fmt.Fprint(w, "id: 2\ndata: {\"type\": \"datadock.status_changed\",\ndata: \"data\": {\"status\": \"Online\"}}\n\n")
+			return // drop the connection
+		}
+		fmt.Fprint(w, "id: 3\nevent: datadock.status_changed\ndata: {}\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{BaseURL: server.URL, OrgID: "org-1", Token: "test-token"})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sub, err := client.Events().Subscribe(ctx, WithEventTypes("datadock.status_changed"))
+	if err != nil {
+		t.Fatalf("Subscribe() unexpected error = %v", err)
+	}
+
+	var ids []string
+	for event := range sub.Events() {
+		if event.Type != "datadock.status_changed" {
+			t.Errorf("Unexpected event type %q", event.Type)
+		}
+		ids = append(ids, event.ID)
+		if len(ids) == 3 {
+			break
+		}
+	}
+	sub.Close()
+
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("Expected events 1, 2, 3, got %v", ids)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(lastEventIDs) < 2 || lastEventIDs[1] != "2" {
+		t.Errorf("Expected reconnection to resume after event 2, got %v", lastEventIDs)
+	}
+	if sub.LastEventID() != "3" {
+		t.Errorf("Expected last event ID 3, got %q", sub.LastEventID())
+	}
+}
+
+func TestEvents_SubscribeAuthFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{BaseURL: server.URL, OrgID: "org-1", Token: "test-token"})
+	if _, err := client.Events().Subscribe(context.Background()); err != utils.ErrPermissionDenied {
+		t.Errorf("Expected ErrPermissionDenied, got %v", err)
+	}
+}
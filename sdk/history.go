@@ -0,0 +1,114 @@
+package sdk
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// HistoryEntry describes a request issued by the client, as recorded when
+// Configuration.HistorySize is set.
+type HistoryEntry struct {
+	Time      time.Time     `json:"time"`
+	Method    string        `json:"method"`
+	Endpoint  string        `json:"endpoint"` // URL without its query string
+	Params    url.Values    `json:"params,omitempty"`
+	Status    int           `json:"status,omitempty"` // HTTP status, 0 when no response was received
+	Duration  time.Duration `json:"duration"`         // Including retries, in nanoseconds
+	Retries   int           `json:"retries"`
+	RequestID string        `json:"request_id"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// requestHistory is a ring buffer of the last requests of a client.
+type requestHistory struct {
+	mu      sync.Mutex
+	entries []HistoryEntry
+	next    int
+	full    bool
+}
+
+// newRequestHistory returns nil when the history is disabled.
+func newRequestHistory(cfg utils.Configuration) *requestHistory {
+	if cfg.HistorySize <= 0 {
+		return nil
+	}
+	return &requestHistory{entries: make([]HistoryEntry, cfg.HistorySize)}
+}
+
+func (h *requestHistory) record(entry HistoryEntry) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries[h.next] = entry
+	h.next = (h.next + 1) % len(h.entries)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+func (h *requestHistory) snapshot() []HistoryEntry {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !h.full {
+		return append([]HistoryEntry(nil), h.entries[:h.next]...)
+	}
+	return append(append([]HistoryEntry(nil), h.entries[h.next:]...), h.entries[:h.next]...)
+}
+
+// newHistoryEntry describes a finished request.
+func newHistoryEntry(method, rawURL string, start time.Time, attempts int, requestID string, resp *utils.Response, err error) HistoryEntry {
+	entry := HistoryEntry{
+		Time:      start,
+		Method:    method,
+		Endpoint:  rawURL,
+		Duration:  time.Since(start),
+		Retries:   max(attempts-1, 0),
+		RequestID: requestID,
+	}
+	if endpoint, query, found := strings.Cut(rawURL, "?"); found {
+		entry.Endpoint = endpoint
+		entry.Params, _ = url.ParseQuery(query)
+	}
+	if resp != nil {
+		entry.Status = resp.HTTPCode
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	return entry
+}
+
+// History returns the last requests of the client, oldest first, or nil when
+// Configuration.HistorySize is not set. Derived clients share the history.
+//
+// Example:
+//
+//	for _, entry := range client.History() {
+//	    fmt.Println(entry.Method, entry.Endpoint, entry.Status, entry.Duration, entry.Error)
+//	}
+func (c *Client) History() []HistoryEntry {
+	return c.history.snapshot()
+}
+
+// DumpHistory writes the request history as indented JSON, e.g. to attach
+// reproduction information to a support ticket.
+func (c *Client) DumpHistory(w io.Writer) error {
+	history := c.History()
+	if history == nil {
+		history = []HistoryEntry{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(history)
+}
@@ -0,0 +1,66 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestClient_History(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/missing":
+			http.Error(w, "not found", http.StatusNotFound)
+		case r.URL.Path == "/flaky" && atomic.AddInt32(&calls, 1) == 1:
+			http.Error(w, "unavailable", http.StatusBadGateway)
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{BaseURL: server.URL, Token: "token", MaxRetries: 2, HistorySize: 2})
+	ctx := context.Background()
+	_, _ = client.Do(ctx, "GET", server.URL+"/first", nil)
+	_, _ = client.Do(ctx, "GET", server.URL+"/flaky?limit=10&select=id", nil)
+	_, _ = client.WithHeader("X-Trace", "1").Do(ctx, "GET", server.URL+"/missing", nil)
+
+	history := client.History()
+	if len(history) != 2 {
+		t.Fatalf("expected the last 2 requests, got %+v", history)
+	}
+	flaky, missing := history[0], history[1]
+	if flaky.Endpoint != server.URL+"/flaky" || flaky.Params.Get("limit") != "10" || flaky.Retries != 1 || flaky.Status != http.StatusOK {
+		t.Errorf("unexpected entry for the retried request: %+v", flaky)
+	}
+	if missing.Status != http.StatusNotFound || missing.Error == "" || missing.RequestID == "" {
+		t.Errorf("unexpected entry for the failed request: %+v", missing)
+	}
+
+	var buf bytes.Buffer
+	if err := client.DumpHistory(&buf); err != nil {
+		t.Fatalf("DumpHistory() error = %v", err)
+	}
+	var dumped []HistoryEntry
+	if err := json.Unmarshal(buf.Bytes(), &dumped); err != nil || len(dumped) != 2 {
+		t.Errorf("DumpHistory() wrote %s (%v)", buf.String(), err)
+	}
+}
+
+func TestClient_HistoryDisabled(t *testing.T) {
+	client := NewClient(utils.Configuration{BaseURL: "https://api.test", Token: "token"})
+	if history := client.History(); history != nil {
+		t.Errorf("expected no history, got %+v", history)
+	}
+	var buf bytes.Buffer
+	if err := client.DumpHistory(&buf); err != nil || buf.String() != "[]\n" {
+		t.Errorf("DumpHistory() = %q, %v", buf.String(), err)
+	}
+}
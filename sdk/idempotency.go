@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/controlplaneapiclient"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// idempotencyKeyHeader carries the key letting the platform deduplicate retried mutations.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// isMutatingMethod reports whether requests with this method change server state.
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// withIdempotencyKey adds a generated Idempotency-Key to ctx for mutating requests
+// when AutoIdempotencyKeys is enabled and the caller did not provide one.
+// The key is attached once, so every retry of the request carries the same key.
+func (c *Client) withIdempotencyKey(ctx context.Context, method string) context.Context {
+	if !c.config.AutoIdempotencyKeys || !isMutatingMethod(method) {
+		return ctx
+	}
+	if utils.HeadersFromContext(ctx).Get(idempotencyKeyHeader) != "" || c.headers.Get(idempotencyKeyHeader) != "" {
+		return ctx
+	}
+	return utils.ContextWithHeaders(ctx, http.Header{idempotencyKeyHeader: {uuid.NewString()}})
+}
+
+// WithIdempotencyKey returns a request editor setting the Idempotency-Key header
+// on a single Control Plane call.
+//
+// Example:
+//
+//	resp, err := cp.CreateHarborCrdWithResponse(ctx, orgID, body, sdk.WithIdempotencyKey(key))
+func WithIdempotencyKey(key string) controlplaneapiclient.RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		req.Header.Set(idempotencyKeyHeader, key)
+		return nil
+	}
+}
+
+// autoIdempotencyKeyEditor generates an Idempotency-Key for mutating Control Plane
+// calls that do not already carry one.
+func autoIdempotencyKeyEditor(ctx context.Context, req *http.Request) error {
+	if isMutatingMethod(req.Method) && req.Header.Get(idempotencyKeyHeader) == "" {
+		req.Header.Set(idempotencyKeyHeader, uuid.NewString())
+	}
+	return nil
+}
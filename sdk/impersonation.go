@@ -0,0 +1,70 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// OAuth 2.0 Token Exchange (RFC 8693) identifiers understood by Keycloak.
+const (
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	accessTokenType        = "urn:ietf:params:oauth:token-type:access_token"
+)
+
+// Impersonate exchanges an end-user access token for a token that lets this
+// client's service account act on behalf of that user, and returns a derived
+// client whose requests carry the exchanged token.
+//
+// The service account must be allowed to perform token exchange in Keycloak.
+// The original client is left untouched and can keep being used with its own
+// identity. When the exchanged token expires, the derived client transparently
+// exchanges the subject token again.
+//
+// Note: ControlPlane() on the derived client still authenticates with the
+// service account credentials, not the impersonated user.
+//
+// Example:
+//
+//	userClient, err := client.Impersonate(ctx, r.Header.Get("X-User-Token"))
+//	if err != nil {
+//	    return err
+//	}
+//	resp, err := userClient.Catalog("sales").Schema("public").Table("orders").Get(ctx)
+func (c *Client) Impersonate(ctx context.Context, subjectToken string) (*Client, error) {
+	if subjectToken == "" {
+		return nil, fmt.Errorf("%w: subject token is required", utils.ErrInvalidRequest)
+	}
+	if !c.hasKeycloakClientCredentials() {
+		return nil, fmt.Errorf("%w: token exchange requires Keycloak client credentials", utils.ErrInvalidConfiguration)
+	}
+
+	// Like WithHeader, the derived client keeps the headers, transformers and shared
+	// state of c. It must never fall back to the service account's own identity.
+	derived := c.derive()
+	derived.subjectToken = subjectToken
+	derived.config.Token = ""
+	derived.config.KeycloakUsername = ""
+	derived.config.KeycloakPassword = ""
+
+	if _, err := derived.refreshToken(ctx); err != nil {
+		return nil, err
+	}
+
+	return &derived, nil
+}
+
+// refreshAccessTokenTokenExchange performs the Token Exchange Grant flow for the subject token.
+func (c *Client) refreshAccessTokenTokenExchange(ctx context.Context) (string, error) {
+	form := url.Values{
+		"grant_type":           {tokenExchangeGrantType},
+		"client_id":            {c.config.KeycloakClientID},
+		"client_secret":        {c.config.KeycloakClientSecret},
+		"subject_token":        {c.subjectToken},
+		"subject_token_type":   {accessTokenType},
+		"requested_token_type": {accessTokenType},
+	}
+	return c.exchangeKeycloakToken(ctx, form)
+}
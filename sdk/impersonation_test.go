@@ -0,0 +1,112 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestImpersonate_ExchangesSubjectToken(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/realms/test/protocol/openid-connect/token" {
+			if err := r.ParseForm(); err != nil {
+				t.Fatalf("failed to parse form: %v", err)
+			}
+			if r.Form.Get("grant_type") != tokenExchangeGrantType {
+				t.Errorf("grant_type = %q, want %q", r.Form.Get("grant_type"), tokenExchangeGrantType)
+			}
+			if r.Form.Get("subject_token") != "user-token" {
+				t.Errorf("subject_token = %q, want %q", r.Form.Get("subject_token"), "user-token")
+			}
+			_, _ = w.Write([]byte(`{"access_token": "exchanged-token"}`))
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{
+		BaseURL:              server.URL,
+		DataDockID:           "dd",
+		Token:                "sa-token",
+		KeycloakBaseURL:      server.URL,
+		KeycloakRealm:        "test",
+		KeycloakClientID:     "sa",
+		KeycloakClientSecret: "secret",
+	})
+
+	derived, err := client.Impersonate(context.Background(), "user-token")
+	if err != nil {
+		t.Fatalf("Impersonate() unexpected error = %v", err)
+	}
+	if derived == client {
+		t.Fatal("Impersonate() should return a new client")
+	}
+	if client.config.Token != "sa-token" {
+		t.Errorf("original client token = %q, want %q", client.config.Token, "sa-token")
+	}
+
+	if _, err := derived.Catalog("c").Schema("s").Table("t").Get(context.Background()); err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if gotAuth != "Bearer exchanged-token" {
+		t.Errorf("Authorization = %q, want %q", gotAuth, "Bearer exchanged-token")
+	}
+}
+
+func TestImpersonate_Validation(t *testing.T) {
+	client := NewClient(utils.Configuration{Token: "sa-token"})
+
+	if _, err := client.Impersonate(context.Background(), ""); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("expected ErrInvalidRequest for empty subject token, got %v", err)
+	}
+	if _, err := client.Impersonate(context.Background(), "user-token"); !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("expected ErrInvalidConfiguration without client credentials, got %v", err)
+	}
+}
+
+func TestImpersonate_KeepsTransformersAndHeaders(t *testing.T) {
+	var gotTenant string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/realms/test/protocol/openid-connect/token" {
+			_, _ = w.Write([]byte(`{"access_token": "exchanged-token"}`))
+			return
+		}
+		gotTenant = r.Header.Get("X-Tenant-ID")
+		_, _ = w.Write([]byte(`[{"ssn": "123-45-6789"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{
+		BaseURL:              server.URL,
+		KeycloakBaseURL:      server.URL,
+		KeycloakRealm:        "test",
+		KeycloakClientID:     "sa",
+		KeycloakClientSecret: "secret",
+	}).WithHeader("X-Tenant-ID", "t1")
+	client.AddResponseTransformer(TransformRows(func(row map[string]any) error {
+		row["ssn"] = "***"
+		return nil
+	}))
+
+	derived, err := client.Impersonate(context.Background(), "user-token")
+	if err != nil {
+		t.Fatalf("Impersonate() unexpected error = %v", err)
+	}
+	resp, err := derived.Do(context.Background(), "GET", server.URL+"/api/rows", nil)
+	if err != nil {
+		t.Fatalf("Do() unexpected error = %v", err)
+	}
+	if rows, _ := resp.Rows(); len(rows) != 1 || rows[0]["ssn"] != "***" {
+		t.Errorf("expected the transformer to mask the row, got %v", rows)
+	}
+	if gotTenant != "t1" {
+		t.Errorf("X-Tenant-ID = %q, want %q", gotTenant, "t1")
+	}
+}
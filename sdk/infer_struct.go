@@ -0,0 +1,222 @@
+package sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// InferStruct generates the Go definition of a struct able to decode the rows of a
+// query result (e.g. resp.Data), to paste into code using the typed decoding APIs.
+// Fields get json tags named after the columns; columns that are null or missing in
+// some rows become pointers. Integral numbers become int64, RFC 3339 strings
+// time.Time, and columns mixing types any.
+//
+// Example:
+//
+//	resp, _ := client.Catalog("sales").Schema("public").Table("orders").Limit(100).Get(ctx)
+//	code, err := sdk.InferStruct(resp.Data, "Order")
+//	// type Order struct {
+//	//     CustomerID int64    `json:"customer_id"`
+//	//     Note       *string  `json:"note"`
+//	//     ...
+func InferStruct(data any, name string) (string, error) {
+	if !token.IsIdentifier(name) {
+		return "", fmt.Errorf("%w: %q is not a valid Go type name", utils.ErrInvalidRequest, name)
+	}
+	rows, err := inferenceRows(data)
+	if err != nil {
+		return "", err
+	}
+
+	columns := map[string]*columnShape{}
+	for _, row := range rows {
+		for column, value := range row {
+			shape, ok := columns[column]
+			if !ok {
+				shape = &columnShape{}
+				columns[column] = shape
+			}
+			shape.observe(value)
+		}
+	}
+
+	names := make([]string, 0, len(columns))
+	for column := range columns {
+		names = append(names, column)
+	}
+	sort.Strings(names)
+
+	var code strings.Builder
+	fmt.Fprintf(&code, "type %s struct {\n", name)
+	usedFields := map[string]int{}
+	for _, column := range names {
+		shape := columns[column]
+		field := fieldName(column)
+		if usedFields[field]++; usedFields[field] > 1 {
+			field = fmt.Sprintf("%s%d", field, usedFields[field])
+		}
+		nullable := shape.null || shape.seen < len(rows)
+		fmt.Fprintf(&code, "\t%s %s `json:%q`\n", field, shape.goType(nullable), column)
+	}
+	code.WriteString("}\n")
+
+	formatted, err := format.Source([]byte(code.String()))
+	if err != nil {
+		return "", fmt.Errorf("failed to format struct: %w", err)
+	}
+	return string(formatted), nil
+}
+
+// inferenceRows extracts the rows of a query result.
+func inferenceRows(data any) ([]map[string]any, error) {
+	if resp, ok := data.(*utils.Response); ok {
+		data = resp.Data
+	}
+	var rows []map[string]any
+	switch v := data.(type) {
+	case []map[string]any:
+		rows = v
+	case map[string]any:
+		rows = []map[string]any{v}
+	case []any:
+		for i, item := range v {
+			row, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%w: row %d is a %T, not an object", utils.ErrInvalidRequest, i, item)
+			}
+			rows = append(rows, row)
+		}
+	default:
+		return nil, fmt.Errorf("%w: expected a list of rows, got %T", utils.ErrInvalidRequest, data)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no rows to infer a struct from", utils.ErrInvalidRequest)
+	}
+	return rows, nil
+}
+
+// columnShape accumulates the kinds of values seen in a column.
+type columnShape struct {
+	seen  int             // Rows having the column, null included
+	null  bool            // A null value was seen
+	kinds map[string]bool // Go types of the non-null values
+}
+
+func (s *columnShape) observe(value any) {
+	s.seen++
+	if s.kinds == nil {
+		s.kinds = map[string]bool{}
+	}
+	switch v := value.(type) {
+	case nil:
+		s.null = true
+	case bool:
+		s.kinds["bool"] = true
+	case float64:
+		s.kinds[numberKind(v)] = true
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			s.kinds["int64"] = true
+		} else {
+			s.kinds["float64"] = true
+		}
+	case int, int32, int64:
+		s.kinds["int64"] = true
+	case float32:
+		s.kinds[numberKind(float64(v))] = true
+	case string:
+		if _, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			s.kinds["time.Time"] = true
+		} else {
+			s.kinds["string"] = true
+		}
+	case map[string]any:
+		s.kinds["map[string]any"] = true
+	case []any:
+		s.kinds["[]any"] = true
+	default:
+		s.kinds["any"] = true
+	}
+}
+
+// numberKind tells integral JSON numbers from floating point ones.
+func numberKind(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+		return "int64"
+	}
+	return "float64"
+}
+
+// goType returns the Go type able to hold every value seen in the column.
+func (s *columnShape) goType(nullable bool) string {
+	kinds := s.kinds
+	if kinds["int64"] && kinds["float64"] {
+		kinds = map[string]bool{"float64": true}
+		for kind := range s.kinds {
+			if kind != "int64" {
+				kinds[kind] = true
+			}
+		}
+	}
+	if kinds["time.Time"] && kinds["string"] {
+		kinds = map[string]bool{"string": true}
+		for kind := range s.kinds {
+			if kind != "time.Time" {
+				kinds[kind] = true
+			}
+		}
+	}
+
+	if len(kinds) != 1 {
+		return "any" // Only nulls, or mixed types
+	}
+	var goType string
+	for kind := range kinds {
+		goType = kind
+	}
+	switch goType {
+	case "any", "map[string]any", "[]any":
+		return goType // Already nilable
+	}
+	if nullable {
+		return "*" + goType
+	}
+	return goType
+}
+
+// commonInitialisms are written in upper case in field names, as golint expects.
+var commonInitialisms = map[string]bool{
+	"ID": true, "URL": true, "URI": true, "API": true, "HTTP": true, "JSON": true,
+	"UUID": true, "SQL": true, "IP": true, "CPU": true, "UTC": true, "XML": true,
+}
+
+// fieldName converts a column name (snake_case, kebab-case, camelCase...) to an exported Go identifier.
+func fieldName(column string) string {
+	words := strings.FieldsFunc(column, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var name strings.Builder
+	for _, word := range words {
+		if upper := strings.ToUpper(word); commonInitialisms[upper] {
+			name.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		name.WriteString(string(runes))
+	}
+	result := name.String()
+	if result == "" || !unicode.IsLetter([]rune(result)[0]) {
+		result = "Column" + result
+	}
+	return result
+}
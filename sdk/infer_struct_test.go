@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestInferStruct(t *testing.T) {
+	var data any
+	err := json.Unmarshal([]byte(`[
+		{"order_id": 1, "customer-name": "Ada", "total": 10, "note": null, "created_at": "2024-05-01T10:00:00Z", "tags": ["a"], "api_url": "https://x"},
+		{"order_id": 2, "customer-name": "Bob", "total": 12.5, "note": "gift", "created_at": "2024-05-02T10:00:00Z", "tags": [], "extra": {"k": 1}, "api_url": "https://y"}
+	]`), &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, err := InferStruct(data, "Order")
+	if err != nil {
+		t.Fatalf("InferStruct() unexpected error = %v", err)
+	}
+	for _, want := range []string{
+		"type Order struct {",
+		"OrderID      int64          `json:\"order_id\"`",
+		"CustomerName string         `json:\"customer-name\"`",
+		"Total        float64        `json:\"total\"`",
+		"Note         *string        `json:\"note\"`",
+		"CreatedAt    time.Time      `json:\"created_at\"`",
+		"Tags         []any          `json:\"tags\"`",
+		"Extra        map[string]any `json:\"extra\"`",
+		"APIURL       string         `json:\"api_url\"`",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("InferStruct() output is missing %q:\n%s", want, code)
+		}
+	}
+
+	if _, err := InferStruct([]any{}, "Order"); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("InferStruct() of no rows error = %v, want ErrInvalidRequest", err)
+	}
+	if _, err := InferStruct(data, "not a name"); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("InferStruct() with an invalid name error = %v, want ErrInvalidRequest", err)
+	}
+}
@@ -0,0 +1,327 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// identifierPattern matches the unquoted SQL identifiers accepted as table name parts and aliases.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// joinTable is a table of a join, with its join type and condition.
+type joinTable struct {
+	kind      string // "JOIN" or "LEFT JOIN", empty for the first table
+	name      string // Quoted catalog.schema.table
+	alias     string
+	condition string
+}
+
+// joinFilter is a WHERE condition of a join.
+type joinFilter struct {
+	expr     string
+	operator string
+	value    any
+}
+
+// JoinBuilder builds a SELECT over several tables, compiled to SQL and executed
+// through the SQL endpoint of the control plane. The table endpoints of the data
+// layer only serve one table at a time.
+type JoinBuilder struct {
+	client     *Client
+	dataDockID string
+	tables     []joinTable
+	selectCols []string
+	filters    []joinFilter
+	orderBy    []string
+	limitVal   int
+	errors     []error
+}
+
+// Join starts a join on a table named "catalog.schema.table", optionally followed
+// by an alias ("catalog.schema.table o" or "catalog.schema.table AS o"). Columns,
+// join conditions and ORDER BY expressions are SQL written against the aliases;
+// filter values are rendered as SQL literals.
+//
+// Example:
+//
+//	resp, err := client.Join("sales.public.orders o").
+//	    On("sales.public.customers c", "c.id = o.customer_id").
+//	    Select("o.id", "o.total", "c.name").
+//	    Where("o.total", ">", 100).
+//	    OrderBy("o.total", "DESC").
+//	    Limit(50).
+//	    Get(ctx)
+func (c *Client) Join(table string) *JoinBuilder {
+	j := &JoinBuilder{client: c, dataDockID: c.config.DataDockID}
+	return j.addTable("", table, "")
+}
+
+func (j *JoinBuilder) clone() *JoinBuilder {
+	cloned := *j
+	cloned.tables = append([]joinTable(nil), j.tables...)
+	cloned.selectCols = append([]string(nil), j.selectCols...)
+	cloned.filters = append([]joinFilter(nil), j.filters...)
+	cloned.orderBy = append([]string(nil), j.orderBy...)
+	cloned.errors = append([]error(nil), j.errors...)
+	return &cloned
+}
+
+func (j *JoinBuilder) addTable(kind, table, condition string) *JoinBuilder {
+	j = j.clone()
+	name, alias, err := parseJoinTable(table)
+	if err != nil {
+		j.errors = append(j.errors, err)
+		return j
+	}
+	if kind != "" && strings.TrimSpace(condition) == "" {
+		j.errors = append(j.errors, fmt.Errorf("join of %s has no condition", table))
+		return j
+	}
+	j.tables = append(j.tables, joinTable{kind: kind, name: name, alias: alias, condition: strings.TrimSpace(condition)})
+	return j
+}
+
+// On adds an inner join with another table, named like the first one.
+func (j *JoinBuilder) On(table, condition string) *JoinBuilder {
+	return j.addTable("JOIN", table, condition)
+}
+
+// LeftOn adds a left outer join with another table, named like the first one.
+func (j *JoinBuilder) LeftOn(table, condition string) *JoinBuilder {
+	return j.addTable("LEFT JOIN", table, condition)
+}
+
+// DataDock overrides the datadock the query runs against.
+func (j *JoinBuilder) DataDock(dataDockID string) *JoinBuilder {
+	j = j.clone()
+	j.dataDockID = dataDockID
+	return j
+}
+
+// Select sets the selected columns or expressions, e.g. "o.id" or "sum(o.total) AS total".
+// All columns are selected by default.
+func (j *JoinBuilder) Select(columns ...string) *JoinBuilder {
+	j = j.clone()
+	j.selectCols = append(j.selectCols, columns...)
+	return j
+}
+
+// Where adds a condition comparing an expression with a value. Operators are
+// =, !=, <>, <, <=, >, >=, LIKE, ILIKE and IN (with a slice value); a nil value
+// with = or != tests for NULL. Conditions are combined with AND.
+func (j *JoinBuilder) Where(expr, operator string, value any) *JoinBuilder {
+	j = j.clone()
+	j.filters = append(j.filters, joinFilter{expr: expr, operator: strings.ToUpper(strings.TrimSpace(operator)), value: value})
+	return j
+}
+
+// OrderBy adds an ordering expression; direction is "ASC" or "DESC".
+func (j *JoinBuilder) OrderBy(expr, direction string) *JoinBuilder {
+	j = j.clone()
+	direction = strings.ToUpper(direction)
+	if direction != "ASC" && direction != "DESC" {
+		j.errors = append(j.errors, fmt.Errorf("invalid order direction %q", direction))
+		return j
+	}
+	j.orderBy = append(j.orderBy, expr+" "+direction)
+	return j
+}
+
+// Limit sets the maximum number of rows returned.
+func (j *JoinBuilder) Limit(n int) *JoinBuilder {
+	j = j.clone()
+	j.limitVal = n
+	return j
+}
+
+// SQL returns the compiled statement.
+func (j *JoinBuilder) SQL() (string, error) {
+	if len(j.errors) > 0 {
+		return "", fmt.Errorf("%w: %w", utils.ErrInvalidRequest, j.errors[0])
+	}
+	if len(j.tables) == 0 {
+		return "", fmt.Errorf("%w: join has no table", utils.ErrInvalidRequest)
+	}
+
+	var sql strings.Builder
+	sql.WriteString("SELECT ")
+	if len(j.selectCols) == 0 {
+		sql.WriteString("*")
+	} else {
+		sql.WriteString(strings.Join(j.selectCols, ", "))
+	}
+	for i, table := range j.tables {
+		if i == 0 {
+			sql.WriteString(" FROM ")
+		} else {
+			sql.WriteString(" " + table.kind + " ")
+		}
+		sql.WriteString(table.name)
+		if table.alias != "" {
+			sql.WriteString(" AS " + table.alias)
+		}
+		if table.condition != "" {
+			sql.WriteString(" ON " + table.condition)
+		}
+	}
+	for i, filter := range j.filters {
+		condition, err := filter.sql()
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", utils.ErrInvalidRequest, err)
+		}
+		if i == 0 {
+			sql.WriteString(" WHERE ")
+		} else {
+			sql.WriteString(" AND ")
+		}
+		sql.WriteString(condition)
+	}
+	if len(j.orderBy) > 0 {
+		sql.WriteString(" ORDER BY " + strings.Join(j.orderBy, ", "))
+	}
+	if j.limitVal > 0 {
+		sql.WriteString(" LIMIT " + strconv.Itoa(j.limitVal))
+	}
+	return sql.String(), nil
+}
+
+// Get executes the join. The rows of the response are objects keyed by column
+// name, like the rows of table queries, so utils.RowScanner and Response.Rows apply.
+func (j *JoinBuilder) Get(ctx context.Context) (*utils.Response, error) {
+	if j.dataDockID == "" {
+		return nil, fmt.Errorf("%w: datadock ID is required", utils.ErrInvalidRequest)
+	}
+	statement, err := j.SQL()
+	if err != nil {
+		return nil, err
+	}
+	resp, result, err := j.client.executeSQL(ctx, j.dataDockID, statement, 0)
+	if err != nil {
+		return resp, err
+	}
+
+	rows := make([]any, 0, len(result.Rows))
+	for _, values := range result.Rows {
+		row := make(map[string]any, len(values))
+		for i, value := range values {
+			if i < len(result.Columns) {
+				row[result.Columns[i].Name] = value
+			}
+		}
+		rows = append(rows, row)
+	}
+	resp.Data = rows
+	resp.RowCount = len(rows)
+	resp.TotalCount = result.TotalRows
+	if err := j.client.transformResponse(ctx, "POST", j.client.sqlExecuteURL(ctx), resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+// parseJoinTable splits "catalog.schema.table [AS] alias" into the quoted table name and the alias.
+func parseJoinTable(table string) (name, alias string, err error) {
+	fields := strings.Fields(table)
+	if len(fields) == 3 && strings.EqualFold(fields[1], "AS") {
+		fields = []string{fields[0], fields[2]}
+	}
+	if len(fields) == 0 || len(fields) > 2 {
+		return "", "", fmt.Errorf("invalid join table %q", table)
+	}
+	if len(fields) == 2 {
+		alias = fields[1]
+		if !identifierPattern.MatchString(alias) {
+			return "", "", fmt.Errorf("invalid alias %q", alias)
+		}
+	}
+	parts := strings.Split(fields[0], ".")
+	if len(parts) != 3 {
+		return "", "", fmt.Errorf("join table %q must be schema-qualified as catalog.schema.table", table)
+	}
+	for i, part := range parts {
+		if !identifierPattern.MatchString(part) {
+			return "", "", fmt.Errorf("invalid identifier %q in join table %q", part, table)
+		}
+		parts[i] = `"` + part + `"`
+	}
+	return strings.Join(parts, "."), alias, nil
+}
+
+// sql renders the filter as a SQL condition.
+func (f joinFilter) sql() (string, error) {
+	switch f.operator {
+	case "=", "!=", "<>":
+		if f.value == nil {
+			if f.operator == "=" {
+				return f.expr + " IS NULL", nil
+			}
+			return f.expr + " IS NOT NULL", nil
+		}
+	case "<", "<=", ">", ">=", "LIKE", "ILIKE":
+	case "IN":
+		values, ok := f.value.([]any)
+		if !ok {
+			raw, _ := json.Marshal(f.value)
+			if err := json.Unmarshal(raw, &values); err != nil {
+				return "", fmt.Errorf("IN on %s requires a slice value, got %T", f.expr, f.value)
+			}
+		}
+		if len(values) == 0 {
+			return "", fmt.Errorf("IN on %s requires at least one value", f.expr)
+		}
+		literals := make([]string, len(values))
+		for i, value := range values {
+			literal, err := sqlLiteral(value)
+			if err != nil {
+				return "", err
+			}
+			literals[i] = literal
+		}
+		return f.expr + " IN (" + strings.Join(literals, ", ") + ")", nil
+	default:
+		return "", fmt.Errorf("unsupported operator %q", f.operator)
+	}
+
+	literal, err := sqlLiteral(f.value)
+	if err != nil {
+		return "", err
+	}
+	if f.operator == "ILIKE" {
+		// Trino has no ILIKE
+		return "lower(" + f.expr + ") LIKE lower(" + literal + ")", nil
+	}
+	return f.expr + " " + f.operator + " " + literal, nil
+}
+
+// sqlLiteral renders a Go value as a SQL literal.
+func sqlLiteral(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case json.Number:
+		if _, err := v.Float64(); err != nil {
+			return "", fmt.Errorf("invalid number %q", v)
+		}
+		return v.String(), nil
+	case time.Time:
+		return "TIMESTAMP '" + v.UTC().Format("2006-01-02 15:04:05.000000") + "'", nil
+	}
+	return "", fmt.Errorf("unsupported filter value type %T", value)
+}
@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestJoinBuilder_SQL(t *testing.T) {
+	client := &Client{config: utils.Configuration{DataDockID: "dd-1"}}
+
+	sql, err := client.Join("sales.public.orders AS o").
+		On("sales.public.customers c", "c.id = o.customer_id").
+		LeftOn("sales.public.regions r", "r.id = c.region_id").
+		Select("o.id", "c.name", "r.label").
+		Where("c.name", "=", "O'Brien").
+		Where("o.total", ">=", 100.5).
+		Where("r.label", "!=", nil).
+		Where("o.status", "in", []string{"paid", "shipped"}).
+		Where("o.created_at", "<", time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)).
+		OrderBy("o.total", "desc").
+		Limit(10).
+		SQL()
+	if err != nil {
+		t.Fatalf("SQL() unexpected error = %v", err)
+	}
+	expected := `SELECT o.id, c.name, r.label FROM "sales"."public"."orders" AS o` +
+		` JOIN "sales"."public"."customers" AS c ON c.id = o.customer_id` +
+		` LEFT JOIN "sales"."public"."regions" AS r ON r.id = c.region_id` +
+		` WHERE c.name = 'O''Brien' AND o.total >= 100.5 AND r.label IS NOT NULL` +
+		` AND o.status IN ('paid', 'shipped') AND o.created_at < TIMESTAMP '2025-03-01 00:00:00.000000'` +
+		` ORDER BY o.total DESC LIMIT 10`
+	if sql != expected {
+		t.Errorf("SQL() =\n%s\nexpected\n%s", sql, expected)
+	}
+}
+
+func TestJoinBuilder_Invalid(t *testing.T) {
+	client := &Client{config: utils.Configuration{DataDockID: "dd-1"}}
+	invalid := []*JoinBuilder{
+		client.Join("orders o"),
+		client.Join("sales.public.orders; DROP TABLE x"),
+		client.Join("sales.public.orders o").On("sales.public.customers c", " "),
+		client.Join("sales.public.orders").Where("id", "BETWEEN", 1),
+		client.Join("sales.public.orders").Where("id", "=", struct{}{}),
+		client.Join("sales.public.orders").OrderBy("id", "sideways"),
+	}
+	for i, join := range invalid {
+		if _, err := join.SQL(); !errors.Is(err, utils.ErrInvalidRequest) {
+			t.Errorf("join %d: expected ErrInvalidRequest, got %v", i, err)
+		}
+	}
+}
+
+func TestJoinBuilder_Get(t *testing.T) {
+	var statement string
+	client := &Client{
+		config: utils.Configuration{BaseURL: "http://localhost", ControlPlaneURL: "http://cp", Token: "test-token", DataDockID: "dd-1"},
+		httpClient: &http.Client{Transport: &mockRoundTripper{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				var body map[string]any
+				_ = json.NewDecoder(req.Body).Decode(&body)
+				statement, _ = body["sql"].(string)
+				result := `{"query_id": "q", "columns": [{"name": "id"}, {"name": "name"}],
+					"rows": [[1, "Ada"], [2, "Grace"]], "total_rows": 2, "has_more": false}`
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(result)), Header: make(http.Header)}, nil
+			},
+		}},
+	}
+
+	resp, err := client.Join("sales.public.orders o").
+		On("sales.public.customers c", "c.id = o.customer_id").
+		Select("o.id", "c.name").
+		Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if !strings.HasPrefix(statement, `SELECT o.id, c.name FROM "sales"."public"."orders" AS o JOIN`) {
+		t.Errorf("Unexpected statement %q", statement)
+	}
+
+	var rows []struct {
+		ID   int    `hyperfluid:"id"`
+		Name string `hyperfluid:"name"`
+	}
+	if err := (utils.RowScanner{}).Scan(resp, &rows); err != nil {
+		t.Fatalf("Scan() unexpected error = %v", err)
+	}
+	if len(rows) != 2 || rows[1].ID != 2 || rows[1].Name != "Grace" || resp.RowCount != 2 {
+		t.Errorf("Unexpected rows %+v", rows)
+	}
+}
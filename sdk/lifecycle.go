@@ -0,0 +1,153 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// clientLifecycle ends the background work of a client and of the clients
+// derived from it when it is closed. Derived clients have a child lifecycle:
+// closing them does not affect their parent. A nil *clientLifecycle is never closed.
+type clientLifecycle struct {
+	ctx    context.Context // Done once the client is closed
+	cancel context.CancelFunc
+	// parent is the lifecycle of the client this one was derived from. Its context
+	// is not derived from the parent's, so that derived clients that are never
+	// closed are not kept alive by their parent.
+	parent *clientLifecycle
+
+	mu      sync.Mutex
+	closed  bool
+	running sync.WaitGroup // Goroutines started by tracked work, such as event streams
+}
+
+func newClientLifecycle() *clientLifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &clientLifecycle{ctx: ctx, cancel: cancel}
+}
+
+// child returns the lifecycle of a derived client, closed with l.
+func (l *clientLifecycle) child() *clientLifecycle {
+	if l == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &clientLifecycle{ctx: ctx, cancel: cancel, parent: l}
+}
+
+// descendsFrom reports whether l is ancestor or descends from it.
+func (l *clientLifecycle) descendsFrom(ancestor *clientLifecycle) bool {
+	for ; l != nil; l = l.parent {
+		if l == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// err returns utils.ErrClientClosed once the client or one of its ancestors is closed.
+func (l *clientLifecycle) err() error {
+	for ; l != nil; l = l.parent {
+		if l.ctx.Err() != nil {
+			return utils.ErrClientClosed
+		}
+	}
+	return nil
+}
+
+// bind returns a context that is also cancelled when the client or one of its
+// ancestors is closed.
+func (l *clientLifecycle) bind(ctx context.Context) (context.Context, context.CancelFunc) {
+	if l == nil {
+		return ctx, func() {}
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	var stops []func() bool
+	for ; l != nil; l = l.parent {
+		stops = append(stops, context.AfterFunc(l.ctx, func() { cancel(utils.ErrClientClosed) }))
+	}
+	return ctx, func() {
+		for _, stop := range stops {
+			stop()
+		}
+		cancel(nil)
+	}
+}
+
+// track calls cancel when the client is closed, and makes Close, of the client
+// and of its ancestors, wait for the work until done is called. It fails once the
+// client is closed.
+func (l *clientLifecycle) track(cancel context.CancelFunc) (done func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed || l.err() != nil {
+		return nil, utils.ErrClientClosed
+	}
+	parentDone, err := l.parent.track(cancel)
+	if err != nil {
+		return nil, err
+	}
+	l.running.Add(1)
+	stop := context.AfterFunc(l.ctx, cancel)
+	return func() {
+		stop()
+		l.running.Done()
+		parentDone()
+	}, nil
+}
+
+// close cancels the tracked work and waits for it to end. It reports whether
+// this call closed the lifecycle.
+func (l *clientLifecycle) close() bool {
+	if l == nil {
+		return false
+	}
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return false
+	}
+	l.closed = true
+	l.mu.Unlock()
+
+	l.cancel()
+	l.running.Wait()
+	return true
+}
+
+// Close ends the life of the client and of the clients derived from it:
+//   - in-flight token refreshes and event subscriptions are cancelled, and Close
+//     waits for the subscriptions to be released;
+//   - the service account files are no longer watched;
+//   - the cached control plane clients are released.
+//
+// Closing a derived client (WithHeader, WithQueryTag, Impersonate) leaves the
+// client it was derived from open. The transport, shared by the clients of the
+// process, stays open.
+//
+// Requests issued afterwards fail with utils.ErrClientClosed. Closing a client
+// more than once is allowed.
+func (c *Client) Close() {
+	if c.watcher != nil {
+		c.watcher.once.Do(func() {
+			close(c.watcher.stop)
+			<-c.watcher.done
+		})
+	}
+	if !c.lifecycle.close() {
+		return
+	}
+
+	controlPlaneMu.Lock()
+	for client := range controlPlaneClients {
+		if client == c || client.lifecycle.descendsFrom(c.lifecycle) {
+			delete(controlPlaneClients, client)
+		}
+	}
+	controlPlaneMu.Unlock()
+}
@@ -0,0 +1,118 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestClient_Close(t *testing.T) {
+	tokenRequested := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/org-1/events":
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		case "/realms/test/protocol/openid-connect/token":
+			_, _ = io.ReadAll(r.Body) // Lets the server notice the disconnection
+			close(tokenRequested)
+			<-r.Context().Done() // Keycloak hangs
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{
+		BaseURL:              server.URL,
+		OrgID:                "org-1",
+		DataDockID:           "dd",
+		Token:                "test-token",
+		ControlPlaneURL:      server.URL,
+		KeycloakBaseURL:      server.URL,
+		KeycloakRealm:        "test",
+		KeycloakClientID:     "sa",
+		KeycloakClientSecret: "secret",
+	})
+	derived := client.WithHeader("X-Tenant-ID", "acme")
+	ctx := context.Background()
+
+	sub, err := derived.Events().Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("Subscribe() unexpected error = %v", err)
+	}
+	if _, err := derived.ControlPlane(); err != nil {
+		t.Fatalf("ControlPlane() unexpected error = %v", err)
+	}
+	refreshed := make(chan error, 1)
+	go func() {
+		_, err := client.refreshToken(ctx)
+		refreshed <- err
+	}()
+	<-tokenRequested
+
+	closed := make(chan struct{})
+	go func() {
+		client.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Close() did not return")
+	}
+
+	if err := <-refreshed; err == nil {
+		t.Error("the in-flight token refresh was not cancelled")
+	}
+	if _, open := <-sub.Events(); open {
+		t.Error("the subscription is still open")
+	}
+	controlPlaneMu.RLock()
+	_, cached := controlPlaneClients[derived]
+	controlPlaneMu.RUnlock()
+	if cached {
+		t.Error("the control plane client of the derived client is still cached")
+	}
+	if _, err := derived.Catalog("c").Schema("s").Table("t").Get(ctx); !errors.Is(err, utils.ErrClientClosed) {
+		t.Errorf("Get() after Close error = %v, want ErrClientClosed", err)
+	}
+	if _, err := client.Events().Subscribe(ctx); !errors.Is(err, utils.ErrClientClosed) {
+		t.Errorf("Subscribe() after Close error = %v, want ErrClientClosed", err)
+	}
+	client.Close() // Closing twice is allowed
+}
+
+func TestClient_CloseDerivedClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{BaseURL: server.URL, OrgID: "org-1", DataDockID: "dd", Token: "test-token"})
+	tenant := client.WithHeader("X-Tenant-ID", "acme")
+	tagged := tenant.WithQueryTag("job:nightly")
+	ctx := context.Background()
+
+	// Closing a derived client closes its own derived clients, not its parent
+	tenant.Close()
+	if _, err := client.Catalog("c").Schema("s").Table("t").Get(ctx); err != nil {
+		t.Errorf("Get() on the parent unexpected error = %v", err)
+	}
+	if _, err := tagged.Catalog("c").Schema("s").Table("t").Get(ctx); !errors.Is(err, utils.ErrClientClosed) {
+		t.Errorf("Get() on a client derived from the closed client error = %v, want ErrClientClosed", err)
+	}
+
+	sibling := client.WithHeader("X-Tenant-ID", "globex")
+	client.Close()
+	if _, err := sibling.Events().Subscribe(ctx); !errors.Is(err, utils.ErrClientClosed) {
+		t.Errorf("Subscribe() after the parent was closed error = %v, want ErrClientClosed", err)
+	}
+}
@@ -0,0 +1,105 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Login cache modes of Profile.LoginCache.
+const (
+	LoginCacheKeyring = "keyring" // OS keyring, no cache when there is none (default)
+	LoginCacheFile    = "file"    // Unencrypted files under the user cache directory
+	LoginCacheNone    = "none"    // Authenticate on every run
+)
+
+// loginKeyringService is the OS keyring service the login tokens are saved under.
+const loginKeyringService = "hyperfluid"
+
+// loginCacheStore returns the store of the tokens obtained by interactive logins
+// for the given mode, or nil when the cache is disabled. Tokens are only written
+// to plaintext files when the file mode is chosen: without an OS keyring, the
+// keyring store fails to save and every run logs in again.
+func loginCacheStore(mode string) (utils.TokenStore, error) {
+	switch mode {
+	case LoginCacheNone:
+		return nil, nil
+	case "", LoginCacheKeyring:
+		return utils.NewKeyringTokenStore(loginKeyringService, nil), nil
+	case LoginCacheFile:
+		return loginFileStore()
+	default:
+		return nil, fmt.Errorf("%w: unknown login_cache %q", utils.ErrInvalidConfiguration, mode)
+	}
+}
+
+// loginFileStore returns the store of the file login cache.
+func loginFileStore() (utils.TokenStore, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("%w: locating the login cache: %w", utils.ErrInvalidConfiguration, err)
+	}
+	return utils.NewFileTokenStore(filepath.Join(cacheDir, "hyperfluid", "login")), nil
+}
+
+// profileTokenStore keeps a single token per profile, so that Logout can find it
+// without knowing the credentials. The client key is saved with the token: a token
+// obtained with other credentials (e.g. after editing the profile) is not reused.
+type profileTokenStore struct {
+	store   utils.TokenStore
+	profile string
+}
+
+func profileStoreKey(profile string) string {
+	return "profile/" + profile
+}
+
+func (s *profileTokenStore) Load(ctx context.Context, key string) ([]byte, error) {
+	raw, err := s.store.Load(ctx, profileStoreKey(s.profile))
+	if err != nil {
+		return nil, err
+	}
+	savedKey, token, ok := strings.Cut(string(raw), "\n")
+	if !ok || savedKey != key {
+		return nil, utils.ErrNotFound
+	}
+	return []byte(token), nil
+}
+
+func (s *profileTokenStore) Save(ctx context.Context, key string, value []byte) error {
+	return s.store.Save(ctx, profileStoreKey(s.profile), []byte(key+"\n"+string(value)))
+}
+
+func (s *profileTokenStore) Delete(ctx context.Context, key string) error {
+	return s.store.Delete(ctx, profileStoreKey(s.profile))
+}
+
+// usesInteractiveLogin reports whether the profile authenticates a person (password
+// grant) rather than a service, whose tokens are then kept in the login cache.
+func (p *Profile) usesInteractiveLogin() bool {
+	return p.ServiceAccountFile == "" && p.Token == "" &&
+		p.KeycloakUsername != "" && p.KeycloakClientSecret == ""
+}
+
+// Logout removes the cached login token of the profile (see Profile.LoginCache),
+// from the OS keyring and from the file cache. An empty name selects
+// HYPERFLUID_PROFILE, then DefaultProfile. Logging out of a profile that has no
+// cached token is not an error.
+//
+// Example:
+//
+//	err := sdk.Logout("staging")
+func Logout(profile string) error {
+	if profile == "" {
+		profile = utils.GetEnvironmentVariable("HYPERFLUID_PROFILE", DefaultProfile)
+	}
+	files, err := loginFileStore()
+	if err != nil {
+		return err
+	}
+	return utils.NewKeyringTokenStore(loginKeyringService, files).Delete(context.Background(), profileStoreKey(profile))
+}
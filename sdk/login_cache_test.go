@@ -0,0 +1,114 @@
+package sdk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProfile_CachesPasswordLogin(t *testing.T) {
+	logins := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/protocol/openid-connect/token") {
+			_ = r.ParseForm()
+			if r.Form.Get("grant_type") != "password" {
+				t.Errorf("Unexpected grant %q", r.Form.Get("grant_type"))
+			}
+			logins++
+			_, _ = w.Write([]byte(`{"access_token": "` + testJWT(time.Now().Add(time.Hour)) + `"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	writeProfiles(t, "sdk-test-login:\n  base_url: "+server.URL+"\n  datadock_id: dd\n  keycloak_base_url: "+server.URL+
+		"\n  keycloak_realm: test\n  keycloak_client_id: cli\n  keycloak_username: jane\n  login_cache: file\n")
+	t.Setenv("KEYCLOAK_PASSWORD", "secret")
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	query := func() {
+		t.Helper()
+		client, err := NewClientFromProfile("sdk-test-login")
+		if err != nil {
+			t.Fatalf("NewClientFromProfile() unexpected error = %v", err)
+		}
+		if _, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background()); err != nil {
+			t.Fatalf("Get() unexpected error = %v", err)
+		}
+	}
+
+	query()
+	query()
+	if logins != 1 {
+		t.Errorf("Expected the second run to reuse the cached login, got %d logins", logins)
+	}
+
+	// Another user of the same profile does not reuse the token
+	t.Setenv("KEYCLOAK_USERNAME", "john")
+	query()
+	if logins != 2 {
+		t.Errorf("Expected a login for another user, got %d logins", logins)
+	}
+
+	t.Setenv("KEYCLOAK_USERNAME", "")
+	query()
+	if err := Logout("sdk-test-login"); err != nil {
+		t.Fatalf("Logout() unexpected error = %v", err)
+	}
+	query()
+	if logins != 4 {
+		t.Errorf("Expected a login after Logout, got %d logins", logins)
+	}
+}
+
+func TestProfile_InvalidLoginCache(t *testing.T) {
+	writeProfiles(t, "dev:\n  base_url: https://api.example.com\n  keycloak_client_id: cli\n  keycloak_username: jane\n  login_cache: memory\n")
+
+	if _, err := NewClientFromProfile("dev"); err == nil || !strings.Contains(err.Error(), "login_cache") {
+		t.Errorf("Expected an invalid login_cache error, got %v", err)
+	}
+}
+
+func TestProfile_KeyringLoginCacheWithoutKeyring(t *testing.T) {
+	logins := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/protocol/openid-connect/token") {
+			logins++
+			_, _ = w.Write([]byte(`{"access_token": "` + testJWT(time.Now().Add(time.Hour)) + `"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	writeProfiles(t, "sdk-test-keyring:\n  base_url: "+server.URL+"\n  datadock_id: dd\n  keycloak_base_url: "+server.URL+
+		"\n  keycloak_realm: test\n  keycloak_client_id: cli\n  keycloak_username: jane\n")
+	t.Setenv("KEYCLOAK_PASSWORD", "secret")
+	t.Setenv("PATH", t.TempDir()) // No keyring tool
+	cacheDir := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cacheDir)
+	t.Setenv("HOME", t.TempDir())
+
+	for range 2 {
+		client, err := NewClientFromProfile("sdk-test-keyring")
+		if err != nil {
+			t.Fatalf("NewClientFromProfile() unexpected error = %v", err)
+		}
+		if _, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background()); err != nil {
+			t.Fatalf("Get() unexpected error = %v", err)
+		}
+	}
+	if logins != 2 {
+		t.Errorf("Expected a login on every run without a keyring, got %d logins", logins)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(cacheDir, "hyperfluid")); len(entries) != 0 {
+		t.Errorf("Expected no plaintext token on disk, got %v", entries)
+	}
+}
@@ -0,0 +1,285 @@
+// Package mask detects common personal data (emails, phone numbers, IBANs) in
+// query results and masks or hashes it, for exposing Hyperfluid data to internal
+// tools that must not see it in clear.
+//
+// A Masker plugs into the response transformer pipeline of the client, so every
+// row returned by queries and searches is masked before it reaches the caller:
+//
+//	masker := mask.New(mask.Policy{
+//	    Actions: map[mask.Kind]mask.Action{mask.Email: mask.Partial, mask.IBAN: mask.Hash},
+//	    HashKey: hashKey,
+//	})
+//	client.AddResponseTransformer(masker.Transform)
+package mask
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Kind is a kind of personal data.
+type Kind string
+
+const (
+	Email Kind = "email"
+	Phone Kind = "phone"
+	IBAN  Kind = "iban"
+)
+
+// Action is what a Masker does with the personal data it finds.
+type Action string
+
+const (
+	// Redact replaces the value with "[REDACTED]".
+	Redact Action = "redact"
+	// Partial keeps enough to recognize the value: the first letter and the domain
+	// of emails, the last digits of phone numbers and the country and last characters of IBANs.
+	Partial Action = "partial"
+	// Hash replaces the value with a keyed hash, stable across queries, so masked
+	// values can still be joined and counted.
+	Hash Action = "hash"
+)
+
+// Redacted replaces the values masked with Redact.
+const Redacted = "[REDACTED]"
+
+// Policy configures a Masker.
+type Policy struct {
+	// Actions maps the kinds of personal data to detect to their action. Nil
+	// detects every kind and redacts it.
+	Actions map[Kind]Action
+	// Columns limits the masking to these columns; empty scans every column.
+	Columns []string
+	// HashKey is the HMAC key of Hash. Without a key, hashes can be reversed by
+	// hashing candidate values.
+	HashKey []byte
+}
+
+// Finding reports personal data found in a column by Detect.
+type Finding struct {
+	Column string
+	Kind   Kind
+	Count  int
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`(?:\+|\(|\b0)[0-9 ().\-]{7,}[0-9]`)
+	ibanPattern  = regexp.MustCompile(`\b[A-Z]{2}[0-9]{2}(?: ?[A-Z0-9]){11,30}\b`)
+)
+
+// Masker masks the personal data of rows according to a Policy.
+type Masker struct {
+	actions map[Kind]Action
+	columns map[string]bool
+	hashKey []byte
+}
+
+// New returns a Masker applying the policy.
+func New(policy Policy) *Masker {
+	m := &Masker{actions: policy.Actions, hashKey: policy.HashKey}
+	if m.actions == nil {
+		m.actions = map[Kind]Action{Email: Redact, Phone: Redact, IBAN: Redact}
+	}
+	if len(policy.Columns) > 0 {
+		m.columns = make(map[string]bool, len(policy.Columns))
+		for _, column := range policy.Columns {
+			m.columns[column] = true
+		}
+	}
+	return m
+}
+
+// String masks the personal data found in s and reports whether any was found.
+func (m *Masker) String(s string) (string, bool) {
+	matches := findMatches(s, m.actions)
+	if len(matches) == 0 {
+		return s, false
+	}
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		b.WriteString(s[last:match.start])
+		b.WriteString(m.apply(match.kind, m.actions[match.kind], s[match.start:match.end]))
+		last = match.end
+	}
+	b.WriteString(s[last:])
+	return b.String(), true
+}
+
+// Row masks the string values of a row in place.
+func (m *Masker) Row(row map[string]any) {
+	for column, value := range row {
+		s, ok := value.(string)
+		if !ok || (m.columns != nil && !m.columns[column]) {
+			continue
+		}
+		if masked, found := m.String(s); found {
+			row[column] = masked
+		}
+	}
+}
+
+// Transform masks the rows of a response in place. Its signature matches
+// sdk.ResponseTransformer, for client.AddResponseTransformer(masker.Transform).
+func (m *Masker) Transform(ctx context.Context, method, endpoint string, resp *utils.Response) error {
+	switch data := resp.Data.(type) {
+	case map[string]any:
+		m.Row(data)
+	case []any:
+		for _, item := range data {
+			if row, ok := item.(map[string]any); ok {
+				m.Row(row)
+			}
+		}
+	}
+	return nil
+}
+
+// Detect reports the personal data found in rows, by column and kind, without
+// masking it. Every kind is looked for, whatever the policy of the Masker.
+func Detect(rows []map[string]any) []Finding {
+	all := map[Kind]Action{Email: Redact, Phone: Redact, IBAN: Redact}
+	counts := make(map[Finding]int)
+	for _, row := range rows {
+		for column, value := range row {
+			if s, ok := value.(string); ok {
+				for _, match := range findMatches(s, all) {
+					counts[Finding{Column: column, Kind: match.kind}]++
+				}
+			}
+		}
+	}
+
+	findings := make([]Finding, 0, len(counts))
+	for finding, count := range counts {
+		finding.Count = count
+		findings = append(findings, finding)
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Column != findings[j].Column {
+			return findings[i].Column < findings[j].Column
+		}
+		return findings[i].Kind < findings[j].Kind
+	})
+	return findings
+}
+
+// match is personal data found at s[start:end].
+type match struct {
+	start, end int
+	kind       Kind
+}
+
+// detectors lists the patterns by priority: IBANs and emails contain digits that
+// would otherwise be taken for phone numbers.
+var detectors = []struct {
+	kind    Kind
+	pattern *regexp.Regexp
+	valid   func(string) bool
+}{
+	{IBAN, ibanPattern, validIBAN},
+	{Email, emailPattern, func(string) bool { return true }},
+	{Phone, phonePattern, validPhone},
+}
+
+// findMatches returns the non-overlapping matches in s of the given kinds, in order.
+func findMatches(s string, kinds map[Kind]Action) []match {
+	var matches []match
+	for _, detector := range detectors {
+		if _, ok := kinds[detector.kind]; !ok {
+			continue
+		}
+	candidates:
+		for _, loc := range detector.pattern.FindAllStringIndex(s, -1) {
+			if !detector.valid(s[loc[0]:loc[1]]) {
+				continue
+			}
+			for _, other := range matches {
+				if loc[0] < other.end && other.start < loc[1] {
+					continue candidates
+				}
+			}
+			matches = append(matches, match{start: loc[0], end: loc[1], kind: detector.kind})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].start < matches[j].start })
+	return matches
+}
+
+// validPhone accepts the E.164 lengths: 8 to 15 digits.
+func validPhone(match string) bool {
+	digits := 0
+	for _, r := range match {
+		if unicode.IsDigit(r) {
+			digits++
+		}
+	}
+	return digits >= 8 && digits <= 15
+}
+
+// validIBAN checks the ISO 13616 mod-97 checksum.
+func validIBAN(match string) bool {
+	iban := strings.ReplaceAll(match, " ", "")
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	var numeric strings.Builder
+	for _, r := range iban[4:] + iban[:4] {
+		if r >= 'A' && r <= 'Z' {
+			numeric.WriteString(strconv.Itoa(int(r-'A') + 10))
+		} else {
+			numeric.WriteRune(r)
+		}
+	}
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	return ok && new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+func (m *Masker) apply(kind Kind, action Action, value string) string {
+	switch action {
+	case Partial:
+		return partial(kind, value)
+	case Hash:
+		mac := hmac.New(sha256.New, m.hashKey)
+		mac.Write([]byte(strings.ToLower(strings.ReplaceAll(value, " ", ""))))
+		return string(kind) + ":" + hex.EncodeToString(mac.Sum(nil))[:16]
+	}
+	return Redacted
+}
+
+// partial masks all but the recognizable part of a value.
+func partial(kind Kind, value string) string {
+	switch kind {
+	case Email:
+		local, domain, _ := strings.Cut(value, "@")
+		return local[:1] + "***@" + domain
+	case IBAN:
+		iban := strings.ReplaceAll(value, " ", "")
+		return iban[:2] + strings.Repeat("*", len(iban)-6) + iban[len(iban)-4:]
+	}
+	// Phone: keep the last two digits and the separators
+	runes := []rune(value)
+	kept := 0
+	for i := len(runes) - 1; i >= 0; i-- {
+		if !unicode.IsDigit(runes[i]) {
+			continue
+		}
+		if kept < 2 {
+			kept++
+			continue
+		}
+		runes[i] = '*'
+	}
+	return string(runes)
+}
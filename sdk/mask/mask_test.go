@@ -0,0 +1,77 @@
+package mask_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/mask"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestMasker_String(t *testing.T) {
+	masker := mask.New(mask.Policy{Actions: map[mask.Kind]mask.Action{
+		mask.Email: mask.Partial, mask.Phone: mask.Partial, mask.IBAN: mask.Partial,
+	}})
+	tests := []struct {
+		input, expected string
+	}{
+		{"Contact jane.doe@example.com today", "Contact j***@example.com today"},
+		{"Call +33 6 12 34 56 78", "Call +** * ** ** ** 78"},
+		{"IBAN FR76 3000 6000 0112 3456 7890 189", "IBAN FR*********************0189"},
+		{"Order 2025-03-01 10:15, total 1234.50", "Order 2025-03-01 10:15, total 1234.50"},
+		{"GB82WEST12345698765433", "GB82WEST12345698765433"}, // Bad checksum
+	}
+	for _, tt := range tests {
+		if got, _ := masker.String(tt.input); got != tt.expected {
+			t.Errorf("String(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestMasker_Transform(t *testing.T) {
+	masker := mask.New(mask.Policy{
+		Actions: map[mask.Kind]mask.Action{mask.Email: mask.Hash, mask.Phone: mask.Redact},
+		Columns: []string{"email", "phone"},
+		HashKey: []byte("secret"),
+	})
+	resp := &utils.Response{Data: []any{
+		map[string]any{"email": "Jane@Example.com", "phone": "06 12 34 56 78", "notes": "jane@example.com", "id": 1},
+		map[string]any{"email": "jane@example.com"},
+	}}
+	if err := masker.Transform(context.Background(), "GET", "https://api/orders", resp); err != nil {
+		t.Fatalf("Transform() unexpected error = %v", err)
+	}
+
+	rows, _ := resp.Rows()
+	email, _ := rows[0]["email"].(string)
+	if !strings.HasPrefix(email, "email:") || email != rows[1]["email"] {
+		t.Errorf("Expected stable hashes, got %v and %v", rows[0]["email"], rows[1]["email"])
+	}
+	if rows[0]["phone"] != mask.Redacted {
+		t.Errorf("phone = %v", rows[0]["phone"])
+	}
+	if rows[0]["notes"] != "jane@example.com" || rows[0]["id"] != 1 {
+		t.Errorf("Columns outside the policy must be left untouched, got %v", rows[0])
+	}
+}
+
+func TestDetect(t *testing.T) {
+	findings := mask.Detect([]map[string]any{
+		{"contact": "jane@example.com or +44 20 7946 0958", "iban": "GB82WEST12345698765432"},
+		{"contact": "john@example.org", "amount": 12},
+	})
+	expected := []mask.Finding{
+		{Column: "contact", Kind: mask.Email, Count: 2},
+		{Column: "contact", Kind: mask.Phone, Count: 1},
+		{Column: "iban", Kind: mask.IBAN, Count: 1},
+	}
+	if len(findings) != len(expected) {
+		t.Fatalf("Detect() = %+v", findings)
+	}
+	for i := range expected {
+		if findings[i] != expected[i] {
+			t.Errorf("finding %d = %+v, expected %+v", i, findings[i], expected[i])
+		}
+	}
+}
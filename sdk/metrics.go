@@ -0,0 +1,195 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// LatencyBuckets are the upper bounds, in seconds, of the request latency histograms.
+var LatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Stats is a snapshot of the counters of a client, shared with its derived
+// clients. Counters only increase; see the sdkmetrics package to export them.
+type Stats struct {
+	// Requests holds the request latency histograms by HTTP method. Their Count is
+	// the number of requests, including failed ones; retries are not counted.
+	Requests map[string]LatencyHistogram
+	// Errors counts the failed requests by error class (see ErrorClass).
+	Errors               map[string]uint64
+	Retries              uint64 // Attempts beyond the first of each request
+	TokenRefreshes       uint64
+	TokenRefreshFailures uint64
+	S3BytesSent          uint64
+	S3BytesReceived      uint64
+}
+
+// LatencyHistogram is a latency distribution over LatencyBuckets.
+type LatencyHistogram struct {
+	Counts []uint64 // Cumulative: Counts[i] requests took at most LatencyBuckets[i]
+	Count  uint64
+	Sum    time.Duration
+}
+
+// ErrorClass returns a short label describing the kind of a request error, such
+// as "not_found" or "timeout", suitable for metrics.
+func ErrorClass(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, utils.ErrAuthenticationFailed), errors.Is(err, utils.ErrTokenExpired):
+		return "authentication"
+	case errors.Is(err, utils.ErrPermissionDenied):
+		return "permission_denied"
+	case errors.Is(err, utils.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, utils.ErrInvalidRequest), errors.Is(err, utils.ErrInvalidConfiguration):
+		return "invalid_request"
+	case errors.Is(err, utils.ErrDataDockAsleep):
+		return "datadock_asleep"
+	case errors.Is(err, utils.ErrCircuitOpen):
+		return "circuit_open"
+	case errors.Is(err, utils.ErrResponseTooLarge):
+		return "response_too_large"
+	}
+	return "server"
+}
+
+// clientMetrics holds the counters of a client and its derived clients.
+// A nil *clientMetrics records nothing.
+type clientMetrics struct {
+	mu       sync.Mutex
+	requests map[string]*LatencyHistogram
+	errors   map[string]uint64
+
+	retries              atomic.Uint64
+	tokenRefreshes       atomic.Uint64
+	tokenRefreshFailures atomic.Uint64
+	s3BytesSent          atomic.Uint64
+	s3BytesReceived      atomic.Uint64
+}
+
+func newClientMetrics() *clientMetrics {
+	return &clientMetrics{requests: map[string]*LatencyHistogram{}, errors: map[string]uint64{}}
+}
+
+// recordRequest counts a finished request.
+func (m *clientMetrics) recordRequest(method string, duration time.Duration, attempts int, err error) {
+	if m == nil {
+		return
+	}
+	m.retries.Add(uint64(max(attempts-1, 0)))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	histogram := m.requests[method]
+	if histogram == nil {
+		histogram = &LatencyHistogram{Counts: make([]uint64, len(LatencyBuckets))}
+		m.requests[method] = histogram
+	}
+	// The first bucket holding the duration, and every following one, count it
+	bucket := sort.SearchFloat64s(LatencyBuckets, duration.Seconds())
+	for i := bucket; i < len(histogram.Counts); i++ {
+		histogram.Counts[i]++
+	}
+	histogram.Count++
+	histogram.Sum += duration
+	if err != nil {
+		m.errors[ErrorClass(err)]++
+	}
+}
+
+// recordTokenRefresh counts a token refresh.
+func (m *clientMetrics) recordTokenRefresh(err error) {
+	if m == nil {
+		return
+	}
+	if err != nil {
+		m.tokenRefreshFailures.Add(1)
+		return
+	}
+	m.tokenRefreshes.Add(1)
+}
+
+func (m *clientMetrics) snapshot() Stats {
+	if m == nil {
+		return Stats{Requests: map[string]LatencyHistogram{}, Errors: map[string]uint64{}}
+	}
+	stats := Stats{
+		Requests:             map[string]LatencyHistogram{},
+		Errors:               map[string]uint64{},
+		Retries:              m.retries.Load(),
+		TokenRefreshes:       m.tokenRefreshes.Load(),
+		TokenRefreshFailures: m.tokenRefreshFailures.Load(),
+		S3BytesSent:          m.s3BytesSent.Load(),
+		S3BytesReceived:      m.s3BytesReceived.Load(),
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for method, histogram := range m.requests {
+		copied := *histogram
+		copied.Counts = append([]uint64(nil), histogram.Counts...)
+		stats.Requests[method] = copied
+	}
+	for class, count := range m.errors {
+		stats.Errors[class] = count
+	}
+	return stats
+}
+
+// Stats returns the request, authentication and S3 transfer counters of the
+// client and of the clients derived from it.
+func (c *Client) Stats() Stats {
+	return c.metrics.snapshot()
+}
+
+// S3Transport wraps the transport of the S3 clients created by S3() to count the
+// bytes transferred. It is called by the S3 builder.
+func (c *Client) S3Transport(base http.RoundTripper) http.RoundTripper {
+	if c.metrics == nil {
+		return base
+	}
+	return &countingTransport{base: base, metrics: c.metrics}
+}
+
+// countingTransport counts the bytes of request and response bodies.
+type countingTransport struct {
+	base    http.RoundTripper
+	metrics *clientMetrics
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil && req.Body != http.NoBody {
+		body := &countingReader{ReadCloser: req.Body, count: &t.metrics.s3BytesSent}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+	resp, err := t.base.RoundTrip(req)
+	if resp != nil && resp.Body != nil {
+		resp.Body = &countingReader{ReadCloser: resp.Body, count: &t.metrics.s3BytesReceived}
+	}
+	return resp, err
+}
+
+// countingReader adds the bytes read to a counter.
+type countingReader struct {
+	io.ReadCloser
+	count *atomic.Uint64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.count.Add(uint64(n))
+	return n, err
+}
@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestClient_Stats(t *testing.T) {
+	calls := 0
+	client := NewClient(utils.Configuration{
+		Token:      "test-token",
+		BaseURL:    "https://test.example.com",
+		DataDockID: "dd",
+		MaxRetries: 2,
+		Transport: &mockRoundTripper{roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			status := http.StatusOK
+			switch {
+			case strings.Contains(req.URL.Path, "/missing"):
+				status = http.StatusNotFound
+			case calls == 1:
+				status = http.StatusBadGateway // Retried
+			}
+			return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(`[]`))}, nil
+		}},
+	})
+	ctx := context.Background()
+
+	if _, err := client.Catalog("c").Schema("s").Table("t").Get(ctx); err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if _, err := client.Catalog("c").Schema("s").Table("missing").Get(ctx); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("Get() error = %v, want ErrNotFound", err)
+	}
+
+	// Derived clients share the counters
+	stats := client.WithHeader("X-Tenant-ID", "acme").Stats()
+	if got := stats.Requests["GET"]; got.Count != 2 || got.Counts[len(got.Counts)-1] != 2 || got.Sum <= 0 {
+		t.Errorf("Requests[GET] = %+v, want 2 requests", got)
+	}
+	if stats.Retries != 1 {
+		t.Errorf("Retries = %d, want 1", stats.Retries)
+	}
+	if len(stats.Errors) != 1 || stats.Errors["not_found"] != 1 {
+		t.Errorf("Errors = %v, want one not_found", stats.Errors)
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := map[error]string{
+		context.DeadlineExceeded:                    "timeout",
+		&utils.RequestError{Err: utils.ErrNotFound}: "not_found",
+		utils.ErrTokenExpired:                       "authentication",
+		utils.ErrCircuitOpen:                        "circuit_open",
+		errors.New("server returned status 503"):    "server",
+	}
+	for err, want := range tests {
+		if got := ErrorClass(err); got != want {
+			t.Errorf("ErrorClass(%v) = %q, want %q", err, got, want)
+		}
+	}
+}
+
+func TestClient_S3TransportCountsBytes(t *testing.T) {
+	client := NewClient(utils.Configuration{BaseURL: "https://test.example.com"})
+	transport := client.S3Transport(&mockRoundTripper{roundTripFunc: func(req *http.Request) (*http.Response, error) {
+		_, _ = io.ReadAll(req.Body)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("0123456789"))}, nil
+	}})
+
+	req, _ := http.NewRequest(http.MethodPut, "https://minio.test/bucket/key", strings.NewReader("hello"))
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() unexpected error = %v", err)
+	}
+	_, _ = io.ReadAll(resp.Body)
+
+	if stats := client.Stats(); stats.S3BytesSent != 5 || stats.S3BytesReceived != 10 {
+		t.Errorf("S3 bytes sent/received = %d/%d, want 5/10", stats.S3BytesSent, stats.S3BytesReceived)
+	}
+}
@@ -0,0 +1,129 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// openIDConfigurationPath is the OpenID Connect discovery endpoint of an issuer.
+const openIDConfigurationPath = "/.well-known/openid-configuration"
+
+// OIDCConfiguration is the OpenID Connect provider metadata of the issuer of the
+// platform tokens, as published at /.well-known/openid-configuration.
+type OIDCConfiguration struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	GrantTypesSupported   []string `json:"grant_types_supported"`
+}
+
+// SupportsGrantType reports whether the provider accepts the OAuth2 grant type
+// (e.g. "client_credentials"). Providers that do not list their grant types are
+// assumed to support every grant.
+func (o *OIDCConfiguration) SupportsGrantType(grantType string) bool {
+	return len(o.GrantTypesSupported) == 0 || slices.Contains(o.GrantTypesSupported, grantType)
+}
+
+// oidcCache holds the provider metadata of each issuer; shared with derived clients.
+// Clients rotating service accounts may use several realms.
+type oidcCache struct {
+	mu        sync.Mutex
+	providers map[string]*OIDCConfiguration
+}
+
+// oidcIssuer returns the issuer of the client tokens: OIDCIssuer, or the Keycloak
+// realm. Empty when neither is configured.
+func (c *Client) oidcIssuer() string {
+	if c.config.OIDCIssuer != "" {
+		return strings.TrimSuffix(c.config.OIDCIssuer, "/")
+	}
+	if c.config.KeycloakBaseURL == "" || c.config.KeycloakRealm == "" {
+		return ""
+	}
+	return strings.TrimSuffix(c.config.KeycloakBaseURL, "/") + "/realms/" + c.config.KeycloakRealm
+}
+
+// OIDCConfiguration returns the OpenID Connect metadata of the issuer of the client
+// tokens (Configuration.OIDCIssuer, or the Keycloak realm): its token endpoint,
+// supported grant types and JWKS URI. The request is not authenticated. The result
+// is cached for the lifetime of the client.
+//
+// Example:
+//
+//	provider, err := client.OIDCConfiguration(ctx)
+//	if err == nil && !provider.SupportsGrantType("urn:ietf:params:oauth:grant-type:token-exchange") {
+//	    // Impersonation is not available
+//	}
+func (c *Client) OIDCConfiguration(ctx context.Context) (*OIDCConfiguration, error) {
+	issuer := c.oidcIssuer()
+	if issuer == "" {
+		return nil, fmt.Errorf("%w: OIDC issuer or Keycloak base URL and realm not configured", utils.ErrInvalidConfiguration)
+	}
+	cache := c.oidc
+	if cache == nil {
+		// Client not built by NewClient: nothing to cache
+		return c.fetchOIDCConfiguration(ctx, issuer)
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if provider, ok := cache.providers[issuer]; ok {
+		return provider, nil
+	}
+	provider, err := c.fetchOIDCConfiguration(ctx, issuer)
+	if err != nil {
+		return nil, err
+	}
+	if cache.providers == nil {
+		cache.providers = map[string]*OIDCConfiguration{}
+	}
+	cache.providers[issuer] = provider
+	return provider, nil
+}
+
+// fetchOIDCConfiguration reads the discovery document of an issuer.
+func (c *Client) fetchOIDCConfiguration(ctx context.Context, issuer string) (*OIDCConfiguration, error) {
+	if c.initErr != nil {
+		return nil, c.initErr
+	}
+	status, body, err := c.getDocument(ctx, issuer+openIDConfigurationPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read the OIDC configuration of %s: %w", utils.ErrAuthenticationFailed, issuer, err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("%w: OIDC discovery of %s returned HTTP %d", utils.ErrAuthenticationFailed, issuer, status)
+	}
+
+	var provider OIDCConfiguration
+	if err := json.Unmarshal(body, &provider); err != nil {
+		return nil, fmt.Errorf("%w: invalid OIDC configuration of %s: %w", utils.ErrAuthenticationFailed, issuer, err)
+	}
+	if provider.TokenEndpoint == "" {
+		return nil, fmt.Errorf("%w: OIDC configuration of %s has no token endpoint", utils.ErrAuthenticationFailed, issuer)
+	}
+	return &provider, nil
+}
+
+// tokenEndpoint returns the token endpoint of the issuer, checking that it accepts
+// the grant type. Keycloak realms whose metadata cannot be read fall back to the
+// standard Keycloak path.
+func (c *Client) tokenEndpoint(ctx context.Context, grantType string) (string, error) {
+	provider, err := c.OIDCConfiguration(ctx)
+	if err != nil {
+		if c.config.OIDCIssuer != "" || c.config.KeycloakBaseURL == "" || c.config.KeycloakRealm == "" {
+			return "", err
+		}
+		return c.oidcIssuer() + "/protocol/openid-connect/token", nil
+	}
+	if grantType != "" && !provider.SupportsGrantType(grantType) {
+		return "", fmt.Errorf("%w: the OIDC issuer does not support the %s grant", utils.ErrInvalidConfiguration, grantType)
+	}
+	return provider.TokenEndpoint, nil
+}
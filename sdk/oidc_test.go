@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// oidcServer serves the OpenID configuration of the issuer /idp, with its token
+// endpoint at /oauth2/token, and the API.
+func oidcServer(t *testing.T, grantTypes string, metadataRequests, tokenRequests *int) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/idp" + openIDConfigurationPath:
+			*metadataRequests++
+			_, _ = w.Write([]byte(`{
+				"issuer": "` + server.URL + `/idp",
+				"token_endpoint": "` + server.URL + `/oauth2/token",
+				"jwks_uri": "` + server.URL + `/oauth2/keys",
+				"grant_types_supported": ` + grantTypes + `
+			}`))
+		case "/oauth2/token":
+			*tokenRequests++
+			_, _ = w.Write([]byte(`{"access_token": "` + testJWT(time.Now().Add(time.Hour)) + `"}`))
+		default:
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestClient_OIDCTokenEndpoint(t *testing.T) {
+	var metadataRequests, tokenRequests int
+	server := oidcServer(t, `["client_credentials", "password"]`, &metadataRequests, &tokenRequests)
+	client := NewClient(utils.Configuration{
+		BaseURL:              server.URL,
+		OIDCIssuer:           server.URL + "/idp/",
+		KeycloakClientID:     "sa",
+		KeycloakClientSecret: "secret",
+	})
+
+	provider, err := client.OIDCConfiguration(context.Background())
+	if err != nil {
+		t.Fatalf("OIDCConfiguration() unexpected error = %v", err)
+	}
+	if provider.JWKSURI != server.URL+"/oauth2/keys" || !provider.SupportsGrantType("password") || provider.SupportsGrantType("implicit") {
+		t.Errorf("OIDCConfiguration() = %+v", provider)
+	}
+
+	for range 2 {
+		if _, err := client.refreshToken(context.Background()); err != nil {
+			t.Fatalf("refreshToken() unexpected error = %v", err)
+		}
+	}
+	if tokenRequests != 2 || metadataRequests != 1 {
+		t.Errorf("token requests = %d, metadata requests = %d, want 2 and 1", tokenRequests, metadataRequests)
+	}
+}
+
+func TestClient_OIDCUnsupportedGrant(t *testing.T) {
+	var metadataRequests, tokenRequests int
+	server := oidcServer(t, `["authorization_code"]`, &metadataRequests, &tokenRequests)
+	client := NewClient(utils.Configuration{
+		BaseURL:          server.URL,
+		OIDCIssuer:       server.URL + "/idp",
+		KeycloakClientID: "cli",
+		KeycloakUsername: "jane",
+		KeycloakPassword: "password",
+	})
+
+	if _, err := client.refreshToken(context.Background()); !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("refreshToken() error = %v, want ErrInvalidConfiguration", err)
+	}
+	if tokenRequests != 0 {
+		t.Errorf("token requests = %d, want none", tokenRequests)
+	}
+}
+
+func TestClient_OIDCIssuerWithoutMetadata(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	// Keycloak realms fall back to the standard token path, other issuers fail
+	client := NewClient(utils.Configuration{KeycloakBaseURL: server.URL, KeycloakRealm: "acme"})
+	if got, err := client.tokenEndpoint(context.Background(), "client_credentials"); err != nil || got != server.URL+"/realms/acme/protocol/openid-connect/token" {
+		t.Errorf("tokenEndpoint() = %q, %v, want the Keycloak token path", got, err)
+	}
+	client = NewClient(utils.Configuration{OIDCIssuer: server.URL + "/idp"})
+	if _, err := client.tokenEndpoint(context.Background(), "client_credentials"); !errors.Is(err, utils.ErrAuthenticationFailed) {
+		t.Errorf("tokenEndpoint() error = %v, want ErrAuthenticationFailed", err)
+	}
+}
@@ -0,0 +1,34 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// PollOptions configures Poll. See utils.PollOptions.
+type PollOptions = utils.PollOptions
+
+// Poll calls fetch until it reports completion, returns an error, or the timeout
+// or ctx expires, and returns the last fetched value.
+//
+// Example:
+//
+//	resp, err := sdk.Poll(ctx, func(ctx context.Context) (*utils.Response, bool, error) {
+//	    resp, err := client.Org(orgID).Harbor(harborID).DataDock(id).Get(ctx)
+//	    if err != nil {
+//	        return nil, false, err
+//	    }
+//	    data, _ := resp.GetDataAsMap()
+//	    return resp, data["status"] == "Sleeping", nil
+//	}, sdk.PollOptions{Interval: 2 * time.Second, Backoff: 1.5, Timeout: time.Minute})
+func Poll[T any](ctx context.Context, fetch func(ctx context.Context) (T, bool, error), opts PollOptions) (T, error) {
+	return utils.Poll(ctx, fetch, opts)
+}
+
+// WaitForDataDockStatus polls a datadock until it reports the given status
+// (e.g. "Online", "Sleeping") and returns its last details.
+func (c *Client) WaitForDataDockStatus(ctx context.Context, dataDockID, status string, opts PollOptions) (map[string]any, error) {
+	return builders.WaitForDataDockStatus(ctx, c, dataDockID, status, opts)
+}
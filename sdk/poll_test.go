@@ -0,0 +1,89 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestPoll_ReturnsWhenDone(t *testing.T) {
+	calls := 0
+	value, err := Poll(context.Background(), func(ctx context.Context) (int, bool, error) {
+		calls++
+		return calls, calls == 3, nil
+	}, PollOptions{Interval: time.Millisecond, Backoff: 2})
+
+	if err != nil {
+		t.Fatalf("Poll() unexpected error = %v", err)
+	}
+	if value != 3 || calls != 3 {
+		t.Errorf("Expected 3 calls, got value=%d calls=%d", value, calls)
+	}
+}
+
+func TestPoll_StopsOnError(t *testing.T) {
+	boom := errors.New("boom")
+	_, err := Poll(context.Background(), func(ctx context.Context) (string, bool, error) {
+		return "", false, boom
+	}, PollOptions{Interval: time.Millisecond})
+
+	if err != boom {
+		t.Errorf("Expected fetch error, got %v", err)
+	}
+}
+
+func TestPoll_Timeout(t *testing.T) {
+	value, err := Poll(context.Background(), func(ctx context.Context) (string, bool, error) {
+		return "Pending", false, nil
+	}, PollOptions{Interval: 5 * time.Millisecond, Timeout: 20 * time.Millisecond})
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if value != "Pending" {
+		t.Errorf("Expected last observed value, got %q", value)
+	}
+}
+
+func TestClient_WaitForDataDockStatus(t *testing.T) {
+	statuses := []string{"Stopping", "Sleeping"}
+	client := &Client{
+		config: utils.Configuration{BaseURL: "http://localhost", Token: "test-token", MaxRetries: 0},
+		httpClient: &http.Client{Transport: &mockRoundTripper{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path != "/data-docks/dd-1" {
+					t.Errorf("Unexpected path %s", req.URL.Path)
+				}
+				status := statuses[0]
+				if len(statuses) > 1 {
+					statuses = statuses[1:]
+				}
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(`{"id": "dd-1", "status": "` + status + `"}`)),
+					Header:     make(http.Header),
+				}, nil
+			},
+		}},
+	}
+
+	data, err := client.WaitForDataDockStatus(context.Background(), "dd-1", "Sleeping", PollOptions{Interval: time.Millisecond})
+	if err != nil {
+		t.Fatalf("WaitForDataDockStatus() unexpected error = %v", err)
+	}
+	if data["status"] != "Sleeping" {
+		t.Errorf("Expected Sleeping, got %v", data["status"])
+	}
+
+	statuses = []string{"Online"}
+	_, err = client.WaitForDataDockStatus(context.Background(), "dd-1", "Sleeping", PollOptions{Interval: time.Millisecond, Timeout: 10 * time.Millisecond})
+	if err == nil || !strings.Contains(err.Error(), "did not reach status Sleeping") {
+		t.Errorf("Expected timeout error, got %v", err)
+	}
+}
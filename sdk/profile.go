@@ -0,0 +1,282 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProfile is the profile used when none is named and HYPERFLUID_PROFILE is unset.
+const DefaultProfile = "default"
+
+// Profile is a named environment of the profiles file (see ProfilesPath): where
+// the platform is and how to authenticate to it. The credentials are tried in
+// this order: service account file, Keycloak client or user, token.
+//
+// Example ~/.hyperfluid/config:
+//
+//	default:
+//	  base_url: https://api.dev.hyperfluid.cloud
+//	  org_id: my-dev-org
+//	  token: ey...
+//	staging:
+//	  base_url: https://api.staging.hyperfluid.cloud
+//	  org_id: my-org
+//	  datadock_id: 0b8e...
+//	  service_account_file: ~/.hyperfluid/staging.json
+//	prod:
+//	  base_url: https://api.hyperfluid.cloud
+//	  org_id: my-org
+//	  keycloak_base_url: https://auth.hyperfluid.cloud
+//	  keycloak_realm: my-org
+//	  keycloak_client_id: cli
+//	  keycloak_username: jane # KEYCLOAK_PASSWORD from the environment, token cached (see Logout)
+type Profile struct {
+	// Name is the name of the profile in the file.
+	Name string `yaml:"-"`
+
+	BaseURL         string `yaml:"base_url"`
+	ControlPlaneURL string `yaml:"control_plane_url"`
+	OrgID           string `yaml:"org_id"`
+	DataDockID      string `yaml:"datadock_id"`
+
+	Token string `yaml:"token"`
+
+	// ServiceAccountFile is a service account JSON file; a leading "~/" is the home directory.
+	ServiceAccountFile string `yaml:"service_account_file"`
+
+	KeycloakBaseURL      string `yaml:"keycloak_base_url"`
+	KeycloakRealm        string `yaml:"keycloak_realm"`
+	KeycloakClientID     string `yaml:"keycloak_client_id"`
+	KeycloakClientSecret string `yaml:"keycloak_client_secret"`
+	KeycloakUsername     string `yaml:"keycloak_username"`
+	KeycloakPassword     string `yaml:"keycloak_password"`
+	// OIDCIssuer replaces keycloak_base_url and keycloak_realm for other issuers.
+	OIDCIssuer string `yaml:"oidc_issuer"`
+
+	MinIOEndpoint string `yaml:"minio_endpoint"`
+	MinIORegion   string `yaml:"minio_region"`
+	MinIOBucket   string `yaml:"minio_bucket"`
+
+	// LoginCache keeps the tokens of password logins between runs: "keyring" (the
+	// default, no cache without an OS keyring), "file" (unencrypted) or "none".
+	// See Logout.
+	LoginCache string `yaml:"login_cache"`
+
+	// DiscoverEndpoints reads the control plane and Keycloak endpoints left unset
+	// from the platform (see Configuration.DiscoverEndpoints).
+	DiscoverEndpoints bool `yaml:"discover_endpoints"`
+	// VerifyTokens verifies the tokens obtained from Keycloak against its JWKS.
+	VerifyTokens bool `yaml:"verify_tokens"`
+
+	SkipTLSVerify  bool          `yaml:"skip_tls_verify"`
+	RequestTimeout time.Duration `yaml:"request_timeout"` // e.g. "30s"
+	MaxRetries     int           `yaml:"max_retries"`
+}
+
+// profileEnv maps the environment variables overriding a profile to its fields.
+var profileEnv = []struct {
+	name  string
+	field func(*Profile) *string
+}{
+	{"HYPERFLUID_BASE_URL", func(p *Profile) *string { return &p.BaseURL }},
+	{"HYPERFLUID_CONTROL_PLANE_URL", func(p *Profile) *string { return &p.ControlPlaneURL }},
+	{"HYPERFLUID_ORG_ID", func(p *Profile) *string { return &p.OrgID }},
+	{"HYPERFLUID_DATADOCK_ID", func(p *Profile) *string { return &p.DataDockID }},
+	{"HYPERFLUID_TOKEN", func(p *Profile) *string { return &p.Token }},
+	{"HYPERFLUID_SERVICE_ACCOUNT_FILE", func(p *Profile) *string { return &p.ServiceAccountFile }},
+	{"KEYCLOAK_BASE_URL", func(p *Profile) *string { return &p.KeycloakBaseURL }},
+	{"KEYCLOAK_REALM", func(p *Profile) *string { return &p.KeycloakRealm }},
+	{"KEYCLOAK_CLIENT_ID", func(p *Profile) *string { return &p.KeycloakClientID }},
+	{"KEYCLOAK_CLIENT_SECRET", func(p *Profile) *string { return &p.KeycloakClientSecret }},
+	{"KEYCLOAK_USERNAME", func(p *Profile) *string { return &p.KeycloakUsername }},
+	{"KEYCLOAK_PASSWORD", func(p *Profile) *string { return &p.KeycloakPassword }},
+	{"HYPERFLUID_OIDC_ISSUER", func(p *Profile) *string { return &p.OIDCIssuer }},
+	{"MINIO_ENDPOINT", func(p *Profile) *string { return &p.MinIOEndpoint }},
+	{"MINIO_REGION", func(p *Profile) *string { return &p.MinIORegion }},
+}
+
+// ProfilesPath returns the path of the profiles file: HYPERFLUID_CONFIG_FILE
+// when set, ~/.hyperfluid/config otherwise.
+func ProfilesPath() (string, error) {
+	if path := os.Getenv("HYPERFLUID_CONFIG_FILE"); path != "" {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("%w: locating the profiles file: %w", utils.ErrInvalidConfiguration, err)
+	}
+	return filepath.Join(home, ".hyperfluid", "config"), nil
+}
+
+// LoadProfile reads the named profile from the profiles file (see ProfilesPath)
+// and applies the environment variables on top of it, like the AWS and GCP SDKs:
+// HYPERFLUID_BASE_URL, HYPERFLUID_CONTROL_PLANE_URL, HYPERFLUID_ORG_ID,
+// HYPERFLUID_DATADOCK_ID, HYPERFLUID_TOKEN, HYPERFLUID_SERVICE_ACCOUNT_FILE,
+// HYPERFLUID_OIDC_ISSUER, the KEYCLOAK_* variables, MINIO_ENDPOINT and MINIO_REGION.
+//
+// An empty name selects HYPERFLUID_PROFILE, then DefaultProfile. The default
+// profile may be missing (the environment alone is then used); a named one may not.
+func LoadProfile(name string) (*Profile, error) {
+	explicit := name != ""
+	if !explicit {
+		name = utils.GetEnvironmentVariable("HYPERFLUID_PROFILE", DefaultProfile)
+		explicit = name != DefaultProfile
+	}
+	path, err := ProfilesPath()
+	if err != nil {
+		return nil, err
+	}
+
+	profile := &Profile{}
+	profiles, err := readProfiles(path)
+	switch {
+	case err == nil && profiles[name] != nil:
+		profile = profiles[name]
+	case err == nil || errors.Is(err, fs.ErrNotExist):
+		if explicit {
+			return nil, fmt.Errorf("%w: profile %q not found in %s", utils.ErrInvalidConfiguration, name, path)
+		}
+	default:
+		return nil, err
+	}
+	profile.Name = name
+
+	for _, env := range profileEnv {
+		if value := os.Getenv(env.name); value != "" {
+			*env.field(profile) = value
+		}
+	}
+	return profile, nil
+}
+
+// readProfiles parses the profiles file at path.
+func readProfiles(path string) (map[string]*Profile, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var profiles map[string]*Profile
+	if err := yaml.Unmarshal(raw, &profiles); err != nil {
+		return nil, fmt.Errorf("%w: parsing %s: %w", utils.ErrInvalidConfiguration, path, err)
+	}
+	return profiles, nil
+}
+
+// NewClientFromProfile creates a client from the named profile of the profiles
+// file, overridden by the environment (see LoadProfile). It is meant for humans
+// running local tools; services should prefer NewClientFromServiceAccountFile.
+//
+// Example:
+//
+//	client, err := sdk.NewClientFromProfile("staging")
+func NewClientFromProfile(name string) (*Client, error) {
+	profile, err := LoadProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	return profile.NewClient()
+}
+
+// NewClient creates a client from the profile.
+func (p *Profile) NewClient() (*Client, error) {
+	if p.BaseURL == "" {
+		return nil, fmt.Errorf("%w: profile %q has no base_url", utils.ErrInvalidConfiguration, p.Name)
+	}
+
+	if p.ServiceAccountFile != "" {
+		path, err := expandHome(p.ServiceAccountFile)
+		if err != nil {
+			return nil, err
+		}
+		return NewClientFromServiceAccountFile(path, ServiceAccountOptions{
+			BaseURL:           p.BaseURL,
+			ControlPlaneURL:   p.ControlPlaneURL,
+			OrgID:             p.OrgID,
+			DataDockID:        p.DataDockID,
+			SkipTLSVerify:     p.SkipTLSVerify,
+			RequestTimeout:    int(p.RequestTimeout / time.Second),
+			MaxRetries:        p.MaxRetries,
+			DiscoverEndpoints: p.DiscoverEndpoints,
+			MinIOEndpoint:     p.MinIOEndpoint,
+			MinIORegion:       p.MinIORegion,
+			MinIOBucket:       p.MinIOBucket,
+		})
+	}
+
+	if p.Token == "" && p.KeycloakClientID == "" {
+		return nil, fmt.Errorf("%w: profile %q has no token, service account or Keycloak client", utils.ErrInvalidConfiguration, p.Name)
+	}
+	cfg := p.Configuration()
+	if p.usesInteractiveLogin() {
+		store, err := loginCacheStore(p.LoginCache)
+		if err != nil {
+			return nil, err
+		}
+		if store != nil {
+			cfg.TokenStore = &profileTokenStore{store: store, profile: p.Name}
+		}
+	}
+	client := NewClient(cfg)
+	if client.initErr != nil {
+		return nil, client.initErr
+	}
+	return client, nil
+}
+
+// Configuration returns the client configuration of the profile, with the SDK
+// defaults for the unset timeout and retries. The service account file is not read.
+func (p *Profile) Configuration() utils.Configuration {
+	cfg := utils.Configuration{
+		BaseURL:              p.BaseURL,
+		ControlPlaneURL:      p.ControlPlaneURL,
+		OrgID:                p.OrgID,
+		DataDockID:           p.DataDockID,
+		Token:                p.Token,
+		SkipTLSVerify:        p.SkipTLSVerify,
+		RequestTimeout:       p.RequestTimeout,
+		MaxRetries:           p.MaxRetries,
+		KeycloakBaseURL:      p.KeycloakBaseURL,
+		KeycloakRealm:        p.KeycloakRealm,
+		KeycloakClientID:     p.KeycloakClientID,
+		KeycloakClientSecret: p.KeycloakClientSecret,
+		KeycloakUsername:     p.KeycloakUsername,
+		KeycloakPassword:     p.KeycloakPassword,
+		OIDCIssuer:           p.OIDCIssuer,
+		DiscoverEndpoints:    p.DiscoverEndpoints,
+		VerifyTokens:         p.VerifyTokens,
+		MinIOEndpoint:        p.MinIOEndpoint,
+		MinIORegion:          p.MinIORegion,
+		MinIOBucket:          p.MinIOBucket,
+	}
+	if cfg.ControlPlaneURL == "" {
+		cfg.ControlPlaneURL = cfg.BaseURL
+	}
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = utils.DefaultRequestTimeout
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = utils.DefaultMaxRetries
+	}
+	return cfg
+}
+
+// expandHome replaces a leading "~/" of path with the home directory.
+func expandHome(path string) (string, error) {
+	rest, ok := strings.CutPrefix(path, "~/")
+	if !ok {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("%w: expanding %s: %w", utils.ErrInvalidConfiguration, path, err)
+	}
+	return filepath.Join(home, rest), nil
+}
@@ -0,0 +1,134 @@
+package sdk
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// writeProfiles writes a profiles file and points HYPERFLUID_CONFIG_FILE to it.
+func writeProfiles(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HYPERFLUID_CONFIG_FILE", path)
+	for _, env := range profileEnv {
+		t.Setenv(env.name, "")
+	}
+	t.Setenv("HYPERFLUID_PROFILE", "")
+	return path
+}
+
+const testProfiles = `
+default:
+  base_url: https://api.dev.example.com
+  token: dev-token
+staging:
+  base_url: https://api.staging.example.com
+  org_id: org-staging
+  datadock_id: dd-staging
+  token: staging-token
+  request_timeout: 5s
+  max_retries: 7
+`
+
+func TestLoadProfile(t *testing.T) {
+	writeProfiles(t, testProfiles)
+
+	profile, err := LoadProfile("staging")
+	if err != nil {
+		t.Fatalf("LoadProfile() unexpected error = %v", err)
+	}
+	if profile.Name != "staging" || profile.OrgID != "org-staging" || profile.RequestTimeout != 5*time.Second {
+		t.Errorf("Unexpected profile %+v", profile)
+	}
+
+	// HYPERFLUID_PROFILE selects the profile, the environment overrides it
+	t.Setenv("HYPERFLUID_PROFILE", "staging")
+	t.Setenv("HYPERFLUID_ORG_ID", "org-env")
+	profile, err = LoadProfile("")
+	if err != nil {
+		t.Fatalf("LoadProfile() unexpected error = %v", err)
+	}
+	if profile.OrgID != "org-env" || profile.DataDockID != "dd-staging" {
+		t.Errorf("Unexpected profile %+v", profile)
+	}
+}
+
+func TestLoadProfile_Missing(t *testing.T) {
+	path := writeProfiles(t, testProfiles)
+
+	if _, err := LoadProfile("prod"); !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for an unknown profile, got %v", err)
+	}
+
+	// Without a file, the default profile comes from the environment alone
+	t.Setenv("HYPERFLUID_CONFIG_FILE", path+".missing")
+	t.Setenv("HYPERFLUID_BASE_URL", "https://api.example.com")
+	profile, err := LoadProfile("")
+	if err != nil {
+		t.Fatalf("LoadProfile() unexpected error = %v", err)
+	}
+	if profile.Name != DefaultProfile || profile.BaseURL != "https://api.example.com" {
+		t.Errorf("Unexpected profile %+v", profile)
+	}
+	if _, err := LoadProfile("staging"); !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for a named profile without file, got %v", err)
+	}
+}
+
+func TestNewClientFromProfile(t *testing.T) {
+	writeProfiles(t, testProfiles)
+
+	client, err := NewClientFromProfile("staging")
+	if err != nil {
+		t.Fatalf("NewClientFromProfile() unexpected error = %v", err)
+	}
+	cfg := client.GetConfig()
+	if cfg.Token != "staging-token" || cfg.ControlPlaneURL != cfg.BaseURL || cfg.MaxRetries != 7 || cfg.RequestTimeout != 5*time.Second {
+		t.Errorf("Unexpected configuration %+v", cfg)
+	}
+
+	client, err = NewClientFromProfile("")
+	if err != nil {
+		t.Fatalf("NewClientFromProfile() unexpected error = %v", err)
+	}
+	if cfg := client.GetConfig(); cfg.BaseURL != "https://api.dev.example.com" || cfg.RequestTimeout != utils.DefaultRequestTimeout {
+		t.Errorf("Unexpected configuration %+v", cfg)
+	}
+}
+
+func TestNewClientFromProfile_ServiceAccount(t *testing.T) {
+	saPath := filepath.Join(t.TempDir(), "sa.json")
+	writeServiceAccount(t, saPath, "cli", "secret", "https://auth.example.com/realms/test")
+	writeProfiles(t, "prod:\n  base_url: https://api.example.com\n  service_account_file: "+saPath+"\n")
+
+	client, err := NewClientFromProfile("prod")
+	if err != nil {
+		t.Fatalf("NewClientFromProfile() unexpected error = %v", err)
+	}
+	if cfg := client.GetConfig(); cfg.KeycloakClientID != "cli" || cfg.KeycloakRealm != "test" {
+		t.Errorf("Unexpected configuration %+v", cfg)
+	}
+}
+
+func TestNewClientFromProfile_Invalid(t *testing.T) {
+	writeProfiles(t, "nourl:\n  token: t\nnoauth:\n  base_url: https://api.example.com\n")
+
+	for _, name := range []string{"nourl", "noauth"} {
+		if _, err := NewClientFromProfile(name); !errors.Is(err, utils.ErrInvalidConfiguration) {
+			t.Errorf("%s: expected ErrInvalidConfiguration, got %v", name, err)
+		}
+	}
+
+	writeProfiles(t, "staging: [not, a, profile]")
+	if _, err := LoadProfile("staging"); !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for an invalid file, got %v", err)
+	}
+}
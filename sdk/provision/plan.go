@@ -0,0 +1,404 @@
+package provision
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/progressive"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Action is the kind of a planned change.
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionReplace Action = "replace" // Delete then create, for immutable resources
+	ActionDelete  Action = "delete"
+)
+
+// symbols prefix the changes in the plan output.
+var symbols = map[Action]string{
+	ActionCreate:  "+",
+	ActionUpdate:  "~",
+	ActionReplace: "-/+",
+	ActionDelete:  "-",
+}
+
+// Kinds of the provisioned resources.
+const (
+	KindHarbor      = "harbor"
+	KindDataDock    = "datadock"
+	KindSearchIndex = "search index"
+)
+
+// Change is a planned operation on one resource.
+type Change struct {
+	Action  Action
+	Kind    string   // KindHarbor, KindDataDock or KindSearchIndex
+	Path    string   // Names from the harbor down, e.g. "analytics/warehouse/products"
+	Details []string // Changed fields, e.g. `max_workers: 2 -> 4`
+
+	apply func(ctx context.Context) error
+}
+
+// String describes the change, e.g. "~ update datadock analytics/warehouse".
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s %s %s", symbols[c.Action], c.Action, c.Kind, c.Path)
+}
+
+// Plan is the ordered list of changes bringing an organization to a spec.
+type Plan struct {
+	Changes []Change
+}
+
+// Empty reports whether the organization already matches the spec.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String renders the plan for a dry run.
+func (p *Plan) String() string {
+	if p.Empty() {
+		return "No changes: the organization matches the spec.\n"
+	}
+	var out strings.Builder
+	counts := map[Action]int{}
+	for _, change := range p.Changes {
+		counts[change.Action]++
+		fmt.Fprintln(&out, change)
+		for _, detail := range change.Details {
+			fmt.Fprintf(&out, "    %s\n", detail)
+		}
+	}
+	fmt.Fprintf(&out, "\nPlan: %d to create, %d to update, %d to replace, %d to delete.\n",
+		counts[ActionCreate], counts[ActionUpdate], counts[ActionReplace], counts[ActionDelete])
+	return out.String()
+}
+
+// Apply performs the changes in order and stops at the first failure. Creations
+// look resources up by name first, so a partially applied plan can be completed by
+// planning and applying again.
+func (p *Plan) Apply(ctx context.Context) error {
+	for i, change := range p.Changes {
+		if err := change.apply(ctx); err != nil {
+			return fmt.Errorf("change %d of %d (%s %s %s) failed: %w", i+1, len(p.Changes), change.Action, change.Kind, change.Path, err)
+		}
+	}
+	return nil
+}
+
+// NewPlan compares the spec with the live state of the organization and returns the
+// changes to apply. Nothing is modified.
+func NewPlan(ctx context.Context, org *progressive.OrgBuilder, spec Spec) (*Plan, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	liveHarbors, err := org.Harbors(ctx, progressive.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list harbors: %w", err)
+	}
+	harbors := byName(liveHarbors, func(h progressive.Harbor) string { return h.Name })
+
+	p := &Plan{}
+	declared := map[string]bool{}
+	for _, harborSpec := range spec.Harbors {
+		declared[harborSpec.Name] = true
+		live, ok, err := lookup(harbors, KindHarbor, harborSpec.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			p.createHarbor(org, harborSpec)
+			continue
+		}
+		if err := p.diffHarbor(ctx, org.Harbor(live.ID), harborSpec, spec.Prune); err != nil {
+			return nil, err
+		}
+	}
+
+	if spec.Prune {
+		for _, live := range liveHarbors {
+			if declared[live.Name] {
+				continue
+			}
+			harbor := org.Harbor(live.ID)
+			p.add(Change{Action: ActionDelete, Kind: KindHarbor, Path: live.Name, apply: func(ctx context.Context) error {
+				_, err := harbor.Delete(ctx)
+				return err
+			}})
+		}
+	}
+	return p, nil
+}
+
+func (p *Plan) add(change Change) {
+	p.Changes = append(p.Changes, change)
+}
+
+// createHarbor plans the creation of a harbor and of everything it contains.
+func (p *Plan) createHarbor(org *progressive.OrgBuilder, spec HarborSpec) {
+	var harborID string
+	p.add(Change{Action: ActionCreate, Kind: KindHarbor, Path: spec.Name, apply: func(ctx context.Context) error {
+		harbor, _, err := org.EnsureHarbor(ctx, spec.Name)
+		harborID = harbor.ID
+		return err
+	}})
+
+	harbor := func() *progressive.HarborBuilder { return org.Harbor(harborID) }
+	for _, dataDockSpec := range spec.DataDocks {
+		p.createDataDock(harbor, spec.Name, dataDockSpec)
+	}
+}
+
+// createDataDock plans the creation of a datadock and of its search indexes. The
+// harbor is resolved when applying, as it may not exist yet.
+func (p *Plan) createDataDock(harbor func() *progressive.HarborBuilder, harborPath string, spec DataDockSpec) {
+	path := harborPath + "/" + spec.Name
+	var dataDockID string
+	p.add(Change{Action: ActionCreate, Kind: KindDataDock, Path: path, apply: func(ctx context.Context) error {
+		dataDock, _, err := harbor().EnsureDataDock(ctx, progressive.DataDockSpec{Name: spec.Name, Type: spec.Type, Config: spec.Config})
+		dataDockID = dataDock.ID
+		return err
+	}})
+
+	dataDock := func() *progressive.DataDockBuilder { return harbor().DataDock(dataDockID) }
+	for _, index := range spec.SearchIndexes {
+		p.createSearchIndex(dataDock, path, index)
+	}
+}
+
+func (p *Plan) createSearchIndex(dataDock func() *progressive.DataDockBuilder, dataDockPath string, spec progressive.SearchIndexSpec) {
+	p.add(Change{Action: ActionCreate, Kind: KindSearchIndex, Path: dataDockPath + "/" + spec.Name, apply: func(ctx context.Context) error {
+		_, err := dataDock().CreateSearchIndex(ctx, spec)
+		return err
+	}})
+}
+
+// diffHarbor plans the changes of the datadocks of an existing harbor.
+func (p *Plan) diffHarbor(ctx context.Context, harbor *progressive.HarborBuilder, spec HarborSpec, prune bool) error {
+	liveDataDocks, err := harbor.DataDocks(ctx, progressive.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list datadocks of harbor %q: %w", spec.Name, err)
+	}
+	dataDocks := byName(liveDataDocks, func(d progressive.DataDock) string { return d.Name })
+
+	declared := map[string]bool{}
+	for _, dataDockSpec := range spec.DataDocks {
+		declared[dataDockSpec.Name] = true
+		live, ok, err := lookup(dataDocks, KindDataDock, dataDockSpec.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			p.createDataDock(func() *progressive.HarborBuilder { return harbor }, spec.Name, dataDockSpec)
+			continue
+		}
+		if err := p.diffDataDock(ctx, harbor.DataDock(live.ID), live, spec.Name+"/"+dataDockSpec.Name, dataDockSpec, prune); err != nil {
+			return err
+		}
+	}
+
+	if prune {
+		for _, live := range liveDataDocks {
+			if declared[live.Name] {
+				continue
+			}
+			dataDock := harbor.DataDock(live.ID)
+			p.add(Change{Action: ActionDelete, Kind: KindDataDock, Path: spec.Name + "/" + live.Name, apply: func(ctx context.Context) error {
+				_, err := dataDock.Delete(ctx)
+				return err
+			}})
+		}
+	}
+	return nil
+}
+
+// diffDataDock plans the update of the configuration of an existing datadock and
+// the changes of its search indexes.
+func (p *Plan) diffDataDock(ctx context.Context, dataDock *progressive.DataDockBuilder, live progressive.DataDock, path string, spec DataDockSpec, prune bool) error {
+	desired := map[string]any{}
+	for key, value := range spec.Config {
+		desired[key] = value
+	}
+	if spec.Type != "" {
+		desired["type"] = spec.Type
+	}
+
+	if len(desired) > 0 {
+		resp, err := dataDock.Get(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get datadock %q: %w", path, err)
+		}
+		current, _ := resp.Data.(map[string]any)
+		if current == nil {
+			current = map[string]any{}
+		}
+		if _, ok := current["type"]; !ok && live.Type != "" {
+			current["type"] = live.Type
+		}
+
+		patch, details := configDiff(current, desired)
+		if len(patch) > 0 {
+			p.add(Change{Action: ActionUpdate, Kind: KindDataDock, Path: path, Details: details, apply: func(ctx context.Context) error {
+				_, err := dataDock.Update(ctx, patch)
+				return err
+			}})
+		}
+	}
+
+	if len(spec.SearchIndexes) == 0 && !prune {
+		return nil
+	}
+	return p.diffSearchIndexes(ctx, dataDock, path, spec.SearchIndexes, prune)
+}
+
+// diffSearchIndexes plans the changes of the search indexes of an existing datadock.
+// Indexes cannot be modified: an index whose definition changed is replaced.
+func (p *Plan) diffSearchIndexes(ctx context.Context, dataDock *progressive.DataDockBuilder, path string, specs []progressive.SearchIndexSpec, prune bool) error {
+	liveIndexes, err := dataDock.SearchIndexes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list search indexes of datadock %q: %w", path, err)
+	}
+	indexes := byName(liveIndexes, func(i progressive.SearchIndex) string { return i.Name })
+
+	declared := map[string]bool{}
+	for _, spec := range specs {
+		declared[spec.Name] = true
+		live, ok, err := lookup(indexes, KindSearchIndex, spec.Name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			p.createSearchIndex(func() *progressive.DataDockBuilder { return dataDock }, path, spec)
+			continue
+		}
+
+		details := indexDiff(live, spec)
+		if len(details) == 0 {
+			continue
+		}
+		p.add(Change{Action: ActionReplace, Kind: KindSearchIndex, Path: path + "/" + spec.Name, Details: details, apply: func(ctx context.Context) error {
+			if _, err := dataDock.DeleteSearchIndex(ctx, live.ID); err != nil {
+				return err
+			}
+			_, err := dataDock.CreateSearchIndex(ctx, spec)
+			return err
+		}})
+	}
+
+	if prune {
+		for _, live := range liveIndexes {
+			if declared[live.Name] {
+				continue
+			}
+			p.add(Change{Action: ActionDelete, Kind: KindSearchIndex, Path: path + "/" + live.Name, apply: func(ctx context.Context) error {
+				_, err := dataDock.DeleteSearchIndex(ctx, live.ID)
+				return err
+			}})
+		}
+	}
+	return nil
+}
+
+// configDiff returns the desired fields that differ from the current configuration,
+// and a description of each difference. Fields are looked up at the top level of the
+// datadock, then under its "config" object.
+func configDiff(current, desired map[string]any) (map[string]any, []string) {
+	nested, _ := current["config"].(map[string]any)
+
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	patch := map[string]any{}
+	var details []string
+	for _, key := range keys {
+		value, ok := current[key]
+		if !ok && nested != nil {
+			value, ok = nested[key]
+		}
+		want := normalize(desired[key])
+		if ok && reflect.DeepEqual(normalize(value), want) {
+			continue
+		}
+		patch[key] = desired[key]
+		was := "(unset)"
+		if ok {
+			was = formatValue(value)
+		}
+		details = append(details, fmt.Sprintf("%s: %s -> %s", key, was, formatValue(want)))
+	}
+	return patch, details
+}
+
+// indexDiff describes the differences between a live search index and its spec.
+func indexDiff(live progressive.SearchIndex, spec progressive.SearchIndexSpec) []string {
+	var details []string
+	for _, field := range []struct{ name, was, want string }{
+		{"catalog", live.Catalog, spec.Catalog},
+		{"schema", live.Schema, spec.Schema},
+		{"table", live.Table, spec.Table},
+	} {
+		if field.was != field.want {
+			details = append(details, fmt.Sprintf("%s: %q -> %q", field.name, field.was, field.want))
+		}
+	}
+	if !reflect.DeepEqual(live.Columns, spec.Columns) {
+		details = append(details, fmt.Sprintf("columns: %s -> %s", formatValue(live.Columns), formatValue(spec.Columns)))
+	}
+	return details
+}
+
+// normalize converts a value to its JSON representation (float64 numbers, []any,
+// map[string]any), so that values built in Go compare equal to decoded ones.
+func normalize(value any) any {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized any
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
+
+func formatValue(value any) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(raw)
+}
+
+// byName indexes live resources by name.
+func byName[T any](items []T, nameOf func(T) string) map[string][]T {
+	named := make(map[string][]T, len(items))
+	for _, item := range items {
+		named[nameOf(item)] = append(named[nameOf(item)], item)
+	}
+	return named
+}
+
+// lookup returns the live resource named name. Resources are identified by name, so
+// duplicates make the plan ambiguous.
+func lookup[T any](named map[string][]T, kind, name string) (T, bool, error) {
+	var zero T
+	switch items := named[name]; len(items) {
+	case 0:
+		return zero, false, nil
+	case 1:
+		return items[0], true, nil
+	default:
+		return zero, false, fmt.Errorf("%w: several %ss are named %q", utils.ErrInvalidRequest, kind, name)
+	}
+}
@@ -0,0 +1,194 @@
+package provision
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/progressive"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// fakeClient serves canned JSON payloads keyed by "METHOD path" (query string ignored)
+// and records the requests as "METHOD path body".
+type fakeClient struct {
+	responses map[string]string
+	requests  []string
+}
+
+func (f *fakeClient) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	path, _, _ := strings.Cut(strings.TrimPrefix(endpoint, f.GetConfig().BaseURL), "?")
+	f.requests = append(f.requests, strings.TrimSpace(method+" "+path+" "+string(body)))
+	payload, ok := f.responses[method+" "+path]
+	if !ok {
+		return &utils.Response{Status: utils.StatusError, HTTPCode: 404}, fmt.Errorf("%w: %s", utils.ErrNotFound, path)
+	}
+	var data any
+	if err := json.Unmarshal([]byte(payload), &data); err != nil {
+		return nil, err
+	}
+	return &utils.Response{Status: utils.StatusOK, Data: data, HTTPCode: 200}, nil
+}
+
+func (f *fakeClient) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://api.test"}
+}
+
+const testSpec = `
+prune: true
+harbors:
+  - name: analytics
+    data_docks:
+      - name: warehouse
+        type: TrinoInternal
+        config:
+          max_workers: 4
+        search_indexes:
+          - name: products
+            catalog: sales
+            schema: public
+            table: products
+            columns: [name, description]
+      - name: lake
+        type: MinioInternal
+  - name: ml
+    data_docks:
+      - name: features
+        type: TrinoInternal
+`
+
+func liveState() map[string]string {
+	return map[string]string{
+		"GET /org-1/harbors":                       `[{"id": "h1", "name": "analytics"}, {"id": "h9", "name": "old"}]`,
+		"GET /harbors/h1/data-docks":               `[{"id": "dd1", "name": "warehouse", "type": "TrinoInternal"}]`,
+		"GET /data-docks/dd1":                      `{"id": "dd1", "name": "warehouse", "config": {"max_workers": 2}}`,
+		"GET /data-docks/dd1/search-indexes":       `[{"id": "i1", "name": "products", "catalog": "sales", "schema": "public", "table": "products", "columns": ["name"]}]`,
+		"PATCH /data-docks/dd1":                    `{}`,
+		"DELETE /data-docks/dd1/search-indexes/i1": `{}`,
+		"POST /data-docks/dd1/search-indexes":      `{"id": "i2", "name": "products"}`,
+		"POST /org-1/harbors":                      `{"id": "h2", "name": "ml"}`,
+		"GET /harbors/h2/data-docks":               `[]`,
+		"POST /data-docks":                         `{"id": "dd3", "name": "created"}`,
+		"DELETE /harbors/h9":                       `{}`,
+	}
+}
+
+func TestParseSpec(t *testing.T) {
+	spec, err := ParseSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatalf("ParseSpec() unexpected error = %v", err)
+	}
+	if !spec.Prune || len(spec.Harbors) != 2 || spec.Harbors[0].DataDocks[0].Config["max_workers"] != float64(4) {
+		t.Errorf("unexpected spec: %+v", spec)
+	}
+
+	invalid := map[string]string{
+		"unknown field":  "harbors:\n  - name: a\n    datadocks: []\n",
+		"duplicate":      "harbors:\n  - name: a\n  - name: a\n",
+		"missing name":   "harbors:\n  - data_docks: []\n",
+		"partial index":  "harbors:\n  - name: a\n    data_docks:\n      - name: d\n        search_indexes:\n          - name: i\n",
+		"malformed yaml": "harbors: [",
+	}
+	for name, raw := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseSpec([]byte(raw)); !errors.Is(err, utils.ErrInvalidConfiguration) {
+				t.Errorf("ParseSpec() error = %v, want ErrInvalidConfiguration", err)
+			}
+		})
+	}
+}
+
+func TestPlanAndApply(t *testing.T) {
+	spec, err := ParseSpec([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &fakeClient{responses: liveState()}
+	org := &progressive.OrgBuilder{Client: client, OrgID: "org-1"}
+
+	plan, err := NewPlan(context.Background(), org, spec)
+	if err != nil {
+		t.Fatalf("NewPlan() unexpected error = %v", err)
+	}
+	for _, request := range client.requests {
+		if !strings.HasPrefix(request, "GET ") {
+			t.Errorf("planning must not modify the organization, got %q", request)
+		}
+	}
+
+	var got []string
+	for _, change := range plan.Changes {
+		got = append(got, change.String())
+	}
+	want := []string{
+		"~ update datadock analytics/warehouse",
+		"-/+ replace search index analytics/warehouse/products",
+		"+ create datadock analytics/lake",
+		"+ create harbor ml",
+		"+ create datadock ml/features",
+		"- delete harbor old",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("plan changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	output := plan.String()
+	for _, expected := range []string{"max_workers: 2 -> 4", `columns: ["name"] -> ["name","description"]`, "Plan: 3 to create, 1 to update, 1 to replace, 1 to delete."} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("plan output misses %q:\n%s", expected, output)
+		}
+	}
+
+	client.requests = nil
+	if err := plan.Apply(context.Background()); err != nil {
+		t.Fatalf("Apply() unexpected error = %v", err)
+	}
+	var writes []string
+	for _, request := range client.requests {
+		if !strings.HasPrefix(request, "GET ") {
+			writes = append(writes, request)
+		}
+	}
+	expectedWrites := []string{
+		`PATCH /data-docks/dd1 {"max_workers":4}`,
+		"DELETE /data-docks/dd1/search-indexes/i1",
+		"POST /data-docks/dd1/search-indexes",
+		"POST /data-docks",
+		"POST /org-1/harbors",
+		"POST /data-docks",
+		"DELETE /harbors/h9",
+	}
+	if len(writes) != len(expectedWrites) {
+		t.Fatalf("Apply() requests:\n%s", strings.Join(writes, "\n"))
+	}
+	for i, prefix := range expectedWrites {
+		if !strings.HasPrefix(writes[i], prefix) {
+			t.Errorf("request %d = %q, want %q", i, writes[i], prefix)
+		}
+	}
+	if !strings.Contains(writes[5], `"harbor_id":"h2"`) {
+		t.Errorf("datadock of the new harbor created with %q, want harbor_id h2", writes[5])
+	}
+}
+
+func TestPlanWithoutChanges(t *testing.T) {
+	spec := Spec{Harbors: []HarborSpec{{
+		Name: "analytics",
+		DataDocks: []DataDockSpec{{
+			Name:   "warehouse",
+			Type:   "TrinoInternal",
+			Config: map[string]any{"max_workers": 2},
+		}},
+	}}}
+	org := &progressive.OrgBuilder{Client: &fakeClient{responses: liveState()}, OrgID: "org-1"}
+
+	plan, err := NewPlan(context.Background(), org, spec)
+	if err != nil {
+		t.Fatalf("NewPlan() unexpected error = %v", err)
+	}
+	if !plan.Empty() {
+		t.Errorf("expected an empty plan without prune, got:\n%s", plan)
+	}
+}
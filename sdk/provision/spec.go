@@ -0,0 +1,141 @@
+// Package provision reconciles an organization with a declarative description of its
+// harbors, datadocks and search indexes, in a plan / apply workflow:
+//
+//	spec, err := provision.LoadSpec("platform.yaml")
+//	plan, err := provision.NewPlan(ctx, client.Org(orgID), spec)
+//	fmt.Print(plan) // Dry run: what would change
+//	err = plan.Apply(ctx)
+//
+// Resources are identified by name. Resources missing from the organization are
+// created and datadocks whose configuration differs are updated. Resources of the
+// organization absent from the spec are deleted only when the spec sets prune.
+package provision
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/progressive"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Spec is the desired state of an organization.
+//
+// In YAML (or JSON):
+//
+//	prune: false
+//	harbors:
+//	  - name: analytics
+//	    data_docks:
+//	      - name: warehouse
+//	        type: TrinoInternal
+//	        config:
+//	          max_workers: 4
+//	        search_indexes:
+//	          - name: products
+//	            catalog: sales
+//	            schema: public
+//	            table: products
+//	            columns: [name, description]
+type Spec struct {
+	// Prune deletes the harbors, datadocks and search indexes absent from the spec.
+	// Without it, they are left untouched.
+	Prune   bool         `json:"prune,omitempty"`
+	Harbors []HarborSpec `json:"harbors"`
+}
+
+// HarborSpec is the desired state of a harbor.
+type HarborSpec struct {
+	Name      string         `json:"name"`
+	DataDocks []DataDockSpec `json:"data_docks,omitempty"`
+}
+
+// DataDockSpec is the desired state of a datadock. Only the fields of Config (and
+// Type) are compared with the live datadock; other live fields are left as they are.
+type DataDockSpec struct {
+	Name          string                        `json:"name"`
+	Type          string                        `json:"type,omitempty"`
+	Config        map[string]any                `json:"config,omitempty"`
+	SearchIndexes []progressive.SearchIndexSpec `json:"search_indexes,omitempty"`
+}
+
+// LoadSpec reads a spec from a YAML or JSON file.
+func LoadSpec(path string) (Spec, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Spec{}, fmt.Errorf("cannot read spec: %w", err)
+	}
+	spec, err := ParseSpec(raw)
+	if err != nil {
+		return Spec{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// ParseSpec decodes a YAML or JSON spec (JSON being valid YAML) and validates it.
+// Unknown fields are rejected, to catch typos.
+func ParseSpec(raw []byte) (Spec, error) {
+	// Decode YAML generically, then through the json tags, so that both formats
+	// share the field names and the datadock config gets JSON types.
+	var generic any
+	if err := yaml.Unmarshal(raw, &generic); err != nil {
+		return Spec{}, fmt.Errorf("%w: invalid spec: %v", utils.ErrInvalidConfiguration, err)
+	}
+	normalized, err := json.Marshal(generic)
+	if err != nil {
+		return Spec{}, fmt.Errorf("%w: invalid spec: %v", utils.ErrInvalidConfiguration, err)
+	}
+
+	var spec Spec
+	decoder := json.NewDecoder(bytes.NewReader(normalized))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&spec); err != nil {
+		return Spec{}, fmt.Errorf("%w: invalid spec: %v", utils.ErrInvalidConfiguration, err)
+	}
+	if err := spec.Validate(); err != nil {
+		return Spec{}, err
+	}
+	return spec, nil
+}
+
+// Validate checks that every resource is named, that names are unique among their
+// siblings, and that search indexes are complete.
+func (s Spec) Validate() error {
+	harbors := map[string]bool{}
+	for _, harbor := range s.Harbors {
+		if harbor.Name == "" {
+			return fmt.Errorf("%w: harbor without a name", utils.ErrInvalidConfiguration)
+		}
+		if harbors[harbor.Name] {
+			return fmt.Errorf("%w: harbor %q is declared twice", utils.ErrInvalidConfiguration, harbor.Name)
+		}
+		harbors[harbor.Name] = true
+
+		dataDocks := map[string]bool{}
+		for _, dataDock := range harbor.DataDocks {
+			if dataDock.Name == "" {
+				return fmt.Errorf("%w: datadock without a name in harbor %q", utils.ErrInvalidConfiguration, harbor.Name)
+			}
+			if dataDocks[dataDock.Name] {
+				return fmt.Errorf("%w: datadock %q is declared twice in harbor %q", utils.ErrInvalidConfiguration, dataDock.Name, harbor.Name)
+			}
+			dataDocks[dataDock.Name] = true
+
+			indexes := map[string]bool{}
+			for _, index := range dataDock.SearchIndexes {
+				if err := index.Validate(); err != nil {
+					return fmt.Errorf("%w: datadock %q: %v", utils.ErrInvalidConfiguration, dataDock.Name, err)
+				}
+				if indexes[index.Name] {
+					return fmt.Errorf("%w: search index %q is declared twice in datadock %q", utils.ErrInvalidConfiguration, index.Name, dataDock.Name)
+				}
+				indexes[index.Name] = true
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+const (
+	// sqlCancelPath and sqlHistoryPath are the SQL cancel and history endpoints of the control plane.
+	sqlCancelPath  = "/api/v1/tiny-query/cancel/"
+	sqlHistoryPath = "/api/v1/tiny-query/history"
+
+	// abandonedQueryCancelTimeout bounds the cancellation of the backend query of
+	// a request whose context was cancelled.
+	abandonedQueryCancelTimeout = 5 * time.Second
+)
+
+// CancelQuery cancels a running SQL statement of a datadock, given the Trino query
+// ID reported by the platform (e.g. in the query history).
+//
+// Example:
+//
+//	err := client.CancelQuery(ctx, dataDockID, "20250301_101530_00042_abcde")
+func (c *Client) CancelQuery(ctx context.Context, dataDockID, trinoQueryID string) error {
+	if dataDockID == "" || trinoQueryID == "" {
+		return fmt.Errorf("%w: datadock ID and Trino query ID are required", utils.ErrInvalidRequest)
+	}
+	endpoint := strings.TrimSuffix(c.controlPlaneBaseURL(ctx), "/") + sqlCancelPath + url.PathEscape(trinoQueryID) +
+		"?" + url.Values{"data_dock_id": {dataDockID}}.Encode()
+	_, err := c.Do(ctx, "POST", endpoint, nil)
+	return err
+}
+
+// cancelAbandonedStatement cancels the backend query of a SQL statement whose
+// request was abandoned because ctx was cancelled, so that it stops consuming
+// cluster resources. The execute endpoint only reports the Trino query ID with
+// the result, so the query is looked up among the running statements of the
+// datadock submitted since the request started. Failures are ignored: the
+// statement may have finished, or not reached Trino yet.
+func (c *Client) cancelAbandonedStatement(ctx context.Context, dataDockID, statement string, submitted time.Time) {
+	if ctx.Err() == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(utils.ContextWithoutTransformers(context.WithoutCancel(ctx)), abandonedQueryCancelTimeout)
+	defer cancel()
+
+	params := url.Values{"data_dock_id": {dataDockID}, "status": {"running"}, "search": {statement}}
+	resp, err := c.Do(ctx, "GET", strings.TrimSuffix(c.controlPlaneBaseURL(ctx), "/")+sqlHistoryPath+"?"+params.Encode(), nil)
+	if err != nil {
+		return
+	}
+	var entries []struct {
+		SQLText      string    `json:"sql_text"`
+		TrinoQueryID string    `json:"trino_query_id"`
+		CreatedAt    time.Time `json:"created_at"`
+	}
+	if err := utils.UnmarshalData(resp.Data, &entries); err != nil {
+		return
+	}
+	// Clocks may drift a little between the client and the platform. Identical
+	// statements submitted later belong to other requests: the earliest one is ours.
+	since := submitted.Add(-time.Second)
+	var queryID string
+	var createdAt time.Time
+	for _, entry := range entries {
+		if entry.SQLText != statement || entry.TrinoQueryID == "" || entry.CreatedAt.Before(since) {
+			continue
+		}
+		if queryID == "" || entry.CreatedAt.Before(createdAt) {
+			queryID, createdAt = entry.TrinoQueryID, entry.CreatedAt
+		}
+	}
+	if queryID != "" {
+		_ = c.CancelQuery(ctx, dataDockID, queryID)
+	}
+}
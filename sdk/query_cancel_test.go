@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestSQLBatch_CancelledContextCancelsBackendQuery(t *testing.T) {
+	const statement = "SELECT count(*) FROM events"
+	now := time.Now().UTC()
+
+	var mu sync.Mutex
+	var canceled []string
+	client := &Client{
+		config: utils.Configuration{BaseURL: "http://localhost", ControlPlaneURL: "http://cp", Token: "test-token", DataDockID: "dd-1"},
+		httpClient: &http.Client{Transport: &mockRoundTripper{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				switch {
+				case req.URL.Path == "/api/v1/tiny-query/execute":
+					<-req.Context().Done()
+					return nil, req.Context().Err()
+				case req.Method == "GET" && req.URL.Path == "/api/v1/tiny-query/history":
+					if req.URL.Query().Get("status") != "running" || req.URL.Query().Get("data_dock_id") != "dd-1" {
+						t.Errorf("Unexpected history query %s", req.URL.RawQuery)
+					}
+					history := fmt.Sprintf(`[
+						{"sql_text": %[1]q, "trino_query_id": "tq-old", "created_at": %[2]q},
+						{"sql_text": %[1]q, "trino_query_id": "tq-later", "created_at": %[3]q},
+						{"sql_text": %[1]q, "trino_query_id": "tq-ours", "created_at": %[4]q}
+					]`, statement, now.Add(-time.Hour).Format(time.RFC3339Nano),
+						now.Add(time.Minute).Format(time.RFC3339Nano), now.Format(time.RFC3339Nano))
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(history)), Header: make(http.Header)}, nil
+				case req.Method == "POST" && strings.HasPrefix(req.URL.Path, "/api/v1/tiny-query/cancel/"):
+					mu.Lock()
+					canceled = append(canceled, strings.TrimPrefix(req.URL.Path, "/api/v1/tiny-query/cancel/")+"?"+req.URL.RawQuery)
+					mu.Unlock()
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{}`)), Header: make(http.Header)}, nil
+				}
+				t.Errorf("Unexpected request %s %s", req.Method, req.URL)
+				return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader("")), Header: make(http.Header)}, nil
+			},
+		}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, err := client.SQLBatch(ctx).Add(statement).Exec(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(canceled) != 1 || canceled[0] != "tq-ours?data_dock_id=dd-1" {
+		t.Errorf("Expected the abandoned query to be cancelled, got %v", canceled)
+	}
+}
+
+func TestClient_CancelQueryValidation(t *testing.T) {
+	client := &Client{}
+	if err := client.CancelQuery(context.Background(), "dd-1", ""); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// queryTagsHeader carries the tags of a request, comma separated.
+const queryTagsHeader = "X-Query-Tags"
+
+// WithQueryTag returns a copy of the client that labels every request with the
+// given tags, in addition to the configured QueryTags. The platform attributes the
+// cost and audit events of tagged requests to their consumer.
+//
+// Example:
+//
+//	analytics := client.WithQueryTag("team:analytics", "job:nightly-report")
+func (c *Client) WithQueryTag(tags ...string) *Client {
+	derived := c.derive()
+	derived.config.QueryTags = append(append([]string(nil), c.config.QueryTags...), tags...)
+	if err := validateQueryTags(tags); err != nil && derived.initErr == nil {
+		derived.initErr = err
+	}
+	return &derived
+}
+
+// validateQueryTags checks that the tags can be sent in the X-Query-Tags header.
+func validateQueryTags(tags []string) error {
+	for _, tag := range tags {
+		if tag == "" {
+			return fmt.Errorf("%w: empty query tag", utils.ErrInvalidConfiguration)
+		}
+		for _, r := range tag {
+			if r <= ' ' || r > '~' || r == ',' {
+				return fmt.Errorf("%w: query tag %q must be printable ASCII without spaces or commas", utils.ErrInvalidConfiguration, tag)
+			}
+		}
+	}
+	return nil
+}
+
+// validateConfiguredQueryTags checks the QueryTags and DataDockQueryTags of the configuration.
+func validateConfiguredQueryTags(cfg utils.Configuration) error {
+	if err := validateQueryTags(cfg.QueryTags); err != nil {
+		return err
+	}
+	for _, tags := range cfg.DataDockQueryTags {
+		if err := validateQueryTags(tags); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyQueryTags sets the X-Query-Tags header from the client tags and the tags of
+// the datadock targeted by the request. Tags already set on the request are kept.
+func (c *Client) applyQueryTags(req *http.Request) {
+	tags := c.config.QueryTags
+	if dataDockID := requestDataDock(c.config.BaseURL, req.URL); dataDockID != "" {
+		tags = append(append([]string(nil), tags...), c.config.DataDockQueryTags[dataDockID]...)
+	}
+	if len(tags) == 0 {
+		return
+	}
+
+	if existing := req.Header.Get(queryTagsHeader); existing != "" {
+		tags = append(strings.Split(existing, ","), tags...)
+	}
+	seen := make(map[string]bool, len(tags))
+	unique := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" && !seen[tag] {
+			seen[tag] = true
+			unique = append(unique, tag)
+		}
+	}
+	req.Header.Set(queryTagsHeader, strings.Join(unique, ","))
+}
+
+// requestDataDock returns the datadock targeted by a request to the API: data
+// queries ({BaseURL}/{datadock}/openapi/...) and datadock endpoints
+// ({BaseURL}/data-docks/{datadock}/...).
+func requestDataDock(baseURL string, u *url.URL) string {
+	base, err := url.Parse(baseURL)
+	if err != nil || base.Host != u.Host {
+		return ""
+	}
+	path, ok := strings.CutPrefix(u.Path, strings.TrimRight(base.Path, "/")+"/")
+	if !ok {
+		return ""
+	}
+	segments := strings.Split(path, "/")
+	switch {
+	case len(segments) >= 2 && segments[1] == "openapi":
+		return segments[0]
+	case len(segments) >= 2 && segments[0] == "data-docks":
+		return segments[1]
+	}
+	return ""
+}
@@ -0,0 +1,65 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestQueryTags(t *testing.T) {
+	var mu sync.Mutex
+	tags := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tags[r.URL.Path] = r.Header.Get(queryTagsHeader)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{
+		BaseURL:           server.URL + "/api",
+		Token:             "token",
+		QueryTags:         []string{"env:prod"},
+		DataDockQueryTags: map[string][]string{"dd1": {"cost-center:42", "env:prod"}},
+	}).WithQueryTag("team:analytics")
+
+	ctx := context.Background()
+	for _, path := range []string{"/api/dd1/openapi/c/s/t", "/api/data-docks/dd1/wake-up", "/api/dd2/openapi/c/s/t", "/api/org/harbors"} {
+		if _, err := client.Do(ctx, "GET", server.URL+path, nil); err != nil {
+			t.Fatalf("Do(%s) unexpected error = %v", path, err)
+		}
+	}
+
+	want := map[string]string{
+		"/api/dd1/openapi/c/s/t":      "env:prod,team:analytics,cost-center:42",
+		"/api/data-docks/dd1/wake-up": "env:prod,team:analytics,cost-center:42",
+		"/api/dd2/openapi/c/s/t":      "env:prod,team:analytics",
+		"/api/org/harbors":            "env:prod,team:analytics",
+	}
+	for path, expected := range want {
+		if tags[path] != expected {
+			t.Errorf("%s tagged %q, want %q", path, tags[path], expected)
+		}
+	}
+}
+
+func TestQueryTagsValidation(t *testing.T) {
+	invalid := NewClient(utils.Configuration{BaseURL: "https://api.test", Token: "token", QueryTags: []string{"team analytics"}})
+	if !errors.Is(invalid.initErr, utils.ErrInvalidConfiguration) {
+		t.Errorf("expected ErrInvalidConfiguration for a tag with a space, got %v", invalid.initErr)
+	}
+
+	client := NewClient(utils.Configuration{BaseURL: "https://api.test", Token: "token"})
+	if _, err := client.WithQueryTag("a,b").Do(context.Background(), "GET", "https://api.test/x", nil); !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("expected ErrInvalidConfiguration for a tag with a comma, got %v", err)
+	}
+	if client.initErr != nil || len(client.config.QueryTags) != 0 {
+		t.Error("WithQueryTag must not modify the original client")
+	}
+}
@@ -0,0 +1,85 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+const (
+	secretToken    = "eyJhbGciOiJSUzI1NiJ9.secret-payload.secret-signature"
+	secretPassword = "hunter2-password"
+	secretClient   = "client-secret-value"
+	secretMinIO    = "minio-secret-key-value"
+)
+
+// assertNoSecrets fails if the output contains any of the secrets.
+func assertNoSecrets(t *testing.T, what, output string) {
+	t.Helper()
+	for _, secret := range []string{secretToken, secretPassword, secretClient, secretMinIO, "secret-payload"} {
+		if strings.Contains(output, secret) {
+			t.Errorf("%s leaks %q: %s", what, secret, output)
+		}
+	}
+}
+
+func TestRedact_FormattedValues(t *testing.T) {
+	cfg := utils.Configuration{
+		BaseURL:              "https://api.example.com",
+		Token:                secretToken,
+		KeycloakClientID:     "sa",
+		KeycloakClientSecret: secretClient,
+		KeycloakUsername:     "alice",
+		KeycloakPassword:     secretPassword,
+		MinIOAccessKey:       "minio-access",
+		MinIOSecretKey:       secretMinIO,
+	}
+	sa := &ServiceAccount{ClientID: "sa", ClientSecret: secretClient, Issuer: "https://auth.example.com/realms/r"}
+	client := NewClient(cfg)
+
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s"} {
+		assertNoSecrets(t, "Configuration "+verb, fmt.Sprintf(verb, cfg))
+		assertNoSecrets(t, "*Configuration "+verb, fmt.Sprintf(verb, &cfg))
+		assertNoSecrets(t, "ServiceAccount "+verb, fmt.Sprintf(verb, sa))
+		assertNoSecrets(t, "Client "+verb, fmt.Sprintf(verb, client))
+	}
+
+	if got := fmt.Sprintf("%v", cfg); !strings.Contains(got, "https://api.example.com") || !strings.Contains(got, "eyJh...") {
+		t.Errorf("Configuration output should keep non-secret fields and the token prefix: %s", got)
+	}
+	if cfg.Redacted().KeycloakUsername != "alice" || cfg.Token != secretToken {
+		t.Error("Redacted() must only redact the copy's secrets")
+	}
+}
+
+func TestRedact_KeycloakErrorEchoingCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		http.Error(w, `{"error": "invalid_grant", "detail": "bad password `+r.Form.Get("password")+`"}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{
+		BaseURL:          server.URL,
+		KeycloakBaseURL:  server.URL,
+		KeycloakRealm:    "r",
+		KeycloakClientID: "app",
+		KeycloakUsername: "alice",
+		KeycloakPassword: secretPassword,
+	})
+
+	_, err := client.refreshToken(context.Background())
+	if !errors.Is(err, utils.ErrAuthenticationFailed) {
+		t.Fatalf("refreshToken() error = %v, want ErrAuthenticationFailed", err)
+	}
+	assertNoSecrets(t, "Keycloak error", err.Error())
+	if !strings.Contains(err.Error(), utils.RedactedValue) {
+		t.Errorf("error should show where the secret was redacted: %v", err)
+	}
+}
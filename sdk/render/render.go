@@ -0,0 +1,283 @@
+// Package render formats query results for humans: aligned text tables,
+// Markdown and CSV, and per-column summaries. It is meant for command-line tools
+// and notebooks (e.g. gophernotes), where results are inspected rather than processed:
+//
+//	resp, err := client.Catalog("sales").Schema("public").Table("orders").Limit(20).Get(ctx)
+//	fmt.Println(render.Table(resp))
+//	fmt.Println(render.Summary(resp))
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Format is an output format of Render.
+type Format string
+
+const (
+	Text     Format = "text"     // Aligned columns, numbers right-aligned
+	Markdown Format = "markdown" // GitHub-flavored Markdown table
+	CSV      Format = "csv"      // RFC 4180, with a header line
+)
+
+// Options configures Render. All fields are optional.
+type Options struct {
+	Format   Format   // Default Text
+	Columns  []string // Columns to show, in order; default every column, sorted by name
+	MaxRows  int      // Rows shown, 0 for all; the number of hidden rows is reported
+	MaxWidth int      // Text and Markdown cells longer than this are cut, 0 for no limit
+}
+
+// defaultMaxWidth is the cell width of Table.
+const defaultMaxWidth = 40
+
+// Table renders the rows of a response as an aligned text table, with long cells cut.
+func Table(resp *utils.Response) string {
+	return Render(resp, Options{MaxWidth: defaultMaxWidth})
+}
+
+// Render renders the rows of a response in the given format.
+func Render(resp *utils.Response, opts Options) string {
+	var b strings.Builder
+	_ = Write(&b, resp, opts) // A strings.Builder does not fail
+	return b.String()
+}
+
+// Write renders the rows of a response to w. Responses that are not a list of rows
+// are rendered as a single row (an object) or a single "value" column.
+func Write(w io.Writer, resp *utils.Response, opts Options) error {
+	rows := rowsOf(resp)
+	columns := opts.Columns
+	if len(columns) == 0 {
+		columns = columnsOf(rows)
+	}
+	shown := rows
+	if opts.MaxRows > 0 && len(rows) > opts.MaxRows {
+		shown = rows[:opts.MaxRows]
+	}
+
+	cells := make([][]string, len(shown))
+	for i, row := range shown {
+		cells[i] = make([]string, len(columns))
+		for j, column := range columns {
+			if opts.Format == CSV {
+				cells[i][j] = csvCell(row[column])
+			} else {
+				cells[i][j] = truncate(formatCell(row[column]), opts.MaxWidth)
+			}
+		}
+	}
+
+	switch opts.Format {
+	case CSV:
+		writer := csv.NewWriter(w)
+		_ = writer.Write(columns)
+		_ = writer.WriteAll(cells)
+		return writer.Error()
+	case Markdown:
+		return writeMarkdown(w, columns, cells, numericColumns(shown, columns), footer(len(shown), len(rows)))
+	case Text, "":
+		return writeText(w, columns, cells, numericColumns(shown, columns), footer(len(shown), len(rows)))
+	}
+	return fmt.Errorf("%w: unknown render format %q", utils.ErrInvalidRequest, opts.Format)
+}
+
+// writeText writes aligned columns, numeric ones right-aligned, then the footer.
+func writeText(w io.Writer, columns []string, cells [][]string, numeric []bool, footer string) error {
+	widths := make([]int, len(columns))
+	for j, column := range columns {
+		widths[j] = utf8.RuneCountInString(column)
+		for _, row := range cells {
+			widths[j] = max(widths[j], utf8.RuneCountInString(row[j]))
+		}
+	}
+
+	var b strings.Builder
+	line := func(values []string, fill func(j int, value string) string) {
+		var l strings.Builder
+		for j, value := range values {
+			if j > 0 {
+				l.WriteString("  ")
+			}
+			l.WriteString(fill(j, value))
+		}
+		b.WriteString(strings.TrimRight(l.String(), " "))
+		b.WriteByte('\n')
+	}
+	align := func(j int, value string) string {
+		padding := strings.Repeat(" ", widths[j]-utf8.RuneCountInString(value))
+		if numeric[j] {
+			return padding + value
+		}
+		return value + padding
+	}
+
+	line(columns, align)
+	rule := make([]string, len(columns))
+	for j := range columns {
+		rule[j] = strings.Repeat("-", widths[j])
+	}
+	line(rule, func(_ int, value string) string { return value })
+	for _, row := range cells {
+		line(row, align)
+	}
+	b.WriteString(footer)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeMarkdown(w io.Writer, columns []string, cells [][]string, numeric []bool, footer string) error {
+	var b strings.Builder
+	line := func(values []string) {
+		b.WriteByte('|')
+		for _, value := range values {
+			b.WriteString(" " + markdownEscaper.Replace(value) + " |")
+		}
+		b.WriteByte('\n')
+	}
+
+	line(columns)
+	b.WriteByte('|')
+	for j := range columns {
+		if numeric[j] {
+			b.WriteString(" ---: |")
+		} else {
+			b.WriteString(" --- |")
+		}
+	}
+	b.WriteByte('\n')
+	for _, row := range cells {
+		line(row)
+	}
+	b.WriteString("\n" + footer)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ", "\r", "")
+
+// footer reports the number of rows, and how many were hidden by MaxRows.
+func footer(shown, total int) string {
+	if shown < total {
+		return fmt.Sprintf("(%d of %d rows)\n", shown, total)
+	}
+	if total == 1 {
+		return "(1 row)\n"
+	}
+	return fmt.Sprintf("(%d rows)\n", total)
+}
+
+// rowsOf returns the rows of a response.
+func rowsOf(resp *utils.Response) []map[string]any {
+	if resp == nil || resp.Data == nil {
+		return nil
+	}
+	if rows, err := resp.Rows(); err == nil {
+		return rows
+	}
+	if row, ok := resp.Data.(map[string]any); ok {
+		return []map[string]any{row}
+	}
+	if items, ok := resp.Data.([]any); ok {
+		rows := make([]map[string]any, len(items))
+		for i, item := range items {
+			rows[i] = map[string]any{"value": item}
+		}
+		return rows
+	}
+	return []map[string]any{{"value": resp.Data}}
+}
+
+// columnsOf returns the union of the columns of the rows, sorted by name.
+func columnsOf(rows []map[string]any) []string {
+	seen := map[string]bool{}
+	var columns []string
+	for _, row := range rows {
+		for column := range row {
+			if !seen[column] {
+				seen[column] = true
+				columns = append(columns, column)
+			}
+		}
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// numericColumns reports the columns whose non-NULL values are all numbers.
+func numericColumns(rows []map[string]any, columns []string) []bool {
+	numeric := make([]bool, len(columns))
+	for j, column := range columns {
+		found := false
+		numeric[j] = true
+		for _, row := range rows {
+			value := row[column]
+			if value == nil {
+				continue
+			}
+			if _, ok := number(value); !ok {
+				numeric[j] = false
+				break
+			}
+			found = true
+		}
+		numeric[j] = numeric[j] && found
+	}
+	return numeric
+}
+
+// number converts the numbers of decoded responses (float64, json.Number) and of
+// rows built in Go.
+func number(value any) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return utils.Float64(value)
+}
+
+// formatCell renders a value on a single line. Nested values are written as JSON.
+func formatCell(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return strings.NewReplacer("\n", `\n`, "\t", " ").Replace(v)
+	case json.Number:
+		return v.String()
+	case map[string]any, []any:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	}
+	return fmt.Sprint(value)
+}
+
+// csvCell renders a value as a CSV field: NULL is empty, strings are kept as they are.
+func csvCell(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	return formatCell(value)
+}
+
+// truncate cuts s to width characters, ending with an ellipsis.
+func truncate(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:max(width-1, 0)]) + "…"
+}
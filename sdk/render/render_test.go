@@ -0,0 +1,68 @@
+package render_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/render"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func ordersResponse() *utils.Response {
+	return &utils.Response{TotalCount: 10, Data: []any{
+		map[string]any{"id": float64(1), "name": "Alice", "amount": json.Number("12.50"), "created": "2025-03-01T10:00:00Z"},
+		map[string]any{"id": float64(2), "name": "Bob | Co", "amount": nil, "created": "2025-03-02"},
+		map[string]any{"id": float64(10), "name": strings.Repeat("x", 50), "amount": float64(7), "created": "2025-03-03"},
+	}}
+}
+
+func TestTable(t *testing.T) {
+	expected := `amount  created               id  name
+------  --------------------  --  ----------------------------------------
+ 12.50  2025-03-01T10:00:00Z   1  Alice
+  NULL  2025-03-02             2  Bob | Co
+     7  2025-03-03            10  xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx…
+(3 rows)
+`
+	if got := render.Table(ordersResponse()); got != expected {
+		t.Errorf("Table() =\n%s\nexpected\n%s", got, expected)
+	}
+}
+
+func TestRender_Formats(t *testing.T) {
+	resp := ordersResponse()
+
+	markdown := render.Render(resp, render.Options{Format: render.Markdown, Columns: []string{"id", "name"}, MaxRows: 2})
+	expected := "| id | name |\n| ---: | --- |\n| 1 | Alice |\n| 2 | Bob \\| Co |\n\n(2 of 3 rows)\n"
+	if markdown != expected {
+		t.Errorf("Markdown =\n%s\nexpected\n%s", markdown, expected)
+	}
+
+	csv := render.Render(resp, render.Options{Format: render.CSV, Columns: []string{"id", "amount", "name"}, MaxRows: 2})
+	if expected := "id,amount,name\n1,12.50,Alice\n2,,Bob | Co\n"; csv != expected {
+		t.Errorf("CSV = %q, expected %q", csv, expected)
+	}
+
+	if got := render.Table(&utils.Response{Data: map[string]any{"status": "ok"}}); !strings.Contains(got, "status\n------\nok\n(1 row)") {
+		t.Errorf("Expected a single object to render as a row, got\n%s", got)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	summary := render.Summary(ordersResponse())
+	if summary.Rows != 3 || summary.TotalCount != 10 || len(summary.Columns) != 4 {
+		t.Fatalf("Unexpected summary %+v", summary)
+	}
+
+	amount, created := summary.Columns[0], summary.Columns[1]
+	if amount.Type != render.TypeNumber || amount.Count != 2 || amount.Nulls != 1 || amount.Min != 7.0 || amount.Max != 12.5 || amount.Mean != 9.75 {
+		t.Errorf("Unexpected amount summary %+v", amount)
+	}
+	if created.Type != render.TypeTime || created.Distinct != 3 {
+		t.Errorf("Unexpected created summary %+v", created)
+	}
+	if text := summary.String(); !strings.HasPrefix(text, "3 rows of 10\n") || !strings.Contains(text, "amount   number") {
+		t.Errorf("Unexpected summary text\n%s", text)
+	}
+}
@@ -0,0 +1,179 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Column types reported by ColumnSummary.
+const (
+	TypeNumber = "number"
+	TypeString = "string"
+	TypeBool   = "bool"
+	TypeTime   = "time" // Strings holding RFC 3339 timestamps or dates
+	TypeObject = "object"
+	TypeMixed  = "mixed"
+	TypeNull   = "null" // Only NULL values
+)
+
+// ColumnSummary describes the values of a column.
+type ColumnSummary struct {
+	Name     string
+	Type     string
+	Count    int // Non-NULL values
+	Nulls    int
+	Distinct int // Distinct non-NULL values
+	// Min and Max are the smallest and largest values of number (float64), time
+	// (time.Time) and string columns; nil for other types.
+	Min, Max any
+	Mean     float64 // Numbers only
+}
+
+// ResultSummary describes the rows of a response. String renders it as a table,
+// one line per column.
+type ResultSummary struct {
+	Rows       int
+	TotalCount int64         // Total matching rows reported by the server, -1 if unknown
+	Duration   time.Duration // Client-side duration of the query
+	Columns    []ColumnSummary
+}
+
+// Summary computes basic statistics on the columns of a response.
+func Summary(resp *utils.Response) *ResultSummary {
+	rows := rowsOf(resp)
+	summary := &ResultSummary{Rows: len(rows), TotalCount: -1}
+	if resp != nil {
+		summary.TotalCount, summary.Duration = resp.TotalCount, resp.Duration
+	}
+	for _, column := range columnsOf(rows) {
+		summary.Columns = append(summary.Columns, summarize(column, rows))
+	}
+	return summary
+}
+
+func summarize(column string, rows []map[string]any) ColumnSummary {
+	s := ColumnSummary{Name: column, Type: TypeNull}
+	distinct := map[string]bool{}
+	sum := 0.0
+	for _, row := range rows {
+		value := row[column]
+		if value == nil {
+			s.Nulls++
+			continue
+		}
+		s.Count++
+		distinct[formatCell(value)] = true
+
+		kind, comparable := classify(value)
+		switch {
+		case s.Type == TypeNull:
+			s.Type = kind
+		case s.Type != kind:
+			s.Type = TypeMixed
+		}
+		if n, ok := comparable.(float64); ok {
+			sum += n
+		}
+		if comparable != nil {
+			if s.Min == nil || less(comparable, s.Min) {
+				s.Min = comparable
+			}
+			if s.Max == nil || less(s.Max, comparable) {
+				s.Max = comparable
+			}
+		}
+	}
+	s.Distinct = len(distinct)
+	if s.Type == TypeNumber && s.Count > 0 {
+		s.Mean = sum / float64(s.Count)
+	}
+	if s.Type != TypeNumber && s.Type != TypeTime && s.Type != TypeString {
+		s.Min, s.Max = nil, nil
+	}
+	return s
+}
+
+// timeLayouts are the layouts of the strings detected as times.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02"}
+
+// classify returns the type of a value, and the value to compare it with others
+// of its type (nil when not comparable).
+func classify(value any) (string, any) {
+	if n, ok := number(value); ok {
+		return TypeNumber, n
+	}
+	switch v := value.(type) {
+	case string:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return TypeTime, t
+			}
+		}
+		return TypeString, v
+	case bool:
+		return TypeBool, nil
+	case map[string]any, []any:
+		return TypeObject, nil
+	}
+	return TypeMixed, nil
+}
+
+// less compares two values returned by classify; values of different types are not ordered.
+func less(a, b any) bool {
+	switch a := a.(type) {
+	case float64:
+		b, ok := b.(float64)
+		return ok && a < b
+	case time.Time:
+		b, ok := b.(time.Time)
+		return ok && a.Before(b)
+	case string:
+		b, ok := b.(string)
+		return ok && a < b
+	}
+	return false
+}
+
+// String renders the summary as a text table.
+func (s *ResultSummary) String() string {
+	columns := []string{"column", "type", "non_null", "nulls", "distinct", "min", "max", "mean"}
+	cells := make([][]string, len(s.Columns))
+	for i, c := range s.Columns {
+		mean := ""
+		if c.Type == TypeNumber {
+			mean = strconv.FormatFloat(c.Mean, 'g', 6, 64)
+		}
+		cells[i] = []string{
+			c.Name, c.Type, strconv.Itoa(c.Count), strconv.Itoa(c.Nulls), strconv.Itoa(c.Distinct),
+			truncate(summaryValue(c.Min), defaultMaxWidth), truncate(summaryValue(c.Max), defaultMaxWidth), mean,
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d rows", s.Rows)
+	if s.TotalCount > int64(s.Rows) {
+		fmt.Fprintf(&b, " of %d", s.TotalCount)
+	}
+	if s.Duration > 0 {
+		fmt.Fprintf(&b, " in %s", s.Duration.Round(time.Millisecond))
+	}
+	b.WriteString("\n\n")
+	_ = writeText(&b, columns, cells, []bool{false, false, true, true, true, false, false, true}, "")
+	return b.String()
+}
+
+func summaryValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	}
+	return fmt.Sprint(value)
+}
@@ -3,23 +3,104 @@ package sdk
 import (
 	"bytes"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"math"
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
 )
 
+const (
+	// requestIDHeader carries the correlation ID of a request.
+	requestIDHeader = "X-Request-ID"
+	// nextCursorHeader carries the server continuation token of a paginated query.
+	nextCursorHeader = "X-Next-Cursor"
+)
+
 func (c *Client) do(ctx context.Context, method, url string, body []byte) (*utils.Response, error) {
+	payload, err := c.jsonBody(method, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.doRequest(ctx, method, url, payload, nil)
+}
+
+// responseStream receives the response of a download, with its body left unread.
+type responseStream struct {
+	resp *http.Response
+}
+
+// doRequest sends a request with any body (nil for none), through the retries,
+// history, metrics and response transformers of the client. With a stream, the
+// body of a successful response is handed to it instead of being decoded.
+func (c *Client) doRequest(ctx context.Context, method, url string, body *requestBody, stream *responseStream) (*utils.Response, error) {
+	if c.initErr != nil {
+		return nil, c.initErr
+	}
+	if err := c.lifecycle.err(); err != nil {
+		return nil, err
+	}
+
+	// Reuse the caller's request ID if any, so retries share the same ID
+	requestID := utils.HeadersFromContext(ctx).Get(requestIDHeader)
+	if requestID == "" {
+		requestID = c.headers.Get(requestIDHeader)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	ctx = utils.ContextWithHeaders(ctx, http.Header{requestIDHeader: {requestID}})
+	ctx = c.withIdempotencyKey(ctx, method)
+
+	start := time.Now()
+	attempts := 0
+	resp, err := c.doWithRetries(ctx, method, url, body, stream, &attempts)
+	if c.history != nil {
+		c.history.record(newHistoryEntry(method, url, start, attempts, requestID, resp, err))
+	}
+	c.metrics.recordRequest(method, time.Since(start), attempts, err)
+	if resp != nil {
+		resp.Duration = time.Since(start)
+		if resp.RequestID == "" {
+			resp.RequestID = requestID
+		}
+	}
+	if err == nil && stream == nil {
+		err = c.transformResponse(ctx, method, url, resp)
+	}
+	if err != nil {
+		return resp, &utils.RequestError{RequestID: requestID, Err: err}
+	}
+	return resp, nil
+}
+
+// doWithRetries executes the request, retrying transport failures and 5xx responses.
+// Retries stop early when the context deadline leaves no time for another attempt,
+// or when the retry budget of the client is spent. attempts counts the requests sent.
+func (c *Client) doWithRetries(ctx context.Context, method, url string, body *requestBody, stream *responseStream, attempts *int) (*utils.Response, error) {
 	var lastErr error
 	var lastResp *utils.Response
+	var lastAttempt time.Duration
 
+	c.retryBudget.recordRequest()
 	for i := 0; i <= c.config.MaxRetries; i++ {
 		if i > 0 {
+			// A streamed body already consumed cannot be sent again
+			if body != nil && !body.replayable {
+				return retriesStopped("retry skipped, the request body cannot be replayed", lastResp, lastErr)
+			}
 			delay := time.Duration(math.Pow(2, float64(i-1))*100) * time.Millisecond
+			// An attempt is expected to last as long as the previous one
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay+lastAttempt {
+				return retriesStopped("retry skipped, not enough time left before the deadline", lastResp, lastErr)
+			}
+			if !c.retryBudget.allowRetry() {
+				return retriesStopped("retry budget exhausted", lastResp, lastErr)
+			}
 			// Respect context cancellation during backoff
 			select {
 			case <-time.After(delay):
@@ -28,38 +109,67 @@ func (c *Client) do(ctx context.Context, method, url string, body []byte) (*util
 			}
 		}
 
-		req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(body))
+		req, err := body.newRequest(ctx, method, url)
 		if err != nil {
-			return nil, fmt.Errorf("%w: %w", utils.ErrInvalidRequest, err)
+			return nil, err
 		}
 
-		// If no token is set, try to get one from Keycloak
-		if c.config.Token == "" {
-			if c.isKeycloakAuthMethodConfigured() {
-				token, err := c.refreshToken(ctx)
-				if err != nil {
-					return nil, fmt.Errorf("failed to obtain token: %w", err)
-				}
-				c.config.Token = token
-			} else {
-				return nil, utils.ErrInvalidConfiguration
-			}
+		if err := c.breaker.allow(req.URL.Host); err != nil {
+			return nil, err
 		}
 
-		req.Header.Set("Authorization", "Bearer "+c.config.Token)
-		if body != nil {
-			req.Header.Set("Content-Type", "application/json")
+		token, err := c.accessToken(ctx)
+		if err != nil {
+			return nil, err
 		}
 
-		resp, err := c.httpClient.Do(req)
+		c.applyHeaders(ctx, req)
+		req.Header.Set("Authorization", "Bearer "+token)
+		body.setHeaders(req)
+		if !c.config.DisableResponseCompression && stream == nil {
+			req.Header.Set("Accept-Encoding", "gzip")
+		}
+
+		httpClient := c.httpClient
+		if stream != nil {
+			// Downloads can outlast the request timeout: reuse the transport only
+			httpClient = &http.Client{Transport: c.httpClient.Transport}
+		}
+		attemptStart := time.Now()
+		*attempts++
+		resp, err := httpClient.Do(req)
+		lastAttempt = time.Since(attemptStart)
+		// A request abandoned by the caller says nothing about the health of the host
+		if ctx.Err() == nil {
+			c.breaker.record(req.URL.Host, err == nil && resp.StatusCode < 500)
+		}
 		if err != nil {
 			lastErr = err
 			continue
 		}
 
+		if stream != nil && resp.StatusCode < 300 {
+			stream.resp = resp
+			return &utils.Response{
+				Status:     utils.StatusOK,
+				HTTPCode:   resp.StatusCode,
+				RequestID:  resp.Header.Get(requestIDHeader),
+				TotalCount: -1,
+			}, nil
+		}
+
 		// Read body and close immediately (not with defer in loop!)
-		respBody, err := io.ReadAll(resp.Body)
+		respBody, err := readResponseBody(resp, c.config.MaxResponseBytes)
 		_ = resp.Body.Close() // Always close, even if ReadAll fails (error ignored - we already have the body)
+		if errors.Is(err, utils.ErrResponseTooLarge) {
+			// The same query would return the same body, retrying is pointless
+			return &utils.Response{
+				Status:    utils.StatusError,
+				Error:     err.Error(),
+				HTTPCode:  resp.StatusCode,
+				RequestID: resp.Header.Get(requestIDHeader),
+			}, err
+		}
 		if err != nil {
 			lastErr = err
 			continue
@@ -67,9 +177,15 @@ func (c *Client) do(ctx context.Context, method, url string, body []byte) (*util
 
 		if resp.StatusCode >= 300 {
 			lastResp = &utils.Response{
-				Status:   utils.StatusError,
-				Error:    string(respBody),
-				HTTPCode: resp.StatusCode,
+				Status:    utils.StatusError,
+				Error:     string(respBody),
+				HTTPCode:  resp.StatusCode,
+				RequestID: resp.Header.Get(requestIDHeader),
+			}
+
+			// A sleeping datadock will not answer until woken up, retrying is pointless
+			if isDataDockAsleep(resp.StatusCode, respBody) {
+				return lastResp, fmt.Errorf("%w: %s", utils.ErrDataDockAsleep, string(respBody))
 			}
 
 			if resp.StatusCode == http.StatusUnauthorized {
@@ -78,6 +194,14 @@ func (c *Client) do(ctx context.Context, method, url string, body []byte) (*util
 						continue // Retry with the new token
 					}
 				}
+				if c.config.VerifyTokens && token != "" {
+					if _, err := c.VerifyToken(ctx, token, VerifyOptions{}); err != nil {
+						return lastResp, fmt.Errorf("%w: %w", utils.ErrAuthenticationFailed, err)
+					}
+				}
+				if expiry, ok := tokenExpiry(token); ok && time.Now().After(expiry) {
+					return lastResp, fmt.Errorf("%w: %w", utils.ErrAuthenticationFailed, utils.ErrTokenExpired)
+				}
 				return lastResp, utils.ErrAuthenticationFailed
 			}
 
@@ -98,22 +222,93 @@ func (c *Client) do(ctx context.Context, method, url string, body []byte) (*util
 			continue
 		}
 
+		// Empty bodies (HEAD, 204 No Content) carry no data
 		var parsedBody any
-		if err := json.Unmarshal(respBody, &parsedBody); err != nil {
-			lastErr = fmt.Errorf("failed to parse response body: %w", err)
-			continue
+		if len(bytes.TrimSpace(respBody)) > 0 {
+			if parsedBody, err = utils.DecodeJSON(respBody, c.config.UseNumber); err != nil {
+				lastErr = fmt.Errorf("failed to parse response body: %w", err)
+				continue
+			}
 		}
 
-		return &utils.Response{
-			Status:   utils.StatusOK,
-			Data:     parsedBody,
-			HTTPCode: resp.StatusCode,
-		}, nil
+		result := &utils.Response{
+			Status:       utils.StatusOK,
+			Data:         parsedBody,
+			HTTPCode:     resp.StatusCode,
+			RequestID:    resp.Header.Get(requestIDHeader),
+			NextCursor:   resp.Header.Get(nextCursorHeader),
+			TotalCount:   utils.TotalCountFromHeaders(resp.Header),
+			ServerTiming: utils.ParseServerTiming(resp.Header),
+		}
+		if rows, ok := parsedBody.([]any); ok {
+			result.RowCount = len(rows)
+		}
+		return result, nil
 	}
 
+	return retriesStopped("max retries exceeded", lastResp, lastErr)
+}
+
+// retriesStopped returns the outcome of the last attempt of a request that will not
+// be retried anymore.
+func retriesStopped(reason string, lastResp *utils.Response, lastErr error) (*utils.Response, error) {
 	if lastResp != nil {
-		return lastResp, fmt.Errorf("max retries exceeded, last response was: %s", lastResp.Error)
+		return lastResp, fmt.Errorf("%s, last response was: %s", reason, lastResp.Error)
 	}
+	return nil, fmt.Errorf("%s, last error: %w", reason, lastErr)
+}
+
+// accessToken returns the configured token. If none is set, it reuses the token of the
+// configured TokenStore while it is valid, or obtains one from Keycloak.
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	if c.config.Token != "" {
+		return c.config.Token, nil
+	}
+	if !c.isKeycloakAuthMethodConfigured() {
+		return "", utils.ErrInvalidConfiguration
+	}
+	// Before the token store lookup, whose key depends on the Keycloak endpoint
+	authMutex.Lock()
+	err := c.resolveEndpoints(ctx, false)
+	authMutex.Unlock()
+	if err != nil {
+		return "", fmt.Errorf("failed to obtain token: %w", err)
+	}
+	if token := c.loadStoredToken(ctx); token != "" {
+		c.config.Token = token
+		return token, nil
+	}
+	token, err := c.refreshToken(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to obtain token: %w", err)
+	}
+	c.config.Token = token
+	return token, nil
+}
 
-	return nil, fmt.Errorf("max retries exceeded, last error: %w", lastErr)
+// applyHeaders sets the User-Agent, client-wide headers and per-request headers
+// carried by the context. Later sources override earlier ones.
+func (c *Client) applyHeaders(ctx context.Context, req *http.Request) {
+	userAgent := c.config.UserAgent
+	if userAgent == "" {
+		userAgent = utils.DefaultUserAgent()
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	for key, values := range c.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	for key, values := range utils.HeadersFromContext(ctx) {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	c.applyQueryTags(req)
+}
+
+// isDataDockAsleep reports whether an error response means the target datadock is sleeping.
+func isDataDockAsleep(statusCode int, body []byte) bool {
+	switch statusCode {
+	case http.StatusServiceUnavailable, http.StatusConflict, http.StatusLocked:
+		return bytes.Contains(bytes.ToLower(body), []byte("sleep"))
+	}
+	return false
 }
@@ -0,0 +1,327 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+	"sync"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// requestBody is the body of a request, opened anew for each attempt.
+type requestBody struct {
+	contentType     string
+	contentEncoding string
+	length          int64 // -1 when unknown: the body is sent chunked
+	open            func() (io.ReadCloser, error)
+	replayable      bool // open can be called for each attempt
+}
+
+// jsonBody returns the body of a JSON request, gzipped above the compression
+// threshold of the client. A nil body sends no body.
+func (c *Client) jsonBody(method string, body []byte) (*requestBody, error) {
+	if body == nil {
+		return nil, nil
+	}
+	payload := &requestBody{contentType: "application/json", replayable: true}
+	if c.shouldCompressRequest(method, body) {
+		gzipped, err := gzipBody(body)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", utils.ErrInvalidRequest, err)
+		}
+		body, payload.contentEncoding = gzipped, "gzip"
+	}
+	payload.length = int64(len(body))
+	payload.open = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+	return payload, nil
+}
+
+// readerBody returns the body streaming r. Seekable readers are rewound for retries;
+// other readers are sent once.
+func readerBody(contentType string, r io.Reader, length int64) *requestBody {
+	payload := &requestBody{contentType: contentType, length: length}
+	if seeker, ok := r.(io.Seeker); ok {
+		if start, err := seeker.Seek(0, io.SeekCurrent); err == nil {
+			payload.replayable = true
+			payload.open = func() (io.ReadCloser, error) {
+				if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+					return nil, fmt.Errorf("failed to rewind request body: %w", err)
+				}
+				return io.NopCloser(r), nil
+			}
+			return payload
+		}
+	}
+	var once sync.Once
+	payload.open = func() (io.ReadCloser, error) {
+		err := errors.New("request body already sent")
+		once.Do(func() { err = nil })
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(r), nil
+	}
+	return payload
+}
+
+// newRequest builds the request of one attempt. A nil body sends no body.
+func (b *requestBody) newRequest(ctx context.Context, method, url string) (*http.Request, error) {
+	if b == nil {
+		req, err := http.NewRequestWithContext(ctx, method, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", utils.ErrInvalidRequest, err)
+		}
+		return req, nil
+	}
+	body, err := b.open()
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		_ = body.Close()
+		return nil, fmt.Errorf("%w: %w", utils.ErrInvalidRequest, err)
+	}
+	req.ContentLength = b.length
+	if b.length == 0 {
+		req.Body = http.NoBody
+	}
+	if b.replayable {
+		req.GetBody = b.open
+	}
+	return req, nil
+}
+
+func (b *requestBody) setHeaders(req *http.Request) {
+	if b == nil {
+		return
+	}
+	if b.contentType != "" {
+		req.Header.Set("Content-Type", b.contentType)
+	}
+	if b.contentEncoding != "" {
+		req.Header.Set("Content-Encoding", b.contentEncoding)
+	}
+}
+
+// RequestBuilder sends a request with a body other than JSON, such as a file
+// upload. Build it with Client.Request.
+type RequestBuilder struct {
+	client   *Client
+	method   string
+	endpoint string
+	headers  http.Header
+	body     *requestBody
+	err      error
+}
+
+// Request starts a request to an endpoint of the platform, sent like the requests
+// of the builders: authenticated, retried and recorded in the history.
+//
+// Example:
+//
+//	archive, _ := os.Open("backup.tar.gz")
+//	defer archive.Close()
+//	resp, err := client.Request("POST", endpoint).
+//	    Multipart(sdk.NewMultipartForm().Field("name", "backup").File("archive", "backup.tar.gz", archive)).
+//	    Do(ctx)
+func (c *Client) Request(method, endpoint string) *RequestBuilder {
+	return &RequestBuilder{client: c, method: method, endpoint: endpoint, headers: http.Header{}}
+}
+
+// Header sets a header of the request.
+func (r *RequestBuilder) Header(key, value string) *RequestBuilder {
+	r.headers.Set(key, value)
+	return r
+}
+
+// JSON sets a JSON body, encoded like the bodies of the builders.
+func (r *RequestBuilder) JSON(value any) *RequestBuilder {
+	body, err := utils.EncodeBody(r.client.config, value)
+	if err != nil {
+		r.err = err
+		return r
+	}
+	r.body, r.err = r.client.jsonBody(r.method, body)
+	return r
+}
+
+// Body streams the body from reader. length is the body size, or -1 when unknown
+// (the body is then sent with chunked transfer encoding). Seekable readers, such
+// as files, are rewound when the request is retried; other readers are sent once
+// and the request is not retried.
+func (r *RequestBuilder) Body(contentType string, reader io.Reader, length int64) *RequestBuilder {
+	r.body = readerBody(contentType, reader, length)
+	return r
+}
+
+// Multipart sets a multipart/form-data body.
+func (r *RequestBuilder) Multipart(form *MultipartForm) *RequestBuilder {
+	r.body = form.body()
+	return r
+}
+
+// Do sends the request.
+func (r *RequestBuilder) Do(ctx context.Context) (*utils.Response, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if len(r.headers) > 0 {
+		ctx = utils.ContextWithHeaders(ctx, r.headers)
+	}
+	return r.client.doRequest(ctx, r.method, r.endpoint, r.body, nil)
+}
+
+// formPart is a field or a file of a multipart form.
+type formPart struct {
+	name     string
+	fileName string // Empty for fields
+	value    string
+	content  io.Reader
+}
+
+// MultipartForm is a multipart/form-data body. Files are streamed, not loaded in
+// memory. The form is sent with its length and can be retried when every file is
+// seekable (e.g. an *os.File); otherwise it is sent chunked, once.
+type MultipartForm struct {
+	boundary string
+	parts    []formPart
+}
+
+// NewMultipartForm returns an empty form.
+func NewMultipartForm() *MultipartForm {
+	boundary := make([]byte, 16)
+	_, _ = rand.Read(boundary)
+	return &MultipartForm{boundary: hex.EncodeToString(boundary)}
+}
+
+// Field adds a text field.
+func (f *MultipartForm) Field(name, value string) *MultipartForm {
+	f.parts = append(f.parts, formPart{name: name, value: value})
+	return f
+}
+
+// File adds a file field, with its content read from content.
+func (f *MultipartForm) File(name, fileName string, content io.Reader) *MultipartForm {
+	f.parts = append(f.parts, formPart{name: name, fileName: fileName, content: content})
+	return f
+}
+
+// ContentType returns the Content-Type of the form, with its boundary.
+func (f *MultipartForm) ContentType() string {
+	return "multipart/form-data; boundary=" + f.boundary
+}
+
+// write writes the form, copying the file contents with copyFile.
+func (f *MultipartForm) write(w io.Writer, copyFile func(io.Writer, io.Reader) error) error {
+	writer := multipart.NewWriter(w)
+	if err := writer.SetBoundary(f.boundary); err != nil {
+		return err
+	}
+	for _, part := range f.parts {
+		if part.fileName == "" {
+			if err := writer.WriteField(part.name, part.value); err != nil {
+				return err
+			}
+			continue
+		}
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, escapeQuotes(part.name), escapeQuotes(part.fileName)))
+		header.Set("Content-Type", "application/octet-stream")
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		if err := copyFile(partWriter, part.content); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// body returns the request body streaming the form through a pipe.
+func (f *MultipartForm) body() *requestBody {
+	payload := &requestBody{contentType: f.ContentType(), length: -1}
+
+	// With seekable files, the length is known and the form can be sent again
+	starts := make(map[int]int64)
+	sizes := int64(0)
+	seekable := true
+	for i, part := range f.parts {
+		if part.fileName == "" {
+			continue
+		}
+		seeker, ok := part.content.(io.Seeker)
+		if !ok {
+			seekable = false
+			break
+		}
+		start, err := seeker.Seek(0, io.SeekCurrent)
+		end, endErr := seeker.Seek(0, io.SeekEnd)
+		if err != nil || endErr != nil {
+			seekable = false
+			break
+		}
+		if _, err := seeker.Seek(start, io.SeekStart); err != nil {
+			seekable = false
+			break
+		}
+		starts[i] = start
+		sizes += end - start
+	}
+	if seekable {
+		var overhead countingWriter
+		_ = f.write(&overhead, func(io.Writer, io.Reader) error { return nil })
+		payload.length = int64(overhead) + sizes
+		payload.replayable = true
+	}
+
+	var once sync.Once
+	payload.open = func() (io.ReadCloser, error) {
+		if seekable {
+			for i, start := range starts {
+				if _, err := f.parts[i].content.(io.Seeker).Seek(start, io.SeekStart); err != nil {
+					return nil, fmt.Errorf("failed to rewind %s: %w", f.parts[i].fileName, err)
+				}
+			}
+		} else {
+			err := errors.New("request body already sent")
+			once.Do(func() { err = nil })
+			if err != nil {
+				return nil, err
+			}
+		}
+		reader, writer := io.Pipe()
+		go func() {
+			writer.CloseWithError(f.write(writer, func(w io.Writer, r io.Reader) error {
+				_, err := io.Copy(w, r)
+				return err
+			}))
+		}()
+		return reader, nil
+	}
+	return payload
+}
+
+// countingWriter counts the bytes written to it.
+type countingWriter int64
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+func escapeQuotes(s string) string {
+	return quoteEscaper.Replace(s)
+}
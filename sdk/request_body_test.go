@@ -0,0 +1,94 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// uploadServer fails the first request of each test with a 503, then records the uploads.
+type uploadServer struct {
+	mu            sync.Mutex
+	requests      int
+	fields        map[string]string
+	contentLength int64
+	body          string
+}
+
+func newUploadServer(t *testing.T) (*uploadServer, *Client) {
+	u := &uploadServer{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		u.requests++
+		if u.requests == 1 {
+			_, _ = io.Copy(io.Discard, r.Body)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		u.contentLength = r.ContentLength
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				t.Errorf("ParseMultipartForm() error = %v", err)
+			}
+			u.fields = map[string]string{"name": r.FormValue("name")}
+			for field, files := range r.MultipartForm.File {
+				file, _ := files[0].Open()
+				content, _ := io.ReadAll(file)
+				u.fields[field] = files[0].Filename + ":" + string(content)
+			}
+		} else {
+			raw, _ := io.ReadAll(r.Body)
+			u.body = string(raw)
+		}
+		_, _ = w.Write([]byte(`{"id": "upload-1"}`))
+	}))
+	t.Cleanup(server.Close)
+	return u, NewClient(utils.Configuration{BaseURL: server.URL, Token: "t", MaxRetries: 2})
+}
+
+func TestRequestBuilder_MultipartRetried(t *testing.T) {
+	u, client := newUploadServer(t)
+	archive := bytes.NewReader([]byte("archive content"))
+
+	form := NewMultipartForm().Field("name", "backup").File("archive", "backup.tar.gz", archive)
+	resp, err := client.Request("POST", client.config.BaseURL+"/archives").Multipart(form).Do(context.Background())
+	if err != nil {
+		t.Fatalf("Do() unexpected error = %v", err)
+	}
+	if data, _ := resp.GetDataAsMap(); data["id"] != "upload-1" {
+		t.Errorf("Unexpected response %v", resp.Data)
+	}
+	if u.requests != 2 || u.fields["name"] != "backup" || u.fields["archive"] != "backup.tar.gz:archive content" {
+		t.Errorf("Expected the form to be sent again after the 503, got %d requests and %v", u.requests, u.fields)
+	}
+	if u.contentLength <= int64(len("archive content")) {
+		t.Errorf("Expected the form to be sent with its length, got %d", u.contentLength)
+	}
+}
+
+func TestRequestBuilder_StreamNotReplayed(t *testing.T) {
+	u, client := newUploadServer(t)
+
+	// A reader that cannot seek is sent once: the 503 is not retried
+	stream := io.MultiReader(strings.NewReader("line 1\n"), strings.NewReader("line 2\n"))
+	_, err := client.Request("PUT", client.config.BaseURL+"/logs").Body("text/plain", stream, -1).Do(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "cannot be replayed") || u.requests != 1 {
+		t.Fatalf("Expected the request not to be retried, got %v after %d requests", err, u.requests)
+	}
+
+	// Seekable readers are rewound, chunked when the length is unknown
+	if _, err := client.Request("PUT", client.config.BaseURL+"/logs").Body("text/plain", strings.NewReader("line 1\n"), -1).Do(context.Background()); err != nil {
+		t.Fatalf("Do() unexpected error = %v", err)
+	}
+	if u.body != "line 1\n" || u.contentLength != -1 {
+		t.Errorf("Unexpected upload %q with length %d", u.body, u.contentLength)
+	}
+}
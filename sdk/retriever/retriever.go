@@ -0,0 +1,144 @@
+// Package retriever adapts Hyperfluid document search to the retriever
+// interfaces of LLM frameworks.
+//
+// A Retriever runs a configured search or hybrid search with the query of the
+// caller and returns the hits as documents:
+//
+//	r := retriever.FromHybridSearch(client.HybridSearch().
+//	    Catalog("docs").Schema("public").Table("articles").
+//	    Columns("title", "content").Limit(5), retriever.Options{})
+//	docs, err := r.GetRelevantDocuments(ctx, "how do I rotate credentials?")
+//
+// Frameworks define their own document types. As converts the documents, so the
+// result implements the framework interface without this package depending on it.
+// With langchaingo's schema.Retriever:
+//
+//	var _ schema.Retriever = retriever.As(r, func(d retriever.Document) schema.Document {
+//	    return schema.Document{PageContent: d.PageContent, Metadata: d.Metadata, Score: d.Score}
+//	})
+package retriever
+
+import (
+	"context"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/fluent"
+)
+
+// Document is a search hit, shaped like the documents of LLM frameworks.
+type Document struct {
+	PageContent string
+	Metadata    map[string]any
+	Score       float32
+}
+
+// Options configures a Retriever.
+type Options struct {
+	// MinScore drops the hits scoring below it (default: keep every hit).
+	MinScore float32
+	// Content extracts the page content of a record (default: its Content field).
+	Content func(record fluent.DocumentRecord) string
+}
+
+// Retriever returns the documents relevant to a query.
+type Retriever struct {
+	search func(ctx context.Context, query string) ([]Document, error)
+	opts   Options
+}
+
+// FromSearch returns a retriever running the full-text search with the query.
+// The search must be complete except for its query.
+func FromSearch(search *fluent.SearchBuilder, opts Options) *Retriever {
+	r := &Retriever{opts: opts}
+	r.search = func(ctx context.Context, query string) ([]Document, error) {
+		results, err := search.Query(query).Execute(ctx)
+		if err != nil {
+			return nil, err
+		}
+		docs := make([]Document, 0, len(results.Results))
+		for _, result := range results.Results {
+			docs = append(docs, r.document(result.Record, result.Score, nil))
+		}
+		return docs, nil
+	}
+	return r
+}
+
+// FromHybridSearch returns a retriever running the hybrid search with the query
+// as both the full-text and the vector query. The search must be complete except
+// for its queries.
+func FromHybridSearch(search *fluent.HybridSearchBuilder, opts Options) *Retriever {
+	r := &Retriever{opts: opts}
+	r.search = func(ctx context.Context, query string) ([]Document, error) {
+		results, err := search.FTSQuery(query).VectorQuery(query).Execute(ctx)
+		if err != nil {
+			return nil, err
+		}
+		docs := make([]Document, 0, len(results.Results))
+		for _, result := range results.Results {
+			docs = append(docs, r.document(result.Record, result.Score, result.Sources))
+		}
+		return docs, nil
+	}
+	return r
+}
+
+// GetRelevantDocuments returns the documents matching the query, best first.
+func (r *Retriever) GetRelevantDocuments(ctx context.Context, query string) ([]Document, error) {
+	docs, err := r.search(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	relevant := docs[:0]
+	for _, doc := range docs {
+		if doc.Score >= r.opts.MinScore {
+			relevant = append(relevant, doc)
+		}
+	}
+	return relevant, nil
+}
+
+// document converts a search hit. The record fields other than the content are
+// kept in the metadata, with the search sources of hybrid hits.
+func (r *Retriever) document(record fluent.DocumentRecord, score float64, sources []string) Document {
+	content := record.Content
+	if r.opts.Content != nil {
+		content = r.opts.Content(record)
+	}
+	metadata := map[string]any{
+		"name":       record.Name,
+		"summary":    record.Summary,
+		"categories": record.Categories,
+		"hf_context": record.HfContext,
+		"source":     record.OriginalFile.OriginalFilePath,
+		"data_dock":  record.OriginalFile.SourceDataDockID,
+		"file_name":  record.OriginalFile.SourceFileName,
+	}
+	if sources != nil {
+		metadata["search_sources"] = sources
+	}
+	return Document{PageContent: content, Metadata: metadata, Score: float32(score)}
+}
+
+// Adapter is a retriever returning the document type of a framework.
+type Adapter[D any] struct {
+	retriever *Retriever
+	convert   func(Document) D
+}
+
+// As adapts the retriever to the document type D of a framework.
+func As[D any](r *Retriever, convert func(Document) D) *Adapter[D] {
+	return &Adapter[D]{retriever: r, convert: convert}
+}
+
+// GetRelevantDocuments returns the documents matching the query, converted to D.
+func (a *Adapter[D]) GetRelevantDocuments(ctx context.Context, query string) ([]D, error) {
+	docs, err := a.retriever.GetRelevantDocuments(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	converted := make([]D, len(docs))
+	for i, doc := range docs {
+		converted[i] = a.convert(doc)
+	}
+	return converted, nil
+}
@@ -0,0 +1,75 @@
+package retriever
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/fluent"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// fakeSearch answers every search with the same two hits and records the request.
+type fakeSearch struct {
+	request map[string]any
+}
+
+func (f *fakeSearch) Do(ctx context.Context, method, endpoint string, body []byte) (*utils.Response, error) {
+	if err := json.Unmarshal(body, &f.request); err != nil {
+		return nil, err
+	}
+	var data any
+	err := json.Unmarshal([]byte(`{"results": [
+		{"record": {"name": "rotation.md", "content": "Rotate keys monthly", "original_file": {"original_file_path": "docs/rotation.md"}}, "score": 0.9, "sources": ["fts", "vector"]},
+		{"record": {"name": "misc.md", "content": "Unrelated"}, "score": 0.1, "sources": ["fts"]}
+	]}`), &data)
+	return &utils.Response{Status: utils.StatusOK, Data: data, HTTPCode: 200}, err
+}
+
+func (f *fakeSearch) GetConfig() utils.Configuration {
+	return utils.Configuration{BaseURL: "https://test.example.com", DataDockID: "dd"}
+}
+
+// frameworkDocument stands for the document type of an LLM framework.
+type frameworkDocument struct {
+	Text  string
+	Score float32
+}
+
+func TestRetriever_HybridSearch(t *testing.T) {
+	client := &fakeSearch{}
+	search := fluent.NewHybridSearchBuilder(client).Catalog("docs").Schema("public").Table("articles").Columns("content")
+	r := FromHybridSearch(search, Options{MinScore: 0.5})
+
+	docs, err := r.GetRelevantDocuments(context.Background(), "rotate credentials")
+	if err != nil {
+		t.Fatalf("GetRelevantDocuments() unexpected error = %v", err)
+	}
+	if client.request["fts_query"] != "rotate credentials" || client.request["vector_query"] != "rotate credentials" {
+		t.Errorf("Expected the query to be used for both searches, got %v", client.request)
+	}
+	if len(docs) != 1 || docs[0].PageContent != "Rotate keys monthly" || docs[0].Metadata["source"] != "docs/rotation.md" || docs[0].Score != 0.9 {
+		t.Errorf("Unexpected documents: %+v", docs)
+	}
+
+	adapted, err := As(r, func(d Document) frameworkDocument {
+		return frameworkDocument{Text: d.PageContent, Score: d.Score}
+	}).GetRelevantDocuments(context.Background(), "rotate credentials")
+	if err != nil || len(adapted) != 1 || adapted[0].Text != "Rotate keys monthly" {
+		t.Errorf("Adapter.GetRelevantDocuments() = %+v, %v", adapted, err)
+	}
+}
+
+func TestRetriever_Search(t *testing.T) {
+	client := &fakeSearch{}
+	search := fluent.NewSearchBuilder(client).Catalog("docs").Schema("public").Table("articles").Columns("content")
+	r := FromSearch(search, Options{Content: func(record fluent.DocumentRecord) string { return record.Name }})
+
+	docs, err := r.GetRelevantDocuments(context.Background(), "keys")
+	if err != nil {
+		t.Fatalf("GetRelevantDocuments() unexpected error = %v", err)
+	}
+	if client.request["query"] != "keys" || len(docs) != 2 || docs[1].PageContent != "misc.md" {
+		t.Errorf("Unexpected documents: %+v for request %v", docs, client.request)
+	}
+}
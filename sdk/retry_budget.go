@@ -0,0 +1,95 @@
+package sdk
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// retryBudget caps the retries of a client to a fraction of its requests over a
+// sliding window, so that a degraded platform is not hit by a retry storm.
+// A nil *retryBudget is disabled.
+type retryBudget struct {
+	mu         sync.Mutex
+	ratio      float64
+	minRetries int
+	window     time.Duration
+
+	// Counts of the current and previous windows; the previous one is weighted by
+	// its overlap with the sliding window.
+	start            time.Time
+	requests         int
+	retries          int
+	previousRequests int
+	previousRetries  int
+}
+
+// newRetryBudget returns nil when the retry budget is disabled in the configuration.
+func newRetryBudget(cfg utils.Configuration) *retryBudget {
+	if cfg.RetryBudgetRatio <= 0 {
+		return nil
+	}
+	window := cfg.RetryBudgetWindow
+	if window <= 0 {
+		window = utils.DefaultRetryBudgetWindow
+	}
+	minRetries := cfg.RetryBudgetMinRetries
+	if minRetries <= 0 {
+		minRetries = utils.DefaultRetryBudgetMinRetries
+	}
+	return &retryBudget{
+		ratio:      cfg.RetryBudgetRatio,
+		minRetries: minRetries,
+		window:     window,
+		start:      time.Now(),
+	}
+}
+
+// recordRequest counts a new request (not a retry).
+func (rb *retryBudget) recordRequest() {
+	if rb == nil {
+		return
+	}
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+	rb.rotate(time.Now())
+	rb.requests++
+}
+
+// allowRetry reports whether a retry fits in the budget, and counts it if so.
+func (rb *retryBudget) allowRetry() bool {
+	if rb == nil {
+		return true
+	}
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	now := time.Now()
+	rb.rotate(now)
+	weight := 1 - float64(now.Sub(rb.start))/float64(rb.window)
+	requests := float64(rb.requests) + float64(rb.previousRequests)*weight
+	retries := float64(rb.retries) + float64(rb.previousRetries)*weight
+
+	if retries >= float64(rb.minRetries) && retries >= rb.ratio*requests {
+		return false
+	}
+	rb.retries++
+	return true
+}
+
+// rotate starts a new window when the current one has elapsed.
+func (rb *retryBudget) rotate(now time.Time) {
+	elapsed := now.Sub(rb.start)
+	if elapsed < rb.window {
+		return
+	}
+	if elapsed < 2*rb.window {
+		rb.previousRequests, rb.previousRetries = rb.requests, rb.retries
+		rb.start = rb.start.Add(rb.window)
+	} else {
+		rb.previousRequests, rb.previousRetries = 0, 0
+		rb.start = now
+	}
+	rb.requests, rb.retries = 0, 0
+}
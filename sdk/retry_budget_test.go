@@ -0,0 +1,90 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func failingServer(t *testing.T, attempts *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(attempts, 1)
+		http.Error(w, "unavailable", http.StatusBadGateway)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRetriesStopBeforeDeadline(t *testing.T) {
+	var attempts int32
+	server := failingServer(t, &attempts)
+	client := NewClient(utils.Configuration{BaseURL: server.URL, Token: "token", MaxRetries: 5})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.Do(ctx, "GET", server.URL+"/x", nil)
+
+	if err == nil || !strings.Contains(err.Error(), "deadline") || errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Do() error = %v, want retries skipped before the deadline", err)
+	}
+	// Attempts at 0ms and 100ms; the next one would start at 300ms
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+	if elapsed := time.Since(start); elapsed >= 250*time.Millisecond {
+		t.Errorf("Do() returned after %v, past the deadline", elapsed)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	var attempts int32
+	server := failingServer(t, &attempts)
+	client := NewClient(utils.Configuration{
+		BaseURL:               server.URL,
+		Token:                 "token",
+		MaxRetries:            3,
+		RetryBudgetRatio:      0.1,
+		RetryBudgetMinRetries: 1,
+	})
+
+	_, err := client.Do(context.Background(), "GET", server.URL+"/x", nil)
+	if err == nil || !strings.Contains(err.Error(), "retry budget exhausted") {
+		t.Fatalf("Do() error = %v, want the retry budget to be exhausted", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("expected 1 attempt and 1 retry, got %d attempts", got)
+	}
+}
+
+func TestRetryBudgetWindow(t *testing.T) {
+	budget := newRetryBudget(utils.Configuration{RetryBudgetRatio: 0.5, RetryBudgetMinRetries: 1, RetryBudgetWindow: time.Minute})
+	for range 4 {
+		budget.recordRequest()
+	}
+	if !budget.allowRetry() || !budget.allowRetry() {
+		t.Fatal("expected 2 retries for 4 requests at a 50% ratio")
+	}
+	if budget.allowRetry() {
+		t.Fatal("expected the budget to be spent")
+	}
+
+	// Once the window and the previous one have elapsed, the budget is renewed
+	budget.start = time.Now().Add(-3 * time.Minute)
+	if !budget.allowRetry() {
+		t.Error("expected the budget to be renewed with the window")
+	}
+
+	var disabled *retryBudget
+	disabled.recordRequest()
+	if !disabled.allowRetry() {
+		t.Error("a disabled budget must allow every retry")
+	}
+}
@@ -0,0 +1,107 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// defaultMinIORegion is used with platform credentials when no region is configured.
+const defaultMinIORegion = "us-east-1"
+
+// s3CredentialsMu serializes the lazy loading of platform MinIO credentials.
+var s3CredentialsMu sync.Mutex
+
+// BucketCredentials are the MinIO endpoint and credentials of a harbor bucket.
+type BucketCredentials struct {
+	Endpoint  string `json:"endpoint"`
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
+}
+
+// BucketCredentials fetches the MinIO endpoint and credentials of a bucket from the
+// control plane. An empty harborID means the harbor of the configured datadock.
+//
+// Example:
+//
+//	creds, err := client.BucketCredentials(ctx, harborID, "raw-data")
+func (c *Client) BucketCredentials(ctx context.Context, harborID, bucket string) (*BucketCredentials, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("%w: bucket is required", utils.ErrInvalidRequest)
+	}
+	if harborID == "" {
+		var err error
+		if harborID, err = c.dataDockHarbor(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v1/harbors/%s/buckets/%s/credentials",
+		strings.TrimSuffix(c.controlPlaneBaseURL(ctx), "/"), url.PathEscape(harborID), url.PathEscape(bucket))
+	resp, err := c.Do(ctx, "GET", endpoint, nil)
+	if errors.Is(err, utils.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s: %w", utils.ErrBucketNotFound, bucket, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var creds BucketCredentials
+	raw, _ := json.Marshal(resp.Data)
+	if err := json.Unmarshal(raw, &creds); err != nil || creds.AccessKey == "" || creds.Endpoint == "" {
+		return nil, fmt.Errorf("%w: unexpected bucket credentials response", utils.ErrAPIError)
+	}
+	return &creds, nil
+}
+
+// dataDockHarbor returns the harbor of the configured datadock.
+func (c *Client) dataDockHarbor(ctx context.Context) (string, error) {
+	if c.config.DataDockID == "" {
+		return "", fmt.Errorf("%w: a harbor ID or a datadock ID is required", utils.ErrInvalidConfiguration)
+	}
+	resp, err := c.Do(ctx, "GET", fmt.Sprintf("%s/data-docks/%s", c.config.BaseURL, url.PathEscape(c.config.DataDockID)), nil)
+	if err != nil {
+		return "", err
+	}
+	details, _ := resp.GetDataAsMap()
+	harborID, _ := details["harbor_id"].(string)
+	if harborID == "" {
+		return "", fmt.Errorf("%w: datadock %s has no harbor_id", utils.ErrAPIError, c.config.DataDockID)
+	}
+	return harborID, nil
+}
+
+// ensureS3Credentials fills the MinIO settings from the platform when the
+// configuration names a bucket but holds no access key.
+func (c *Client) ensureS3Credentials() error {
+	s3CredentialsMu.Lock()
+	defer s3CredentialsMu.Unlock()
+	if c.config.MinIOBucket == "" || c.config.MinIOAccessKey != "" {
+		return nil
+	}
+
+	ctx := context.Background()
+	if c.config.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.RequestTimeout)
+		defer cancel()
+	}
+	creds, err := c.BucketCredentials(ctx, c.config.MinIOHarborID, c.config.MinIOBucket)
+	if err != nil {
+		return fmt.Errorf("failed to fetch MinIO credentials of bucket %s: %w", c.config.MinIOBucket, err)
+	}
+
+	c.config.MinIOEndpoint = creds.Endpoint
+	c.config.MinIOAccessKey = creds.AccessKey
+	c.config.MinIOSecretKey = creds.SecretKey
+	if c.config.MinIORegion == "" {
+		c.config.MinIORegion = defaultMinIORegion
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestClient_S3WithPlatformCredentials(t *testing.T) {
+	t.Setenv("AWS_CA_BUNDLE", "")
+	t.Setenv("AWS_CONFIG_FILE", "/dev/null")
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", "/dev/null")
+	t.Setenv("MINIO_USE_OIDC", "false")
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/data-docks/dd-1":
+			_, _ = w.Write([]byte(`{"id": "dd-1", "harbor_id": "h-1"}`))
+		case "/api/v1/harbors/h-1/buckets/raw/credentials":
+			_, _ = w.Write([]byte(`{"endpoint": "https://minio.example.com", "access_key": "ak", "secret_key": "sk"}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{BaseURL: server.URL, Token: "token", DataDockID: "dd-1", MinIOBucket: "raw"})
+	if _, err := client.S3(); err != nil {
+		t.Fatalf("S3() unexpected error = %v", err)
+	}
+	cfg := client.GetConfig()
+	if cfg.MinIOEndpoint != "https://minio.example.com" || cfg.MinIOAccessKey != "ak" || cfg.MinIOSecretKey != "sk" || cfg.MinIORegion != "us-east-1" {
+		t.Errorf("Unexpected MinIO configuration %+v", cfg)
+	}
+
+	// Credentials are fetched once
+	if _, err := client.S3(); err != nil {
+		t.Fatalf("S3() unexpected error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 platform calls, got %d", calls)
+	}
+}
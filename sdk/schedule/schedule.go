@@ -0,0 +1,333 @@
+// Package schedule runs jobs, such as queries refreshing a local cache or
+// exports to S3, on cron expressions inside the current process.
+//
+// A run is skipped when the previous run of the same job is still going, failed
+// attempts are retried according to the retry policy of the job, and every run
+// is reported to the completion callbacks:
+//
+//	s := schedule.New(schedule.Options{
+//	    OnComplete: func(run schedule.Run) {
+//	        if run.Err != nil { log.Printf("%s failed: %v", run.Job, run.Err) }
+//	    },
+//	})
+//	err := s.Add("orders-cache", "*/15 * * * *", schedule.Query(
+//	    client.Catalog("sales").Schema("public").Table("orders").Where("status", "=", "open"),
+//	    func(ctx context.Context, rows []map[string]any) error { cache.Replace(rows); return nil },
+//	), schedule.JobOptions{Retry: schedule.RetryPolicy{MaxAttempts: 3, Backoff: 10 * time.Second}})
+//	go s.Run(ctx)
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/fluent"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// DefaultRetryMaxBackoff caps the delay between two attempts of a run.
+const DefaultRetryMaxBackoff = 5 * time.Minute
+
+// Job is the work run on each activation.
+type Job func(ctx context.Context) error
+
+// RetryPolicy configures the attempts of a run. The zero value makes a single attempt.
+type RetryPolicy struct {
+	MaxAttempts int           // Attempts per run, including the first (default 1)
+	Backoff     time.Duration // Delay before the second attempt, doubled for each next one
+	MaxBackoff  time.Duration // Upper bound of the delay (default 5 minutes)
+}
+
+// JobOptions configures a job.
+type JobOptions struct {
+	Retry RetryPolicy
+	// Timeout bounds each attempt (default: no timeout).
+	Timeout time.Duration
+	// OnComplete is called after each run of the job, after Options.OnComplete.
+	OnComplete func(Run)
+}
+
+// Options configures a Scheduler.
+type Options struct {
+	// Location is the time zone of the cron expressions (default time.Local).
+	Location *time.Location
+	// OnComplete is called after each run of every job, including skipped runs.
+	OnComplete func(Run)
+}
+
+// Run describes a run of a job.
+type Run struct {
+	Job       string
+	Scheduled time.Time // Activation time, or the trigger time for RunNow
+	Started   time.Time
+	Finished  time.Time
+	Attempts  int
+	Skipped   bool  // The previous run was still going
+	Err       error // Error of the last attempt
+}
+
+// Scheduler runs registered jobs on their cron expressions.
+// It is safe for concurrent use.
+type Scheduler struct {
+	opts Options
+	now  func() time.Time
+
+	mu      sync.Mutex
+	jobs    map[string]*entry
+	changed chan struct{} // Wakes Run up when the jobs change
+	wg      sync.WaitGroup
+}
+
+// entry is a registered job.
+type entry struct {
+	name     string
+	schedule *utils.CronSchedule
+	job      Job
+	opts     JobOptions
+	next     time.Time
+	running  bool
+}
+
+// New creates a scheduler. Jobs only run once Run is called.
+func New(opts Options) *Scheduler {
+	if opts.Location == nil {
+		opts.Location = time.Local
+	}
+	return &Scheduler{
+		opts:    opts,
+		now:     time.Now,
+		jobs:    map[string]*entry{},
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// Add registers a job running on the cron expression (see utils.ValidateCron).
+// Job names are unique, and expressions that match no date (e.g. "0 0 31 2 *")
+// are rejected.
+func (s *Scheduler) Add(name, cron string, job Job, opts JobOptions) error {
+	if name == "" || job == nil {
+		return fmt.Errorf("%w: job name and function are required", utils.ErrInvalidRequest)
+	}
+	schedule, err := utils.ParseCron(cron)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.jobs[name]; exists {
+		return fmt.Errorf("%w: job %s is already registered", utils.ErrInvalidRequest, name)
+	}
+	next := schedule.Next(s.now().In(s.opts.Location))
+	if next.IsZero() {
+		return fmt.Errorf("%w: cron expression %q never matches", utils.ErrInvalidRequest, cron)
+	}
+	s.jobs[name] = &entry{
+		name:     name,
+		schedule: schedule,
+		job:      job,
+		opts:     opts,
+		next:     next,
+	}
+	s.notify()
+	return nil
+}
+
+// Remove unregisters a job. A running run of the job is not interrupted.
+func (s *Scheduler) Remove(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.jobs, name)
+	s.notify()
+}
+
+// Jobs returns the names of the registered jobs, sorted.
+func (s *Scheduler) Jobs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Next returns the next activation of a job, or false when it is not registered.
+func (s *Scheduler) Next(name string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.jobs[name]
+	if !ok {
+		return time.Time{}, false
+	}
+	return e.next, true
+}
+
+// RunNow runs a job immediately and waits for the run, for instance to fill a
+// cache at startup. The run is skipped when the job is already running.
+func (s *Scheduler) RunNow(ctx context.Context, name string) (Run, error) {
+	s.mu.Lock()
+	e, ok := s.jobs[name]
+	s.mu.Unlock()
+	if !ok {
+		return Run{}, fmt.Errorf("%w: job %s is not registered", utils.ErrNotFound, name)
+	}
+	run := s.run(ctx, e, s.now())
+	return run, run.Err
+}
+
+// Run starts the due jobs until ctx is done, then waits for the running jobs to
+// return. Jobs receive ctx, so cancelling it also cancels their attempts.
+func (s *Scheduler) Run(ctx context.Context) {
+	defer s.wg.Wait()
+	for {
+		s.mu.Lock()
+		now := s.now()
+		var wake time.Time
+		for _, e := range s.jobs {
+			// A zero next time means the expression matches no date anymore
+			if !e.next.IsZero() && !e.next.After(now) {
+				scheduled := e.next
+				e.next = e.schedule.Next(now.In(s.opts.Location))
+				s.wg.Add(1)
+				go func() {
+					defer s.wg.Done()
+					s.run(ctx, e, scheduled)
+				}()
+			}
+			if !e.next.IsZero() && (wake.IsZero() || e.next.Before(wake)) {
+				wake = e.next
+			}
+		}
+		s.mu.Unlock()
+
+		delay := time.Hour // Nothing scheduled: only wait for changes
+		if !wake.IsZero() {
+			delay = wake.Sub(now)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.changed:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// run runs a job with its retry policy, unless it is already running, and reports the run.
+func (s *Scheduler) run(ctx context.Context, e *entry, scheduled time.Time) Run {
+	run := Run{Job: e.name, Scheduled: scheduled, Started: s.now()}
+
+	s.mu.Lock()
+	run.Skipped = e.running
+	e.running = true
+	s.mu.Unlock()
+
+	if !run.Skipped {
+		run.Attempts, run.Err = attempt(ctx, e.job, e.opts)
+		s.mu.Lock()
+		e.running = false
+		s.mu.Unlock()
+	}
+	run.Finished = s.now()
+
+	if s.opts.OnComplete != nil {
+		s.opts.OnComplete(run)
+	}
+	if e.opts.OnComplete != nil {
+		e.opts.OnComplete(run)
+	}
+	return run
+}
+
+// attempt calls the job until it succeeds, the attempts are exhausted or ctx is done.
+func attempt(ctx context.Context, job Job, opts JobOptions) (int, error) {
+	maxAttempts := max(opts.Retry.MaxAttempts, 1)
+	maxBackoff := opts.Retry.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+
+	backoff := opts.Retry.Backoff
+	var err error
+	for attempts := 1; ; attempts++ {
+		err = callJob(ctx, job, opts.Timeout)
+		if err == nil || attempts == maxAttempts {
+			return attempts, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempts, err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// callJob calls the job once, bounded by the timeout, turning panics into errors
+// so that a faulty job does not stop the process.
+func callJob(ctx context.Context, job Job, timeout time.Duration) (err error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return job(ctx)
+}
+
+// notify wakes Run up to take job changes into account. Callers hold s.mu.
+func (s *Scheduler) notify() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Query returns a job running the query and passing its rows to fn, e.g. to
+// replace the content of a local cache.
+func Query(qb *fluent.QueryBuilder, fn func(ctx context.Context, rows []map[string]any) error) Job {
+	return func(ctx context.Context) error {
+		resp, err := qb.Get(ctx)
+		if err != nil {
+			return err
+		}
+		rows, err := resp.Rows()
+		if err != nil {
+			return err
+		}
+		return fn(ctx, rows)
+	}
+}
+
+// Export returns a job exporting the rows of the query to S3 (see
+// fluent.QueryBuilder.ExportToS3). Each run writes its own objects under
+// "<keyPrefix><UTC start time>/", e.g. "orders/20250301T083000Z/".
+func Export(qb *fluent.QueryBuilder, bucket, keyPrefix string, format fluent.ExportFormat) Job {
+	return func(ctx context.Context) error {
+		prefix := keyPrefix + time.Now().UTC().Format("20060102T150405Z") + "/"
+		_, err := qb.ExportToS3(ctx, bucket, prefix, format)
+		return err
+	}
+}
+
+// Incremental returns a job reading the rows added since the previous run with
+// the reader and passing them to fn (see fluent.IncrementalReader).
+func Incremental(reader *fluent.IncrementalReader, fn func(ctx context.Context, rows []map[string]any) error) Job {
+	return func(ctx context.Context) error {
+		_, err := reader.Run(ctx, fn)
+		return err
+	}
+}
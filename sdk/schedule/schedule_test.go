@@ -0,0 +1,198 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestParseCron_Next(t *testing.T) {
+	paris, _ := time.LoadLocation("Europe/Paris")
+	from := time.Date(2025, 3, 1, 8, 30, 0, 0, paris) // A Saturday
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2025, 3, 1, 8, 45, 0, 0, paris)},
+		{"0 8 * * MON-FRI", time.Date(2025, 3, 3, 8, 0, 0, 0, paris)},
+		{"@daily", time.Date(2025, 3, 2, 0, 0, 0, 0, paris)},
+		{"0 0 1 1 *", time.Date(2026, 1, 1, 0, 0, 0, 0, paris)},
+		{"0 12 15 * 1", time.Date(2025, 3, 3, 12, 0, 0, 0, paris)}, // Day of month or day of week
+		{"30 8 1 3 *", time.Date(2026, 3, 1, 8, 30, 0, 0, paris)},  // Strictly after
+		{"0 0 * * 7", time.Date(2025, 3, 2, 0, 0, 0, 0, paris)},    // 7 is Sunday
+		{"0 0 31 2 *", time.Time{}},
+	}
+	for _, tt := range tests {
+		schedule, err := utils.ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q) unexpected error = %v", tt.expr, err)
+		}
+		if got := schedule.Next(from); !got.Equal(tt.want) {
+			t.Errorf("Next(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+
+	// Hours start on the local hour in zones with a half-hour offset
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	schedule, _ := utils.ParseCron("0 11 * * *")
+	if got, want := schedule.Next(time.Date(2025, 3, 1, 10, 15, 0, 0, kolkata)), time.Date(2025, 3, 1, 11, 0, 0, 0, kolkata); !got.Equal(want) {
+		t.Errorf("Next(0 11 * * *) in +05:30 = %v, want %v", got, want)
+	}
+
+	for _, expr := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *"} {
+		if _, err := utils.ParseCron(expr); !errors.Is(err, utils.ErrInvalidRequest) {
+			t.Errorf("ParseCron(%q) error = %v, want ErrInvalidRequest", expr, err)
+		}
+	}
+}
+
+func TestScheduler_Add(t *testing.T) {
+	s := New(Options{Location: time.UTC})
+	s.now = func() time.Time { return time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC) }
+	job := func(ctx context.Context) error { return nil }
+
+	if err := s.Add("hourly", "@hourly", job, JobOptions{}); err != nil {
+		t.Fatalf("Add() unexpected error = %v", err)
+	}
+	if err := s.Add("hourly", "@daily", job, JobOptions{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Add() duplicate error = %v, want ErrInvalidRequest", err)
+	}
+	if err := s.Add("bad", "every minute", job, JobOptions{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Add() invalid cron error = %v, want ErrInvalidRequest", err)
+	}
+	if err := s.Add("never", "0 0 31 2 *", job, JobOptions{}); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Add() never-matching cron error = %v, want ErrInvalidRequest", err)
+	}
+	if next, _ := s.Next("hourly"); !next.Equal(time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Next() = %v", next)
+	}
+
+	s.Remove("hourly")
+	if jobs := s.Jobs(); len(jobs) != 0 {
+		t.Errorf("Jobs() = %v, want none", jobs)
+	}
+	if _, err := s.RunNow(context.Background(), "hourly"); !errors.Is(err, utils.ErrNotFound) {
+		t.Errorf("RunNow() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestScheduler_RunNowRetries(t *testing.T) {
+	var reported []Run
+	s := New(Options{OnComplete: func(run Run) { reported = append(reported, run) }})
+
+	calls := 0
+	errUnavailable := errors.New("unavailable")
+	job := func(ctx context.Context) error {
+		if calls++; calls < 3 {
+			return errUnavailable
+		}
+		return nil
+	}
+	retry := RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+	if err := s.Add("refresh", "@hourly", job, JobOptions{Retry: retry}); err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := s.RunNow(context.Background(), "refresh")
+	if err != nil || run.Attempts != 3 {
+		t.Errorf("RunNow() = %+v, %v, want success after 3 attempts", run, err)
+	}
+
+	calls = -10
+	run, err = s.RunNow(context.Background(), "refresh")
+	if !errors.Is(err, errUnavailable) || run.Attempts != 3 {
+		t.Errorf("RunNow() = %+v, %v, want the job error after 3 attempts", run, err)
+	}
+	if len(reported) != 2 {
+		t.Errorf("OnComplete called %d times, want 2", len(reported))
+	}
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	s := New(Options{})
+	started, release := make(chan struct{}), make(chan struct{})
+	job := func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	}
+	if err := s.Add("slow", "@hourly", job, JobOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := s.RunNow(context.Background(), "slow"); err != nil {
+			t.Errorf("RunNow() unexpected error = %v", err)
+		}
+	}()
+	<-started
+	if run, _ := s.RunNow(context.Background(), "slow"); !run.Skipped {
+		t.Errorf("RunNow() = %+v, want a skipped run", run)
+	}
+	close(release)
+	wg.Wait()
+}
+
+func TestScheduler_RecoversPanics(t *testing.T) {
+	s := New(Options{})
+	job := func(ctx context.Context) error { panic("boom") }
+	if err := s.Add("faulty", "@hourly", job, JobOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RunNow(context.Background(), "faulty"); err == nil {
+		t.Error("RunNow() expected an error from the panicking job")
+	}
+}
+
+func TestScheduler_Run(t *testing.T) {
+	s := New(Options{Location: time.UTC})
+	var mu sync.Mutex
+	now := time.Date(2025, 3, 1, 8, 59, 59, 0, time.UTC)
+	s.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+
+	ran := make(chan Run, 1)
+	job := func(ctx context.Context) error { return nil }
+	if err := s.Add("hourly", "@hourly", job, JobOptions{OnComplete: func(run Run) { ran <- run }}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	// Move the clock past the activation and wake the scheduler up
+	mu.Lock()
+	now = now.Add(time.Second)
+	mu.Unlock()
+	s.mu.Lock()
+	s.notify()
+	s.mu.Unlock()
+
+	select {
+	case run := <-ran:
+		if !run.Scheduled.Equal(time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)) {
+			t.Errorf("Scheduled = %v", run.Scheduled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("job did not run")
+	}
+	if next, _ := s.Next("hourly"); !next.Equal(time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Next() = %v", next)
+	}
+	cancel()
+	<-done
+}
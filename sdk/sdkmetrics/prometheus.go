@@ -0,0 +1,158 @@
+// Package sdkmetrics exports the counters of an SDK client (see sdk.Client.Stats)
+// to monitoring systems.
+//
+// PrometheusHandler serves them in the Prometheus text exposition format, so
+// they can be scraped next to the metrics of the application:
+//
+//	http.Handle("/metrics/hyperfluid", sdkmetrics.NewPrometheusHandler(client))
+//
+// It is not a prometheus.Collector: the module does not depend on the Prometheus
+// client library. To add the metrics to an existing text exposition instead of
+// serving them on their own path, write them after it with WriteTo.
+//
+// The exported metrics are:
+//
+//	hyperfluid_sdk_requests_total{method}                 counter
+//	hyperfluid_sdk_request_errors_total{class}            counter (see sdk.ErrorClass)
+//	hyperfluid_sdk_request_duration_seconds{method}       histogram
+//	hyperfluid_sdk_request_retries_total                  counter
+//	hyperfluid_sdk_token_refreshes_total{result}          counter ("success" or "failure")
+//	hyperfluid_sdk_s3_bytes_total{direction}              counter ("sent" or "received")
+package sdkmetrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk"
+)
+
+// contentType is the media type of the Prometheus text exposition format.
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// PrometheusHandler renders the counters of a client in the Prometheus text
+// exposition format. It is an http.Handler and an io.WriterTo.
+type PrometheusHandler struct {
+	client *sdk.Client
+	labels string // Constant labels, rendered
+}
+
+// NewPrometheusHandler returns a handler of the counters of client and of
+// the clients derived from it. Optional label name/value pairs are added to
+// every metric, e.g. to tell several clients apart:
+//
+//	sdkmetrics.NewPrometheusHandler(client, "tenant", "acme")
+func NewPrometheusHandler(client *sdk.Client, labelPairs ...string) *PrometheusHandler {
+	var labels []string
+	for i := 0; i+1 < len(labelPairs); i += 2 {
+		labels = append(labels, label(labelPairs[i], labelPairs[i+1]))
+	}
+	return &PrometheusHandler{client: client, labels: strings.Join(labels, ",")}
+}
+
+// ServeHTTP writes the metrics.
+func (p *PrometheusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentType)
+	_, _ = p.WriteTo(w)
+}
+
+// WriteTo writes the metrics in the Prometheus text exposition format, e.g. after
+// the metrics of the application in a handler serving both.
+func (p *PrometheusHandler) WriteTo(w io.Writer) (int64, error) {
+	stats := p.client.Stats()
+	out := &countingWriter{w: bufio.NewWriter(w)}
+
+	methods := sortedKeys(stats.Requests)
+	out.header("hyperfluid_sdk_requests_total", "counter", "Requests issued by the SDK, retries excluded.")
+	for _, method := range methods {
+		out.sample("hyperfluid_sdk_requests_total", p.with(label("method", method)), float64(stats.Requests[method].Count))
+	}
+
+	out.header("hyperfluid_sdk_request_errors_total", "counter", "Failed requests by error class.")
+	for _, class := range sortedKeys(stats.Errors) {
+		out.sample("hyperfluid_sdk_request_errors_total", p.with(label("class", class)), float64(stats.Errors[class]))
+	}
+
+	out.header("hyperfluid_sdk_request_duration_seconds", "histogram", "Request latency, retries included.")
+	for _, method := range methods {
+		histogram := stats.Requests[method]
+		for i, bound := range sdk.LatencyBuckets {
+			le := label("le", strconv.FormatFloat(bound, 'g', -1, 64))
+			out.sample("hyperfluid_sdk_request_duration_seconds_bucket", p.with(label("method", method), le), float64(histogram.Counts[i]))
+		}
+		labels := p.with(label("method", method))
+		out.sample("hyperfluid_sdk_request_duration_seconds_bucket", p.with(label("method", method), label("le", "+Inf")), float64(histogram.Count))
+		out.sample("hyperfluid_sdk_request_duration_seconds_sum", labels, histogram.Sum.Seconds())
+		out.sample("hyperfluid_sdk_request_duration_seconds_count", labels, float64(histogram.Count))
+	}
+
+	out.header("hyperfluid_sdk_request_retries_total", "counter", "Request attempts beyond the first.")
+	out.sample("hyperfluid_sdk_request_retries_total", p.with(), float64(stats.Retries))
+
+	out.header("hyperfluid_sdk_token_refreshes_total", "counter", "Access token refreshes.")
+	out.sample("hyperfluid_sdk_token_refreshes_total", p.with(label("result", "success")), float64(stats.TokenRefreshes))
+	out.sample("hyperfluid_sdk_token_refreshes_total", p.with(label("result", "failure")), float64(stats.TokenRefreshFailures))
+
+	out.header("hyperfluid_sdk_s3_bytes_total", "counter", "Bytes transferred to and from S3 (MinIO).")
+	out.sample("hyperfluid_sdk_s3_bytes_total", p.with(label("direction", "sent")), float64(stats.S3BytesSent))
+	out.sample("hyperfluid_sdk_s3_bytes_total", p.with(label("direction", "received")), float64(stats.S3BytesReceived))
+
+	if out.err == nil {
+		out.err = out.w.Flush()
+	}
+	return out.n, out.err
+}
+
+// with renders the constant labels followed by the given ones, in braces.
+func (p *PrometheusHandler) with(labels ...string) string {
+	if p.labels != "" {
+		labels = append([]string{p.labels}, labels...)
+	}
+	if len(labels) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(labels, ",") + "}"
+}
+
+// label renders a label pair, escaping the value.
+func label(name, value string) string {
+	return name + `="` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value) + `"`
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// countingWriter writes exposition lines, keeping the first error.
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) printf(format string, args ...any) {
+	if c.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(c.w, format, args...)
+	c.n += int64(n)
+	c.err = err
+}
+
+func (c *countingWriter) header(name, metricType, help string) {
+	c.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+func (c *countingWriter) sample(name, labels string, value float64) {
+	c.printf("%s%s %s\n", name, labels, strconv.FormatFloat(value, 'g', -1, 64))
+}
@@ -0,0 +1,64 @@
+package sdkmetrics
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestPrometheusHandler(t *testing.T) {
+	client := sdk.NewClient(utils.Configuration{
+		Token:      "test-token",
+		BaseURL:    "https://test.example.com",
+		DataDockID: "dd",
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusForbidden, Body: io.NopCloser(strings.NewReader(`{}`))}, nil
+		}),
+	})
+	_, _ = client.Catalog("c").Schema("s").Table("t").Get(context.Background())
+
+	recorder := httptest.NewRecorder()
+	NewPrometheusHandler(client, "tenant", `a"b`).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if got := recorder.Header().Get("Content-Type"); got != contentType {
+		t.Errorf("Content-Type = %q", got)
+	}
+	body := recorder.Body.String()
+	for _, want := range []string{
+		"# TYPE hyperfluid_sdk_requests_total counter\n",
+		`hyperfluid_sdk_requests_total{tenant="a\"b",method="GET"} 1` + "\n",
+		`hyperfluid_sdk_request_errors_total{tenant="a\"b",class="permission_denied"} 1` + "\n",
+		"# TYPE hyperfluid_sdk_request_duration_seconds histogram\n",
+		`hyperfluid_sdk_request_duration_seconds_bucket{tenant="a\"b",method="GET",le="30"} 1` + "\n",
+		`hyperfluid_sdk_request_duration_seconds_bucket{tenant="a\"b",method="GET",le="+Inf"} 1` + "\n",
+		`hyperfluid_sdk_request_duration_seconds_count{tenant="a\"b",method="GET"} 1` + "\n",
+		`hyperfluid_sdk_request_retries_total{tenant="a\"b"} 0` + "\n",
+		`hyperfluid_sdk_token_refreshes_total{tenant="a\"b",result="failure"} 0` + "\n",
+		`hyperfluid_sdk_s3_bytes_total{tenant="a\"b",direction="received"} 0` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, body)
+		}
+	}
+
+	// Appended to an existing exposition
+	exposition := bytes.NewBufferString("# TYPE app_up gauge\napp_up 1\n")
+	n, err := NewPrometheusHandler(client).WriteTo(exposition)
+	if err != nil || n != int64(exposition.Len()-len("# TYPE app_up gauge\napp_up 1\n")) {
+		t.Errorf("WriteTo() = %d, %v for %d bytes", n, err, exposition.Len())
+	}
+	if !strings.HasPrefix(exposition.String(), "# TYPE app_up gauge\napp_up 1\n# HELP hyperfluid_sdk_requests_total") {
+		t.Errorf("Unexpected exposition:\n%s", exposition)
+	}
+}
@@ -0,0 +1,328 @@
+// Package sdktest provides helpers to test code built on the SDK without a live
+// Hyperfluid platform.
+//
+// A Recorder is an http.RoundTripper that records the traffic of a client to a golden
+// file, with credentials removed, and replays it later without network access:
+//
+//	func TestOrders(t *testing.T) {
+//	    rec := sdktest.Start(t, "orders") // testdata/recordings/orders.json
+//	    client := sdk.NewClient(rec.Configure(loadConfigFromEnv()))
+//	    table := rec.Value("table", os.Getenv("TEST_TABLE"))
+//	    ...
+//	}
+//
+// Run the tests with HYPERFLUID_RECORD=1 against a real platform to (re)record the
+// golden files, and commit them. Without it, the recordings are replayed.
+package sdktest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// RecordEnv is the environment variable switching Start to recording mode.
+const RecordEnv = "HYPERFLUID_RECORD"
+
+// Mode selects whether a Recorder records or replays traffic.
+type Mode int
+
+const (
+	// ModeReplay serves the recorded responses and never touches the network.
+	ModeReplay Mode = iota
+	// ModeRecord forwards requests to the platform and records them.
+	ModeRecord
+)
+
+// ModeFromEnv returns ModeRecord when HYPERFLUID_RECORD is set to "1" or "true".
+func ModeFromEnv() Mode {
+	if record, _ := strconv.ParseBool(os.Getenv(RecordEnv)); record {
+		return ModeRecord
+	}
+	return ModeReplay
+}
+
+// replayToken is the bearer token of replaying clients; recorded tokens are redacted.
+const replayToken = "replayed-token"
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the sanitized part of a request kept in a recording.
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response.
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// recording is the content of a golden file.
+type recording struct {
+	Values       map[string]string `json:"values,omitempty"`
+	Interactions []Interaction     `json:"interactions"`
+}
+
+// Recorder records or replays the HTTP traffic of a client. It is safe for concurrent use.
+type Recorder struct {
+	// Sanitize is called on every interaction before it is saved, after the built-in
+	// sanitization of credentials. Use it to remove other sensitive data.
+	Sanitize func(*Interaction)
+
+	mode Mode
+	path string
+	real http.RoundTripper
+
+	mu        sync.Mutex
+	recording recording
+	used      []bool
+}
+
+// NewRecorder creates a recorder backed by the golden file at path. In replay mode,
+// the file must exist. Requests are forwarded to http.DefaultTransport when recording,
+// unless Configure is used.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path, real: http.DefaultTransport, recording: recording{Values: map[string]string{}}}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read recording: %w", err)
+	}
+	if err := json.Unmarshal(raw, &r.recording); err != nil {
+		return nil, fmt.Errorf("invalid recording %s: %w", path, err)
+	}
+	if r.recording.Values == nil {
+		r.recording.Values = map[string]string{}
+	}
+	r.used = make([]bool, len(r.recording.Interactions))
+	return r, nil
+}
+
+// Start creates a recorder for a test, backed by testdata/recordings/<name>.json.
+// The mode comes from HYPERFLUID_RECORD. When replaying and no recording exists, the
+// test is skipped. When recording, the file is written when the test ends.
+func Start(t testing.TB, name string) *Recorder {
+	t.Helper()
+	path := filepath.Join("testdata", "recordings", name+".json")
+	mode := ModeFromEnv()
+	if mode == ModeReplay {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			t.Skipf("⏭️  No recording %s, run with %s=1 against a live platform to create it", path, RecordEnv)
+		}
+	}
+
+	r, err := NewRecorder(path, mode)
+	if err != nil {
+		t.Fatalf("sdktest: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := r.Save(); err != nil {
+			t.Errorf("sdktest: %v", err)
+		}
+	})
+	return r
+}
+
+// Mode returns whether the recorder records or replays.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Configure returns the configuration routed through the recorder. When recording,
+// requests are forwarded with the transport settings of cfg and its URLs are saved.
+// When replaying, the recorded URLs are restored and a placeholder token replaces the
+// credentials, so the test needs no environment.
+func (r *Recorder) Configure(cfg utils.Configuration) utils.Configuration {
+	if r.mode == ModeRecord {
+		if transport, err := utils.SharedTransport(cfg); err == nil {
+			r.real = transport
+		}
+		r.Value("base_url", cfg.BaseURL)
+		r.Value("control_plane_url", cfg.ControlPlaneURL)
+		r.Value("org_id", cfg.OrgID)
+		r.Value("data_dock_id", cfg.DataDockID)
+	} else {
+		cfg.BaseURL = r.Value("base_url", cfg.BaseURL)
+		cfg.ControlPlaneURL = r.Value("control_plane_url", cfg.ControlPlaneURL)
+		cfg.OrgID = r.Value("org_id", cfg.OrgID)
+		cfg.DataDockID = r.Value("data_dock_id", cfg.DataDockID)
+		cfg.Token = replayToken
+		cfg.MaxRetries = 0
+	}
+	cfg.Transport = r
+	return cfg
+}
+
+// Value records a test parameter (e.g. a table name read from the environment) when
+// recording and returns it. When replaying, it returns the recorded value, or live
+// if none was recorded. Values are stored in clear: never pass secrets.
+func (r *Recorder) Value(key, live string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode == ModeRecord {
+		r.recording.Values[key] = live
+		return live
+	}
+	if recorded, ok := r.recording.Values[key]; ok {
+		return recorded
+	}
+	return live
+}
+
+// RoundTrip records or replays a request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeReplay {
+		return r.replay(req)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		_ = req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	resp, err := r.real.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+			Header: req.Header.Clone(),
+			Body:   decodedBody(req.Header, body),
+		},
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: resp.Header.Clone(),
+			Body:   decodedBody(resp.Header, respBody),
+		},
+	}
+	interaction.Request.Header.Del("Content-Encoding")
+	interaction.Response.Header.Del("Content-Encoding")
+	sanitize(&interaction)
+	if r.Sanitize != nil {
+		r.Sanitize(&interaction)
+	}
+
+	r.mu.Lock()
+	r.recording.Interactions = append(r.recording.Interactions, interaction)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay serves the first unused interaction recorded for the same method, path and
+// query, so that repeated requests (e.g. pagination) are answered in recording order.
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	key := requestKey(req.Method, req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, interaction := range r.recording.Interactions {
+		if r.used[i] {
+			continue
+		}
+		recordedURL, err := url.Parse(interaction.Request.URL)
+		if err != nil || requestKey(interaction.Request.Method, recordedURL) != key {
+			continue
+		}
+		r.used[i] = true
+
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("sdktest: no recorded interaction left for %s in %s", key, r.path)
+}
+
+// decodedBody returns the body as text, decompressing gzip-encoded bodies so that
+// recordings stay readable.
+func decodedBody(header http.Header, body []byte) string {
+	if !strings.EqualFold(header.Get("Content-Encoding"), "gzip") {
+		return string(body)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return string(body)
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return string(body)
+	}
+	return string(decoded)
+}
+
+// requestKey identifies a request regardless of the host it was sent to.
+func requestKey(method string, u *url.URL) string {
+	u = sanitizeURL(u) // Recorded URLs are sanitized
+	key := method + " " + u.EscapedPath()
+	if u.RawQuery != "" {
+		key += "?" + u.Query().Encode() // Encode sorts the parameters
+	}
+	return key
+}
+
+// Save writes the recording to its golden file. It does nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	raw, err := json.MarshalIndent(r.recording, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(raw, '\n'), 0o644)
+}
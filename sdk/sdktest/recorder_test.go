@@ -0,0 +1,89 @@
+package sdktest_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/sdktest"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestRecorder_RecordThenReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/protocol/openid-connect/token") {
+			_, _ = w.Write([]byte(`{"access_token": "live-access-token", "expires_in": 300}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer live-access-token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		offset := r.URL.Query().Get("__offset")
+		_, _ = w.Write([]byte(`[{"id": 1, "page": "` + offset + `"}]`))
+	}))
+
+	path := filepath.Join(t.TempDir(), "orders.json")
+	ctx := context.Background()
+
+	// Record against the live server
+	recorder, err := sdktest.NewRecorder(path, sdktest.ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := sdk.NewClient(recorder.Configure(utils.Configuration{
+		BaseURL:              server.URL,
+		DataDockID:           "dd",
+		KeycloakBaseURL:      server.URL,
+		KeycloakRealm:        "test",
+		KeycloakClientID:     "app",
+		KeycloakClientSecret: "live-client-secret",
+	}))
+	table := client.Catalog("c").Schema("s").Table(recorder.Value("table", "orders"))
+	for _, offset := range []int{0, 10} {
+		if _, err := table.Limit(10).Offset(offset).Get(ctx); err != nil {
+			t.Fatalf("recording Get() unexpected error = %v", err)
+		}
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Save() unexpected error = %v", err)
+	}
+	server.Close()
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"live-access-token", "live-client-secret"} {
+		if strings.Contains(string(raw), secret) {
+			t.Errorf("recording contains %q", secret)
+		}
+	}
+
+	// Replay without network nor configuration
+	replayer, err := sdktest.NewRecorder(path, sdktest.ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := sdk.NewClient(replayer.Configure(utils.Configuration{}))
+	table = replayed.Catalog("c").Schema("s").Table(replayer.Value("table", ""))
+	for _, offset := range []int{10, 0} {
+		resp, err := table.Limit(10).Offset(offset).Get(ctx)
+		if err != nil {
+			t.Fatalf("replayed Get() unexpected error = %v", err)
+		}
+		rows, _ := resp.Rows()
+		if len(rows) != 1 || (offset == 10) != (rows[0]["page"] == "10") {
+			t.Errorf("replayed rows for offset %d = %v", offset, rows)
+		}
+	}
+
+	if _, err := table.Limit(5).Get(ctx); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("Get() of an unrecorded request error = %v, want a missing interaction error", err)
+	}
+}
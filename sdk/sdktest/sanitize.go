@@ -0,0 +1,134 @@
+package sdktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// sensitiveHeaders are dropped from recordings.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie", "X-Amz-Security-Token"}
+
+// sensitiveFields are redacted from form, query and JSON bodies (compared in lower case).
+var sensitiveFields = map[string]bool{
+	"access_token":           true,
+	"refresh_token":          true,
+	"id_token":               true,
+	"token":                  true,
+	"subject_token":          true,
+	"client_secret":          true,
+	"client_assertion":       true,
+	"password":               true,
+	"secret":                 true,
+	"secret_key":             true,
+	"x-amz-signature":        true,
+	"x-amz-credential":       true,
+	"x-amz-security-token":   true,
+	"webidentitytoken":       true,
+	"secretaccesskey":        true,
+	"sessiontoken":           true,
+	"minio_secret_key":       true,
+	"keycloak_client_secret": true,
+}
+
+// sensitiveXMLElements matches the credentials of STS responses.
+var sensitiveXMLElements = regexp.MustCompile(`<(SecretAccessKey|SessionToken)>[^<]*</(SecretAccessKey|SessionToken)>`)
+
+// sanitize removes credentials from a recorded interaction.
+func sanitize(interaction *Interaction) {
+	for _, header := range sensitiveHeaders {
+		interaction.Request.Header.Del(header)
+		interaction.Response.Header.Del(header)
+	}
+	if u, err := url.Parse(interaction.Request.URL); err == nil {
+		interaction.Request.URL = sanitizeURL(u).String()
+	}
+	interaction.Request.Body = sanitizeBody(interaction.Request.Body)
+	interaction.Response.Body = sanitizeBody(interaction.Response.Body)
+}
+
+// sanitizeURL redacts sensitive query parameters, e.g. of presigned S3 URLs.
+func sanitizeURL(u *url.URL) *url.URL {
+	if u.RawQuery == "" {
+		return u
+	}
+	query := u.Query()
+	if !redactValues(query) {
+		return u
+	}
+	sanitized := *u
+	sanitized.RawQuery = query.Encode()
+	return &sanitized
+}
+
+// sanitizeBody redacts credentials from JSON, form and STS XML bodies.
+func sanitizeBody(body string) string {
+	trimmed := strings.TrimSpace(body)
+	switch {
+	case trimmed == "":
+		return body
+	case strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "["):
+		decoder := json.NewDecoder(strings.NewReader(trimmed))
+		decoder.UseNumber() // Keep numbers as recorded
+		var value any
+		if decoder.Decode(&value) != nil || !redactJSON(value) {
+			return body
+		}
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if encoder.Encode(value) != nil {
+			return body
+		}
+		return strings.TrimSuffix(buf.String(), "\n")
+	case strings.HasPrefix(trimmed, "<"):
+		return sensitiveXMLElements.ReplaceAllString(body, "<$1>"+utils.RedactedValue+"</$2>")
+	default:
+		form, err := url.ParseQuery(trimmed)
+		if err != nil || !redactValues(form) {
+			return body
+		}
+		return form.Encode()
+	}
+}
+
+// redactValues redacts the sensitive fields of a form or query; it reports whether any was found.
+func redactValues(values url.Values) bool {
+	redacted := false
+	for key, list := range values {
+		if !sensitiveFields[strings.ToLower(key)] {
+			continue
+		}
+		for i := range list {
+			list[i] = utils.RedactedValue
+		}
+		redacted = true
+	}
+	return redacted
+}
+
+// redactJSON redacts the sensitive fields of a decoded JSON value in place; it reports
+// whether any was found.
+func redactJSON(value any) bool {
+	redacted := false
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if _, isString := field.(string); isString && sensitiveFields[strings.ToLower(key)] {
+				v[key] = utils.RedactedValue
+				redacted = true
+				continue
+			}
+			redacted = redactJSON(field) || redacted
+		}
+	case []any:
+		for _, item := range v {
+			redacted = redactJSON(item) || redacted
+		}
+	}
+	return redacted
+}
@@ -139,6 +139,23 @@ func (sa *ServiceAccount) ParseIssuer() (baseURL, realm string, err error) {
 	return parseKeycloakURL(sa.Issuer)
 }
 
+// Redacted returns a copy of the service account with its client secret redacted, safe to log.
+func (sa ServiceAccount) Redacted() ServiceAccount {
+	sa.ClientSecret = utils.RedactToken(sa.ClientSecret)
+	return sa
+}
+
+// String prints the service account with its client secret redacted.
+func (sa ServiceAccount) String() string {
+	return fmt.Sprintf("{ClientID:%s ClientSecret:%s Issuer:%s}", sa.ClientID, utils.RedactToken(sa.ClientSecret), sa.Issuer)
+}
+
+// GoString prints the service account with its client secret redacted, for the %#v verb.
+func (sa ServiceAccount) GoString() string {
+	return fmt.Sprintf("sdk.ServiceAccount{ClientID:%q, ClientSecret:%q, Issuer:%q, AuthURI:%q, TokenURI:%q}",
+		sa.ClientID, utils.RedactToken(sa.ClientSecret), sa.Issuer, sa.AuthURI, sa.TokenURI)
+}
+
 // parseKeycloakURL extracts base URL and realm from a Keycloak URL.
 // Supports both issuer format (https://host/realms/realm) and
 // token URL format (https://host/realms/realm/protocol/openid-connect/token).
@@ -201,6 +218,10 @@ type ServiceAccountOptions struct {
 	// Defaults to 3 if not specified.
 	MaxRetries int
 
+	// DiscoverEndpoints reads the Control Plane URL from the platform when
+	// ControlPlaneURL is not set (optional). See utils.Configuration.DiscoverEndpoints.
+	DiscoverEndpoints bool
+
 	// MinIOEndpoint is the MinIO endpoint for S3 operations (required).
 	MinIOEndpoint string
 
@@ -212,6 +233,31 @@ type ServiceAccountOptions struct {
 
 	// MinIORegion is the MinIO region for S3 operations (required).
 	MinIORegion string
+
+	// MinIOBucket fetches the MinIO endpoint and credentials of this bucket from the
+	// platform instead of MinIOEndpoint/MinIOAccessKey/MinIOSecretKey (optional).
+	MinIOBucket string
+
+	// MinIOHarborID is the harbor of MinIOBucket (optional). Defaults to the harbor of DataDockID.
+	MinIOHarborID string
+
+	// FallbackServiceAccounts are tried in order when the service account fails to
+	// authenticate, e.g. the next credentials of a rotation (optional).
+	FallbackServiceAccounts []*ServiceAccount
+
+	// WatchServiceAccountFile reloads the credentials when the service account file
+	// (or directory) changes, e.g. when Kubernetes updates a mounted secret (optional).
+	// Only used by NewClientFromServiceAccountFile and NewClientFromServiceAccountDir;
+	// call Client.Close to stop watching.
+	WatchServiceAccountFile bool
+
+	// WatchInterval is how often the watched files are checked, in seconds (optional).
+	// Defaults to 10 seconds.
+	WatchInterval int
+
+	// TokenStore persists the access tokens so that they are reused across restarts (optional).
+	// See utils.NewEncryptedTokenStore to encrypt them.
+	TokenStore utils.TokenStore
 }
 
 // ToConfiguration converts the ServiceAccount to a utils.Configuration.
@@ -242,6 +288,10 @@ func (sa *ServiceAccount) ToConfiguration(opts ServiceAccountOptions) (utils.Con
 		MinIOAccessKey:       opts.MinIOAccessKey,
 		MinIOSecretKey:       opts.MinIOSecretKey,
 		MinIORegion:          opts.MinIORegion,
+		MinIOBucket:          opts.MinIOBucket,
+		TokenStore:           opts.TokenStore,
+		MinIOHarborID:        opts.MinIOHarborID,
+		DiscoverEndpoints:    opts.DiscoverEndpoints,
 	}
 
 	// Apply defaults for optional fields
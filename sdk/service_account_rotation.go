@@ -0,0 +1,207 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// serviceAccountSet holds the service accounts a client authenticates with, in
+// order of preference, and where they were loaded from so they can be reloaded.
+// It is guarded by authMutex.
+type serviceAccountSet struct {
+	accounts []*ServiceAccount
+	paths    []string // File of each account, "" for accounts given in memory
+	dir      string   // Directory the accounts were loaded from, if any
+	active   int      // Index of the account the current token was obtained with
+}
+
+// newServiceAccountSet validates the accounts and builds their set.
+func newServiceAccountSet(accounts []*ServiceAccount, paths []string) (*serviceAccountSet, error) {
+	for i, sa := range accounts {
+		if sa == nil {
+			return nil, fmt.Errorf("service account %d is nil", i)
+		}
+		if _, _, err := sa.ParseIssuer(); err != nil {
+			return nil, fmt.Errorf("service account %s: failed to parse issuer: %w", sa.ClientID, err)
+		}
+	}
+	if paths == nil {
+		paths = make([]string, len(accounts))
+	}
+	return &serviceAccountSet{accounts: accounts, paths: paths}, nil
+}
+
+// useServiceAccount points the Keycloak credentials of the configuration at the account.
+func (c *Client) useServiceAccount(sa *ServiceAccount) {
+	baseURL, realm, _ := sa.ParseIssuer() // Validated when the set was built
+	c.config.KeycloakBaseURL = baseURL
+	c.config.KeycloakRealm = realm
+	c.config.KeycloakClientID = sa.ClientID
+	c.config.KeycloakClientSecret = sa.ClientSecret
+}
+
+// refreshWithServiceAccounts obtains a token with the first service account that
+// authenticates, starting from the primary one. Must be called with authMutex held.
+func (c *Client) refreshWithServiceAccounts(ctx context.Context) (string, error) {
+	set := c.serviceAccounts
+	var errs []error
+	for i, sa := range set.accounts {
+		c.useServiceAccount(sa)
+		token, err := c.refreshAccessTokenClientCredentials(ctx)
+		if err == nil {
+			set.active = i
+			return token, nil
+		}
+		errs = append(errs, fmt.Errorf("service account %s: %w", sa.ClientID, err))
+	}
+	c.useServiceAccount(set.accounts[set.active])
+	return "", errors.Join(errs...)
+}
+
+// ActiveServiceAccount returns the client ID of the service account the current
+// token was obtained with, or "" if the client does not use service accounts.
+func (c *Client) ActiveServiceAccount() string {
+	authMutex.Lock()
+	defer authMutex.Unlock()
+	if c.serviceAccounts == nil {
+		return ""
+	}
+	return c.serviceAccounts.accounts[c.serviceAccounts.active].ClientID
+}
+
+// ReloadServiceAccount re-reads the service account files the client was created
+// from (e.g. after Kubernetes rotated a mounted secret) and drops the current token,
+// so that the next request authenticates with the new credentials. When the client
+// was created from a directory, the directory is listed again.
+//
+// If a file cannot be read or is invalid, the current credentials are kept.
+func (c *Client) ReloadServiceAccount() error {
+	authMutex.Lock()
+	defer authMutex.Unlock()
+
+	set := c.serviceAccounts
+	if set == nil {
+		return fmt.Errorf("%w: client was not created from a service account", utils.ErrInvalidConfiguration)
+	}
+
+	var reloaded *serviceAccountSet
+	var err error
+	if set.dir != "" {
+		if reloaded, err = loadServiceAccountDir(set.dir); err == nil {
+			// Accounts given in memory are kept after the files
+			for i, path := range set.paths {
+				if path == "" {
+					reloaded.accounts = append(reloaded.accounts, set.accounts[i])
+					reloaded.paths = append(reloaded.paths, "")
+				}
+			}
+		}
+	} else {
+		reloaded, err = reloadServiceAccountFiles(set)
+	}
+	if err != nil {
+		return err
+	}
+
+	*set = *reloaded
+	c.useServiceAccount(set.accounts[0])
+	c.config.Token = ""
+
+	// The Control Plane client caches tokens of the previous credentials
+	controlPlaneMu.Lock()
+	delete(controlPlaneClients, c)
+	controlPlaneMu.Unlock()
+	return nil
+}
+
+// reloadServiceAccountFiles reads again the accounts loaded from files and keeps the others.
+func reloadServiceAccountFiles(set *serviceAccountSet) (*serviceAccountSet, error) {
+	accounts := make([]*ServiceAccount, len(set.accounts))
+	reloadable := false
+	for i, path := range set.paths {
+		accounts[i] = set.accounts[i]
+		if path == "" {
+			continue
+		}
+		reloadable = true
+		sa, err := LoadServiceAccount(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", utils.ErrInvalidConfiguration, path, err)
+		}
+		accounts[i] = sa
+	}
+	if !reloadable {
+		return nil, fmt.Errorf("%w: client was not created from a service account file", utils.ErrInvalidConfiguration)
+	}
+	return newServiceAccountSet(accounts, set.paths)
+}
+
+// loadServiceAccountDir loads every *.json file of a directory, in name order.
+func loadServiceAccountDir(dir string) (*serviceAccountSet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", utils.ErrInvalidConfiguration, err)
+	}
+	sort.Strings(paths)
+
+	var accounts []*ServiceAccount
+	var loaded []string
+	for _, path := range paths {
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue // Skip directories and dangling symlinks
+		}
+		sa, err := LoadServiceAccount(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", utils.ErrInvalidConfiguration, path, err)
+		}
+		accounts = append(accounts, sa)
+		loaded = append(loaded, path)
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("%w: no service account file in %s", utils.ErrInvalidConfiguration, dir)
+	}
+
+	set, err := newServiceAccountSet(accounts, loaded)
+	if err != nil {
+		return nil, err
+	}
+	set.dir = dir
+	return set, nil
+}
+
+// NewClientFromServiceAccountDir creates a client from every *.json service account
+// file of a directory. Files are used in name order: the first one is the primary
+// account and the others are tried in turn when it fails to authenticate.
+//
+// Example:
+//
+//	// /var/run/secrets/hyperfluid/{1-primary.json,2-secondary.json}
+//	client, err := sdk.NewClientFromServiceAccountDir("/var/run/secrets/hyperfluid", sdk.ServiceAccountOptions{
+//	    BaseURL: "https://api.hyperfluid.cloud",
+//	})
+func NewClientFromServiceAccountDir(dir string, opts ServiceAccountOptions) (*Client, error) {
+	set, err := loadServiceAccountDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	opts.FallbackServiceAccounts = append(set.accounts[1:], opts.FallbackServiceAccounts...)
+
+	client, err := NewClientFromServiceAccount(set.accounts[0], opts)
+	if err != nil {
+		return nil, err
+	}
+	client.serviceAccounts.dir = dir
+	copy(client.serviceAccounts.paths, set.paths)
+	if opts.WatchServiceAccountFile {
+		if err := client.watchServiceAccountFiles(utils.SecondsToDuration(opts.WatchInterval)); err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
+}
@@ -0,0 +1,176 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// newRotationServer accepts the client credentials of the given secrets and
+// records the bearer token of API requests.
+func newRotationServer(t *testing.T, valid map[string]string, gotAuth *string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/realms/test/protocol/openid-connect/token" {
+			_ = r.ParseForm()
+			clientID := r.Form.Get("client_id")
+			if secret, ok := valid[clientID]; !ok || secret != r.Form.Get("client_secret") {
+				http.Error(w, `{"error": "invalid_client"}`, http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token": "token-` + clientID + `"}`))
+			return
+		}
+		*gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func writeServiceAccount(t *testing.T, path, clientID, secret, issuer string) {
+	t.Helper()
+	content := `{"client_id": "` + clientID + `", "client_secret": "` + secret + `", "issuer": "` + issuer + `"}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServiceAccount_FallbackOnAuthenticationFailure(t *testing.T) {
+	var gotAuth string
+	server := newRotationServer(t, map[string]string{"secondary": "s2"}, &gotAuth)
+	issuer := server.URL + "/realms/test"
+
+	client, err := NewClientFromServiceAccount(
+		&ServiceAccount{ClientID: "primary", ClientSecret: "revoked", Issuer: issuer},
+		ServiceAccountOptions{
+			BaseURL:                 server.URL,
+			DataDockID:              "dd",
+			FallbackServiceAccounts: []*ServiceAccount{{ClientID: "secondary", ClientSecret: "s2", Issuer: issuer}},
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewClientFromServiceAccount() unexpected error = %v", err)
+	}
+
+	if _, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background()); err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if gotAuth != "Bearer token-secondary" {
+		t.Errorf("Authorization = %q, want the secondary account token", gotAuth)
+	}
+	if client.ActiveServiceAccount() != "secondary" {
+		t.Errorf("ActiveServiceAccount() = %q, want %q", client.ActiveServiceAccount(), "secondary")
+	}
+}
+
+func TestServiceAccount_Reload(t *testing.T) {
+	var gotAuth string
+	valid := map[string]string{"sa": "old"}
+	server := newRotationServer(t, valid, &gotAuth)
+	issuer := server.URL + "/realms/test"
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "service_account.json")
+	writeServiceAccount(t, path, "sa", "old", issuer)
+
+	client, err := NewClientFromServiceAccountFile(path, ServiceAccountOptions{BaseURL: server.URL, DataDockID: "dd"})
+	if err != nil {
+		t.Fatalf("NewClientFromServiceAccountFile() unexpected error = %v", err)
+	}
+	if _, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background()); err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+
+	// The secret is rotated: the old one is revoked and the file is updated
+	valid["sa"] = "new"
+	writeServiceAccount(t, path, "sa", "new", issuer)
+	if err := client.ReloadServiceAccount(); err != nil {
+		t.Fatalf("ReloadServiceAccount() unexpected error = %v", err)
+	}
+	if client.config.KeycloakClientSecret != "new" || client.config.Token != "" {
+		t.Errorf("Expected the new secret and no cached token, got %+v", client.config)
+	}
+	if _, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background()); err != nil {
+		t.Fatalf("Get() after reload unexpected error = %v", err)
+	}
+
+	// An invalid file keeps the current credentials
+	if err := os.WriteFile(path, []byte(`{}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.ReloadServiceAccount(); !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+	}
+	if client.config.KeycloakClientSecret != "new" {
+		t.Errorf("Credentials must be kept after a failed reload")
+	}
+
+	if err := NewClient(utils.Configuration{}).ReloadServiceAccount(); !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration without service account, got %v", err)
+	}
+}
+
+func TestNewClientFromServiceAccountDir(t *testing.T) {
+	var gotAuth string
+	server := newRotationServer(t, map[string]string{"b": "sb"}, &gotAuth)
+	issuer := server.URL + "/realms/test"
+
+	dir := t.TempDir()
+	writeServiceAccount(t, filepath.Join(dir, "1-a.json"), "a", "sa", issuer)
+	writeServiceAccount(t, filepath.Join(dir, "2-b.json"), "b", "sb", issuer)
+
+	client, err := NewClientFromServiceAccountDir(dir, ServiceAccountOptions{BaseURL: server.URL, DataDockID: "dd"})
+	if err != nil {
+		t.Fatalf("NewClientFromServiceAccountDir() unexpected error = %v", err)
+	}
+	if _, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background()); err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if gotAuth != "Bearer token-b" {
+		t.Errorf("Authorization = %q, want the second account token", gotAuth)
+	}
+
+	if _, err := NewClientFromServiceAccountDir(t.TempDir(), ServiceAccountOptions{BaseURL: server.URL}); !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration for an empty directory, got %v", err)
+	}
+}
+
+func TestServiceAccount_WatchFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "service_account.json")
+	writeServiceAccount(t, path, "sa", "old", "https://auth.example.com/realms/test")
+
+	client, err := NewClientFromServiceAccountFile(path, ServiceAccountOptions{BaseURL: "https://api.example.com"})
+	if err != nil {
+		t.Fatalf("NewClientFromServiceAccountFile() unexpected error = %v", err)
+	}
+	if err := client.watchServiceAccountFiles(5 * time.Millisecond); err != nil {
+		t.Fatalf("watchServiceAccountFiles() unexpected error = %v", err)
+	}
+	defer client.Close()
+
+	secret := func() string {
+		authMutex.Lock()
+		defer authMutex.Unlock()
+		return client.config.KeycloakClientSecret
+	}
+
+	writeServiceAccount(t, path, "sa", "rotated-secret", "https://auth.example.com/realms/test")
+	deadline := time.Now().Add(2 * time.Second)
+	for secret() != "rotated-secret" {
+		if time.Now().After(deadline) {
+			t.Fatalf("credentials were not reloaded, secret = %q", secret())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	client.Close()
+	client.Close() // Closing twice is allowed
+}
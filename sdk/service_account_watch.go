@@ -0,0 +1,102 @@
+package sdk
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultServiceAccountWatchInterval is how often watched service account files are checked.
+const DefaultServiceAccountWatchInterval = 10 * time.Second
+
+// serviceAccountWatcher periodically checks the service account files of a client
+// and reloads them when they change.
+type serviceAccountWatcher struct {
+	stop chan struct{}
+	once sync.Once
+	done chan struct{}
+}
+
+// watchServiceAccountFiles starts reloading the client service accounts whenever
+// their files change. Files are polled rather than watched with inotify, which does
+// not see the symlink swaps Kubernetes uses to update mounted secrets.
+func (c *Client) watchServiceAccountFiles(interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultServiceAccountWatchInterval
+	}
+	authMutex.Lock()
+	signature, err := serviceAccountFilesSignature(c.serviceAccounts)
+	authMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	watcher := &serviceAccountWatcher{stop: make(chan struct{}), done: make(chan struct{})}
+	c.watcher = watcher
+	go func() {
+		defer close(watcher.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-watcher.stop:
+				return
+			case <-ticker.C:
+			}
+
+			authMutex.Lock()
+			current, err := serviceAccountFilesSignature(c.serviceAccounts)
+			authMutex.Unlock()
+			if err != nil || current == signature {
+				continue
+			}
+			// A file being written may be invalid: keep the old signature and retry on the next tick
+			if err := c.ReloadServiceAccount(); err == nil {
+				signature = current
+			}
+		}
+	}()
+	return nil
+}
+
+// serviceAccountFilesSignature summarizes the names, sizes and modification times
+// of the service account files, following symlinks.
+func serviceAccountFilesSignature(set *serviceAccountSet) (string, error) {
+	if set == nil {
+		return "", fmt.Errorf("client was not created from a service account")
+	}
+
+	paths := set.paths
+	if set.dir != "" {
+		listed, err := filepath.Glob(filepath.Join(set.dir, "*.json"))
+		if err != nil {
+			return "", err
+		}
+		paths = listed
+	}
+
+	var signature strings.Builder
+	sorted := append([]string(nil), paths...)
+	sort.Strings(sorted)
+	watched := 0
+	for _, path := range sorted {
+		if path == "" {
+			continue
+		}
+		watched++
+		info, err := os.Stat(path)
+		if err != nil {
+			fmt.Fprintf(&signature, "%s:missing;", path)
+			continue
+		}
+		fmt.Fprintf(&signature, "%s:%d:%d;", path, info.Size(), info.ModTime().UnixNano())
+	}
+	if watched == 0 && set.dir == "" {
+		return "", fmt.Errorf("client was not created from a service account file")
+	}
+	return signature.String(), nil
+}
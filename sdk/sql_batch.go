@@ -0,0 +1,206 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// sqlExecutePath is the SQL execution endpoint of the control plane.
+const sqlExecutePath = "/api/v1/tiny-query/execute"
+
+// SQLStatementResult is the outcome of one statement of a batch.
+type SQLStatementResult struct {
+	Statement    string
+	QueryID      string
+	RowsAffected int64  // Rows written by DML statements, rows returned otherwise
+	Error        string // Empty when the statement succeeded
+}
+
+// SQLBatchResult is the outcome of a batch. Results holds one entry per executed
+// statement; statements after the first failure are not executed.
+//
+// Atomic execution is not possible on the SQL endpoint, which has no session to
+// hold a transaction: a failure rolls nothing back, and the statements executed
+// before it stay applied.
+type SQLBatchResult struct {
+	Results []SQLStatementResult
+	// Atomic reports whether the statements were applied as a whole or not at
+	// all. It is always false (see above).
+	Atomic bool
+}
+
+// Failed returns the statement that stopped the batch, or nil if every statement succeeded.
+func (r *SQLBatchResult) Failed() *SQLStatementResult {
+	for i := range r.Results {
+		if r.Results[i].Error != "" {
+			return &r.Results[i]
+		}
+	}
+	return nil
+}
+
+// SQLBatchBuilder collects SQL statements executed in order against a datadock.
+// Each statement is a separate request to the SQL endpoint, which has no session:
+// a batch is not a transaction.
+type SQLBatchBuilder struct {
+	client     *Client
+	dataDockID string
+	statements []string
+	timeout    int64
+}
+
+// SQLBatch starts a batch of SQL statements against the configured datadock.
+//
+// Example:
+//
+//	result, err := client.SQLBatch(ctx).
+//	    Add("INSERT INTO sales.public.orders (id, total) VALUES (1, 10)").
+//	    Add("UPDATE sales.public.stock SET qty = qty - 1 WHERE id = 7").
+//	    Exec(ctx)
+//	if err != nil && result != nil {
+//	    log.Printf("batch stopped at %q: %s", result.Failed().Statement, result.Failed().Error)
+//	}
+func (c *Client) SQLBatch(ctx context.Context) *SQLBatchBuilder {
+	return &SQLBatchBuilder{client: c, dataDockID: c.config.DataDockID}
+}
+
+func (b *SQLBatchBuilder) clone() *SQLBatchBuilder {
+	cloned := *b
+	cloned.statements = append([]string(nil), b.statements...)
+	return &cloned
+}
+
+// DataDock overrides the datadock the statements run against.
+func (b *SQLBatchBuilder) DataDock(dataDockID string) *SQLBatchBuilder {
+	b = b.clone()
+	b.dataDockID = dataDockID
+	return b
+}
+
+// Timeout sets the server-side timeout of each statement, in seconds.
+func (b *SQLBatchBuilder) Timeout(seconds int64) *SQLBatchBuilder {
+	b = b.clone()
+	b.timeout = seconds
+	return b
+}
+
+// Add appends a statement to the batch. A trailing semicolon is ignored.
+func (b *SQLBatchBuilder) Add(statement string) *SQLBatchBuilder {
+	b = b.clone()
+	b.statements = append(b.statements, strings.TrimSuffix(strings.TrimSpace(statement), ";"))
+	return b
+}
+
+// Exec runs the statements one by one and stops at the first failure. Execution is
+// sequential, not atomic: statements that succeeded before the failure stay applied.
+func (b *SQLBatchBuilder) Exec(ctx context.Context) (*SQLBatchResult, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	result := &SQLBatchResult{}
+	for _, statement := range b.statements {
+		stmtResult, err := b.execute(ctx, statement)
+		result.Results = append(result.Results, stmtResult)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func (b *SQLBatchBuilder) validate() error {
+	if b.dataDockID == "" {
+		return fmt.Errorf("%w: datadock ID is required", utils.ErrInvalidRequest)
+	}
+	if len(b.statements) == 0 {
+		return fmt.Errorf("%w: batch has no statements", utils.ErrInvalidRequest)
+	}
+	for i, statement := range b.statements {
+		if statement == "" {
+			return fmt.Errorf("%w: statement %d is empty", utils.ErrInvalidRequest, i)
+		}
+	}
+	return nil
+}
+
+// execute runs one statement of the batch.
+func (b *SQLBatchBuilder) execute(ctx context.Context, statement string) (SQLStatementResult, error) {
+	result := SQLStatementResult{Statement: statement}
+
+	resp, payload, err := b.client.executeSQL(ctx, b.dataDockID, statement, b.timeout)
+	if err != nil {
+		result.Error = err.Error()
+		if resp != nil && resp.Error != "" {
+			result.Error = resp.Error
+		}
+		return result, err
+	}
+	result.QueryID = payload.QueryID
+	result.RowsAffected = payload.TotalRows
+
+	// Trino reports the rows written by DML statements as a single "rows" value
+	if len(payload.Columns) == 1 && len(payload.Rows) == 1 && len(payload.Rows[0]) == 1 && payload.Columns[0].Name == "rows" {
+		switch count := payload.Rows[0][0].(type) {
+		case float64:
+			result.RowsAffected = int64(count)
+		case json.Number:
+			if n, err := count.Int64(); err == nil {
+				result.RowsAffected = n
+			}
+		}
+	}
+	return result, nil
+}
+
+// sqlResult is the result of a statement run by the SQL execution endpoint.
+type sqlResult struct {
+	QueryID string `json:"query_id"`
+	Columns []struct {
+		Name string `json:"name"`
+	} `json:"columns"`
+	Rows      [][]any `json:"rows"`
+	TotalRows int64   `json:"total_rows"`
+}
+
+// executeSQL runs one statement through the SQL execution endpoint. The response
+// is returned with failed requests for their error message.
+func (c *Client) executeSQL(ctx context.Context, dataDockID, statement string, timeout int64) (*utils.Response, *sqlResult, error) {
+	request := map[string]any{"data_dock_id": dataDockID, "sql": statement}
+	if timeout > 0 {
+		request["timeout_seconds"] = timeout
+	}
+	body, err := utils.EncodeBody(c.config, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	submitted := time.Now()
+	resp, err := c.Do(utils.ContextWithoutTransformers(ctx), "POST", c.sqlExecuteURL(ctx), body)
+	if err != nil {
+		// Do not leave the statement running on the cluster when the caller gave up
+		c.cancelAbandonedStatement(ctx, dataDockID, statement, submitted)
+		return resp, nil, fmt.Errorf("statement %q failed: %w", statement, err)
+	}
+
+	var payload sqlResult
+	raw, _ := json.Marshal(resp.Data)
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if c.config.UseNumber {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(&payload); err != nil {
+		return resp, nil, fmt.Errorf("%w: unexpected result for statement %q: %w", utils.ErrAPIError, statement, err)
+	}
+	return resp, &payload, nil
+}
+
+// sqlExecuteURL returns the SQL execution endpoint, served by the control plane.
+func (c *Client) sqlExecuteURL(ctx context.Context) string {
+	return strings.TrimSuffix(c.controlPlaneBaseURL(ctx), "/") + sqlExecutePath
+}
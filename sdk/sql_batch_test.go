@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func newSQLTestClient(t *testing.T, executed *[]string, fail string) *Client {
+	return &Client{
+		config: utils.Configuration{BaseURL: "http://localhost", ControlPlaneURL: "http://cp", Token: "test-token", DataDockID: "dd-1"},
+		httpClient: &http.Client{Transport: &mockRoundTripper{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				if req.URL.Host != "cp" || req.URL.Path != "/api/v1/tiny-query/execute" {
+					t.Errorf("Unexpected URL %s", req.URL)
+				}
+				var body map[string]any
+				_ = json.NewDecoder(req.Body).Decode(&body)
+				if body["data_dock_id"] != "dd-1" {
+					t.Errorf("Unexpected body %v", body)
+				}
+				statement := body["sql"].(string)
+				*executed = append(*executed, statement)
+
+				if statement == fail {
+					return &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader("constraint violation")), Header: make(http.Header)}, nil
+				}
+				result := `{"query_id": "q", "columns": [{"name": "rows"}], "rows": [[3]], "total_rows": 1, "has_more": false, "execution_time_ms": 1}`
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(result)), Header: make(http.Header)}, nil
+			},
+		}},
+	}
+}
+
+func TestSQLBatch_Exec(t *testing.T) {
+	var executed []string
+	client := newSQLTestClient(t, &executed, "")
+
+	result, err := client.SQLBatch(context.Background()).
+		Add("INSERT INTO a VALUES (1);").
+		Add("UPDATE b SET x = 1").
+		Exec(context.Background())
+	if err != nil {
+		t.Fatalf("Exec() unexpected error = %v", err)
+	}
+
+	expected := []string{"INSERT INTO a VALUES (1)", "UPDATE b SET x = 1"}
+	if strings.Join(executed, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected %v, got %v", expected, executed)
+	}
+	if len(result.Results) != 2 || result.Results[0].RowsAffected != 3 || result.Failed() != nil {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
+
+func TestSQLBatch_ExecStopsAtFailure(t *testing.T) {
+	var executed []string
+	client := newSQLTestClient(t, &executed, "UPDATE b SET x = 1")
+
+	result, err := client.SQLBatch(context.Background()).
+		Add("INSERT INTO a VALUES (1)").
+		Add("UPDATE b SET x = 1").
+		Add("DELETE FROM c").
+		Exec(context.Background())
+	if !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Fatalf("Expected ErrInvalidRequest, got %v", err)
+	}
+
+	if len(executed) != 2 {
+		t.Errorf("Expected the batch to stop after the failed statement, got %v", executed)
+	}
+	if result.Failed() == nil || result.Failed().Error != "constraint violation" {
+		t.Errorf("Unexpected result %+v", result)
+	}
+	if result.Atomic || result.Results[0].Error != "" {
+		t.Errorf("Expected the statement before the failure to stay applied, got %+v", result)
+	}
+}
+
+func TestSQLBatch_Validation(t *testing.T) {
+	var executed []string
+	client := newSQLTestClient(t, &executed, "")
+
+	if _, err := client.SQLBatch(context.Background()).Exec(context.Background()); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for an empty batch, got %v", err)
+	}
+	if _, err := client.SQLBatch(context.Background()).Add(" ; ").Exec(context.Background()); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for an empty statement, got %v", err)
+	}
+	if len(executed) != 0 {
+		t.Errorf("No statement must be sent, got %v", executed)
+	}
+}
@@ -0,0 +1,302 @@
+package sqldriver
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders/fluent"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// conn is a connection. It holds no server-side state: every statement is
+// translated into a single HTTP request.
+type conn struct {
+	client builders.ClientInterface
+}
+
+var (
+	_ driver.Conn               = (*conn)(nil)
+	_ driver.ConnPrepareContext = (*conn)(nil)
+	_ driver.QueryerContext     = (*conn)(nil)
+	_ driver.ExecerContext      = (*conn)(nil)
+)
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	parsed, err := parseStatement(query)
+	if err != nil {
+		return nil, err
+	}
+	return &stmt{client: c.client, parsed: parsed}, nil
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+// Begin always fails: the OpenAPI data layer has no transactions.
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("%w: transactions are not supported", utils.ErrInvalidRequest)
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	parsed, err := parseStatement(query)
+	if err != nil {
+		return nil, err
+	}
+	return (&stmt{client: c.client, parsed: parsed}).QueryContext(ctx, args)
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	parsed, err := parseStatement(query)
+	if err != nil {
+		return nil, err
+	}
+	return (&stmt{client: c.client, parsed: parsed}).ExecContext(ctx, args)
+}
+
+// stmt is a parsed statement, executed on every call.
+type stmt struct {
+	client builders.ClientInterface
+	parsed *statement
+}
+
+var (
+	_ driver.StmtQueryContext = (*stmt)(nil)
+	_ driver.StmtExecContext  = (*stmt)(nil)
+)
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+func (s *stmt) NumInput() int {
+	return s.parsed.numInput
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	if s.parsed.kind == kindInsert {
+		return nil, fmt.Errorf("%w: INSERT statements must be run with Exec", utils.ErrInvalidRequest)
+	}
+	qb, err := s.queryBuilder(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.parsed.kind == kindCount {
+		count, err := qb.Count(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &rows{columns: []string{"count"}, values: [][]driver.Value{{int64(count)}}}, nil
+	}
+
+	resp, err := qb.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	data, err := resp.Rows()
+	if err != nil {
+		return nil, err
+	}
+	return newRows(s.parsed.columns, data), nil
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if s.parsed.kind != kindInsert {
+		return nil, fmt.Errorf("%w: SELECT statements must be run with Query", utils.ErrInvalidRequest)
+	}
+	resolve, err := s.binder(args)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]map[string]any, 0, len(s.parsed.rows))
+	for _, row := range s.parsed.rows {
+		record := make(map[string]any, len(row))
+		for i, value := range row {
+			record[s.parsed.columns[i]] = resolve(value)
+		}
+		records = append(records, record)
+	}
+
+	p := s.parsed
+	if _, err := fluent.NewQueryBuilder(s.client).Catalog(p.catalog).Schema(p.schema).Table(p.tbl).Post(ctx, records); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(len(records)), nil
+}
+
+// queryBuilder translates a SELECT statement into a fluent query.
+func (s *stmt) queryBuilder(args []driver.NamedValue) (*fluent.QueryBuilder, error) {
+	resolve, err := s.binder(args)
+	if err != nil {
+		return nil, err
+	}
+	p := s.parsed
+
+	qb := fluent.NewQueryBuilder(s.client).Catalog(p.catalog).Schema(p.schema).Table(p.tbl)
+	if len(p.columns) > 0 {
+		qb = qb.Select(p.columns...)
+	}
+	for _, cond := range p.where {
+		values := make([]string, len(cond.values))
+		for i, value := range cond.values {
+			values[i] = fmt.Sprint(resolve(value))
+		}
+		qb = qb.Where(cond.column, cond.operator, strings.Join(values, ","))
+	}
+	for _, order := range p.orderBy {
+		qb = qb.OrderBy(order.Column, order.Direction)
+	}
+	if p.limit != nil {
+		n, err := intValue(resolve(*p.limit), "LIMIT")
+		if err != nil {
+			return nil, err
+		}
+		qb = qb.Limit(n)
+	}
+	if p.offset != nil {
+		n, err := intValue(resolve(*p.offset), "OFFSET")
+		if err != nil {
+			return nil, err
+		}
+		qb = qb.Offset(n)
+	}
+	return qb, nil
+}
+
+// binder checks the arguments and returns a function resolving expressions to values.
+func (s *stmt) binder(args []driver.NamedValue) (func(expr) any, error) {
+	if len(args) != s.parsed.numInput {
+		return nil, fmt.Errorf("%w: statement expects %d arguments, got %d", utils.ErrInvalidRequest, s.parsed.numInput, len(args))
+	}
+	values := make([]any, len(args)+1)
+	for _, arg := range args {
+		if arg.Name != "" {
+			return nil, fmt.Errorf("%w: named arguments are not supported", utils.ErrInvalidRequest)
+		}
+		switch v := arg.Value.(type) {
+		case time.Time:
+			values[arg.Ordinal] = v.Format(time.RFC3339Nano)
+		case []byte:
+			values[arg.Ordinal] = string(v)
+		default:
+			values[arg.Ordinal] = v
+		}
+	}
+	return func(e expr) any {
+		if e.param > 0 {
+			return values[e.param]
+		}
+		return e.value
+	}, nil
+}
+
+func intValue(value any, clause string) (int, error) {
+	if n, ok := value.(int64); ok && n >= 0 {
+		return int(n), nil
+	}
+	return 0, fmt.Errorf("%w: %s must be a non-negative integer, got %v", utils.ErrInvalidRequest, clause, value)
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+	}
+	return named
+}
+
+// rows is a fully fetched result set.
+type rows struct {
+	columns []string
+	values  [][]driver.Value
+	pos     int
+}
+
+// newRows converts JSON rows into a result set. Columns are the selected ones,
+// or the sorted union of the row keys for SELECT *.
+func newRows(columns []string, data []map[string]any) *rows {
+	if len(columns) == 0 {
+		seen := map[string]bool{}
+		for _, row := range data {
+			for key := range row {
+				if !seen[key] {
+					seen[key] = true
+					columns = append(columns, key)
+				}
+			}
+		}
+		sort.Strings(columns)
+	}
+
+	values := make([][]driver.Value, len(data))
+	for i, row := range data {
+		values[i] = make([]driver.Value, len(columns))
+		for j, column := range columns {
+			values[i][j] = driverValue(row[column])
+		}
+	}
+	return &rows{columns: columns, values: values}
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.pos])
+	r.pos++
+	return nil
+}
+
+// driverValue converts a decoded JSON value into a driver.Value. Integral numbers
+// become int64 so they scan into integer types; nested values are returned as JSON.
+func driverValue(value any) driver.Value {
+	switch v := value.(type) {
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v)
+		}
+		return v
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]any, []any:
+		encoded, _ := json.Marshal(v)
+		return encoded
+	}
+	return value
+}
@@ -0,0 +1,145 @@
+// Package sqldriver is a database/sql driver backed by the Bifrost OpenAPI data layer.
+//
+// Simple SELECT and INSERT statements on fully qualified tables are translated into
+// requests on the OpenAPI table endpoints, so tools built on database/sql can read
+// Hyperfluid tables without going through the Postgres wire protocol:
+//
+//	import _ "github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/sqldriver"
+//
+//	db, err := sql.Open("hyperfluid", "hyperfluid://TOKEN@bifrost.hyperfluid.cloud/DATADOCK_ID")
+//	rows, err := db.QueryContext(ctx, "SELECT id, total FROM sales.public.orders WHERE status = ? LIMIT 10", "paid")
+//
+// A client configured with a service account or Keycloak can be used with NewConnector:
+//
+//	db := sql.OpenDB(sqldriver.NewConnector(client))
+//
+// Transactions, joins, aggregates other than COUNT(*) and UPDATE/DELETE statements
+// are not supported.
+package sqldriver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// DriverName is the name under which the driver is registered.
+const DriverName = "hyperfluid"
+
+func init() {
+	sql.Register(DriverName, &Driver{})
+}
+
+// Driver implements driver.Driver and driver.DriverContext.
+type Driver struct{}
+
+// Open returns a new connection to the data source described by dsn.
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	connector, err := d.OpenConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return connector.Connect(context.Background())
+}
+
+// OpenConnector parses the DSN once and returns a connector sharing a single client.
+//
+// The DSN has the form:
+//
+//	hyperfluid://[token@]host[:port]/datadock-id[?org=ID&tls=false&skip_tls_verify=true&timeout=30s&service_account=PATH]
+//
+// When service_account is set, the client authenticates with the service account
+// file instead of the token.
+func (d *Driver) OpenConnector(dsn string) (driver.Connector, error) {
+	client, err := clientFromDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &Connector{client: client, driver: d}, nil
+}
+
+// Connector implements driver.Connector on top of an SDK client.
+type Connector struct {
+	client builders.ClientInterface
+	driver *Driver
+}
+
+// NewConnector returns a connector issuing its requests through client.
+// Queries target the DataDockID of the client configuration.
+func NewConnector(client builders.ClientInterface) *Connector {
+	return &Connector{client: client, driver: &Driver{}}
+}
+
+// Connect returns a connection. Connections are stateless and share the client.
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &conn{client: c.client}, nil
+}
+
+// Driver returns the underlying driver.
+func (c *Connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// clientFromDSN creates an SDK client from a DSN.
+func clientFromDSN(dsn string) (*sdk.Client, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid DSN: %w", utils.ErrInvalidConfiguration, err)
+	}
+	if u.Scheme != DriverName {
+		return nil, fmt.Errorf("%w: DSN scheme must be %q", utils.ErrInvalidConfiguration, DriverName)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("%w: DSN host is required", utils.ErrInvalidConfiguration)
+	}
+	dataDockID := ""
+	if len(u.Path) > 1 {
+		dataDockID = u.Path[1:]
+	}
+
+	query := u.Query()
+	scheme := "https"
+	if query.Get("tls") == "false" {
+		scheme = "http"
+	}
+	skipTLSVerify, _ := strconv.ParseBool(query.Get("skip_tls_verify"))
+	timeout := utils.DefaultRequestTimeout
+	if value := query.Get("timeout"); value != "" {
+		if timeout, err = time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("%w: invalid DSN timeout: %w", utils.ErrInvalidConfiguration, err)
+		}
+	}
+	baseURL := scheme + "://" + u.Host
+
+	if path := query.Get("service_account"); path != "" {
+		return sdk.NewClientFromServiceAccountFile(path, sdk.ServiceAccountOptions{
+			BaseURL:        baseURL,
+			OrgID:          query.Get("org"),
+			DataDockID:     dataDockID,
+			SkipTLSVerify:  skipTLSVerify,
+			RequestTimeout: int(timeout / time.Second),
+		})
+	}
+
+	token := u.User.Username()
+	if token == "" {
+		return nil, fmt.Errorf("%w: DSN must contain a token or a service_account", utils.ErrInvalidConfiguration)
+	}
+	return sdk.NewClient(utils.Configuration{
+		BaseURL:        baseURL,
+		OrgID:          query.Get("org"),
+		DataDockID:     dataDockID,
+		Token:          token,
+		SkipTLSVerify:  skipTLSVerify,
+		RequestTimeout: timeout,
+		MaxRetries:     utils.DefaultMaxRetries,
+	}), nil
+}
@@ -0,0 +1,151 @@
+package sqldriver
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func openTestDB(t *testing.T, handler http.HandlerFunc) *sql.DB {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	dsn := "hyperfluid://test-token@" + strings.TrimPrefix(server.URL, "http://") + "/dd-1?tls=false&org=org-1"
+	db, err := sql.Open(DriverName, dsn)
+	if err != nil {
+		t.Fatalf("sql.Open() unexpected error = %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestDriver_Select(t *testing.T) {
+	db := openTestDB(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dd-1/openapi/sales/public/orders" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Missing bearer token")
+		}
+		query := r.URL.Query()
+		if query.Get("__select") != "id,total,meta" || query.Get("status.eq") != "paid" ||
+			query.Get("region.in") != "eu,us" || query.Get("order") != "id.desc" || query.Get("__limit") != "2" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`[{"id": 2, "total": 10.5, "meta": {"a": 1}}, {"id": 1, "total": null}]`))
+	})
+
+	rows, err := db.QueryContext(context.Background(),
+		`SELECT id, total, meta FROM sales.public.orders WHERE status = ? AND region IN ('eu', 'us') ORDER BY id DESC LIMIT 2`, "paid")
+	if err != nil {
+		t.Fatalf("QueryContext() unexpected error = %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	type order struct {
+		id    int
+		total sql.NullFloat64
+		meta  []byte
+	}
+	var orders []order
+	for rows.Next() {
+		var o order
+		if err := rows.Scan(&o.id, &o.total, &o.meta); err != nil {
+			t.Fatalf("Scan() unexpected error = %v", err)
+		}
+		orders = append(orders, o)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(orders) != 2 || orders[0].id != 2 || orders[0].total.Float64 != 10.5 || orders[1].total.Valid {
+		t.Errorf("Unexpected rows %+v", orders)
+	}
+	if string(orders[0].meta) != `{"a":1}` {
+		t.Errorf("Expected nested values as JSON, got %s", orders[0].meta)
+	}
+}
+
+func TestDriver_Count(t *testing.T) {
+	db := openTestDB(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("total.gt") != "100" {
+			t.Errorf("Unexpected query %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Range", "0-0/42")
+	})
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sales.public.orders WHERE total > $1", 100).Scan(&count); err != nil {
+		t.Fatalf("QueryRow() unexpected error = %v", err)
+	}
+	if count != 42 {
+		t.Errorf("Expected 42, got %d", count)
+	}
+}
+
+func TestDriver_Insert(t *testing.T) {
+	db := openTestDB(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("Expected POST, got %s", r.Method)
+		}
+		body, _ := io.ReadAll(r.Body)
+		var records []map[string]any
+		if err := json.Unmarshal(body, &records); err != nil || len(records) != 2 {
+			t.Fatalf("Unexpected body %s", body)
+		}
+		if records[0]["name"] != "it's" || records[1]["active"] != false || records[1]["qty"] != float64(3) {
+			t.Errorf("Unexpected records %v", records)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	result, err := db.Exec(`INSERT INTO sales.public.items (name, qty, active) VALUES ('it''s', 1, TRUE), (?, ?, FALSE)`, "b", 3)
+	if err != nil {
+		t.Fatalf("Exec() unexpected error = %v", err)
+	}
+	if affected, _ := result.RowsAffected(); affected != 2 {
+		t.Errorf("Expected 2 rows affected, got %d", affected)
+	}
+}
+
+func TestDriver_Unsupported(t *testing.T) {
+	db := openTestDB(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Unexpected request %s", r.URL)
+	})
+
+	statements := []string{
+		"UPDATE sales.public.orders SET total = 1",
+		"SELECT * FROM orders",
+		"SELECT * FROM sales.public.orders WHERE a = 1 OR b = 2",
+		"SELECT * FROM sales.public.orders WHERE name = 'unterminated",
+		"SELECT * FROM sales.public.orders WHERE a = ? AND b = $2",
+		"INSERT INTO sales.public.orders (a, b) VALUES (1)",
+	}
+	for _, statement := range statements {
+		if _, err := db.Query(statement); !errors.Is(err, utils.ErrInvalidRequest) {
+			t.Errorf("%q: expected ErrInvalidRequest, got %v", statement, err)
+		}
+	}
+
+	if _, err := db.Begin(); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected transactions to be rejected, got %v", err)
+	}
+}
+
+func TestDriver_InvalidDSN(t *testing.T) {
+	for _, dsn := range []string{"postgres://u@host/db", "hyperfluid:///dd-1", "hyperfluid://host/dd-1"} {
+		if _, err := (&Driver{}).OpenConnector(dsn); !errors.Is(err, utils.ErrInvalidConfiguration) {
+			t.Errorf("%q: expected ErrInvalidConfiguration, got %v", dsn, err)
+		}
+	}
+}
@@ -0,0 +1,509 @@
+package sqldriver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// statementKind is the type of a parsed statement.
+type statementKind int
+
+const (
+	kindSelect statementKind = iota
+	kindCount
+	kindInsert
+)
+
+// expr is a literal value or a placeholder, resolved when the statement is executed.
+type expr struct {
+	value any
+	param int // 1-based ordinal of the placeholder, 0 for a literal
+}
+
+// condition is a WHERE condition. IN conditions have several values.
+type condition struct {
+	column   string
+	operator string
+	values   []expr
+}
+
+// comparisonOperators are the symbolic operators accepted in WHERE conditions.
+var comparisonOperators = map[string]bool{"=": true, "!=": true, "<>": true, "<": true, "<=": true, ">": true, ">=": true}
+
+// statement is a parsed SELECT or INSERT statement.
+type statement struct {
+	kind                 statementKind
+	catalog, schema, tbl string
+
+	columns []string // SELECT columns or INSERT columns
+	where   []condition
+	orderBy []builders.OrderClause
+	limit   *expr
+	offset  *expr
+	rows    [][]expr // INSERT values
+
+	numInput int
+}
+
+// token is a lexical token of a statement.
+type token struct {
+	kind  tokenKind
+	text  string // keywords and identifiers as written, strings unquoted
+	param int    // placeholder ordinal
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokQuotedIdent
+	tokString
+	tokNumber
+	tokParam
+	tokSymbol
+)
+
+// parseStatement parses the subset of SQL supported by the driver:
+//
+//	SELECT * | col, ... | COUNT(*) FROM catalog.schema.table
+//	    [WHERE col op value [AND ...]] [ORDER BY col [ASC|DESC], ...] [LIMIT n] [OFFSET n]
+//	INSERT INTO catalog.schema.table (col, ...) VALUES (value, ...), ...
+//
+// Values are string or numeric literals, TRUE, FALSE, NULL or placeholders (? or $n).
+func parseStatement(query string) (*statement, error) {
+	tokens, numInput, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+
+	var stmt *statement
+	switch {
+	case p.keyword("SELECT"):
+		stmt, err = p.parseSelect()
+	case p.keyword("INSERT"):
+		stmt, err = p.parseInsert()
+	default:
+		return nil, fmt.Errorf("%w: only SELECT and INSERT statements are supported", utils.ErrInvalidRequest)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.symbol(";")
+	if p.peek().kind != tokEOF {
+		return nil, p.unexpected()
+	}
+	stmt.numInput = numInput
+	return stmt, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+// keyword consumes the next token if it is the given keyword.
+func (p *parser) keyword(kw string) bool {
+	if tok := p.peek(); tok.kind == tokIdent && strings.EqualFold(tok.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// symbol consumes the next token if it is the given symbol.
+func (p *parser) symbol(sym string) bool {
+	if tok := p.peek(); tok.kind == tokSymbol && tok.text == sym {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectKeyword(kw string) error {
+	if !p.keyword(kw) {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *parser) expectSymbol(sym string) error {
+	if !p.symbol(sym) {
+		return p.unexpected()
+	}
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	tok := p.peek()
+	if tok.kind == tokEOF {
+		return fmt.Errorf("%w: unexpected end of statement", utils.ErrInvalidRequest)
+	}
+	return fmt.Errorf("%w: unexpected %q in statement", utils.ErrInvalidRequest, tok.text)
+}
+
+func (p *parser) identifier() (string, error) {
+	tok := p.peek()
+	if tok.kind != tokIdent && tok.kind != tokQuotedIdent {
+		return "", p.unexpected()
+	}
+	p.pos++
+	return tok.text, nil
+}
+
+// tableName parses a catalog.schema.table name.
+func (p *parser) tableName(stmt *statement) error {
+	parts := make([]string, 0, 3)
+	for {
+		name, err := p.identifier()
+		if err != nil {
+			return err
+		}
+		parts = append(parts, name)
+		if !p.symbol(".") {
+			break
+		}
+	}
+	if len(parts) != 3 {
+		return fmt.Errorf("%w: table must be qualified as catalog.schema.table, got %q", utils.ErrInvalidRequest, strings.Join(parts, "."))
+	}
+	stmt.catalog, stmt.schema, stmt.tbl = parts[0], parts[1], parts[2]
+	return nil
+}
+
+func (p *parser) parseSelect() (*statement, error) {
+	stmt := &statement{kind: kindSelect}
+	switch {
+	case p.symbol("*"):
+	case p.keyword("COUNT"):
+		if err := p.expectSymbol("("); err != nil {
+			return nil, err
+		}
+		if err := p.expectSymbol("*"); err != nil {
+			return nil, err
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		stmt.kind = kindCount
+	default:
+		for {
+			column, err := p.identifier()
+			if err != nil {
+				return nil, err
+			}
+			stmt.columns = append(stmt.columns, column)
+			if !p.symbol(",") {
+				break
+			}
+		}
+	}
+
+	if err := p.expectKeyword("FROM"); err != nil {
+		return nil, err
+	}
+	if err := p.tableName(stmt); err != nil {
+		return nil, err
+	}
+
+	if p.keyword("WHERE") {
+		for {
+			cond, err := p.condition()
+			if err != nil {
+				return nil, err
+			}
+			stmt.where = append(stmt.where, cond)
+			if !p.keyword("AND") {
+				break
+			}
+		}
+	}
+
+	if p.keyword("ORDER") {
+		if err := p.expectKeyword("BY"); err != nil {
+			return nil, err
+		}
+		for {
+			column, err := p.identifier()
+			if err != nil {
+				return nil, err
+			}
+			direction := "ASC"
+			if p.keyword("DESC") {
+				direction = "DESC"
+			} else {
+				p.keyword("ASC")
+			}
+			stmt.orderBy = append(stmt.orderBy, builders.OrderClause{Column: column, Direction: direction})
+			if !p.symbol(",") {
+				break
+			}
+		}
+	}
+
+	if p.keyword("LIMIT") {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		stmt.limit = &value
+	}
+	if p.keyword("OFFSET") {
+		value, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		stmt.offset = &value
+	}
+	return stmt, nil
+}
+
+// condition parses "col op value", "col [NOT] LIKE value" or "col IN (value, ...)".
+func (p *parser) condition() (condition, error) {
+	column, err := p.identifier()
+	if err != nil {
+		return condition{}, err
+	}
+	cond := condition{column: column}
+
+	switch tok := p.peek(); {
+	case tok.kind == tokSymbol && comparisonOperators[tok.text]:
+		p.pos++
+		cond.operator = tok.text
+		if cond.operator == "<>" {
+			cond.operator = "!="
+		}
+	case p.keyword("LIKE"):
+		cond.operator = "LIKE"
+	case p.keyword("ILIKE"):
+		cond.operator = "ILIKE"
+	case p.keyword("NOT"):
+		if err := p.expectKeyword("LIKE"); err != nil {
+			return condition{}, err
+		}
+		cond.operator = "NOT_LIKE"
+	case p.keyword("IN"):
+		cond.operator = "IN"
+		if err := p.expectSymbol("("); err != nil {
+			return condition{}, err
+		}
+		for {
+			value, err := p.value()
+			if err != nil {
+				return condition{}, err
+			}
+			cond.values = append(cond.values, value)
+			if !p.symbol(",") {
+				break
+			}
+		}
+		return cond, p.expectSymbol(")")
+	default:
+		return condition{}, p.unexpected()
+	}
+
+	value, err := p.value()
+	if err != nil {
+		return condition{}, err
+	}
+	cond.values = []expr{value}
+	return cond, nil
+}
+
+func (p *parser) parseInsert() (*statement, error) {
+	stmt := &statement{kind: kindInsert}
+	if err := p.expectKeyword("INTO"); err != nil {
+		return nil, err
+	}
+	if err := p.tableName(stmt); err != nil {
+		return nil, err
+	}
+
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	for {
+		column, err := p.identifier()
+		if err != nil {
+			return nil, err
+		}
+		stmt.columns = append(stmt.columns, column)
+		if !p.symbol(",") {
+			break
+		}
+	}
+	if err := p.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+
+	if err := p.expectKeyword("VALUES"); err != nil {
+		return nil, err
+	}
+	for {
+		if err := p.expectSymbol("("); err != nil {
+			return nil, err
+		}
+		var row []expr
+		for {
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, value)
+			if !p.symbol(",") {
+				break
+			}
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		if len(row) != len(stmt.columns) {
+			return nil, fmt.Errorf("%w: INSERT has %d columns but %d values", utils.ErrInvalidRequest, len(stmt.columns), len(row))
+		}
+		stmt.rows = append(stmt.rows, row)
+		if !p.symbol(",") {
+			break
+		}
+	}
+	return stmt, nil
+}
+
+// value parses a literal or a placeholder.
+func (p *parser) value() (expr, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokParam:
+		p.pos++
+		return expr{param: tok.param}, nil
+	case tokString:
+		p.pos++
+		return expr{value: tok.text}, nil
+	case tokNumber:
+		p.pos++
+		if n, err := strconv.ParseInt(tok.text, 10, 64); err == nil {
+			return expr{value: n}, nil
+		}
+		f, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return expr{}, fmt.Errorf("%w: invalid number %q", utils.ErrInvalidRequest, tok.text)
+		}
+		return expr{value: f}, nil
+	case tokIdent:
+		switch strings.ToUpper(tok.text) {
+		case "TRUE":
+			p.pos++
+			return expr{value: true}, nil
+		case "FALSE":
+			p.pos++
+			return expr{value: false}, nil
+		case "NULL":
+			p.pos++
+			return expr{value: nil}, nil
+		}
+	}
+	return expr{}, p.unexpected()
+}
+
+// tokenize splits a statement into tokens and returns the number of placeholders.
+// Placeholders are either all positional (?) or all numbered ($n).
+func tokenize(query string) ([]token, int, error) {
+	var tokens []token
+	positional, numbered := 0, 0
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+
+		case r == '\'' || r == '"':
+			var text strings.Builder
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, 0, fmt.Errorf("%w: unterminated quoted string", utils.ErrInvalidRequest)
+				}
+				if runes[i] == r {
+					if i+1 < len(runes) && runes[i+1] == r {
+						text.WriteRune(r) // escaped quote
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				text.WriteRune(runes[i])
+				i++
+			}
+			kind := tokString
+			if r == '"' {
+				kind = tokQuotedIdent
+			}
+			tokens = append(tokens, token{kind: kind, text: text.String()})
+
+		case r == '?':
+			positional++
+			tokens = append(tokens, token{kind: tokParam, text: "?", param: positional})
+			i++
+
+		case r == '$':
+			start := i + 1
+			for i++; i < len(runes) && unicode.IsDigit(runes[i]); i++ {
+			}
+			n, err := strconv.Atoi(string(runes[start:i]))
+			if err != nil || n < 1 {
+				return nil, 0, fmt.Errorf("%w: invalid placeholder %q", utils.ErrInvalidRequest, string(runes[start-1:i]))
+			}
+			numbered = max(numbered, n)
+			tokens = append(tokens, token{kind: tokParam, text: string(runes[start-1 : i]), param: n})
+
+		case unicode.IsDigit(r) || (r == '-' && i+1 < len(runes) && unicode.IsDigit(runes[i+1])):
+			start := i
+			for i++; i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.' || runes[i] == 'e' || runes[i] == 'E'); i++ {
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(runes[start:i])})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i++; i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_'); i++ {
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[start:i])})
+
+		default:
+			text := string(r)
+			if i+1 < len(runes) {
+				if two := string(runes[i : i+2]); two == "!=" || two == "<>" || two == "<=" || two == ">=" {
+					text = two
+				}
+			}
+			if !strings.Contains("=<>!(),.*;", text[:1]) {
+				return nil, 0, fmt.Errorf("%w: unexpected character %q in statement", utils.ErrInvalidRequest, r)
+			}
+			tokens = append(tokens, token{kind: tokSymbol, text: text})
+			i += len([]rune(text))
+		}
+	}
+
+	if positional > 0 && numbered > 0 {
+		return nil, 0, fmt.Errorf("%w: cannot mix ? and $n placeholders", utils.ErrInvalidRequest)
+	}
+	tokens = append(tokens, token{kind: tokEOF})
+	return tokens, positional + numbered, nil
+}
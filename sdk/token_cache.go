@@ -0,0 +1,82 @@
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// storedTokenMinValidity is how long a stored token must still be valid to be reused.
+const storedTokenMinValidity = 30 * time.Second
+
+// tokenStoreKey identifies the credentials a token was obtained with, so that clients
+// sharing a store never reuse each other's tokens.
+func (c *Client) tokenStoreKey() string {
+	identity := strings.Join([]string{
+		c.config.KeycloakBaseURL,
+		c.config.KeycloakRealm,
+		c.config.KeycloakClientID,
+		c.config.KeycloakUsername,
+	}, "\n")
+	if c.config.OIDCIssuer != "" {
+		identity += "\n" + c.config.OIDCIssuer
+	}
+	sum := sha256.Sum256([]byte(identity))
+	return "keycloak:" + hex.EncodeToString(sum[:])
+}
+
+// usesTokenStore reports whether tokens of the client are persisted. Impersonated
+// tokens are never persisted: they belong to another user.
+func (c *Client) usesTokenStore() bool {
+	return c.config.TokenStore != nil && c.subjectToken == ""
+}
+
+// loadStoredToken returns the persisted token of the client, or "" if there is none
+// or it is about to expire. Store errors are ignored: a new token is requested instead.
+func (c *Client) loadStoredToken(ctx context.Context) string {
+	if !c.usesTokenStore() {
+		return ""
+	}
+	raw, err := c.config.TokenStore.Load(ctx, c.tokenStoreKey())
+	if err != nil {
+		return ""
+	}
+	token := string(raw)
+	expiry, ok := tokenExpiry(token)
+	if !ok || time.Until(expiry) < storedTokenMinValidity {
+		return ""
+	}
+	return token
+}
+
+// saveToken persists a token obtained from Keycloak. Failing to persist a token
+// does not fail the request, the token is only requested again on the next start.
+func (c *Client) saveToken(ctx context.Context, token string) {
+	if !c.usesTokenStore() {
+		return
+	}
+	_ = c.config.TokenStore.Save(ctx, c.tokenStoreKey(), []byte(token))
+}
+
+// tokenExpiry reads the exp claim of a JWT without verifying its signature.
+func tokenExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
@@ -0,0 +1,112 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// testJWT builds an unsigned JWT expiring at exp.
+func testJWT(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp": %d}`, exp.Unix())))
+	return "eyJhbGciOiJub25lIn0." + payload + ".sig"
+}
+
+func TestEncryptedTokenStore_KeepsPlaintextOffDisk(t *testing.T) {
+	t.Setenv("TEST_TOKEN_KEY", base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32)))
+	dir := t.TempDir()
+	store := utils.NewEncryptedTokenStore(utils.NewFileTokenStore(dir), utils.KeyFromEnv("TEST_TOKEN_KEY"))
+	ctx := context.Background()
+
+	if _, err := store.Load(ctx, "k"); !errors.Is(err, utils.ErrNotFound) {
+		t.Fatalf("Load() of a missing key error = %v, want ErrNotFound", err)
+	}
+	if err := store.Save(ctx, "k", []byte("secret-token")); err != nil {
+		t.Fatalf("Save() unexpected error = %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.token"))
+	if len(files) != 1 {
+		t.Fatalf("expected one token file, got %v", files)
+	}
+	raw, _ := os.ReadFile(files[0])
+	if bytes.Contains(raw, []byte("secret-token")) {
+		t.Error("token file contains the plaintext token")
+	}
+	if info, _ := os.Stat(files[0]); info.Mode().Perm() != 0o600 {
+		t.Errorf("token file mode = %v, want 0600", info.Mode().Perm())
+	}
+
+	value, err := store.Load(ctx, "k")
+	if err != nil || string(value) != "secret-token" {
+		t.Errorf("Load() = %q, %v, want the saved token", value, err)
+	}
+
+	t.Setenv("TEST_TOKEN_KEY", base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{8}, 32)))
+	if _, err := store.Load(ctx, "k"); err == nil {
+		t.Error("Load() with another key should fail")
+	}
+}
+
+func TestClient_ReusesStoredToken(t *testing.T) {
+	issued := 0
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/protocol/openid-connect/token") {
+			issued++
+			_, _ = w.Write([]byte(`{"access_token": "` + testJWT(time.Now().Add(time.Hour)) + `"}`))
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	store := utils.NewFileTokenStore(t.TempDir())
+	newClient := func() *Client {
+		client, err := NewClientFromServiceAccount(
+			&ServiceAccount{ClientID: "sa", ClientSecret: "s", Issuer: server.URL + "/realms/test"},
+			ServiceAccountOptions{BaseURL: server.URL, DataDockID: "dd", TokenStore: store},
+		)
+		if err != nil {
+			t.Fatalf("NewClientFromServiceAccount() unexpected error = %v", err)
+		}
+		return client
+	}
+
+	ctx := context.Background()
+	for range 2 {
+		if _, err := newClient().Catalog("c").Schema("s").Table("t").Get(ctx); err != nil {
+			t.Fatalf("Get() unexpected error = %v", err)
+		}
+	}
+	if issued != 1 {
+		t.Errorf("Keycloak issued %d tokens, want 1 (the second client reuses the stored token)", issued)
+	}
+	if !strings.HasPrefix(gotAuth, "Bearer eyJ") {
+		t.Errorf("Authorization = %q, want the stored token", gotAuth)
+	}
+
+	// An expired token is not reused
+	expired := newClient()
+	if err := store.Save(ctx, expired.tokenStoreKey(), []byte(testJWT(time.Now().Add(-time.Minute)))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := expired.Catalog("c").Schema("s").Table("t").Get(ctx); err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if issued != 2 {
+		t.Errorf("Keycloak issued %d tokens, want 2 after the stored token expired", issued)
+	}
+}
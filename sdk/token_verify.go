@@ -0,0 +1,408 @@
+package sdk
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	_ "crypto/sha256" // Hashes of the RS, PS and ES algorithms
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// Cache periods of the JWKS. A token signed with an unknown key triggers a refetch,
+// for key rotations, at most once per jwksMinRefreshInterval.
+var (
+	jwksMaxAge             = time.Hour
+	jwksMinRefreshInterval = 10 * time.Second
+)
+
+// defaultTokenLeeway is the clock skew tolerated on the token expiry and start.
+const defaultTokenLeeway = 30 * time.Second
+
+// Claims are the claims of a JWT access token.
+type Claims struct {
+	Issuer            string
+	Subject           string
+	Audience          []string
+	ExpiresAt         time.Time // Zero if the token does not expire
+	IssuedAt          time.Time
+	NotBefore         time.Time
+	AuthorizedParty   string   // "azp": the client the token was issued to
+	PreferredUsername string   // Keycloak "preferred_username"
+	Email             string   // Keycloak "email"
+	Scopes            []string // "scope", split on spaces
+	RealmRoles        []string // Keycloak "realm_access.roles"
+	// Raw holds every claim of the token, including custom ones.
+	Raw map[string]any
+}
+
+// HasAudience reports whether the token is intended for audience.
+func (c *Claims) HasAudience(audience string) bool {
+	return slices.Contains(c.Audience, audience)
+}
+
+// HasScope reports whether the token grants scope.
+func (c *Claims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// ParseTokenClaims decodes the claims of a JWT without verifying it. The claims
+// cannot be trusted; see Client.VerifyToken.
+func ParseTokenClaims(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: not a JWT", utils.ErrInvalidToken)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid payload encoding: %w", utils.ErrInvalidToken, err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, fmt.Errorf("%w: invalid claims: %w", utils.ErrInvalidToken, err)
+	}
+
+	claims := &Claims{Raw: raw}
+	claims.Issuer, _ = raw["iss"].(string)
+	claims.Subject, _ = raw["sub"].(string)
+	claims.AuthorizedParty, _ = raw["azp"].(string)
+	claims.PreferredUsername, _ = raw["preferred_username"].(string)
+	claims.Email, _ = raw["email"].(string)
+	claims.ExpiresAt = unixClaim(raw["exp"])
+	claims.IssuedAt = unixClaim(raw["iat"])
+	claims.NotBefore = unixClaim(raw["nbf"])
+	switch aud := raw["aud"].(type) {
+	case string:
+		claims.Audience = []string{aud}
+	case []any:
+		claims.Audience = stringList(aud)
+	}
+	if scope, ok := raw["scope"].(string); ok {
+		claims.Scopes = strings.Fields(scope)
+	}
+	if access, ok := raw["realm_access"].(map[string]any); ok {
+		roles, _ := access["roles"].([]any)
+		claims.RealmRoles = stringList(roles)
+	}
+	return claims, nil
+}
+
+func unixClaim(value any) time.Time {
+	seconds, ok := value.(float64)
+	if !ok || seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(seconds), 0)
+}
+
+func stringList(values []any) []string {
+	list := make([]string, 0, len(values))
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+// VerifyOptions configures VerifyToken. All fields are optional.
+type VerifyOptions struct {
+	Audience string        // Required audience (e.g. the client ID of the service), none if empty
+	Leeway   time.Duration // Clock skew tolerated on the expiry, default 30s
+}
+
+// VerifyToken verifies a JWT issued by the issuer of the client (see
+// OIDCConfiguration): its signature against the issuer's JWKS, its issuer, audience
+// and validity period. The JWKS is cached and refetched when a token is signed with
+// an unknown key, after a key rotation.
+//
+// Authentic tokens past their expiry fail with utils.ErrTokenExpired; every other
+// failure wraps utils.ErrInvalidToken.
+//
+// Example:
+//
+//	claims, err := client.VerifyToken(ctx, bearer, sdk.VerifyOptions{Audience: "reporting"})
+//	switch {
+//	case errors.Is(err, utils.ErrTokenExpired):
+//	    // Ask the caller to refresh its token
+//	case err != nil:
+//	    // Reject the request
+//	}
+//	fmt.Println(claims.Subject, claims.RealmRoles)
+func (c *Client) VerifyToken(ctx context.Context, token string, opts VerifyOptions) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: not a JWT", utils.ErrInvalidToken)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err == nil {
+		err = json.Unmarshal(rawHeader, &header)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid header: %w", utils.ErrInvalidToken, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid signature encoding: %w", utils.ErrInvalidToken, err)
+	}
+
+	provider, err := c.OIDCConfiguration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if provider.JWKSURI == "" {
+		return nil, fmt.Errorf("%w: the OIDC issuer publishes no JWKS", utils.ErrInvalidConfiguration)
+	}
+	key, err := c.signingKey(ctx, provider.JWKSURI, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if key.alg != "" && key.alg != header.Alg {
+		return nil, fmt.Errorf("%w: signed with %q, the key is for %q", utils.ErrInvalidToken, header.Alg, key.alg)
+	}
+	if err := verifySignature(header.Alg, key.key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	claims, err := ParseTokenClaims(token)
+	if err != nil {
+		return nil, err
+	}
+	issuer := provider.Issuer
+	if issuer == "" {
+		issuer = c.oidcIssuer()
+	}
+	if claims.Issuer != issuer {
+		return nil, fmt.Errorf("%w: issued by %q, want %q", utils.ErrInvalidToken, claims.Issuer, issuer)
+	}
+	if opts.Audience != "" && !claims.HasAudience(opts.Audience) {
+		return nil, fmt.Errorf("%w: not intended for %q", utils.ErrInvalidToken, opts.Audience)
+	}
+
+	leeway := opts.Leeway
+	if leeway <= 0 {
+		leeway = defaultTokenLeeway
+	}
+	now := time.Now()
+	if !claims.ExpiresAt.IsZero() && now.After(claims.ExpiresAt.Add(leeway)) {
+		return nil, fmt.Errorf("%w: expired at %s", utils.ErrTokenExpired, claims.ExpiresAt.Format(time.RFC3339))
+	}
+	if !claims.NotBefore.IsZero() && now.Add(leeway).Before(claims.NotBefore) {
+		return nil, fmt.Errorf("%w: not valid before %s", utils.ErrInvalidToken, claims.NotBefore.Format(time.RFC3339))
+	}
+	return claims, nil
+}
+
+// verifySignature checks a JWS signature. Only asymmetric algorithms are accepted,
+// and ES algorithms only with the curve they are defined on.
+func verifySignature(alg string, key crypto.PublicKey, signed, signature []byte) error {
+	var hash crypto.Hash
+	var curve elliptic.Curve
+	switch alg {
+	case "RS256", "PS256":
+		hash = crypto.SHA256
+	case "RS384", "PS384":
+		hash = crypto.SHA384
+	case "RS512", "PS512":
+		hash = crypto.SHA512
+	case "ES256":
+		hash, curve = crypto.SHA256, elliptic.P256()
+	case "ES384":
+		hash, curve = crypto.SHA384, elliptic.P384()
+	case "ES512":
+		hash, curve = crypto.SHA512, elliptic.P521()
+	default:
+		return fmt.Errorf("%w: unsupported algorithm %q", utils.ErrInvalidToken, alg)
+	}
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	var err error
+	if curve == nil {
+		rsaKey, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("%w: %s requires an RSA key", utils.ErrInvalidToken, alg)
+		}
+		if alg[0] == 'R' {
+			err = rsa.VerifyPKCS1v15(rsaKey, hash, digest, signature)
+		} else {
+			err = rsa.VerifyPSS(rsaKey, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+	} else {
+		ecKey, ok := key.(*ecdsa.PublicKey)
+		if !ok || ecKey.Curve != curve {
+			return fmt.Errorf("%w: %s requires a %s key", utils.ErrInvalidToken, alg, curve.Params().Name)
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("%w: invalid %s signature", utils.ErrInvalidToken, alg)
+		}
+		r, s := new(big.Int).SetBytes(signature[:size]), new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(ecKey, digest, r, s) {
+			err = fmt.Errorf("verification error")
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%w: signature mismatch", utils.ErrInvalidToken)
+	}
+	return nil
+}
+
+// jwksCache holds the signing keys of each JWKS URI; shared with derived clients.
+type jwksCache struct {
+	mu   sync.Mutex
+	sets map[string]*keySet
+}
+
+// keySet is a fetched JWKS, keyed by key ID.
+type keySet struct {
+	keys    map[string]jwksKey
+	fetched time.Time
+}
+
+// jwksKey is a key of a JWKS and the algorithm it is restricted to, if any.
+type jwksKey struct {
+	key crypto.PublicKey
+	alg string
+}
+
+// signingKey returns the key kid of the JWKS, refetching the set when it is stale
+// or does not know kid. Tokens without a key ID are accepted for single-key sets.
+// The cache is not locked during the fetch, so that a slow issuer does not hold up
+// the tokens signed with known keys.
+func (c *Client) signingKey(ctx context.Context, jwksURI, kid string) (jwksKey, error) {
+	cache := c.jwks
+	if cache == nil {
+		cache = &jwksCache{}
+	}
+	cache.mu.Lock()
+	set := cache.sets[jwksURI]
+	cache.mu.Unlock()
+
+	if set != nil && time.Since(set.fetched) < jwksMaxAge {
+		if key, ok := set.key(kid); ok {
+			return key, nil
+		}
+		if time.Since(set.fetched) < jwksMinRefreshInterval {
+			return jwksKey{}, fmt.Errorf("%w: unknown signing key %q", utils.ErrInvalidToken, kid)
+		}
+	}
+
+	set, err := c.fetchKeySet(ctx, jwksURI)
+	if err != nil {
+		return jwksKey{}, err
+	}
+	cache.mu.Lock()
+	if cache.sets == nil {
+		cache.sets = map[string]*keySet{}
+	}
+	if current := cache.sets[jwksURI]; current == nil || current.fetched.Before(set.fetched) {
+		cache.sets[jwksURI] = set
+	}
+	cache.mu.Unlock()
+	if key, ok := set.key(kid); ok {
+		return key, nil
+	}
+	return jwksKey{}, fmt.Errorf("%w: unknown signing key %q", utils.ErrInvalidToken, kid)
+}
+
+func (s *keySet) key(kid string) (jwksKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// fetchKeySet reads a JWKS, keeping its RSA and EC signature keys.
+func (c *Client) fetchKeySet(ctx context.Context, jwksURI string) (*keySet, error) {
+	status, body, err := c.getDocument(ctx, jwksURI)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read the JWKS: %w", utils.ErrAuthenticationFailed, err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("%w: JWKS request returned HTTP %d", utils.ErrAuthenticationFailed, status)
+	}
+	var document struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			Alg string `json:"alg"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			Crv string `json:"crv"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, fmt.Errorf("%w: invalid JWKS: %w", utils.ErrAuthenticationFailed, err)
+	}
+
+	set := &keySet{keys: map[string]jwksKey{}, fetched: time.Now()}
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		var key crypto.PublicKey
+		switch jwk.Kty {
+		case "RSA":
+			n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+			e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+			if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+				continue // Skip malformed keys, others may still verify tokens
+			}
+			key = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		case "EC":
+			key = parseECKey(jwk.Crv, jwk.X, jwk.Y)
+		}
+		if key != nil {
+			set.keys[jwk.Kid] = jwksKey{key: key, alg: jwk.Alg}
+		}
+	}
+	return set, nil
+}
+
+// parseECKey decodes the coordinates of an EC JWK, nil if invalid.
+func parseECKey(crv, x, y string) *ecdsa.PublicKey {
+	curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+	curve, ok := curves[crv]
+	if !ok {
+		return nil
+	}
+	size := (curve.Params().BitSize + 7) / 8
+	xBytes, errX := base64.RawURLEncoding.DecodeString(x)
+	yBytes, errY := base64.RawURLEncoding.DecodeString(y)
+	if errX != nil || errY != nil || len(xBytes) > size || len(yBytes) > size {
+		return nil
+	}
+	point := make([]byte, 1+2*size)
+	point[0] = 4 // Uncompressed
+	copy(point[1+size-len(xBytes):], xBytes)
+	copy(point[1+2*size-len(yBytes):], yBytes)
+	key, err := ecdsa.ParseUncompressedPublicKey(curve, point)
+	if err != nil {
+		return nil
+	}
+	return key
+}
@@ -0,0 +1,272 @@
+package sdk
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// jwksServer serves the OpenID configuration of the issuer /idp and its JWKS,
+// counting the JWKS requests. Keys can be rotated with setKeys.
+type jwksServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	keys     []map[string]string
+	requests int
+}
+
+func newJWKSServer(t *testing.T, keys ...map[string]string) *jwksServer {
+	s := &jwksServer{keys: keys}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/idp" + openIDConfigurationPath:
+			_, _ = w.Write([]byte(`{"issuer": "` + s.URL + `/idp", "token_endpoint": "` + s.URL + `/idp/token", "jwks_uri": "` + s.URL + `/idp/keys"}`))
+		case "/idp/keys":
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.requests++
+			_ = json.NewEncoder(w).Encode(map[string]any{"keys": s.keys})
+		case "/api/rows":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *jwksServer) setKeys(keys ...map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = keys
+}
+
+func (s *jwksServer) jwksRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{"kty": "RSA", "kid": kid, "use": "sig", "n": b64(key.N.Bytes()), "e": b64(big.NewInt(int64(key.E)).Bytes())}
+}
+
+// signJWT builds a token signed with an RSA (RS256) or EC (ES256) key.
+func signJWT(t *testing.T, key crypto.Signer, kid string, claims map[string]any) string {
+	t.Helper()
+	alg := "RS256"
+	if _, ok := key.(*ecdsa.PrivateKey); ok {
+		alg = "ES256"
+	}
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+	}
+	return signed + "." + b64(signature)
+}
+
+func TestClient_VerifyToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	server := newJWKSServer(t, rsaJWK("k1", key))
+	issuer := server.URL + "/idp"
+	client := NewClient(utils.Configuration{OIDCIssuer: issuer})
+	ctx := context.Background()
+
+	valid := signJWT(t, key, "k1", map[string]any{
+		"iss": issuer, "sub": "user-1", "aud": []string{"reporting", "account"}, "exp": time.Now().Add(time.Hour).Unix(),
+		"scope": "openid email", "preferred_username": "jane", "realm_access": map[string]any{"roles": []string{"analyst"}},
+	})
+	claims, err := client.VerifyToken(ctx, valid, VerifyOptions{Audience: "reporting"})
+	if err != nil {
+		t.Fatalf("VerifyToken() unexpected error = %v", err)
+	}
+	if claims.Subject != "user-1" || claims.PreferredUsername != "jane" || !claims.HasScope("email") || len(claims.RealmRoles) != 1 {
+		t.Errorf("VerifyToken() claims = %+v", claims)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		opts  VerifyOptions
+		want  error
+	}{
+		{"expired", signJWT(t, key, "k1", map[string]any{"iss": issuer, "exp": time.Now().Add(-time.Hour).Unix()}), VerifyOptions{}, utils.ErrTokenExpired},
+		{"within leeway", signJWT(t, key, "k1", map[string]any{"iss": issuer, "exp": time.Now().Add(-10 * time.Second).Unix()}), VerifyOptions{}, nil},
+		{"other key", signJWT(t, other, "k1", map[string]any{"iss": issuer}), VerifyOptions{}, utils.ErrInvalidToken},
+		{"other issuer", signJWT(t, key, "k1", map[string]any{"iss": server.URL + "/realms/acme"}), VerifyOptions{}, utils.ErrInvalidToken},
+		{"other audience", valid, VerifyOptions{Audience: "billing"}, utils.ErrInvalidToken},
+		{"not a JWT", "opaque", VerifyOptions{}, utils.ErrInvalidToken},
+		{"unsigned", testJWT(time.Now().Add(time.Hour)), VerifyOptions{}, utils.ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.VerifyToken(ctx, tt.token, tt.opts)
+			if tt.want == nil && err != nil {
+				t.Errorf("VerifyToken() unexpected error = %v", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("VerifyToken() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if got := server.jwksRequests(); got != 1 {
+		t.Errorf("JWKS requests = %d, want 1", got)
+	}
+}
+
+func TestClient_VerifyTokenKeyRotation(t *testing.T) {
+	defer func(interval time.Duration) { jwksMinRefreshInterval = interval }(jwksMinRefreshInterval)
+	jwksMinRefreshInterval = 0
+
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newJWKSServer(t, rsaJWK("old", oldKey))
+	issuer := server.URL + "/idp"
+	client := NewClient(utils.Configuration{OIDCIssuer: issuer})
+	ctx := context.Background()
+
+	if _, err := client.VerifyToken(ctx, signJWT(t, oldKey, "old", map[string]any{"iss": issuer}), VerifyOptions{}); err != nil {
+		t.Fatalf("VerifyToken() unexpected error = %v", err)
+	}
+
+	// The issuer rotates to an EC key: the unknown key ID refreshes the cached set
+	point, _ := newKey.PublicKey.Bytes()
+	server.setKeys(map[string]string{"kty": "EC", "kid": "new", "crv": "P-256", "x": b64(point[1:33]), "y": b64(point[33:])})
+	if _, err := client.VerifyToken(ctx, signJWT(t, newKey, "new", map[string]any{"iss": issuer}), VerifyOptions{}); err != nil {
+		t.Fatalf("VerifyToken() after rotation unexpected error = %v", err)
+	}
+	if _, err := client.VerifyToken(ctx, signJWT(t, oldKey, "old", map[string]any{"iss": issuer}), VerifyOptions{}); !errors.Is(err, utils.ErrInvalidToken) {
+		t.Errorf("VerifyToken() with a retired key error = %v, want ErrInvalidToken", err)
+	}
+	if got := server.jwksRequests(); got != 3 {
+		t.Errorf("JWKS requests = %d, want 3", got)
+	}
+}
+
+func TestClient_VerifyTokensOnUnauthorized(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	forger, _ := rsa.GenerateKey(rand.Reader, 2048)
+	server := newJWKSServer(t, rsaJWK("k1", key))
+	issuer := server.URL + "/idp"
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"expired", signJWT(t, key, "k1", map[string]any{"iss": issuer, "exp": time.Now().Add(-time.Hour).Unix()}), utils.ErrTokenExpired},
+		{"forged", signJWT(t, forger, "k1", map[string]any{"iss": issuer, "exp": time.Now().Add(-time.Hour).Unix()}), utils.ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(utils.Configuration{BaseURL: server.URL, OIDCIssuer: issuer, Token: tt.token, VerifyTokens: true})
+			_, err := client.Do(context.Background(), "GET", server.URL+"/api/rows", nil)
+			if !errors.Is(err, utils.ErrAuthenticationFailed) || !errors.Is(err, tt.want) {
+				t.Errorf("Do() error = %v, want ErrAuthenticationFailed and %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTokenClaims(t *testing.T) {
+	claims, err := ParseTokenClaims(testJWT(time.Unix(1700000000, 0)))
+	if err != nil {
+		t.Fatalf("ParseTokenClaims() unexpected error = %v", err)
+	}
+	if !claims.ExpiresAt.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("ExpiresAt = %v", claims.ExpiresAt)
+	}
+	if _, err := ParseTokenClaims("a.b"); !errors.Is(err, utils.ErrInvalidToken) {
+		t.Errorf("ParseTokenClaims() error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestClient_VerifyTokenAlgorithmBinding(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restricted := rsaJWK("rsa", rsaKey)
+	restricted["alg"] = "PS256"
+	point, _ := ecKey.PublicKey.Bytes()
+	server := newJWKSServer(t, restricted,
+		map[string]string{"kty": "EC", "kid": "p384", "crv": "P-384", "x": b64(point[1:49]), "y": b64(point[49:])})
+	issuer := server.URL + "/idp"
+	client := NewClient(utils.Configuration{OIDCIssuer: issuer})
+	ctx := context.Background()
+
+	// An RS256 token cannot use a key the JWKS restricts to PS256
+	if _, err := client.VerifyToken(ctx, signJWT(t, rsaKey, "rsa", map[string]any{"iss": issuer}), VerifyOptions{}); !errors.Is(err, utils.ErrInvalidToken) {
+		t.Errorf("VerifyToken() with a mismatched JWK alg error = %v, want ErrInvalidToken", err)
+	}
+
+	// ES256 is only defined on P-256: a valid SHA-256 signature by a P-384 key is rejected
+	header, _ := json.Marshal(map[string]string{"alg": "ES256", "kid": "p384", "typ": "JWT"})
+	payload, _ := json.Marshal(map[string]any{"iss": issuer})
+	signed := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signed))
+	r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := make([]byte, 96)
+	r.FillBytes(signature[:48])
+	s.FillBytes(signature[48:])
+	if _, err := client.VerifyToken(ctx, signed+"."+b64(signature), VerifyOptions{}); !errors.Is(err, utils.ErrInvalidToken) {
+		t.Errorf("VerifyToken() with an ES256 token signed on P-384 error = %v, want ErrInvalidToken", err)
+	}
+
+	for _, alg := range []string{"none", "HS256", "RS1", "ES256K"} {
+		if err := verifySignature(alg, &rsaKey.PublicKey, []byte(signed), signature); !errors.Is(err, utils.ErrInvalidToken) {
+			t.Errorf("verifySignature(%q) error = %v, want ErrInvalidToken", alg, err)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// ResponseTransformer rewrites a successful response before it is returned, e.g.
+// renaming keys or masking values of resp.Data. method and endpoint identify the
+// request, so that a transformer can be limited to some endpoints. An error fails
+// the request.
+type ResponseTransformer func(ctx context.Context, method, endpoint string, resp *utils.Response) error
+
+// responseTransformers is the transformer pipeline of a client, shared with derived clients.
+type responseTransformers struct {
+	mu  sync.RWMutex
+	fns []ResponseTransformer
+}
+
+// AddResponseTransformer appends a transformer run, in the order added, on every
+// successful response of the client and of the clients derived from it: table
+// queries, searches, catalog and control plane calls. Responses the SDK decodes
+// itself (capabilities, Trino protocol, SQL results before they are turned into
+// rows) are not transformed.
+//
+// Transformers renaming keys also affect helpers reading columns by name, such as
+// keyset cursors; limit them to the endpoints concerned.
+//
+// Example:
+//
+//	client.AddResponseTransformer(sdk.TransformRows(func(row map[string]any) error {
+//	    delete(row, "internal_notes")
+//	    return nil
+//	}))
+func (c *Client) AddResponseTransformer(fn ResponseTransformer) {
+	c.transformers.mu.Lock()
+	defer c.transformers.mu.Unlock()
+	c.transformers.fns = append(c.transformers.fns, fn)
+}
+
+// transformResponse runs the transformers of the client on resp.
+func (c *Client) transformResponse(ctx context.Context, method, endpoint string, resp *utils.Response) error {
+	if c.transformers == nil || resp == nil || utils.TransformersSkipped(ctx) {
+		return nil
+	}
+	c.transformers.mu.RLock()
+	fns := c.transformers.fns
+	c.transformers.mu.RUnlock()
+
+	for _, fn := range fns {
+		if err := fn(ctx, method, endpoint, resp); err != nil {
+			return fmt.Errorf("response transformer failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// TransformRows returns a transformer applying fn to the rows of list responses,
+// and to the object of single-object responses. fn edits the row in place.
+func TransformRows(fn func(row map[string]any) error) ResponseTransformer {
+	return func(ctx context.Context, method, endpoint string, resp *utils.Response) error {
+		switch data := resp.Data.(type) {
+		case map[string]any:
+			return fn(data)
+		case []any:
+			for _, item := range data {
+				if row, ok := item.(map[string]any); ok {
+					if err := fn(row); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// CamelCaseKeys returns a transformer renaming the snake_case keys of rows to
+// camelCase, e.g. "customer_id" to "customerId".
+func CamelCaseKeys() ResponseTransformer {
+	return TransformRows(func(row map[string]any) error {
+		for key, value := range row {
+			if renamed := camelCase(key); renamed != key {
+				delete(row, key)
+				row[renamed] = value
+			}
+		}
+		return nil
+	})
+}
+
+func camelCase(key string) string {
+	if !strings.Contains(key, "_") {
+		return key
+	}
+	var b strings.Builder
+	upper := false
+	for i, r := range key {
+		switch {
+		case r == '_' && i > 0:
+			upper = true
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// timestampLayouts are the layouts recognized by NormalizeTimestamps.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999 Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// NormalizeTimestamps returns a transformer rewriting the string values of rows
+// that parse as timestamps to RFC 3339 in loc (UTC when nil). Timestamps without
+// a zone are taken as UTC. Dates and other strings are left untouched.
+func NormalizeTimestamps(loc *time.Location) ResponseTransformer {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return TransformRows(func(row map[string]any) error {
+		for key, value := range row {
+			s, ok := value.(string)
+			if !ok || len(s) < len("2006-01-02T15:04") || s[4] != '-' {
+				continue
+			}
+			for _, layout := range timestampLayouts {
+				if t, err := time.Parse(layout, s); err == nil {
+					row[key] = t.In(loc).Format(time.RFC3339Nano)
+					break
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,70 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func newTransformerTestClient() *Client {
+	return &Client{
+		config:       utils.Configuration{BaseURL: "http://localhost", Token: "test-token"},
+		transformers: &responseTransformers{},
+		httpClient: &http.Client{Transport: &mockRoundTripper{
+			roundTripFunc: func(req *http.Request) (*http.Response, error) {
+				body := `[{"customer_id": 1, "created_at": "2025-03-01 10:15:00.000 +01:00", "day": "2025-03-01"}]`
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+			},
+		}},
+	}
+}
+
+func TestClient_AddResponseTransformer(t *testing.T) {
+	client := newTransformerTestClient()
+	client.AddResponseTransformer(NormalizeTimestamps(nil))
+	client.AddResponseTransformer(CamelCaseKeys())
+
+	// Derived clients share the pipeline
+	resp, err := client.WithHeader("X-Tenant-ID", "t1").Do(context.Background(), "GET", "http://localhost/orders", nil)
+	if err != nil {
+		t.Fatalf("Do() unexpected error = %v", err)
+	}
+	rows, _ := resp.Rows()
+	if len(rows) != 1 || rows[0]["customerId"] == nil || rows[0]["customer_id"] != nil {
+		t.Fatalf("Expected camelCase keys, got %v", rows)
+	}
+	if rows[0]["createdAt"] != "2025-03-01T09:15:00Z" || rows[0]["day"] != "2025-03-01" {
+		t.Errorf("Expected the timestamp to be normalized to UTC, got %v", rows[0])
+	}
+
+	resp, err = client.Do(utils.ContextWithoutTransformers(context.Background()), "GET", "http://localhost/orders", nil)
+	if err != nil {
+		t.Fatalf("Do() unexpected error = %v", err)
+	}
+	if rows, _ := resp.Rows(); rows[0]["customer_id"] == nil {
+		t.Errorf("Expected the response to be left untouched, got %v", rows)
+	}
+}
+
+func TestClient_ResponseTransformerError(t *testing.T) {
+	client := newTransformerTestClient()
+	masked := errors.New("masking failed")
+	client.AddResponseTransformer(func(ctx context.Context, method, endpoint string, resp *utils.Response) error {
+		if strings.HasSuffix(endpoint, "/orders") {
+			return masked
+		}
+		return nil
+	})
+
+	if _, err := client.Do(context.Background(), "GET", "http://localhost/orders", nil); !errors.Is(err, masked) {
+		t.Errorf("Expected the transformer error, got %v", err)
+	}
+	if _, err := client.Do(context.Background(), "GET", "http://localhost/customers", nil); err != nil {
+		t.Errorf("Do() unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,448 @@
+// Package trino speaks the Trino REST protocol to the Trino coordinator of a
+// datadock, for analytics that outgrow the OpenAPI layer: large scans, joins
+// across catalogs and long-running statements.
+//
+// Requests go through the SDK client, so they share its authentication, retries,
+// metrics and history. Results are streamed page by page as Trino produces them:
+//
+//	stmt, err := client.Trino().Catalog("sales", "public").Execute(ctx,
+//	    "SELECT region, sum(amount) FROM orders GROUP BY region")
+//	if err != nil { ... }
+//	for row, err := range stmt.Rows(ctx) {
+//	    if err != nil { ... }
+//	    fmt.Println(row[0], row[1])
+//	}
+package trino
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/builders"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// cancelTimeout bounds the cancellation of a statement abandoned by its caller.
+const cancelTimeout = 5 * time.Second
+
+// DefaultSource is the X-Trino-Source of the statements, shown in the Trino UI.
+const DefaultSource = "hyperfluid-sdk-go"
+
+// Client submits statements to a Trino coordinator. It is safe for concurrent
+// use; Catalog, User and Session return modified copies.
+type Client struct {
+	client   builders.ClientInterface
+	resolver *endpointResolver
+
+	catalog string
+	schema  string
+	user    string
+	source  string
+	session map[string]string
+}
+
+// endpointResolver resolves the coordinator URL once; shared by the copies of a Client.
+type endpointResolver struct {
+	resolve  func(ctx context.Context) (string, error)
+	mu       sync.Mutex
+	endpoint string
+}
+
+func (r *endpointResolver) get(ctx context.Context) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.endpoint != "" {
+		return r.endpoint, nil
+	}
+	endpoint, err := r.resolve(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the Trino endpoint: %w", err)
+	}
+	r.endpoint = strings.TrimSuffix(endpoint, "/")
+	return r.endpoint, nil
+}
+
+// New returns a client for the Trino coordinator at endpoint, e.g.
+// "https://trino.example.com:443".
+func New(client builders.ClientInterface, endpoint string) *Client {
+	return NewWithResolver(client, func(context.Context) (string, error) { return endpoint, nil })
+}
+
+// NewWithResolver returns a client whose coordinator URL is resolved on first use,
+// e.g. from the connection details of a datadock (see sdk.Client.Trino).
+func NewWithResolver(client builders.ClientInterface, resolve func(ctx context.Context) (string, error)) *Client {
+	return &Client{client: client, resolver: &endpointResolver{resolve: resolve}, source: DefaultSource}
+}
+
+// Catalog returns a copy of the client resolving unqualified table names in the
+// given catalog and schema. An empty schema leaves it unset.
+func (c *Client) Catalog(catalog, schema string) *Client {
+	derived := c.clone()
+	derived.catalog, derived.schema = catalog, schema
+	return derived
+}
+
+// User returns a copy of the client sending the given X-Trino-User. By default
+// Trino takes the user from the access token.
+func (c *Client) User(user string) *Client {
+	derived := c.clone()
+	derived.user = user
+	return derived
+}
+
+// Session returns a copy of the client setting a session property on its
+// statements, e.g. Session("query_max_run_time", "10m").
+func (c *Client) Session(name, value string) *Client {
+	derived := c.clone()
+	derived.session[name] = value
+	return derived
+}
+
+func (c *Client) clone() *Client {
+	derived := *c
+	derived.session = make(map[string]string, len(c.session)+1)
+	for name, value := range c.session {
+		derived.session[name] = value
+	}
+	return &derived
+}
+
+// headers returns the Trino protocol headers of the statements of the client.
+func (c *Client) headers() http.Header {
+	headers := http.Header{}
+	headers.Set("X-Trino-Source", c.source)
+	if c.catalog != "" {
+		headers.Set("X-Trino-Catalog", c.catalog)
+	}
+	if c.schema != "" {
+		headers.Set("X-Trino-Schema", c.schema)
+	}
+	if c.user != "" {
+		headers.Set("X-Trino-User", c.user)
+	}
+	if len(c.session) > 0 {
+		names := make([]string, 0, len(c.session))
+		for name := range c.session {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		properties := make([]string, len(names))
+		for i, name := range names {
+			properties[i] = name + "=" + url.QueryEscape(c.session[name])
+		}
+		headers.Set("X-Trino-Session", strings.Join(properties, ","))
+	}
+	return headers
+}
+
+// Execute submits a statement and returns it once Trino accepted it. The
+// results are then read with Pages or Rows; Cancel stops the statement.
+func (c *Client) Execute(ctx context.Context, sql string) (*Statement, error) {
+	if strings.TrimSpace(sql) == "" {
+		return nil, fmt.Errorf("%w: empty statement", utils.ErrInvalidRequest)
+	}
+	endpoint, err := c.resolver.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := &Statement{client: c.client}
+	resp, err := c.client.Do(utils.ContextWithoutTransformers(utils.ContextWithHeaders(ctx, c.headers())), "POST", endpoint+"/v1/statement", []byte(sql))
+	if err != nil {
+		return nil, err
+	}
+	if err := stmt.apply(resp); err != nil {
+		return nil, err
+	}
+	return stmt, nil
+}
+
+// Query runs a statement and returns its columns and every row. Prefer Execute
+// and Rows for results that do not fit in memory.
+func (c *Client) Query(ctx context.Context, sql string) ([]Column, [][]any, error) {
+	stmt, err := c.Execute(ctx, sql)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rows [][]any
+	for row, err := range stmt.Rows(ctx) {
+		if err != nil {
+			return nil, nil, err
+		}
+		rows = append(rows, row)
+	}
+	return stmt.Columns(), rows, nil
+}
+
+// Statement is a statement submitted to Trino. Its methods must not be called concurrently,
+// except Cancel.
+type Statement struct {
+	client builders.ClientInterface
+
+	mu      sync.Mutex
+	id      string
+	infoURI string
+	nextURI string
+	columns []Column
+	stats   Stats
+	pending [][]any // Rows of the submit response, returned by the first page
+}
+
+// Column is a column of a result, with its Trino type.
+type Column struct {
+	Name string
+	// Type is the full Trino type, e.g. "varchar(20)" or "decimal(10,2)".
+	Type string
+	// RawType is the type without parameters, e.g. "varchar" or "decimal".
+	RawType string
+}
+
+// Stats is the progress of a statement.
+type Stats struct {
+	State           string // QUEUED, PLANNING, RUNNING, FINISHED or FAILED
+	Queued          bool
+	Scheduled       bool
+	CompletedSplits int64
+	TotalSplits     int64
+	ProcessedRows   int64
+	ProcessedBytes  int64
+	ElapsedTimeMs   int64
+}
+
+// Page is a batch of rows returned by Trino.
+type Page struct {
+	Columns []Column
+	Rows    [][]any
+	Stats   Stats
+}
+
+// ID returns the Trino query ID, e.g. for system.runtime.queries.
+func (s *Statement) ID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.id
+}
+
+// InfoURI returns the page of the statement in the Trino UI.
+func (s *Statement) InfoURI() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.infoURI
+}
+
+// Columns returns the columns of the result, or nil until Trino reported them
+// (usually with the first page).
+func (s *Statement) Columns() []Column {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.columns
+}
+
+// Stats returns the last reported progress of the statement.
+func (s *Statement) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
+
+// Done reports whether every page has been read.
+func (s *Statement) Done() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.nextURI == "" && s.pending == nil
+}
+
+// NextPage fetches the next page of rows. Pages can be empty while the statement
+// is queued or running. It returns nil once the statement is done. When ctx is
+// cancelled while waiting for a page, the statement is cancelled on the cluster.
+func (s *Statement) NextPage(ctx context.Context) (*Page, error) {
+	s.mu.Lock()
+	if pending := s.pending; pending != nil {
+		s.pending = nil
+		page := &Page{Columns: s.columns, Rows: pending, Stats: s.stats}
+		s.mu.Unlock()
+		return page, nil
+	}
+	nextURI := s.nextURI
+	s.mu.Unlock()
+	if nextURI == "" {
+		return nil, nil
+	}
+
+	resp, err := s.client.Do(utils.ContextWithoutTransformers(ctx), "GET", nextURI, nil)
+	if err != nil {
+		if ctx.Err() != nil {
+			// The caller gave up: stop the query on the cluster rather than letting it run
+			cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cancelTimeout)
+			defer cancel()
+			_ = s.Cancel(cancelCtx)
+		}
+		return nil, err
+	}
+	if err := s.apply(resp); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	page := &Page{Columns: s.columns, Rows: s.pending, Stats: s.stats}
+	s.pending = nil
+	return page, nil
+}
+
+// Pages returns an iterator over the pages of the result that skips the empty
+// ones. Stopping the iteration before the end cancels the statement.
+func (s *Statement) Pages(ctx context.Context) iter.Seq2[*Page, error] {
+	return func(yield func(*Page, error) bool) {
+		for {
+			page, err := s.NextPage(ctx)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if page == nil {
+				return
+			}
+			if len(page.Rows) == 0 {
+				continue
+			}
+			if !yield(page, nil) {
+				if !s.Done() {
+					_ = s.Cancel(context.WithoutCancel(ctx))
+				}
+				return
+			}
+		}
+	}
+}
+
+// Rows returns an iterator over the rows of the result, with the values in the
+// order of Columns. Stopping the iteration before the end cancels the statement.
+func (s *Statement) Rows(ctx context.Context) iter.Seq2[[]any, error] {
+	return func(yield func([]any, error) bool) {
+		for page, err := range s.Pages(ctx) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, row := range page.Rows {
+				if !yield(row, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Cancel stops the statement. Canceling a finished statement is a no-op.
+func (s *Statement) Cancel(ctx context.Context) error {
+	s.mu.Lock()
+	nextURI := s.nextURI
+	s.nextURI, s.pending = "", nil
+	s.mu.Unlock()
+	if nextURI == "" {
+		return nil
+	}
+	_, err := s.client.Do(utils.ContextWithoutTransformers(ctx), "DELETE", nextURI, nil)
+	return err
+}
+
+// apply records a response of the Trino protocol.
+func (s *Statement) apply(resp *utils.Response) error {
+	var result queryResults
+	if err := utils.UnmarshalData(resp.Data, &result); err != nil {
+		return fmt.Errorf("%w: unexpected Trino response: %w", utils.ErrAPIError, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.id, s.infoURI, s.nextURI = result.ID, result.InfoURI, result.NextURI
+	s.stats = result.Stats.stats()
+	if result.Error != nil {
+		s.nextURI = ""
+		return result.Error
+	}
+	if len(result.Columns) > 0 && s.columns == nil {
+		s.columns = make([]Column, len(result.Columns))
+		for i, column := range result.Columns {
+			s.columns[i] = Column{Name: column.Name, Type: column.Type, RawType: column.TypeSignature.RawType}
+		}
+	}
+	if data := resultData(resp.Data); data != nil {
+		s.pending = data
+	}
+	return nil
+}
+
+// resultData returns the rows of a response as decoded by the client, so that
+// numbers stay json.Number with Configuration.UseNumber.
+func resultData(body any) [][]any {
+	fields, _ := body.(map[string]any)
+	data, _ := fields["data"].([]any)
+	if data == nil {
+		return nil
+	}
+	rows := make([][]any, len(data))
+	for i, row := range data {
+		rows[i], _ = row.([]any)
+	}
+	return rows
+}
+
+// queryResults is a response of the Trino protocol.
+type queryResults struct {
+	ID      string `json:"id"`
+	InfoURI string `json:"infoUri"`
+	NextURI string `json:"nextUri"`
+	Columns []struct {
+		Name          string `json:"name"`
+		Type          string `json:"type"`
+		TypeSignature struct {
+			RawType string `json:"rawType"`
+		} `json:"typeSignature"`
+	} `json:"columns"`
+	Stats statsResult `json:"stats"`
+	Error *Error      `json:"error"`
+}
+
+type statsResult struct {
+	State           string `json:"state"`
+	Queued          bool   `json:"queued"`
+	Scheduled       bool   `json:"scheduled"`
+	CompletedSplits int64  `json:"completedSplits"`
+	TotalSplits     int64  `json:"totalSplits"`
+	ProcessedRows   int64  `json:"processedRows"`
+	ProcessedBytes  int64  `json:"processedBytes"`
+	ElapsedTimeMs   int64  `json:"elapsedTimeMillis"`
+}
+
+func (r statsResult) stats() Stats {
+	return Stats(r)
+}
+
+// Error is a statement failed by Trino. errors.Is matches utils.ErrInvalidRequest
+// for user errors (syntax, missing table, permission) and utils.ErrAPIError otherwise.
+type Error struct {
+	Message   string `json:"message"`
+	ErrorCode int    `json:"errorCode"`
+	ErrorName string `json:"errorName"` // e.g. "TABLE_NOT_FOUND"
+	ErrorType string `json:"errorType"` // USER_ERROR, INTERNAL_ERROR, INSUFFICIENT_RESOURCES or EXTERNAL
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("Trino query failed: %s: %s", e.ErrorName, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	if e.ErrorType == "USER_ERROR" {
+		return utils.ErrInvalidRequest
+	}
+	return utils.ErrAPIError
+}
@@ -0,0 +1,173 @@
+package trino_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/trino"
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// fakeTrino serves a statement in three pages: queued, then two pages of rows.
+// Statements containing "slow" never produce their first page.
+type fakeTrino struct {
+	server   *httptest.Server
+	mu       sync.Mutex
+	headers  http.Header
+	sql      string
+	canceled bool
+}
+
+func newFakeTrino(t *testing.T) *fakeTrino {
+	f := &fakeTrino{}
+	f.server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/slow") {
+			<-r.Context().Done()
+			return
+		}
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		next := func(page int) string { return fmt.Sprintf("%s/v1/statement/executing/q1/%d", f.server.URL, page) }
+		var body map[string]any
+		switch {
+		case r.URL.Path == "/data-docks/dd1":
+			host, port, _ := strings.Cut(strings.TrimPrefix(f.server.URL, "https://"), ":")
+			body = map[string]any{"host": host, "port": json.Number(port), "connection_kind": map[string]any{"type": "TrinoInternal"}}
+		case r.URL.Path == "/api/v1/bifrost/info":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case r.Method == "POST" && r.URL.Path == "/v1/statement":
+			raw, _ := io.ReadAll(r.Body)
+			f.sql, f.headers = string(raw), r.Header.Clone()
+			if strings.Contains(f.sql, "missing") {
+				body = map[string]any{"id": "q2", "stats": map[string]any{"state": "FAILED"},
+					"error": map[string]any{"message": "Table 'missing' does not exist", "errorName": "TABLE_NOT_FOUND", "errorType": "USER_ERROR"}}
+				break
+			}
+			nextURI := next(1)
+			if strings.Contains(f.sql, "slow") {
+				nextURI = f.server.URL + "/v1/statement/queued/q1/slow"
+			}
+			body = map[string]any{"id": "q1", "infoUri": f.server.URL + "/ui/query.html?q1", "nextUri": nextURI, "stats": map[string]any{"state": "QUEUED"}}
+		case r.Method == "DELETE":
+			f.canceled = true
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case strings.HasSuffix(r.URL.Path, "/1"):
+			body = map[string]any{"id": "q1", "nextUri": next(2), "stats": map[string]any{"state": "RUNNING", "processedRows": 1},
+				"columns": []any{
+					map[string]any{"name": "id", "type": "bigint", "typeSignature": map[string]any{"rawType": "bigint"}},
+					map[string]any{"name": "region", "type": "varchar(10)", "typeSignature": map[string]any{"rawType": "varchar"}},
+				},
+				"data": []any{[]any{json.Number("9007199254740993"), "eu"}}}
+		case strings.HasSuffix(r.URL.Path, "/2"):
+			body = map[string]any{"id": "q1", "stats": map[string]any{"state": "FINISHED", "processedRows": 2},
+				"data": []any{[]any{json.Number("2"), "us"}}}
+		default:
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+func newClient(f *fakeTrino) *sdk.Client {
+	return sdk.NewClient(utils.Configuration{
+		BaseURL:       f.server.URL,
+		DataDockID:    "dd1",
+		Token:         "t",
+		SkipTLSVerify: true,
+		UseNumber:     true,
+		MaxRetries:    1,
+	})
+}
+
+func TestClient_Query(t *testing.T) {
+	f := newFakeTrino(t)
+
+	columns, rows, err := newClient(f).Trino().Catalog("sales", "public").Session("query_max_run_time", "10m").
+		Query(context.Background(), "SELECT id, region FROM orders")
+	if err != nil {
+		t.Fatalf("Query() unexpected error = %v", err)
+	}
+	if len(columns) != 2 || columns[1].Type != "varchar(10)" || columns[1].RawType != "varchar" {
+		t.Errorf("Unexpected columns %+v", columns)
+	}
+	if len(rows) != 2 || rows[0][0] != json.Number("9007199254740993") || rows[1][1] != "us" {
+		t.Errorf("Unexpected rows %v", rows)
+	}
+	if f.sql != "SELECT id, region FROM orders" || f.headers.Get("X-Trino-Catalog") != "sales" ||
+		f.headers.Get("X-Trino-Schema") != "public" || f.headers.Get("Authorization") != "Bearer t" {
+		t.Errorf("Unexpected statement %q with headers %v", f.sql, f.headers)
+	}
+	if session, _ := url.QueryUnescape(f.headers.Get("X-Trino-Session")); session != "query_max_run_time=10m" {
+		t.Errorf("X-Trino-Session = %q", session)
+	}
+}
+
+func TestStatement_StopCancels(t *testing.T) {
+	f := newFakeTrino(t)
+
+	stmt, err := trino.New(newClient(f), f.server.URL).Execute(context.Background(), "SELECT 1")
+	if err != nil {
+		t.Fatalf("Execute() unexpected error = %v", err)
+	}
+	if stmt.ID() != "q1" || stmt.Stats().State != "QUEUED" || stmt.Columns() != nil {
+		t.Errorf("Unexpected submitted statement %s %+v", stmt.ID(), stmt.Stats())
+	}
+	for page, err := range stmt.Pages(context.Background()) {
+		if err != nil {
+			t.Fatalf("Pages() unexpected error = %v", err)
+		}
+		if len(page.Rows) != 1 || page.Stats.State != "RUNNING" {
+			t.Errorf("Unexpected first page %+v", page)
+		}
+		break
+	}
+	if !f.canceled || !stmt.Done() {
+		t.Error("Expected stopping the iteration to cancel the statement")
+	}
+}
+
+func TestStatement_CancelledContextCancels(t *testing.T) {
+	f := newFakeTrino(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	_, _, err := newClient(f).Trino().Query(ctx, "SELECT slow()")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.canceled {
+		t.Error("Expected cancelling the context to cancel the statement")
+	}
+}
+
+func TestClient_QueryError(t *testing.T) {
+	f := newFakeTrino(t)
+
+	_, _, err := newClient(f).Trino().Query(context.Background(), "SELECT * FROM missing")
+	var trinoErr *trino.Error
+	if !errors.As(err, &trinoErr) || trinoErr.ErrorName != "TABLE_NOT_FOUND" || !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected a TABLE_NOT_FOUND user error, got %v", err)
+	}
+	if _, err := newClient(f).Trino().Execute(context.Background(), " "); !errors.Is(err, utils.ErrInvalidRequest) {
+		t.Errorf("Expected ErrInvalidRequest for an empty statement, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Optional features reported in Capabilities.Features.
+const (
+	FeatureSearch       = "search"
+	FeatureHybridSearch = "hybrid_search"
+	FeatureCostEstimate = "cost_estimate"
+	FeatureEmbeddings   = "embeddings"
+	FeatureOpenAPI      = "openapi"
+	FeatureGraphQL      = "graphql"
+	FeaturePostgreSQL   = "postgresql"
+	FeatureMCP          = "mcp"
+)
+
+// Capabilities describes what a Hyperfluid deployment supports.
+//
+// A list the server does not report (nil) is assumed to allow everything, so that
+// partial capability documents keep working. When Discovered is false the server
+// predates capability discovery and every check succeeds: requests are sent as is.
+type Capabilities struct {
+	Version         string   `json:"version"`
+	APIVersions     []string `json:"api_versions,omitempty"`
+	FilterOperators []string `json:"filter_operators,omitempty"` // Query parameter suffixes: "eq", "gte", "ilike"...
+	CountModes      []string `json:"count_modes,omitempty"`      // "exact", "planned", "estimated"
+	ExportFormats   []string `json:"export_formats,omitempty"`   // Server-side export formats: "csv", "jsonl", "parquet"...
+	Features        []string `json:"features,omitempty"`         // FeatureSearch, FeatureHybridSearch...
+
+	// Discovered reports that the server answered the capability discovery request.
+	Discovered bool `json:"-"`
+}
+
+// SupportsFeature reports whether an optional feature (e.g. FeatureSearch) is available.
+func (c *Capabilities) SupportsFeature(name string) bool {
+	return c.allows(c.Features, name)
+}
+
+// SupportsFilterOperator reports whether a filter operator (e.g. "ilike") is available.
+func (c *Capabilities) SupportsFilterOperator(operator string) bool {
+	return c.allows(c.FilterOperators, operator)
+}
+
+// SupportsCountMode reports whether a count mode (e.g. "estimated") is available.
+func (c *Capabilities) SupportsCountMode(mode string) bool {
+	return c.allows(c.CountModes, mode)
+}
+
+// SupportsExportFormat reports whether the server can export to a format (e.g. "parquet").
+func (c *Capabilities) SupportsExportFormat(format string) bool {
+	return c.allows(c.ExportFormats, format)
+}
+
+// Unsupported returns an ErrUnsupportedFeature error naming the missing feature and
+// the server version.
+func (c *Capabilities) Unsupported(what string) error {
+	version := c.Version
+	if version == "" {
+		version = "unknown"
+	}
+	return fmt.Errorf("%w: %s (server version %s)", ErrUnsupportedFeature, what, version)
+}
+
+func (c *Capabilities) allows(list []string, value string) bool {
+	return c == nil || !c.Discovered || list == nil || slices.Contains(list, value)
+}
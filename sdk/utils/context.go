@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+)
+
+type headersContextKey struct{}
+
+// ContextWithHeaders returns a context carrying extra HTTP headers that the
+// client adds to the request. Headers already present in ctx are kept unless
+// overridden by the new ones.
+func ContextWithHeaders(ctx context.Context, headers http.Header) context.Context {
+	if len(headers) == 0 {
+		return ctx
+	}
+	merged := HeadersFromContext(ctx).Clone()
+	if merged == nil {
+		merged = http.Header{}
+	}
+	for key, values := range headers {
+		merged[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+	}
+	return context.WithValue(ctx, headersContextKey{}, merged)
+}
+
+// HeadersFromContext returns the extra HTTP headers carried by ctx, if any.
+func HeadersFromContext(ctx context.Context) http.Header {
+	headers, _ := ctx.Value(headersContextKey{}).(http.Header)
+	return headers
+}
+
+type skipTransformersContextKey struct{}
+
+// ContextWithoutTransformers returns a context whose requests skip the response
+// transformers of the client. The SDK uses it for responses it decodes itself,
+// such as the capabilities document or the Trino protocol.
+func ContextWithoutTransformers(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipTransformersContextKey{}, true)
+}
+
+// TransformersSkipped reports whether ctx was returned by ContextWithoutTransformers.
+func TransformersSkipped(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipTransformersContextKey{}).(bool)
+	return skip
+}
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField describes the allowed range of one field of a 5-field cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}},
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}},
+}
+
+var cronMacros = map[string]string{
+	"@yearly": "0 0 1 1 *", "@annually": "0 0 1 1 *", "@monthly": "0 0 1 * *",
+	"@weekly": "0 0 * * 0", "@daily": "0 0 * * *", "@midnight": "0 0 * * *", "@hourly": "0 * * * *",
+}
+
+// cronSearchLimit bounds the search of the next activation of expressions that
+// never match, such as "0 0 31 2 *".
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// CronSchedule is a parsed cron expression.
+type CronSchedule struct {
+	expr   string
+	fields [5]uint64 // Bit i is set when value i matches
+	// When both day fields are restricted, a day matches either of them (standard cron semantics)
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+// ValidateCron checks a standard 5-field cron expression ("minute hour dom month dow").
+// Lists, ranges, steps, month/day names and the @daily-style macros are accepted.
+// Errors wrap ErrInvalidRequest.
+func ValidateCron(expr string) error {
+	_, err := ParseCron(expr)
+	return err
+}
+
+// ParseCron parses a cron expression accepted by ValidateCron.
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	fields := strings.Fields(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		fields = strings.Fields(macro)
+	}
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("%w: cron expression %q must have 5 fields, got %d", ErrInvalidRequest, expr, len(fields))
+	}
+
+	schedule := &CronSchedule{expr: expr}
+	for i, field := range fields {
+		bits, err := cronFields[i].parse(field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: cron expression %q: %s", ErrInvalidRequest, expr, err)
+		}
+		schedule.fields[i] = bits
+	}
+	// Sunday is both 0 and 7
+	if schedule.fields[4]&(1<<7) != 0 {
+		schedule.fields[4] |= 1
+	}
+	schedule.anyDayOfMonth = strings.HasPrefix(fields[2], "*")
+	schedule.anyDayOfWeek = strings.HasPrefix(fields[4], "*")
+	return schedule, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *CronSchedule) String() string {
+	return s.expr
+}
+
+// Next returns the first activation strictly after t, in the location of t, or
+// the zero time when the expression matches no date in the next five years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+	for t.Before(limit) {
+		switch {
+		case !s.matches(3, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !s.matches(1, t.Hour()):
+			// Not t.Truncate(time.Hour): it truncates the UTC time, off by the
+			// minutes of zones such as Asia/Kolkata
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !s.matches(0, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) matches(field, value int) bool {
+	return s.fields[field]&(1<<uint(value)) != 0
+}
+
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := s.matches(2, t.Day())
+	dayOfWeek := s.matches(4, int(t.Weekday()))
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// parse returns the values matched by a field as a bit set.
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step, hasStep := strings.Cut(part, "/")
+		stepVal := 1
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", step, f.name)
+			}
+			stepVal = n
+		}
+
+		lowVal, highVal := f.min, f.max
+		if rangePart != "*" {
+			low, high, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if lowVal, err = f.value(low); err != nil {
+				return 0, err
+			}
+			switch {
+			case isRange:
+				if highVal, err = f.value(high); err != nil {
+					return 0, err
+				}
+				if lowVal > highVal {
+					return 0, fmt.Errorf("invalid range %q in %s field", rangePart, f.name)
+				}
+			case !hasStep:
+				highVal = lowVal // "5/15" runs from 5 to the maximum
+			}
+		}
+		for v := lowVal; v <= highVal; v += stepVal {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field (allowed %d-%d)", s, f.name, f.min, f.max)
+	}
+	return v, nil
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Encoder serializes request bodies. Set Configuration.Encoder to use another JSON
+// library or a custom time format.
+type Encoder interface {
+	Marshal(value any) ([]byte, error)
+}
+
+// EncoderFunc adapts a marshal function to Encoder, e.g. to plug in a faster JSON
+// library:
+//
+//	cfg.Encoder = utils.EncoderFunc(jsoniter.ConfigCompatibleWithStandardLibrary.Marshal)
+type EncoderFunc func(value any) ([]byte, error)
+
+// Marshal calls f(value).
+func (f EncoderFunc) Marshal(value any) ([]byte, error) {
+	return f(value)
+}
+
+// JSONEncoder is the default Encoder, based on encoding/json. When TimeFormat is set
+// (e.g. time.DateTime), the time.Time values of maps, slices and struct rows (see
+// RowsFromStructs) are formatted with it instead of RFC 3339.
+type JSONEncoder struct {
+	TimeFormat string
+}
+
+// Marshal encodes value as JSON.
+func (e JSONEncoder) Marshal(value any) ([]byte, error) {
+	if e.TimeFormat != "" {
+		value = formatTimes(value, e.TimeFormat)
+	}
+	return json.Marshal(value)
+}
+
+// EncodeBody serializes a request body with the Encoder of the configuration,
+// JSONEncoder by default. Failures wrap ErrInvalidRequest.
+func EncodeBody(cfg Configuration, value any) ([]byte, error) {
+	encoder := cfg.Encoder
+	if encoder == nil {
+		encoder = JSONEncoder{}
+	}
+	body, err := encoder.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot encode request body: %w", ErrInvalidRequest, err)
+	}
+	return body, nil
+}
+
+// formatTimes returns a copy of value where the time.Time values nested in maps and
+// slices are replaced by their formatted string. Other values are kept as they are.
+func formatTimes(value any, layout string) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(layout)
+	case *time.Time:
+		if v == nil {
+			return v
+		}
+		return v.Format(layout)
+	case map[string]any:
+		formatted := make(map[string]any, len(v))
+		for key, item := range v {
+			formatted[key] = formatTimes(item, layout)
+		}
+		return formatted
+	case []map[string]any:
+		formatted := make([]map[string]any, len(v))
+		for i, row := range v {
+			formatted[i], _ = formatTimes(row, layout).(map[string]any)
+		}
+		return formatted
+	case []any:
+		formatted := make([]any, len(v))
+		for i, item := range v {
+			formatted[i] = formatTimes(item, layout)
+		}
+		return formatted
+	}
+	return value
+}
@@ -1,6 +1,11 @@
 package utils
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 var (
 	ErrInvalidConfiguration = errors.New("invalid client configuration")
@@ -9,4 +14,71 @@ var (
 	ErrPermissionDenied     = errors.New("permission denied")
 	ErrInvalidRequest       = errors.New("invalid request")
 	ErrAPIError             = errors.New("API error")
+	ErrCircuitOpen          = errors.New("circuit breaker is open")
+	ErrDataDockAsleep       = errors.New("datadock is asleep")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrUnsupportedFeature   = errors.New("feature not supported by the server")
+	ErrResponseTooLarge     = errors.New("response too large")
+	ErrClientClosed         = errors.New("client is closed")
+	ErrCheckFailed          = errors.New("data quality check failed")
+
+	// S3 and authentication failure classes. The underlying AWS or Keycloak error
+	// stays in the chain, e.g. for errors.As with smithy.APIError or *KeycloakError.
+	ErrObjectNotFound = errors.New("object not found")
+	ErrBucketNotFound = errors.New("bucket not found")
+	ErrSTSFailed      = errors.New("STS credentials request failed")
+	ErrTokenExpired   = errors.New("token expired")
+	ErrInvalidToken   = errors.New("invalid token")
+	ErrRealmNotFound  = errors.New("Keycloak realm not found")
 )
+
+// RequestError wraps an error returned by a client request with the request ID
+// sent in the X-Request-ID header, for correlation with platform logs.
+// errors.Is and errors.As see through it to the underlying error.
+type RequestError struct {
+	RequestID string
+	Err       error
+}
+
+func (e *RequestError) Error() string {
+	return fmt.Sprintf("%v (request ID: %s)", e.Err, e.RequestID)
+}
+
+func (e *RequestError) Unwrap() error {
+	return e.Err
+}
+
+// RequestIDFromError returns the request ID attached to err, if any.
+func RequestIDFromError(err error) string {
+	var requestErr *RequestError
+	if errors.As(err, &requestErr) {
+		return requestErr.RequestID
+	}
+	return ""
+}
+
+// KeycloakError is a token request rejected by Keycloak. errors.Is matches
+// ErrAuthenticationFailed, and ErrRealmNotFound or ErrTokenExpired when the
+// response tells so.
+type KeycloakError struct {
+	StatusCode  int
+	Code        string // OAuth 2.0 error code, e.g. "invalid_grant"
+	Description string
+	Body        string // Raw response, with the submitted secrets redacted
+}
+
+func (e *KeycloakError) Error() string {
+	return fmt.Sprintf("%v: Keycloak token exchange failed (%d): %s", ErrAuthenticationFailed, e.StatusCode, e.Body)
+}
+
+func (e *KeycloakError) Unwrap() []error {
+	description := strings.ToLower(e.Description)
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return []error{ErrAuthenticationFailed, ErrRealmNotFound}
+	case (e.Code == "invalid_grant" || e.Code == "invalid_token") &&
+		(strings.Contains(description, "expired") || strings.Contains(description, "not active")):
+		return []error{ErrAuthenticationFailed, ErrTokenExpired}
+	}
+	return []error{ErrAuthenticationFailed}
+}
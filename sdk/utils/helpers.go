@@ -1,10 +1,9 @@
 package utils
 
 import (
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"log"
 	"os"
 	"strconv"
 	"time"
@@ -17,8 +16,40 @@ const (
 
 	// DefaultMaxRetries is the default number of retry attempts for failed requests.
 	DefaultMaxRetries = 3
+
+	// DefaultMaxIdleConnsPerHost is the default number of idle connections kept per host.
+	DefaultMaxIdleConnsPerHost = 32
+
+	// DefaultIdleConnTimeout is the default time an idle connection stays in the pool.
+	DefaultIdleConnTimeout = 90 * time.Second
+
+	// DefaultCircuitBreakerCooldown is how long an open circuit fails fast by default.
+	DefaultCircuitBreakerCooldown = 30 * time.Second
+
+	// DefaultRetryBudgetWindow is the period over which the retry budget is computed.
+	DefaultRetryBudgetWindow = 10 * time.Second
+
+	// DefaultRetryBudgetMinRetries is the number of retries allowed per window
+	// whatever the ratio, so that low-traffic clients can still retry.
+	DefaultRetryBudgetMinRetries = 10
+
+	// DefaultWakeUpTimeout bounds how long an automatic wake-up waits for a datadock.
+	DefaultWakeUpTimeout = 5 * time.Minute
+
+	// DefaultWakeUpPollInterval is the delay between datadock status checks during a wake-up.
+	DefaultWakeUpPollInterval = 5 * time.Second
 )
 
+// Warn reports a warning to OnWarning, or to the standard logger when it is nil.
+func (c Configuration) Warn(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	if c.OnWarning != nil {
+		c.OnWarning(message)
+		return
+	}
+	log.Printf("hyperfluid: warning: %s", message)
+}
+
 // SecondsToDuration converts an integer number of seconds to time.Duration.
 func SecondsToDuration(seconds int) time.Duration {
 	return time.Duration(seconds) * time.Second
@@ -43,15 +74,6 @@ func GetEnvironmentVariableInt(key string, fallback int) int {
 	return fallback
 }
 
-// HTTP client handling
-func CreateHTTPClientWithSettings(skipTLSVerification bool, timeoutDuration time.Duration) *http.Client {
-	transport := &http.Transport{}
-	if skipTLSVerification {
-		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
-	}
-	return &http.Client{Transport: transport, Timeout: timeoutDuration}
-}
-
 // Error handling
 func (response *Response) HasError() bool {
 	return response != nil && response.Error != ""
@@ -67,6 +89,9 @@ func (response *Response) IsOK() bool {
 func ResponseSuccess(data any) *Response {
 	return &Response{Status: StatusOK, Data: data}
 }
+
+// JsonMarshal encodes value with encoding/json and returns nil when it cannot be
+// encoded. Request bodies go through EncodeBody, which reports the error.
 func JsonMarshal(value any) []byte {
 	encodedBytes, _ := json.Marshal(value)
 	return encodedBytes
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// errKeyringUnavailable is returned when the OS keyring cannot be used.
+var errKeyringUnavailable = errors.New("OS keyring unavailable")
+
+// keyringTokenStore keeps values in the OS keyring: the login keychain on macOS
+// (security) and the Secret Service on Linux (secret-tool). Where the keyring is
+// missing or fails, values go to the fallback store instead.
+type keyringTokenStore struct {
+	service  string
+	fallback TokenStore
+}
+
+// NewKeyringTokenStore returns a TokenStore keeping values in the OS keyring
+// under the given service name, and in fallback when no keyring is available
+// (e.g. a headless Linux box without a Secret Service, or Windows).
+//
+// Example:
+//
+//	store := utils.NewKeyringTokenStore("hyperfluid",
+//	    utils.NewFileTokenStore(filepath.Join(cacheDir, "hyperfluid")))
+func NewKeyringTokenStore(service string, fallback TokenStore) TokenStore {
+	return &keyringTokenStore{service: service, fallback: fallback}
+}
+
+func (s *keyringTokenStore) Load(ctx context.Context, key string) ([]byte, error) {
+	encoded, err := s.run(ctx, keyringLoad, key, "")
+	if err == nil {
+		if value, err := hex.DecodeString(strings.TrimSpace(encoded)); err == nil {
+			return value, nil
+		}
+	}
+	if s.fallback == nil {
+		return nil, ErrNotFound
+	}
+	return s.fallback.Load(ctx, key)
+}
+
+func (s *keyringTokenStore) Save(ctx context.Context, key string, value []byte) error {
+	if _, err := s.run(ctx, keyringSave, key, hex.EncodeToString(value)); err != nil {
+		if s.fallback == nil {
+			return err
+		}
+		return s.fallback.Save(ctx, key, value)
+	}
+	if s.fallback != nil {
+		// Do not leave an older copy behind that would outlive a Delete of the keyring entry
+		_ = s.fallback.Delete(ctx, key)
+	}
+	return nil
+}
+
+func (s *keyringTokenStore) Delete(ctx context.Context, key string) error {
+	_, _ = s.run(ctx, keyringDelete, key, "")
+	if s.fallback == nil {
+		return nil
+	}
+	return s.fallback.Delete(ctx, key)
+}
+
+type keyringOp int
+
+const (
+	keyringLoad keyringOp = iota
+	keyringSave
+	keyringDelete
+)
+
+// run performs op with the keyring tool of the OS and returns its output.
+// Values are hex encoded so that they never need quoting.
+func (s *keyringTokenStore) run(ctx context.Context, op keyringOp, key, value string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		switch op {
+		case keyringLoad:
+			cmd = exec.CommandContext(ctx, "security", "find-generic-password", "-s", s.service, "-a", key, "-w")
+		case keyringSave:
+			// The value is passed on stdin rather than argv, which other users can see
+			cmd = exec.CommandContext(ctx, "security", "-i")
+			cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %q -a %q -w %q\n", s.service, key, value))
+		case keyringDelete:
+			cmd = exec.CommandContext(ctx, "security", "delete-generic-password", "-s", s.service, "-a", key)
+		}
+	case "linux", "freebsd", "openbsd":
+		attributes := []string{"service", s.service, "account", key}
+		switch op {
+		case keyringLoad:
+			cmd = exec.CommandContext(ctx, "secret-tool", append([]string{"lookup"}, attributes...)...)
+		case keyringSave:
+			cmd = exec.CommandContext(ctx, "secret-tool", append([]string{"store", "--label=" + s.service + " " + key}, attributes...)...)
+			cmd.Stdin = strings.NewReader(value)
+		case keyringDelete:
+			cmd = exec.CommandContext(ctx, "secret-tool", append([]string{"clear"}, attributes...)...)
+		}
+	default:
+		return "", errKeyringUnavailable
+	}
+	if _, err := exec.LookPath(cmd.Path); err != nil {
+		return "", errKeyringUnavailable
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %s: %w", errKeyringUnavailable, strings.TrimSpace(stderr.String()), err)
+	}
+	if op == keyringLoad && stdout.Len() == 0 {
+		return "", ErrNotFound
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// DecodeJSON decodes a JSON document into a generic value. With useNumber, numbers
+// are decoded as json.Number instead of float64, so that integers above 2^53 and
+// decimals keep their exact representation.
+func DecodeJSON(data []byte, useNumber bool) (any, error) {
+	var value any
+	if !useNumber {
+		err := json.Unmarshal(data, &value)
+		return value, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("invalid data after top-level JSON value")
+	}
+	return value, nil
+}
+
+// Float64 returns the value of a decoded JSON number, whether it was decoded as
+// float64 or as json.Number (Configuration.UseNumber).
+func Float64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultPollInterval is the delay between two checks of Poll.
+	DefaultPollInterval = time.Second
+
+	// DefaultPollMaxInterval caps the delay between two checks when a backoff is set.
+	DefaultPollMaxInterval = 30 * time.Second
+)
+
+// PollOptions configures Poll.
+type PollOptions struct {
+	Interval    time.Duration // Delay between checks (default 1 second)
+	Backoff     float64       // Multiplier applied to the delay after each check (default 1: fixed interval)
+	MaxInterval time.Duration // Upper bound of the delay when Backoff > 1 (default 30 seconds)
+	Timeout     time.Duration // Overall deadline (default: bounded by ctx only)
+}
+
+// Poll calls fetch until it reports completion, returns an error, or the timeout
+// or ctx expires. The value of the last call is returned in every case, so callers
+// can report the last observed state on timeout.
+//
+// Example:
+//
+//	status, err := utils.Poll(ctx, func(ctx context.Context) (string, bool, error) {
+//	    status, err := getStatus(ctx)
+//	    return status, status == "Done", err
+//	}, utils.PollOptions{Interval: 2 * time.Second, Backoff: 1.5, Timeout: time.Minute})
+func Poll[T any](ctx context.Context, fetch func(ctx context.Context) (T, bool, error), opts PollOptions) (T, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultPollMaxInterval
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	for {
+		value, done, err := fetch(ctx)
+		if err != nil || done {
+			return value, err
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return value, fmt.Errorf("polling did not complete: %w", ctx.Err())
+		}
+
+		if opts.Backoff > 1 {
+			interval = min(time.Duration(float64(interval)*opts.Backoff), maxInterval)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RedactedValue replaces secret material in printed values.
+const RedactedValue = "[REDACTED]"
+
+// RedactToken hides a secret, keeping the first characters of long values (e.g. the
+// "eyJh" of a JWT) so that logs still tell which kind of value was set.
+// Empty values stay empty.
+func RedactToken(secret string) string {
+	switch {
+	case secret == "":
+		return ""
+	case len(secret) < 16:
+		return RedactedValue
+	default:
+		return secret[:4] + "..." + RedactedValue
+	}
+}
+
+// RedactText replaces every occurrence of the secrets in s, e.g. in a server
+// response echoing a submitted form.
+func RedactText(s string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.ReplaceAll(s, secret, RedactedValue)
+		}
+	}
+	return s
+}
+
+// Redacted returns a copy of the configuration with its token, passwords and secret
+// keys redacted, safe to log.
+func (c Configuration) Redacted() Configuration {
+	c.Token = RedactToken(c.Token)
+	c.KeycloakClientSecret = RedactToken(c.KeycloakClientSecret)
+	c.KeycloakPassword = RedactToken(c.KeycloakPassword)
+	c.MinIOSecretKey = RedactToken(c.MinIOSecretKey)
+	c.ClientKeyPEM = RedactToken(c.ClientKeyPEM)
+	return c
+}
+
+// plainConfiguration has the fields of Configuration without its formatting methods.
+type plainConfiguration Configuration
+
+// String prints the configuration with its secrets redacted.
+func (c Configuration) String() string {
+	return fmt.Sprintf("%+v", plainConfiguration(c.Redacted()))
+}
+
+// GoString prints the configuration with its secrets redacted, for the %#v verb.
+func (c Configuration) GoString() string {
+	printed := fmt.Sprintf("%#v", plainConfiguration(c.Redacted()))
+	return strings.Replace(printed, "utils.plainConfiguration", "utils.Configuration", 1)
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TotalCountFromHeaders returns the total number of matching rows reported by the
+// server, from a PostgREST-style Content-Range header ("0-24/3573", "*/3573") or an
+// X-Total-Count header. Returns -1 when the total is unknown.
+func TotalCountFromHeaders(header http.Header) int64 {
+	if contentRange := header.Get("Content-Range"); contentRange != "" {
+		if total, ok := ParseContentRangeTotal(contentRange); ok {
+			return total
+		}
+	}
+	if totalCount := header.Get("X-Total-Count"); totalCount != "" {
+		if total, err := strconv.ParseInt(strings.TrimSpace(totalCount), 10, 64); err == nil && total >= 0 {
+			return total
+		}
+	}
+	return -1
+}
+
+// ParseContentRangeTotal extracts the total from a Content-Range header value.
+// The unit prefix is optional ("items 0-9/100"); a "*" total is reported as unknown.
+func ParseContentRangeTotal(contentRange string) (int64, bool) {
+	_, rangeAndTotal, found := strings.Cut(strings.TrimSpace(contentRange), "/")
+	if !found {
+		return 0, false
+	}
+	total, err := strconv.ParseInt(strings.TrimSpace(rangeAndTotal), 10, 64)
+	if err != nil || total < 0 {
+		return 0, false
+	}
+	return total, true
+}
+
+// ParseServerTiming parses a Server-Timing header ("db;dur=53, app;dur=47.2")
+// into durations keyed by metric name. Metrics without a duration are skipped.
+func ParseServerTiming(header http.Header) map[string]time.Duration {
+	var timings map[string]time.Duration
+	for _, value := range header.Values("Server-Timing") {
+		for _, metric := range strings.Split(value, ",") {
+			parts := strings.Split(metric, ";")
+			name := strings.TrimSpace(parts[0])
+			if name == "" {
+				continue
+			}
+			for _, param := range parts[1:] {
+				key, raw, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(key, "dur") {
+					continue
+				}
+				ms, err := strconv.ParseFloat(strings.Trim(raw, `"`), 64)
+				if err != nil {
+					continue
+				}
+				if timings == nil {
+					timings = map[string]time.Duration{}
+				}
+				timings[name] = time.Duration(ms * float64(time.Millisecond))
+			}
+		}
+	}
+	return timings
+}
+
+// ContentInfoFromHeaders describes the body of a response from its headers.
+// contentLength is the length known by the transport, -1 if unknown.
+func ContentInfoFromHeaders(header http.Header, contentLength int64) ContentInfo {
+	info := ContentInfo{
+		ContentType:   header.Get("Content-Type"),
+		ContentLength: contentLength,
+		ETag:          header.Get("ETag"),
+		RequestID:     header.Get("X-Request-ID"),
+	}
+	if _, params, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil {
+		info.FileName = params["filename"]
+	}
+	if lastModified, err := http.ParseTime(header.Get("Last-Modified")); err == nil {
+		info.LastModified = lastModified
+	}
+	return info
+}
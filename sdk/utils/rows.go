@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"fmt"
+)
+
+// Rows returns the rows of a table query response.
+func (response *Response) Rows() ([]map[string]any, error) {
+	if response == nil {
+		return nil, fmt.Errorf("response is nil")
+	}
+	data, ok := response.GetDataAsSlice()
+	if !ok {
+		return nil, fmt.Errorf("unexpected response format: expected a list of rows, got %T", response.Data)
+	}
+
+	rows := make([]map[string]any, 0, len(data))
+	for _, item := range data {
+		row, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unexpected row format: %T", item)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// FirstRow decodes the first row of a table query response into dest.
+// Returns ErrNotFound when the response has no rows.
+func FirstRow(response *Response, dest any) error {
+	rows, err := response.Rows()
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return fmt.Errorf("%w: query returned no rows", ErrNotFound)
+	}
+	return UnmarshalData(rows[0], dest)
+}
+
+// ColumnValues returns the values of a single column across the rows of a
+// table query response. Rows without the column yield nil.
+func ColumnValues(response *Response, column string) ([]any, error) {
+	rows, err := response.Rows()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, 0, len(rows))
+	for _, row := range rows {
+		values = append(values, row[column])
+	}
+	return values, nil
+}
+
+// ConvertValues decodes loosely-typed values (e.g. from ColumnValues) into a typed slice.
+func ConvertValues[T any](values []any) ([]T, error) {
+	converted := make([]T, 0, len(values))
+	if len(values) == 0 {
+		return converted, nil
+	}
+	if err := UnmarshalData(values, &converted); err != nil {
+		return nil, err
+	}
+	return converted, nil
+}
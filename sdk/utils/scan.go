@@ -0,0 +1,363 @@
+package utils
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScanMode controls how a RowScanner handles rows that do not match the struct.
+type ScanMode int
+
+const (
+	// ScanLenient ignores unknown columns, leaves the fields of missing columns
+	// untouched, zeroes non-nullable fields on NULL and converts between strings,
+	// numbers and booleans.
+	ScanLenient ScanMode = iota
+	// ScanStrict fails on unknown or missing columns, on NULL in a field that is not
+	// a pointer, an interface or a sql.Scanner (sql.NullString...), and on any
+	// value that needs a conversion.
+	ScanStrict
+)
+
+// timeLayouts are the timestamp formats accepted in time columns.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999 Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	time.DateOnly,
+}
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// RowScanner maps table rows into structs following the hyperfluid struct tags
+// (see StructTag). NULL columns map to nil pointers and invalid sql.Null* values.
+//
+// Example:
+//
+//	type Order struct {
+//	    ID       int64          `hyperfluid:"order_id"`
+//	    Note     sql.NullString `hyperfluid:"note"`
+//	    PaidAt   *time.Time     `hyperfluid:"paid_at"`
+//	}
+//	var orders []Order
+//	err := utils.RowScanner{Mode: utils.ScanStrict}.Scan(resp, &orders)
+type RowScanner struct {
+	Mode ScanMode
+}
+
+// Scan decodes the rows of a table query response into dest, a pointer to a slice
+// of structs or of pointers to structs.
+func (s RowScanner) Scan(response *Response, dest any) error {
+	rows, err := response.Rows()
+	if err != nil {
+		return err
+	}
+	return s.ScanRows(rows, dest)
+}
+
+// ScanRows decodes rows into dest, a pointer to a slice of structs or of pointers
+// to structs.
+func (s RowScanner) ScanRows(rows []map[string]any, dest any) error {
+	slice := reflect.ValueOf(dest)
+	if slice.Kind() != reflect.Pointer || slice.IsNil() || slice.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("%w: scan destination must be a pointer to a slice, got %T", ErrInvalidRequest, dest)
+	}
+	slice = slice.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("%w: scan destination must be a slice of structs, got %T", ErrInvalidRequest, dest)
+	}
+
+	scanned := reflect.MakeSlice(slice.Type(), len(rows), len(rows))
+	for i, row := range rows {
+		item := reflect.New(structType)
+		if err := s.scanStruct(row, item.Elem()); err != nil {
+			return fmt.Errorf("row %d: %w", i, err)
+		}
+		if elemType.Kind() == reflect.Pointer {
+			scanned.Index(i).Set(item)
+		} else {
+			scanned.Index(i).Set(item.Elem())
+		}
+	}
+	slice.Set(scanned)
+	return nil
+}
+
+// ScanRow decodes one row into dest, a pointer to a struct.
+func (s RowScanner) ScanRow(row map[string]any, dest any) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: scan destination must be a pointer to a struct, got %T", ErrInvalidRequest, dest)
+	}
+	return s.scanStruct(row, value.Elem())
+}
+
+// ScanColumn decodes one column of a row into dest, a pointer to any type
+// supported by RowScanner (e.g. *sql.NullInt64, **string, *time.Time). A missing
+// column is decoded as NULL. Conversions are lenient.
+//
+// Example:
+//
+//	var discount sql.NullFloat64
+//	err := utils.ScanColumn(row, "discount", &discount)
+func ScanColumn(row map[string]any, column string, dest any) error {
+	value := reflect.ValueOf(dest)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return fmt.Errorf("%w: scan destination must be a non-nil pointer, got %T", ErrInvalidRequest, dest)
+	}
+	if err := (RowScanner{}).assign(value.Elem(), row[column]); err != nil {
+		return fmt.Errorf("column %q: %w", column, err)
+	}
+	return nil
+}
+
+// scanStruct decodes a row into the fields of a struct value.
+func (s RowScanner) scanStruct(row map[string]any, value reflect.Value) error {
+	fields := make(map[string]reflect.Value)
+	collectFields(fields, value)
+
+	if s.Mode == ScanStrict {
+		for column := range row {
+			if _, ok := fields[column]; !ok {
+				return fmt.Errorf("column %q has no matching field in %s", column, value.Type())
+			}
+		}
+	}
+	for column, field := range fields {
+		columnValue, ok := row[column]
+		if !ok {
+			if s.Mode == ScanStrict {
+				return fmt.Errorf("column %q is missing from the row", column)
+			}
+			continue
+		}
+		if err := s.assign(field, columnValue); err != nil {
+			return fmt.Errorf("column %q: %w", column, err)
+		}
+	}
+	return nil
+}
+
+// collectFields indexes the settable fields of a struct by column name, flattening
+// untagged embedded structs like RowsFromStructs.
+func collectFields(fields map[string]reflect.Value, value reflect.Value) {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		name, _, tagged := fieldColumn(field)
+		if name == "-" {
+			continue
+		}
+		fieldValue := value.Field(i)
+		if field.Anonymous && !tagged {
+			embedded := fieldValue
+			if embedded.Kind() == reflect.Pointer && embedded.Type().Elem().Kind() == reflect.Struct {
+				if !field.IsExported() {
+					continue
+				}
+				if embedded.IsNil() {
+					embedded.Set(reflect.New(embedded.Type().Elem()))
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && embedded.Type() != timeType {
+				collectFields(fields, embedded)
+				continue
+			}
+		}
+		if field.IsExported() {
+			fields[name] = fieldValue
+		}
+	}
+}
+
+// assign stores a JSON-decoded column value into a field.
+func (s RowScanner) assign(field reflect.Value, value any) error {
+	strict := s.Mode == ScanStrict
+
+	if field.CanAddr() && field.Addr().Type().Implements(scannerType) {
+		scanner := field.Addr().Interface().(sql.Scanner)
+		if value != nil && isTimeScanner(field.Type()) {
+			t, err := s.parseTime(value)
+			if err != nil {
+				return err
+			}
+			value = t
+		}
+		if number, ok := value.(json.Number); ok {
+			value = string(number)
+		}
+		return scanner.Scan(value)
+	}
+
+	if value == nil {
+		switch field.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		default:
+			if strict {
+				return fmt.Errorf("NULL value for non-nullable %s field", field.Type())
+			}
+		}
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(field.Type().Elem())
+		if err := s.assign(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	case reflect.Interface:
+		field.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	if field.Type() == timeType {
+		t, err := s.parseTime(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		if str, ok := value.(string); ok {
+			field.SetString(str)
+			return nil
+		}
+		if strict {
+			return mismatch(value, field)
+		}
+		field.SetString(fmt.Sprint(value))
+		return nil
+	case reflect.Bool:
+		b, err := s.toBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := s.toInt(value)
+		if err != nil {
+			return err
+		}
+		if field.OverflowInt(n) {
+			return fmt.Errorf("value %d overflows %s", n, field.Type())
+		}
+		field.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := s.toInt(value)
+		if err != nil {
+			return err
+		}
+		if n < 0 || field.OverflowUint(uint64(n)) {
+			return fmt.Errorf("value %d overflows %s", n, field.Type())
+		}
+		field.SetUint(uint64(n))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := s.toFloat(value)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+		return nil
+	}
+
+	// Structs, maps and slices: decode through JSON
+	if err := UnmarshalData(value, field.Addr().Interface()); err != nil {
+		return err
+	}
+	return nil
+}
+
+func isTimeScanner(t reflect.Type) bool {
+	return t == reflect.TypeOf(sql.NullTime{}) || t == reflect.TypeOf(sql.Null[time.Time]{})
+}
+
+func (s RowScanner) parseTime(value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("cannot parse %q as a time", v)
+	}
+	return time.Time{}, fmt.Errorf("cannot use %T as a time", value)
+}
+
+func (s RowScanner) toBool(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if s.Mode == ScanLenient {
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, nil
+			}
+		}
+	case float64:
+		if s.Mode == ScanLenient && (v == 0 || v == 1) {
+			return v == 1, nil
+		}
+	}
+	return false, fmt.Errorf("cannot use %T %v as a bool", value, value)
+}
+
+func (s RowScanner) toInt(value any) (int64, error) {
+	switch v := value.(type) {
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, fmt.Errorf("value %v is not an integer", v)
+		}
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	case string:
+		if s.Mode == ScanLenient {
+			return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("cannot use %T %v as an integer", value, value)
+}
+
+func (s RowScanner) toFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		if s.Mode == ScanLenient {
+			return strconv.ParseFloat(strings.TrimSpace(v), 64)
+		}
+	}
+	return 0, fmt.Errorf("cannot use %T %v as a float", value, value)
+}
+
+func mismatch(value any, field reflect.Value) error {
+	return fmt.Errorf("cannot use %T %v as %s", value, value, field.Type())
+}
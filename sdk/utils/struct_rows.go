@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"time"
+)
+
+// StructTag is the struct tag mapping a field to a table column:
+//
+//	type Order struct {
+//	    ID        int64     `hyperfluid:"order_id"`
+//	    Note      *string   `hyperfluid:"note"`               // nil is sent as NULL
+//	    Discount  float64   `hyperfluid:"discount,omitempty"` // zero is not sent
+//	    ShippedOn time.Time `hyperfluid:"shipped_on,date"`    // "2006-01-02"
+//	    Internal  string    `hyperfluid:"-"`                  // never sent
+//	}
+//
+// Fields without the tag fall back to their json tag, then to the field name.
+// time.Time values are sent as RFC 3339 timestamps unless the "date" option is set.
+const StructTag = "hyperfluid"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// RowsFromStructs converts a struct, a pointer to a struct or a slice of them into
+// rows keyed by column name, following the hyperfluid struct tags. Any other value
+// (maps, slices of maps, ...) is returned unchanged.
+func RowsFromStructs(data any) any {
+	value := reflect.ValueOf(data)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	switch {
+	case value.Kind() == reflect.Struct && value.Type() != timeType:
+		return structRow(value)
+	case (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && isStructType(value.Type().Elem()):
+		rows := make([]map[string]any, 0, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			item := value.Index(i)
+			for item.Kind() == reflect.Pointer {
+				if item.IsNil() {
+					break
+				}
+				item = item.Elem()
+			}
+			if item.Kind() != reflect.Struct {
+				rows = append(rows, nil)
+				continue
+			}
+			rows = append(rows, structRow(item))
+		}
+		return rows
+	}
+	return data
+}
+
+func isStructType(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct && t != timeType
+}
+
+// structRow converts one struct into a row.
+func structRow(value reflect.Value) map[string]any {
+	row := make(map[string]any, value.NumField())
+	addStructFields(row, value)
+	return row
+}
+
+func addStructFields(row map[string]any, value reflect.Value) {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		name, options, tagged := fieldColumn(field)
+		if name == "-" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		// Embedded structs without an explicit column name are flattened
+		if field.Anonymous && !tagged {
+			embedded := fieldValue
+			if embedded.Kind() == reflect.Pointer {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addStructFields(row, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if options["omitempty"] && fieldValue.IsZero() {
+			continue
+		}
+		row[name] = columnValue(fieldValue, options["date"])
+	}
+}
+
+// fieldColumn returns the column name and options of a field, and whether the
+// name comes from a tag.
+func fieldColumn(field reflect.StructField) (name string, options map[string]bool, tagged bool) {
+	tag, ok := field.Tag.Lookup(StructTag)
+	if !ok {
+		tag, ok = field.Tag.Lookup("json")
+	}
+	parts := strings.Split(tag, ",")
+	options = make(map[string]bool, len(parts)-1)
+	for _, option := range parts[1:] {
+		options[strings.TrimSpace(option)] = true
+	}
+	if ok && parts[0] != "" {
+		return parts[0], options, true
+	}
+	return field.Name, options, false
+}
+
+// columnValue converts a field value into a JSON-serializable column value.
+// Nil pointers become nil (NULL). Times are kept so that the Encoder formats them
+// (RFC 3339 by default), except date-only fields.
+func columnValue(value reflect.Value, dateOnly bool) any {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Type() == timeType {
+		t := value.Interface().(time.Time)
+		if dateOnly {
+			return t.Format(time.DateOnly)
+		}
+		return t
+	}
+	return value.Interface()
+}
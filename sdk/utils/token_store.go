@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TokenStore persists tokens obtained by the client (e.g. Keycloak access tokens)
+// so that they survive restarts. Implementations must be safe for concurrent use.
+// Plug a secret manager in by implementing it; wrap any store with
+// NewEncryptedTokenStore to keep plaintext tokens off disk.
+type TokenStore interface {
+	// Load returns the value saved under key, or ErrNotFound.
+	Load(ctx context.Context, key string) ([]byte, error)
+	Save(ctx context.Context, key string, value []byte) error
+	Delete(ctx context.Context, key string) error
+}
+
+// KeyProvider returns the 32-byte AES-256 key of an encrypted token store.
+// It is called for every operation, so it can fetch the key from a KMS and cache it.
+type KeyProvider func(ctx context.Context) ([]byte, error)
+
+// KeyFromEnv returns a KeyProvider reading a base64 or hex encoded 32-byte key
+// from the given environment variable.
+func KeyFromEnv(name string) KeyProvider {
+	return func(ctx context.Context) ([]byte, error) {
+		encoded := strings.TrimSpace(os.Getenv(name))
+		if encoded == "" {
+			return nil, fmt.Errorf("%w: %s is not set", ErrInvalidConfiguration, name)
+		}
+		if key, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(key) == 32 {
+			return key, nil
+		}
+		if key, err := hex.DecodeString(encoded); err == nil && len(key) == 32 {
+			return key, nil
+		}
+		return nil, fmt.Errorf("%w: %s must hold a base64 or hex encoded 32-byte key", ErrInvalidConfiguration, name)
+	}
+}
+
+// fileTokenStore keeps one file per key in a directory.
+type fileTokenStore struct {
+	dir string
+}
+
+// NewFileTokenStore returns a TokenStore writing one file per key in dir, readable
+// by the current user only. Values are stored as is: wrap the store with
+// NewEncryptedTokenStore to encrypt them.
+func NewFileTokenStore(dir string) TokenStore {
+	return &fileTokenStore{dir: dir}
+}
+
+// path maps a key to a file name that is safe whatever the key contains.
+func (s *fileTokenStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:16])+".token")
+}
+
+func (s *fileTokenStore) Load(ctx context.Context, key string) ([]byte, error) {
+	value, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+func (s *fileTokenStore) Save(ctx context.Context, key string, value []byte) error {
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+	// Write then rename so that readers never see a partial file
+	tmp, err := os.CreateTemp(s.dir, ".token-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(value); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(key))
+}
+
+func (s *fileTokenStore) Delete(ctx context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// encryptedTokenStore encrypts values with AES-256-GCM before handing them to another store.
+type encryptedTokenStore struct {
+	store TokenStore
+	key   KeyProvider
+}
+
+// NewEncryptedTokenStore wraps a store so that values are encrypted with AES-256-GCM.
+// The key is used as additional data, so a value cannot be moved to another key.
+//
+// Example:
+//
+//	store := utils.NewEncryptedTokenStore(
+//	    utils.NewFileTokenStore("/var/cache/hyperfluid"),
+//	    utils.KeyFromEnv("HYPERFLUID_TOKEN_KEY"),
+//	)
+func NewEncryptedTokenStore(store TokenStore, key KeyProvider) TokenStore {
+	return &encryptedTokenStore{store: store, key: key}
+}
+
+func (s *encryptedTokenStore) aead(ctx context.Context) (cipher.AEAD, error) {
+	key, err := s.key(ctx)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid token encryption key: %w", ErrInvalidConfiguration, err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *encryptedTokenStore) Load(ctx context.Context, key string) ([]byte, error) {
+	sealed, err := s.store.Load(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := s.aead(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("stored token for %q is corrupted", key)
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	value, err := aead.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt stored token for %q: %w", key, err)
+	}
+	return value, nil
+}
+
+func (s *encryptedTokenStore) Save(ctx context.Context, key string, value []byte) error {
+	aead, err := s.aead(ctx)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	return s.store.Save(ctx, key, aead.Seal(nonce, nonce, value, []byte(key)))
+}
+
+func (s *encryptedTokenStore) Delete(ctx context.Context, key string) error {
+	return s.store.Delete(ctx, key)
+}
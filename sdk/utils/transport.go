@@ -0,0 +1,202 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// transportKey identifies the transport settings of a configuration.
+// Configurations with equal keys share the same connection pool.
+type transportKey struct {
+	skipTLSVerify       bool
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	forceHTTP2          bool
+	disableKeepAlives   bool
+
+	caCertFile     string
+	caCertPEM      string
+	clientCertFile string
+	clientKeyFile  string
+	clientCertPEM  string
+	clientKeyPEM   string
+	minTLSVersion  uint16
+
+	proxyURL string
+}
+
+var (
+	sharedTransports   = make(map[transportKey]*http.Transport)
+	sharedTransportsMu sync.Mutex
+)
+
+func newTransportKey(cfg Configuration) transportKey {
+	key := transportKey{
+		skipTLSVerify:       cfg.SkipTLSVerify,
+		maxIdleConnsPerHost: cfg.MaxIdleConnsPerHost,
+		idleConnTimeout:     cfg.IdleConnTimeout,
+		forceHTTP2:          cfg.ForceHTTP2,
+		disableKeepAlives:   cfg.DisableKeepAlives,
+		caCertFile:          cfg.CACertFile,
+		caCertPEM:           cfg.CACertPEM,
+		clientCertFile:      cfg.ClientCertFile,
+		clientKeyFile:       cfg.ClientKeyFile,
+		clientCertPEM:       cfg.ClientCertPEM,
+		clientKeyPEM:        cfg.ClientKeyPEM,
+		minTLSVersion:       cfg.MinTLSVersion,
+		proxyURL:            cfg.ProxyURL,
+	}
+	if key.maxIdleConnsPerHost <= 0 {
+		key.maxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if key.idleConnTimeout <= 0 {
+		key.idleConnTimeout = DefaultIdleConnTimeout
+	}
+	return key
+}
+
+// NewTLSConfig builds the TLS configuration described by the configuration.
+// It returns nil when no TLS option is set, so Go defaults apply.
+func NewTLSConfig(cfg Configuration) (*tls.Config, error) {
+	return newTransportKey(cfg).tlsConfig()
+}
+
+func (key transportKey) tlsConfig() (*tls.Config, error) {
+	hasCA := key.caCertFile != "" || key.caCertPEM != ""
+	hasClientCert := key.clientCertFile != "" || key.clientCertPEM != ""
+	if !key.skipTLSVerify && !hasCA && !hasClientCert && key.minTLSVersion == 0 {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: key.skipTLSVerify,
+		MinVersion:         key.minTLSVersion,
+	}
+
+	if hasCA {
+		caPEM := []byte(key.caCertPEM)
+		if len(caPEM) == 0 {
+			data, err := os.ReadFile(key.caCertFile)
+			if err != nil {
+				return nil, fmt.Errorf("%w: failed to read CA certificate: %w", ErrInvalidConfiguration, err)
+			}
+			caPEM = data
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("%w: no valid certificate found in CA certificate", ErrInvalidConfiguration)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if hasClientCert {
+		var cert tls.Certificate
+		var err error
+		if key.clientCertPEM != "" {
+			cert, err = tls.X509KeyPair([]byte(key.clientCertPEM), []byte(key.clientKeyPEM))
+		} else {
+			cert, err = tls.LoadX509KeyPair(key.clientCertFile, key.clientKeyFile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to load client certificate: %w", ErrInvalidConfiguration, err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// proxyFunc returns the proxy selection function for the transport.
+func (key transportKey) proxyFunc() (func(*http.Request) (*url.URL, error), error) {
+	if key.proxyURL == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+
+	proxy, err := url.Parse(key.proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid proxy URL: %w", ErrInvalidConfiguration, err)
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return nil, fmt.Errorf("%w: unsupported proxy scheme %q", ErrInvalidConfiguration, proxy.Scheme)
+	}
+	return http.ProxyURL(proxy), nil
+}
+
+// SharedTransport returns the pooled transport matching the configuration's
+// transport settings (pooling, TLS, proxy). Every client, Keycloak exchange,
+// control plane call and S3 operation with the same settings reuses the same
+// connections.
+func SharedTransport(cfg Configuration) (*http.Transport, error) {
+	key := newTransportKey(cfg)
+
+	sharedTransportsMu.Lock()
+	defer sharedTransportsMu.Unlock()
+
+	if transport, ok := sharedTransports[key]; ok {
+		return transport, nil
+	}
+
+	tlsConfig, err := key.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	proxy, err := key.proxyFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 0 // No global cap, MaxIdleConnsPerHost bounds the pool
+	transport.MaxIdleConnsPerHost = key.maxIdleConnsPerHost
+	transport.IdleConnTimeout = key.idleConnTimeout
+	transport.DisableKeepAlives = key.disableKeepAlives
+	transport.Proxy = proxy
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+	if key.forceHTTP2 {
+		protocols := new(http.Protocols)
+		protocols.SetHTTP2(true)
+		protocols.SetUnencryptedHTTP2(true)
+		transport.Protocols = protocols
+	}
+
+	sharedTransports[key] = transport
+	return transport, nil
+}
+
+// ClientTransport returns Configuration.Transport when set, the shared transport
+// of the configuration otherwise.
+func ClientTransport(cfg Configuration) (http.RoundTripper, error) {
+	if cfg.Transport != nil {
+		return cfg.Transport, nil
+	}
+	return SharedTransport(cfg)
+}
+
+// CreateHTTPClient creates an HTTP client backed by the transport of the configuration.
+func CreateHTTPClient(cfg Configuration) (*http.Client, error) {
+	transport, err := ClientTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport, Timeout: cfg.RequestTimeout}, nil
+}
+
+func CreateHTTPClientWithSettings(skipTLSVerification bool, timeoutDuration time.Duration) *http.Client {
+	// Cannot fail: no certificate material is involved.
+	client, _ := CreateHTTPClient(Configuration{SkipTLSVerify: skipTLSVerification, RequestTimeout: timeoutDuration})
+	return client
+}
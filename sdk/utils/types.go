@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"net/http"
 	"time"
 )
 
@@ -15,19 +16,142 @@ type Configuration struct {
 	RequestTimeout time.Duration
 	MaxRetries     int
 
-	KeycloakBaseURL      string
-	KeycloakRealm        string
+	// RetryBudgetRatio caps retries to this fraction of the requests of the client
+	// (e.g. 0.1 for 10%) over RetryBudgetWindow (default 10s), with at least
+	// RetryBudgetMinRetries (default 10) allowed per window. Once the budget is
+	// spent, failures are returned without retrying. Zero disables the budget.
+	RetryBudgetRatio      float64
+	RetryBudgetWindow     time.Duration
+	RetryBudgetMinRetries int
+
+	// CircuitBreakerThreshold opens the circuit of a host after this many
+	// consecutive 5xx or transport failures; requests then fail fast with
+	// ErrCircuitOpen for CircuitBreakerCooldown. Zero disables the breaker.
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
+	// OnCircuitStateChange is called whenever a host's circuit changes state.
+	OnCircuitStateChange func(host string, state CircuitState)
+
+	// AutoIdempotencyKeys adds a generated Idempotency-Key header to every
+	// POST, PUT, PATCH and DELETE request that does not already carry one.
+	AutoIdempotencyKeys bool
+
+	// AutoWakeUp wakes a sleeping datadock up when a query fails with
+	// ErrDataDockAsleep, waits until it is online and retries the query once.
+	AutoWakeUp         bool
+	WakeUpTimeout      time.Duration // Default 5 minutes
+	WakeUpPollInterval time.Duration // Default 5 seconds
+
+	// PaginationOrder checks the queries paginated with Limit, Offset or Iter without
+	// OrderBy, whose pages may overlap or skip rows as the row order is not
+	// stable. The primary key of the table, when the column metadata identifies
+	// one, is then added as ORDER BY; otherwise the query is reported
+	// (PaginationOrderWarn) or refused (PaginationOrderStrict). The check costs
+	// a columns request per query. The zero value disables it.
+	PaginationOrder PaginationOrderMode
+	// OnWarning receives the warnings of the SDK. Nil writes them with the log package.
+	OnWarning func(message string)
+
+	// DiscoverCapabilities makes builders query the platform capabilities on first
+	// use (see Client.Capabilities) and fail with ErrUnsupportedFeature instead of
+	// sending requests the server cannot serve.
+	DiscoverCapabilities bool
+
+	// DiscoverEndpoints fills ControlPlaneURL, KeycloakBaseURL and KeycloakRealm
+	// (or OIDCIssuer), when left empty, from the /.well-known/hyperfluid-configuration document of
+	// BaseURL (see Client.Endpoints), so that BaseURL is the only URL to configure.
+	DiscoverEndpoints bool
+
+	// UserAgent overrides the default "hyperfluid-sdk-go/<version>" User-Agent.
+	UserAgent string
+
+	// QueryTags label every request (e.g. "team:analytics") in the X-Query-Tags
+	// header, so that the platform attributes cost and audit events to their
+	// consumer. DataDockQueryTags adds tags to the requests targeting a datadock,
+	// keyed by datadock ID. Tags are printable ASCII without spaces or commas.
+	QueryTags         []string
+	DataDockQueryTags map[string][]string
+
+	// HistorySize keeps the last N requests for Client.History. Zero disables the history.
+	HistorySize int
+
+	// UseNumber decodes the numbers of responses as json.Number instead of float64,
+	// so that integer IDs above 2^53 and decimals survive round-trips. Code reading
+	// resp.Data must then accept json.Number (see Float64 and RowScanner).
+	UseNumber bool
+
+	// Encoder serializes request bodies. Nil uses encoding/json (see JSONEncoder).
+	Encoder Encoder
+
+	// TLS. CA certificates are trusted in addition to the system pool,
+	// client certificates enable mutual TLS. Files and PEM strings are
+	// alternatives; PEM content takes precedence when both are set.
+	CACertFile     string
+	CACertPEM      string
+	ClientCertFile string
+	ClientKeyFile  string
+	ClientCertPEM  string
+	ClientKeyPEM   string
+	MinTLSVersion  uint16 // e.g. tls.VersionTLS12, zero keeps the Go default
+
+	// ProxyURL routes every request (API, Keycloak, control plane, S3/STS)
+	// through the given http://, https:// or socks5:// proxy. When empty,
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY are honored.
+	ProxyURL string
+
+	// RequestCompressionThreshold gzips POST/PUT/PATCH bodies of at least this
+	// many bytes. Zero disables request compression.
+	RequestCompressionThreshold int
+	// DisableResponseCompression stops the client from asking for gzip responses.
+	DisableResponseCompression bool
+	// MaxResponseBytes caps the decompressed size of a response body. Larger
+	// responses fail with ErrResponseTooLarge instead of being read into memory.
+	// Zero means no limit.
+	MaxResponseBytes int64
+
+	// Transport replaces the pooled transport of every request (API, Keycloak,
+	// control plane, S3/STS), e.g. with an sdktest.Recorder. The TLS, proxy and
+	// pooling settings are then up to that transport.
+	Transport http.RoundTripper
+
+	// Connection pooling. Zero values fall back to the SDK defaults.
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	// ForceHTTP2 restricts the transport to HTTP/2, including cleartext HTTP/2 for http:// URLs.
+	ForceHTTP2        bool
+	DisableKeepAlives bool
+
+	KeycloakBaseURL string
+	KeycloakRealm   string
+	// OIDCIssuer replaces KeycloakBaseURL and KeycloakRealm for issuers outside the
+	// Keycloak /realms/<realm> layout (e.g. Keycloak under a path prefix, or another
+	// identity provider). Token endpoints are read from its OpenID configuration.
+	OIDCIssuer string
+	// VerifyTokens verifies the tokens obtained from the issuer against its JWKS, and
+	// tells expired from invalid tokens when the API rejects one. See Client.VerifyToken.
+	VerifyTokens         bool
 	KeycloakClientID     string
 	KeycloakClientSecret string
 	KeycloakUsername     string
 	KeycloakPassword     string
 
+	// TokenStore persists the tokens obtained from Keycloak so that they are reused
+	// across restarts while valid. Wrap it with NewEncryptedTokenStore to keep
+	// plaintext tokens off disk. Nil disables persistence.
+	TokenStore TokenStore
+
 	MinIORegion    string
 	MinIOEndpoint  string
 	MinIOAccessKey string
 	MinIOSecretKey string
 	MinIOUseSSL    string
 	MinIOUseOIDC   string
+
+	// When no MinIO access key is set, the MinIO endpoint and credentials of this
+	// bucket are fetched from the control plane by Client.S3(). MinIOHarborID
+	// defaults to the harbor of DataDockID.
+	MinIOHarborID string
+	MinIOBucket   string
 }
 
 type Response struct {
@@ -35,9 +159,51 @@ type Response struct {
 	Data     any
 	Error    string
 	HTTPCode int
+
+	// RequestID is the X-Request-ID returned by the server, or the one sent by the client.
+	RequestID string
+
+	// NextCursor continues a paginated query (see QueryBuilder.After). It is the
+	// X-Next-Cursor token issued by the server, or a keyset cursor built by the SDK.
+	NextCursor string
+
+	// Result metadata, filled by the client on successful responses.
+	RowCount     int                      // Rows returned, for list responses
+	TotalCount   int64                    // Total matching rows (Content-Range / X-Total-Count), -1 if unknown
+	ServerTiming map[string]time.Duration // Parsed Server-Timing header
+	Duration     time.Duration            // Client-side duration of the call, retries included
 }
 
 const (
 	StatusOK    = "ok"
 	StatusError = "error"
 )
+
+// ContentInfo describes the body of a download (see Client.Download).
+type ContentInfo struct {
+	ContentType   string
+	ContentLength int64  // -1 if unknown
+	FileName      string // From the Content-Disposition header, if any
+	ETag          string
+	LastModified  time.Time // Zero if unknown
+	RequestID     string
+}
+
+// CircuitState is the state of a host's circuit breaker.
+type CircuitState string
+
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// PaginationOrderMode selects how queries paginated without OrderBy are handled
+// (see Configuration.PaginationOrder).
+type PaginationOrderMode string
+
+const (
+	PaginationOrderOff    PaginationOrderMode = ""       // No check
+	PaginationOrderWarn   PaginationOrderMode = "warn"   // Reported through OnWarning
+	PaginationOrderStrict PaginationOrderMode = "strict" // Refused with ErrInvalidRequest
+)
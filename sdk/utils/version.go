@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"runtime/debug"
+	"sync"
+)
+
+// modulePath is the Go module path of the SDK.
+const modulePath = "github.com/nudibranches-tech/hyperfluid-sdk-go"
+
+// SDKVersion returns the version of the SDK module linked in the running
+// binary, or "dev" when it cannot be determined (e.g. local development).
+func SDKVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "dev"
+	}
+	if info.Main.Path == modulePath && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			return dep.Version
+		}
+	}
+	return "dev"
+}
+
+// defaultUserAgent is computed once: reading the build info allocates heavily.
+var defaultUserAgent = sync.OnceValue(func() string {
+	return "hyperfluid-sdk-go/" + SDKVersion()
+})
+
+// DefaultUserAgent is the User-Agent sent when Configuration.UserAgent is empty.
+func DefaultUserAgent() string {
+	return defaultUserAgent()
+}
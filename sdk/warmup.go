@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// WarmUp prepares the client for its first request: it resolves the API and
+// MinIO hosts, opens a connection to each of them (completing the TLS handshake)
+// and leaves it in the connection pool, and fetches an access token when the
+// client authenticates with Keycloak and holds no valid token. Call it during
+// initialization, e.g. in the init phase of a serverless function, so that the
+// first user-facing query does not pay for the cold start.
+//
+// Any HTTP status answered by the hosts is fine: only resolution, connection
+// and authentication failures are returned.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//	defer cancel()
+//	if err := client.WarmUp(ctx); err != nil {
+//	    log.Printf("warm-up failed: %v", err)
+//	}
+func (c *Client) WarmUp(ctx context.Context) error {
+	if c.initErr != nil {
+		return c.initErr
+	}
+
+	endpoints := []string{c.config.BaseURL}
+	if c.config.MinIOEndpoint != "" {
+		endpoints = append(endpoints, c.config.MinIOEndpoint)
+	}
+	errs := make([]error, len(endpoints)+1)
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.warmUpEndpoint(ctx, endpoint)
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs[len(endpoints)] = c.warmUpToken(ctx)
+	}()
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// warmUpEndpoint resolves the host of the endpoint and pools a connection to it.
+func (c *Client) warmUpEndpoint(ctx context.Context, endpoint string) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("%w: invalid endpoint %q", utils.ErrInvalidConfiguration, endpoint)
+	}
+	// Warms the resolver cache up. A proxy resolves the host itself, so a failed
+	// lookup only matters when the connection fails too.
+	_, lookupErr := net.DefaultResolver.LookupHost(ctx, parsed.Hostname())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, parsed.Scheme+"://"+parsed.Host+"/", nil)
+	if err != nil {
+		return fmt.Errorf("%w: %w", utils.ErrInvalidConfiguration, err)
+	}
+	c.applyHeaders(ctx, req)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if lookupErr != nil {
+			return fmt.Errorf("failed to resolve %s: %w", parsed.Hostname(), lookupErr)
+		}
+		return fmt.Errorf("failed to connect to %s: %w", parsed.Host, err)
+	}
+	// Drain the body so that the connection returns to the pool
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	return nil
+}
+
+// warmUpToken fetches an access token if the client will need a new one.
+func (c *Client) warmUpToken(ctx context.Context) error {
+	if !c.isKeycloakAuthMethodConfigured() && c.subjectToken == "" && c.federation == nil && c.serviceAccounts == nil {
+		return nil // Static token or no authentication
+	}
+	if c.config.Token != "" {
+		if expiry, ok := tokenExpiry(c.config.Token); !ok || time.Until(expiry) > time.Minute {
+			return nil
+		}
+		_, err := c.refreshToken(ctx)
+		return err
+	}
+	_, err := c.accessToken(ctx)
+	return err
+}
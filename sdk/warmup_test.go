@@ -0,0 +1,73 @@
+package sdk
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestClient_WarmUp(t *testing.T) {
+	var connections, tokenRequests atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/realms/test/protocol/openid-connect/token":
+			tokenRequests.Add(1)
+			_, _ = w.Write([]byte(`{"access_token": "warm-token", "expires_in": 300}`))
+		case "/", "/realms/test/.well-known/openid-configuration":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			if r.Header.Get("Authorization") != "Bearer warm-token" {
+				t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+			}
+			_, _ = w.Write([]byte(`[]`))
+		}
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := NewClient(utils.Configuration{
+		BaseURL:              server.URL,
+		DataDockID:           "dd",
+		KeycloakBaseURL:      server.URL,
+		KeycloakRealm:        "test",
+		KeycloakClientID:     "sa",
+		KeycloakClientSecret: "secret",
+	})
+	if err := client.WarmUp(context.Background()); err != nil {
+		t.Fatalf("WarmUp() unexpected error = %v", err)
+	}
+	if tokenRequests.Load() != 1 {
+		t.Errorf("token requests = %d, want 1", tokenRequests.Load())
+	}
+
+	warm := connections.Load()
+	if _, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background()); err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if connections.Load() != warm {
+		t.Errorf("the first query opened a connection, want a pooled one")
+	}
+	if tokenRequests.Load() != 1 {
+		t.Errorf("the first query fetched a token")
+	}
+}
+
+func TestClient_WarmUpUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := NewClient(utils.Configuration{BaseURL: server.URL, Token: "static"})
+	if err := client.WarmUp(context.Background()); err == nil {
+		t.Error("WarmUp() expected an error for an unreachable host")
+	}
+}
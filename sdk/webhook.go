@@ -0,0 +1,97 @@
+package sdk
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+const (
+	// WebhookSignatureHeader carries the "sha256=<hex>" HMAC of a webhook delivery.
+	WebhookSignatureHeader = "X-Hyperfluid-Signature"
+	// WebhookTimestampHeader carries the Unix time at which the delivery was signed.
+	WebhookTimestampHeader = "X-Hyperfluid-Timestamp"
+
+	// DefaultWebhookTolerance is the maximum age accepted for a webhook delivery.
+	DefaultWebhookTolerance = 5 * time.Minute
+
+	// maxWebhookBodySize bounds the body read by VerifyWebhookRequest.
+	maxWebhookBodySize = 1 << 20
+)
+
+// WebhookEvent is the payload of a webhook delivery.
+type WebhookEvent = Event
+
+// SignWebhookPayload computes the signature of a delivery: the hex HMAC-SHA256,
+// keyed with the webhook secret, of "<unix timestamp>.<payload>".
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature checks the signature and timestamp headers of a delivery.
+// Deliveries older (or further in the future) than tolerance are rejected to
+// prevent replays; a zero tolerance uses DefaultWebhookTolerance. An empty secret,
+// with which anyone could sign deliveries, is an ErrInvalidConfiguration.
+func VerifyWebhookSignature(secret string, payload []byte, signature, timestamp string, tolerance time.Duration) error {
+	if secret == "" {
+		return fmt.Errorf("%w: webhook secret is empty", utils.ErrInvalidConfiguration)
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+
+	ts, err := strconv.ParseInt(strings.TrimSpace(timestamp), 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: missing or invalid timestamp", utils.ErrInvalidSignature)
+	}
+	if age := time.Since(time.Unix(ts, 0)); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp outside tolerance", utils.ErrInvalidSignature)
+	}
+
+	expected := SignWebhookPayload(secret, ts, payload)
+	if !hmac.Equal([]byte(expected), []byte(strings.TrimSpace(signature))) {
+		return fmt.Errorf("%w: signature mismatch", utils.ErrInvalidSignature)
+	}
+	return nil
+}
+
+// VerifyWebhookRequest reads the body of an incoming webhook delivery, verifies
+// its signature with the secret given at registration, and decodes the event.
+//
+// Example:
+//
+//	http.HandleFunc("/hooks/hyperfluid", func(w http.ResponseWriter, r *http.Request) {
+//	    event, err := sdk.VerifyWebhookRequest(r, secret)
+//	    if err != nil {
+//	        http.Error(w, "invalid signature", http.StatusUnauthorized)
+//	        return
+//	    }
+//	    log.Printf("received %s", event.Type)
+//	})
+func VerifyWebhookRequest(r *http.Request, secret string) (*WebhookEvent, error) {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read webhook body: %w", err)
+	}
+	if err := VerifyWebhookSignature(secret, payload, r.Header.Get(WebhookSignatureHeader), r.Header.Get(WebhookTimestampHeader), 0); err != nil {
+		return nil, err
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook event: %w", err)
+	}
+	return &event, nil
+}
@@ -0,0 +1,63 @@
+package sdk
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestVerifyWebhookRequest(t *testing.T) {
+	payload := `{"id": "evt-1", "type": "datadock.status_changed", "data": {"status": "Online"}}`
+	now := time.Now().Unix()
+
+	tests := []struct {
+		name      string
+		body      string
+		secret    string
+		timestamp int64
+		wantErr   bool
+	}{
+		{"valid", payload, "s3cret", now, false},
+		{"tampered body", strings.Replace(payload, "Online", "Offline", 1), "s3cret", now, true},
+		{"wrong secret", payload, "other", now, true},
+		{"replayed", payload, "s3cret", now - 3600, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/hooks", strings.NewReader(tt.body))
+			req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(tt.secret, tt.timestamp, []byte(payload)))
+			req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(tt.timestamp, 10))
+
+			event, err := VerifyWebhookRequest(req, "s3cret")
+			if tt.wantErr {
+				if !errors.Is(err, utils.ErrInvalidSignature) {
+					t.Errorf("Expected ErrInvalidSignature, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if event.Type != "datadock.status_changed" || string(event.Data) != `{"status": "Online"}` {
+				t.Errorf("Unexpected event: %+v", event)
+			}
+		})
+	}
+}
+
+func TestVerifyWebhookRequest_EmptySecret(t *testing.T) {
+	now := time.Now().Unix()
+	req := httptest.NewRequest("POST", "/hooks", strings.NewReader(`{"id": "evt-1"}`))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload("", now, []byte(`{"id": "evt-1"}`)))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(now, 10))
+
+	// A delivery forged with the empty key must not be accepted
+	if _, err := VerifyWebhookRequest(req, ""); !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+	}
+}
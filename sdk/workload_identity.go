@@ -0,0 +1,123 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+// DefaultKubernetesTokenPath is where Kubernetes mounts the service account token of a pod.
+const DefaultKubernetesTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// jwtTokenType is the RFC 8693 type of the Kubernetes token sent as subject token.
+const jwtTokenType = "urn:ietf:params:oauth:token-type:jwt"
+
+// KubernetesServiceAccountOptions configures NewClientFromKubernetesServiceAccount.
+type KubernetesServiceAccountOptions struct {
+	// ServiceAccountOptions holds the API settings (BaseURL is required).
+	ServiceAccountOptions
+
+	// Issuer is the Keycloak realm URL (e.g., "https://auth.hyperfluid.cloud/realms/my-org") (required).
+	Issuer string
+
+	// ClientID is the Keycloak client the token is exchanged with (required).
+	ClientID string
+
+	// ClientSecret authenticates a confidential exchange client (optional).
+	// Leave empty for a public client to avoid any static secret.
+	ClientSecret string
+
+	// SubjectIssuer is the alias of the Keycloak identity provider trusting the
+	// cluster's service account issuer (optional, required by Keycloak for external tokens).
+	SubjectIssuer string
+
+	// TokenPath is the file holding the Kubernetes service account token, usually a
+	// projected token with the Keycloak audience (optional). Defaults to DefaultKubernetesTokenPath.
+	TokenPath string
+}
+
+// NewClientFromKubernetesServiceAccount creates a client authenticated with the
+// Kubernetes service account of the pod: the projected token is exchanged with
+// Keycloak (OAuth 2.0 Token Exchange) for a Hyperfluid token, so no client secret
+// has to be deployed. When the Hyperfluid token expires, the token file is read
+// again (the kubelet rotates it) and exchanged anew.
+//
+// Keycloak must trust the cluster's service account issuer as an identity provider,
+// and the exchange client must be allowed to exchange its tokens.
+//
+// Example:
+//
+//	// Pod spec: a projected serviceAccountToken volume with audience "hyperfluid"
+//	// mounted at /var/run/secrets/tokens
+//	client, err := sdk.NewClientFromKubernetesServiceAccount(ctx, sdk.KubernetesServiceAccountOptions{
+//	    ServiceAccountOptions: sdk.ServiceAccountOptions{BaseURL: "https://api.hyperfluid.cloud"},
+//	    Issuer:                "https://auth.hyperfluid.cloud/realms/my-org",
+//	    ClientID:              "k8s-workloads",
+//	    SubjectIssuer:         "my-cluster",
+//	    TokenPath:             "/var/run/secrets/tokens/hyperfluid",
+//	})
+func NewClientFromKubernetesServiceAccount(ctx context.Context, opts KubernetesServiceAccountOptions) (*Client, error) {
+	if opts.BaseURL == "" {
+		return nil, fmt.Errorf("%w: BaseURL is required", utils.ErrInvalidConfiguration)
+	}
+	if opts.Issuer == "" || opts.ClientID == "" {
+		return nil, fmt.Errorf("%w: Keycloak issuer and client ID are required", utils.ErrInvalidConfiguration)
+	}
+	if opts.TokenPath == "" {
+		opts.TokenPath = DefaultKubernetesTokenPath
+	}
+
+	exchangeClient := &ServiceAccount{ClientID: opts.ClientID, ClientSecret: opts.ClientSecret, Issuer: opts.Issuer}
+	cfg, err := exchangeClient.ToConfiguration(opts.ServiceAccountOptions)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", utils.ErrInvalidConfiguration, err)
+	}
+
+	client := NewClient(cfg)
+	if client.initErr != nil {
+		return nil, client.initErr
+	}
+	client.federation = &workloadIdentity{tokenPath: opts.TokenPath, subjectIssuer: opts.SubjectIssuer}
+
+	if _, err := client.accessToken(ctx); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// workloadIdentity describes the external token a client exchanges for its tokens.
+type workloadIdentity struct {
+	tokenPath     string
+	subjectIssuer string
+}
+
+// refreshAccessTokenWorkloadIdentity exchanges the current Kubernetes token for a Hyperfluid token.
+func (c *Client) refreshAccessTokenWorkloadIdentity(ctx context.Context) (string, error) {
+	raw, err := os.ReadFile(c.federation.tokenPath)
+	if err != nil {
+		return "", fmt.Errorf("%w: cannot read Kubernetes service account token: %w", utils.ErrAuthenticationFailed, err)
+	}
+	subjectToken := strings.TrimSpace(string(raw))
+	if subjectToken == "" {
+		return "", fmt.Errorf("%w: Kubernetes service account token %s is empty", utils.ErrAuthenticationFailed, c.federation.tokenPath)
+	}
+
+	form := url.Values{
+		"grant_type":           {tokenExchangeGrantType},
+		"client_id":            {c.config.KeycloakClientID},
+		"subject_token":        {subjectToken},
+		"subject_token_type":   {jwtTokenType},
+		"requested_token_type": {accessTokenType},
+	}
+	if c.config.KeycloakClientSecret != "" {
+		form.Set("client_secret", c.config.KeycloakClientSecret)
+	}
+	if c.federation.subjectIssuer != "" {
+		form.Set("subject_issuer", c.federation.subjectIssuer)
+	}
+	return c.exchangeKeycloakToken(ctx, form)
+}
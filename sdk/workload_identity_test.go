@@ -0,0 +1,82 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nudibranches-tech/hyperfluid-sdk-go/sdk/utils"
+)
+
+func TestNewClientFromKubernetesServiceAccount(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/realms/test/protocol/openid-connect/token" {
+			_ = r.ParseForm()
+			if r.Form.Get("grant_type") != tokenExchangeGrantType || r.Form.Get("subject_token_type") != jwtTokenType ||
+				r.Form.Get("subject_issuer") != "cluster" || r.Form.Has("client_secret") {
+				t.Errorf("unexpected exchange form %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"access_token": "hf-` + r.Form.Get("subject_token") + `"}`))
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		if gotAuth == "Bearer hf-k8s-1" {
+			w.WriteHeader(http.StatusUnauthorized) // The first token expired
+			return
+		}
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenPath, []byte("k8s-1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewClientFromKubernetesServiceAccount(context.Background(), KubernetesServiceAccountOptions{
+		ServiceAccountOptions: ServiceAccountOptions{BaseURL: server.URL, DataDockID: "dd"},
+		Issuer:                server.URL + "/realms/test",
+		ClientID:              "workloads",
+		SubjectIssuer:         "cluster",
+		TokenPath:             tokenPath,
+	})
+	if err != nil {
+		t.Fatalf("NewClientFromKubernetesServiceAccount() unexpected error = %v", err)
+	}
+	if client.config.Token != "hf-k8s-1" {
+		t.Errorf("Token = %q, want %q", client.config.Token, "hf-k8s-1")
+	}
+
+	// The kubelet rotated the projected token; the expired Hyperfluid token is replaced
+	if err := os.WriteFile(tokenPath, []byte("k8s-2"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Catalog("c").Schema("s").Table("t").Get(context.Background()); err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if gotAuth != "Bearer hf-k8s-2" {
+		t.Errorf("Authorization = %q, want %q", gotAuth, "Bearer hf-k8s-2")
+	}
+}
+
+func TestNewClientFromKubernetesServiceAccount_Errors(t *testing.T) {
+	opts := KubernetesServiceAccountOptions{
+		ServiceAccountOptions: ServiceAccountOptions{BaseURL: "https://api.example.com"},
+		Issuer:                "https://auth.example.com/realms/test",
+		ClientID:              "workloads",
+		TokenPath:             filepath.Join(t.TempDir(), "missing"),
+	}
+	if _, err := NewClientFromKubernetesServiceAccount(context.Background(), opts); !errors.Is(err, utils.ErrAuthenticationFailed) {
+		t.Errorf("Expected ErrAuthenticationFailed for a missing token, got %v", err)
+	}
+
+	opts.ClientID = ""
+	if _, err := NewClientFromKubernetesServiceAccount(context.Background(), opts); !errors.Is(err, utils.ErrInvalidConfiguration) {
+		t.Errorf("Expected ErrInvalidConfiguration, got %v", err)
+	}
+}